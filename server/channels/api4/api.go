@@ -146,6 +146,9 @@ type Routes struct {
 
 	Reports *mux.Router // 'api/v4/reports'
 
+	Broadcasts *mux.Router // 'api/v4/broadcasts'
+	Broadcast  *mux.Router // 'api/v4/broadcasts/{broadcast_id:[A-Za-z0-9]+}'
+
 	Limits *mux.Router // 'api/v4/limits'
 
 	OutgoingOAuthConnections *mux.Router // 'api/v4/oauth/outgoing_connections'
@@ -294,6 +297,9 @@ func Init(srv *app.Server) (*API, error) {
 
 	api.BaseRoutes.Reports = api.BaseRoutes.APIRoot.PathPrefix("/reports").Subrouter()
 
+	api.BaseRoutes.Broadcasts = api.BaseRoutes.APIRoot.PathPrefix("/broadcasts").Subrouter()
+	api.BaseRoutes.Broadcast = api.BaseRoutes.Broadcasts.PathPrefix("/{broadcast_id:[A-Za-z0-9]+}").Subrouter()
+
 	api.BaseRoutes.Limits = api.BaseRoutes.APIRoot.PathPrefix("/limits").Subrouter()
 
 	api.BaseRoutes.OutgoingOAuthConnections = api.BaseRoutes.APIRoot.PathPrefix("/oauth/outgoing_connections").Subrouter()
@@ -360,6 +366,11 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitOutgoingOAuthConnection()
 	api.InitClientPerformanceMetrics()
 	api.InitScheduledPost()
+	api.InitPostReadReceipt()
+	api.InitReadReceiptPolicy()
+	api.InitReadReceiptWebhook()
+	api.InitReadReceiptBroadcast()
+	api.InitReadReceiptEvents()
 	api.InitCustomProfileAttributes()
 	api.InitAuditLogging()
 	api.InitAccessControlPolicy()
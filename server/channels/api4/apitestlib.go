@@ -927,6 +927,44 @@ func (th *TestHelper) CreatePinnedPostWithClient(client *model.Client4, channel
 	return rpost
 }
 
+// EnableReadReceipts turns read receipts on for every channel and user.
+func (th *TestHelper) EnableReadReceipts() {
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn
+		*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = true
+	})
+}
+
+func (th *TestHelper) MarkPostAsRead(post *model.Post) *model.PostReadReceipt {
+	return th.MarkPostAsReadWithClient(th.Client, post)
+}
+
+func (th *TestHelper) MarkPostAsReadWithClient(client *model.Client4, post *model.Post) *model.PostReadReceipt {
+	receipt, _, err := client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+	if err != nil {
+		panic(err)
+	}
+	return receipt
+}
+
+// MarkPostsAsReadWithClient marks the posts of the channel as read in a single batch.
+func (th *TestHelper) MarkPostsAsReadWithClient(client *model.Client4, channel *model.Channel, posts ...*model.Post) []*model.PostReadReceipt {
+	postIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIds = append(postIds, post.Id)
+	}
+
+	resp, _, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+		ChannelId: channel.Id,
+		PostIds:   postIds,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return resp.Receipts
+}
+
 func (th *TestHelper) CreateMessagePostWithClient(client *model.Client4, channel *model.Channel, message string) *model.Post {
 	post := &model.Post{
 		ChannelId: channel.Id,
@@ -1510,9 +1510,23 @@ func getChannelMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	members, err := c.App.GetChannelMembersPage(c.AppContext, c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
-	if err != nil {
-		c.Err = err
+	var members model.ChannelMembers
+	var appErr *model.AppError
+	switch r.URL.Query().Get("sort") {
+	case "":
+		members, appErr = c.App.GetChannelMembersPage(c.AppContext, c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	case model.ChannelMembersSortByLastRead:
+		requireReadReceiptsEnabled(c, "getChannelMembers")
+		if c.Err != nil {
+			return
+		}
+		members, appErr = c.App.GetChannelMembersPageByLastReadActivity(c.AppContext, c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	default:
+		c.SetInvalidURLParam("sort")
+		return
+	}
+	if appErr != nil {
+		c.Err = appErr
 		return
 	}
 
@@ -310,6 +310,7 @@ func getCloudLimits(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Err = model.NewAppError("Api4.getCloudLimits", "api.cloud.request_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		return
 	}
+	c.App.AddReadReceiptsUsageToCloudLimits(c.AppContext, limits)
 
 	json, err := json.Marshal(limits)
 	if err != nil {
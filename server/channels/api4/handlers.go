@@ -22,6 +22,12 @@ type APIHandlerOption string
 
 const (
 	handlerParamFileAPI = APIHandlerOption("fileAPI")
+	// handlerParamReadReceiptsScope opens the endpoint to OAuth apps granted the
+	// read_receipts scope only.
+	handlerParamReadReceiptsScope = APIHandlerOption("readReceiptsScope")
+	// handlerParamReadReceiptsWriteScope opens the endpoint to OAuth apps granted
+	// the read_receipts:write scope only.
+	handlerParamReadReceiptsWriteScope = APIHandlerOption("readReceiptsWriteScope")
 )
 
 // APIHandler provides a handler for API endpoints which do not require the user to be logged in order for access to be
@@ -247,6 +253,10 @@ func setHandlerOpts(handler *web.Handler, opts ...APIHandlerOption) {
 		switch option {
 		case handlerParamFileAPI:
 			handler.FileAPI = true
+		case handlerParamReadReceiptsScope:
+			handler.OAuthScopes = append(handler.OAuthScopes, model.ReadReceiptsScope)
+		case handlerParamReadReceiptsWriteScope:
+			handler.OAuthScopes = append(handler.OAuthScopes, model.ReadReceiptsWriteScope)
 		}
 	}
 }
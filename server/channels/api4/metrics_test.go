@@ -31,6 +31,8 @@ func setupMetricsMock() *mocks.MetricsInterface {
 	metricsMock.On("IncrementHTTPRequest").Return()
 	metricsMock.On("ObserveAPIEndpointDuration", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return()
 	metricsMock.On("ObserveRedisEndpointDuration", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return()
+	metricsMock.On("ObserveReadReceiptSummaryStaleness", mock.AnythingOfType("float64")).Return().Maybe()
+	metricsMock.On("IncrementReadReceiptEventsPublished", mock.AnythingOfType("float64")).Return().Maybe()
 	metricsMock.On("Register").Return()
 
 	return metricsMock
@@ -264,6 +264,9 @@ func getPostsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	c.App.AddCursorIdsForPostList(list, afterPost, beforePost, since, page, perPage, collapsedThreads)
+	if appErr := c.App.SetFirstUnreadPostIdForPostList(c.AppContext, list, channel, c.AppContext.Session().UserId); appErr != nil {
+		c.Logger.Warn("Failed to set the first unread post of the post list", mlog.String("channel_id", channelId), mlog.Err(appErr))
+	}
 	clientPostList := c.App.PreparePostListForClient(c.AppContext, list)
 	clientPostList, err = c.App.SanitizePostListMetadataForUser(c.AppContext, clientPostList, c.AppContext.Session().UserId)
 	if err != nil {
@@ -331,6 +334,9 @@ func getPostsForChannelAroundLastUnread(c *Context, w http.ResponseWriter, r *ht
 
 	postList.NextPostId = c.App.GetNextPostIdFromPostList(postList, collapsedThreads)
 	postList.PrevPostId = c.App.GetPrevPostIdFromPostList(postList, collapsedThreads)
+	if appErr := c.App.SetFirstUnreadPostIdForPostList(c.AppContext, postList, channel, userId); appErr != nil {
+		c.Logger.Warn("Failed to set the first unread post of the post list", mlog.String("channel_id", channelId), mlog.Err(appErr))
+	}
 
 	clientPostList := c.App.PreparePostListForClient(c.AppContext, postList)
 	clientPostList, err = c.App.SanitizePostListMetadataForUser(c.AppContext, clientPostList, c.AppContext.Session().UserId)
@@ -602,6 +608,12 @@ func deletePost(c *Context, w http.ResponseWriter, _ *http.Request) {
 			c.SetPermissionError(model.PermissionDeletePost)
 			return
 		}
+		if !permanent {
+			if appErr := c.App.CheckPostRecallAllowed(c.AppContext, post); appErr != nil {
+				c.Err = appErr
+				return
+			}
+		}
 	} else {
 		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), post.ChannelId, model.PermissionDeleteOthersPosts) {
 			c.SetPermissionError(model.PermissionDeleteOthersPosts)
@@ -1087,6 +1099,10 @@ func saveIsPinnedPost(c *Context, w http.ResponseWriter, isPinned bool) {
 	}
 	auditRec.AddEventResultState(patchedPost)
 
+	if isPinned && !post.IsPinned && *c.App.Config().ServiceSettings.EnableReadReceipts {
+		c.App.SendPinnedPostUnreadNotice(c.AppContext, c.AppContext.Session().UserId, patchedPost)
+	}
+
 	auditRec.Success()
 	ReturnStatusOK(w)
 }
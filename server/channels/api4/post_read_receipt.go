@@ -0,0 +1,1664 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitPostReadReceipt() {
+	api.BaseRoutes.Post.Handle("/read", api.APISessionRequired(savePostReadReceipt, handlerParamReadReceiptsWriteScope)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/read", api.APISessionRequired(deletePostReadReceipt)).Methods(http.MethodDelete)
+	api.BaseRoutes.Post.Handle("/read/thread", api.APISessionRequired(saveThreadReadReceipts, handlerParamReadReceiptsWriteScope)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/read/bot", api.APISessionRequired(saveBotPostReadReceipt, handlerParamReadReceiptsWriteScope)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/read/email_link", api.APISessionRequired(saveEmailLinkPostReadReceipt, handlerParamReadReceiptsWriteScope)).Methods(http.MethodPost)
+	api.BaseRoutes.Post.Handle("/receipts", api.APISessionRequired(getPostReadReceipts, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/receipts/summary", api.APISessionRequired(getPostReadReceiptSummary, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/read_receipts/me", api.APISessionRequired(headPostReadReceipt, handlerParamReadReceiptsScope)).Methods(http.MethodHead)
+	api.BaseRoutes.Post.Handle("/read_receipts/seen", api.APISessionRequired(getPostSeenState, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/read_receipts/mentions", api.APISessionRequired(getPostMentionReadState, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/read_receipts/extremes", api.APISessionRequired(getPostReadReceiptExtremes, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/receipts/{user_id:[A-Za-z0-9]+}/devices", api.APISessionRequired(getReadDevicesForPostUser, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Posts.Handle("/read/batch", api.APISessionRequired(savePostReadReceiptsBatch, handlerParamReadReceiptsWriteScope)).Methods(http.MethodPost)
+	api.BaseRoutes.PostsForChannel.Handle("/latest_read_counts", api.APISessionRequired(getReadCountsForLatestPosts, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/channels/{channel_id:[A-Za-z0-9]+}/read_receipts", api.APISessionRequired(getChannelReadReceiptSummaries, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/channels/{channel_id:[A-Za-z0-9]+}/read_receipts/changes", api.APISessionRequired(getChannelReadReceiptChanges, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/posts/read_state", api.APISessionRequired(getPostsReadState, handlerParamReadReceiptsScope)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/read_receipts", api.APISessionRequired(getReadReceiptsForUser)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/read_receipts/page", api.APISessionRequired(getReadReceiptsPageForUser)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/read_receipts/activity", api.APISessionRequired(getReadReceiptSessionActivity)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/read_receipts/export", api.APISessionRequired(exportReadReceiptsForUser)).Methods(http.MethodGet)
+	api.BaseRoutes.ChannelMembers.Handle("/read_activity", api.APISessionRequired(getChannelMembersReadActivity)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/read_receipts/daily_readers", api.APISessionRequired(getChannelDailyReaderCounts, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/read_receipts/verify", api.APISessionRequired(verifyReadReceiptChain)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/read_receipts/settings", api.APISessionRequired(getReadReceiptChannelSettings)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/read_receipts/settings", api.APISessionRequired(updateReadReceiptChannelSettings)).Methods(http.MethodPut)
+	api.BaseRoutes.APIRoot.Handle("/threads/{thread_id:[A-Za-z0-9]+}/read_summary", api.APISessionRequired(getThreadReadReceiptSummary, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	if api.srv.Config().FeatureFlags.ChannelBookmarks {
+		api.BaseRoutes.ChannelBookmarks.Handle("/read_summaries", api.APISessionRequired(getChannelBookmarkReadSummaries)).Methods(http.MethodGet)
+	}
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/overview", api.APISessionRequired(getReadReceiptsOverview, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/health", api.APISessionRequired(getReadReceiptsHealth)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/usage", api.APISessionRequired(getReadReceiptTeamUsage, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/sessions/{session_id:[A-Za-z0-9]+}", api.APISessionRequired(getReadReceiptsForSession)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/changes", api.APISessionRequired(getReadReceiptChanges, handlerParamReadReceiptsScope)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/cold_storage", api.APISessionRequired(getColdStorageReadReceipts)).Methods(http.MethodGet)
+}
+
+// requireReadReceiptsEnabled rejects the request when read receipts are disabled.
+// where is the name of the calling handler, reported in the error.
+func requireReadReceiptsEnabled(c *Context, where string) {
+	if !*c.App.Config().ServiceSettings.EnableReadReceipts {
+		c.Err = model.NewAppError(where, "api.read_receipt.disabled.app_error", nil, "", http.StatusNotImplemented).WithCode(model.ReadReceiptErrorCodeDisabled)
+		return
+	}
+}
+
+// requireHumanSession rejects bot sessions on the endpoints meant for people;
+// bots must use the dedicated bot acknowledgement endpoint instead.
+func requireHumanSession(c *Context, where string) {
+	if c.AppContext.Session().IsBotUser() {
+		c.Err = model.NewAppError(where, "api.read_receipt.bot_session.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeBotSessionNotAllowed)
+		return
+	}
+}
+
+// requireDeviceSession rejects the tokens issued to integrations on the endpoints
+// recording reads, so that integrations fetching posts on behalf of users do not
+// mark them as read. OAuth apps granted the read_receipts:write scope may.
+func requireDeviceSession(c *Context, where string) {
+	if !c.AppContext.Session().CanRecordReadReceipts() {
+		c.Err = model.NewAppError(where, "api.read_receipt.integration_session.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeIntegrationNotAllowed)
+		return
+	}
+}
+
+// requireReadReceiptsVisibleForPost rejects the requests for the readers of a post
+// whose channel is governed by a read receipt policy hiding them from the user.
+func requireReadReceiptsVisibleForPost(c *Context, postID string) {
+	if appErr := c.App.CheckReadReceiptsVisibleForPost(c.AppContext, c.AppContext.Session().UserId, postID); appErr != nil {
+		c.Err = appErr
+		return
+	}
+}
+
+// requireReadReceiptsVisibleForChannel is requireReadReceiptsVisibleForPost for
+// the readers of every post of the channel.
+func requireReadReceiptsVisibleForChannel(c *Context, channelID string) {
+	if appErr := c.App.CheckReadReceiptsVisibleForChannel(c.AppContext, c.AppContext.Session().UserId, channelID); appErr != nil {
+		c.Err = appErr
+		return
+	}
+}
+
+// auditClampedReadAt records the read time sent by the client when the server
+// stored a different one for any of the receipts because it was out of range.
+func auditClampedReadAt(c *Context, clientReadAt int64, receipts []*model.PostReadReceipt) {
+	if clientReadAt == 0 {
+		return
+	}
+
+	var clampedPostIDs []string
+	for _, receipt := range receipts {
+		if receipt.ReadAt != clientReadAt {
+			clampedPostIDs = append(clampedPostIDs, receipt.PostId)
+		}
+	}
+	if len(clampedPostIDs) == 0 {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventClampReadReceiptReadAt, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("client_read_at", clientReadAt)
+	auditRec.AddMeta("post_ids", clampedPostIDs)
+	auditRec.Success()
+}
+
+// marshalReadReceiptResponse encodes the response of the receipt endpoints, in
+// the compact encoding when the client asked for it and the response has one.
+func marshalReadReceiptResponse(w http.ResponseWriter, r *http.Request, response any) ([]byte, error) {
+	compact, ok := response.(interface{ MarshalCompactJSON() ([]byte, error) })
+	if !ok || !model.WantsCompactReadReceipts(r.URL.Query().Get(model.ReadReceiptFormatParam), r.Header.Get("Accept")) {
+		return json.Marshal(response)
+	}
+
+	w.Header().Set("Content-Type", model.ReadReceiptCompactContentType)
+	return compact.MarshalCompactJSON()
+}
+
+func savePostReadReceipt(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "savePostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	requireHumanSession(c, "savePostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	requireDeviceSession(c, "savePostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	var req model.ReadReceiptRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.SetInvalidParamWithErr("read_receipt", err)
+			return
+		}
+	}
+	req.PostId = c.Params.PostId
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	coalesced, appErr := c.App.CoalesceReadReceiptForPost(c.AppContext, c.AppContext.Session().UserId, &req)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if coalesced {
+		w.WriteHeader(http.StatusAccepted)
+		if _, err := w.Write([]byte(`{"coalesced":true}`)); err != nil {
+			c.Logger.Warn("Error while writing response", mlog.Err(err))
+		}
+		return
+	}
+
+	receipt, changed, appErr := c.App.SaveReadReceiptForPost(c.AppContext, c.AppContext.Session().UserId, &req)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if changed {
+		auditClampedReadAt(c, req.ReadAt, []*model.PostReadReceipt{receipt})
+	}
+
+	var response any = receipt
+	if !changed {
+		response = map[string]bool{"changed": false}
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, response)
+	if err != nil {
+		c.Err = model.NewAppError("savePostReadReceipt", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// saveThreadReadReceipts marks the thread rooted at the post as read.
+func saveThreadReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "saveThreadReadReceipts")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	requireHumanSession(c, "saveThreadReadReceipts")
+	if c.Err != nil {
+		return
+	}
+
+	requireDeviceSession(c, "saveThreadReadReceipts")
+	if c.Err != nil {
+		return
+	}
+
+	var req model.ReadReceiptRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.SetInvalidParamWithErr("read_receipt", err)
+			return
+		}
+	}
+	req.PostId = c.Params.PostId
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	resp, appErr := c.App.SaveThreadReadReceipts(c.AppContext, c.AppContext.Session().UserId, &req)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditClampedReadAt(c, req.ReadAt, resp.Receipts)
+
+	js, err := marshalReadReceiptResponse(w, r, resp)
+	if err != nil {
+		c.Err = model.NewAppError("saveThreadReadReceipts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// saveBotPostReadReceipt lets a bot acknowledge that it processed a post. Only
+// personal access tokens issued to bot accounts and granted
+// model.UserAccessTokenScopeBotReadReceipts are accepted.
+func saveBotPostReadReceipt(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "saveBotPostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	session := c.AppContext.Session()
+	if !session.IsBotUser() || !session.IsUserAccessToken() {
+		c.Err = model.NewAppError("saveBotPostReadReceipt", "api.read_receipt.bot_token_required.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeBotTokenRequired)
+		return
+	}
+
+	if !session.HasUserAccessTokenScope(model.UserAccessTokenScopeBotReadReceipts) {
+		c.Err = model.NewAppError("saveBotPostReadReceipt", "api.read_receipt.bot_token_scope.app_error", map[string]any{"Scope": model.UserAccessTokenScopeBotReadReceipts}, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeBotTokenScopeRequired)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*session, c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	receipt, appErr := c.App.SaveBotReadReceiptForPost(c.AppContext, session.UserId, c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, receipt)
+	if err != nil {
+		c.Err = model.NewAppError("saveBotPostReadReceipt", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// saveEmailLinkPostReadReceipt records that the user opened the post from the
+// permalink of a notification email, once signed in.
+func saveEmailLinkPostReadReceipt(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "saveEmailLinkPostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	requireHumanSession(c, "saveEmailLinkPostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	requireDeviceSession(c, "saveEmailLinkPostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	var req model.ReadReceiptEmailLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("email_link", err)
+		return
+	}
+	if req.Token == "" {
+		c.SetInvalidParam("token")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	receipt, appErr := c.App.SaveEmailLinkReadReceipt(c.AppContext, c.AppContext.Session().UserId, c.Params.PostId, req.Token)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	var response any = receipt
+	if receipt == nil {
+		response = map[string]bool{"changed": false}
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, response)
+	if err != nil {
+		c.Err = model.NewAppError("saveEmailLinkPostReadReceipt", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deletePostReadReceipt(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "deletePostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if appErr := c.App.DeleteReadReceiptForPost(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func getPostReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostReadReceipts")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	deviceType := r.URL.Query().Get("device_type")
+	if deviceType != "" && !model.IsValidReadReceiptDeviceType(deviceType) {
+		c.SetInvalidURLParam("device_type")
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	// Channel admins can hide receipts from members through channel moderation.
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.PostId)
+	if c.Err != nil {
+		return
+	}
+
+	info, appErr := c.App.GetReadReceiptInfoForPost(c.AppContext, c.Params.PostId, deviceType)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// The counts are left as they are, only the readers the user cannot see are hidden.
+	info.Receipts, appErr = c.App.FilterReadReceiptsForViewer(c.AppContext, c.AppContext.Session().UserId, info.Receipts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, info)
+	if err != nil {
+		c.Err = model.NewAppError("getPostReadReceipts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// headPostReadReceipt lets clients check whether they read the post without
+// fetching its receipts, answering 200 or 404 with no body.
+func headPostReadReceipt(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "headPostReadReceipt")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	read, appErr := c.App.IsPostReadByUser(c.AppContext, c.Params.PostId, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !read {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func getPostReadReceiptSummary(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostReadReceiptSummary")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.PostId)
+	if c.Err != nil {
+		return
+	}
+
+	summary, appErr := c.App.GetReadReceiptSummaryForPost(c.AppContext, c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(summary)
+	if err != nil {
+		c.Err = model.NewAppError("getPostReadReceiptSummary", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getThreadReadReceiptSummary returns how many participants of a thread caught
+// up with its replies.
+func getThreadReadReceiptSummary(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getThreadReadReceiptSummary")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireThreadId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.ThreadId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.ThreadId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.ThreadId)
+	if c.Err != nil {
+		return
+	}
+
+	summary, appErr := c.App.GetThreadReadReceiptSummary(c.AppContext, c.Params.ThreadId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(summary)
+	if err != nil {
+		c.Err = model.NewAppError("getThreadReadReceiptSummary", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getChannelBookmarkReadSummaries returns the read summaries of the posts the
+// bookmarks of a channel link to, for its channel admins. The posts of channels
+// where they can't view read receipts are left out.
+func getChannelBookmarkReadSummaries(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelBookmarkReadSummaries")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionManageChannelRoles) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	summaries, appErr := c.App.GetChannelBookmarkReadSummaries(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(summaries)
+	if err != nil {
+		c.Err = model.NewAppError("getChannelBookmarkReadSummaries", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getPostSeenState returns whether a direct message was seen, to toggle its check
+// mark without fetching its receipts.
+func getPostSeenState(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostSeenState")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.PostId)
+	if c.Err != nil {
+		return
+	}
+
+	state, appErr := c.App.GetPostSeenState(c.AppContext, c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(state)
+	if err != nil {
+		c.Err = model.NewAppError("getPostSeenState", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getPostMentionReadState returns which of the users mentioned by the post read it.
+func getPostMentionReadState(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostMentionReadState")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.PostId)
+	if c.Err != nil {
+		return
+	}
+
+	state, appErr := c.App.GetMentionReadStateForPost(c.AppContext, c.AppContext.Session().UserId, c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(state)
+	if err != nil {
+		c.Err = model.NewAppError("getPostMentionReadState", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getPostReadReceiptExtremes returns the first and the last readers of the post,
+// for instance to build incident timelines.
+func getPostReadReceiptExtremes(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostReadReceiptExtremes")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannelByPost(*c.AppContext.Session(), c.Params.PostId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForPost(c, c.Params.PostId)
+	if c.Err != nil {
+		return
+	}
+
+	extremes, appErr := c.App.GetReadReceiptExtremesForPost(c.AppContext, c.AppContext.Session().UserId, c.Params.PostId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(extremes)
+	if err != nil {
+		c.Err = model.NewAppError("getPostReadReceiptExtremes", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReadDevicesForPostUser lists the devices a user read a post on. It is meant
+// for compliance reviews and is restricted accordingly.
+func getReadDevicesForPostUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadDevicesForPostUser")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequirePostId().RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	devices, appErr := c.App.GetReadDevicesForPostUser(c.AppContext, c.AppContext.Session().UserId, c.Params.PostId, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(devices)
+	if err != nil {
+		c.Err = model.NewAppError("getReadDevicesForPostUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func savePostReadReceiptsBatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "savePostReadReceiptsBatch")
+	if c.Err != nil {
+		return
+	}
+
+	requireHumanSession(c, "savePostReadReceiptsBatch")
+	if c.Err != nil {
+		return
+	}
+
+	requireDeviceSession(c, "savePostReadReceiptsBatch")
+	if c.Err != nil {
+		return
+	}
+
+	var req model.ReadReceiptBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("read_receipts", err)
+		return
+	}
+
+	if appErr := req.IsValid(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), req.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	resp, appErr := c.App.SaveReadReceiptsBatch(c.AppContext, c.AppContext.Session().UserId, &req)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditClampedReadAt(c, req.ReadAt, resp.Receipts)
+
+	js, err := marshalReadReceiptResponse(w, r, resp)
+	if err != nil {
+		c.Err = model.NewAppError("savePostReadReceiptsBatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getChannelReadReceiptSummaries(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelReadReceiptSummaries")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId().RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForChannel(c, c.Params.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceString, 10, 64)
+		if err != nil {
+			c.SetInvalidParamWithErr("since", err)
+			return
+		}
+	}
+
+	summaries, appErr := c.App.GetReadReceiptSummariesForChannel(c.AppContext, c.Params.ChannelId, since)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(summaries)
+	if err != nil {
+		c.Err = model.NewAppError("getChannelReadReceiptSummaries", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getChannelReadReceiptChanges lets clients resynchronizing the receipts of a
+// channel after a websocket reconnection tail the changes from the last sequence
+// they saw, rather than fetching the summaries since a time.
+func getChannelReadReceiptChanges(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelReadReceiptChanges")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId().RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForChannel(c, c.Params.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	writeReadReceiptChanges(c, w, r, c.Params.ChannelId)
+}
+
+// getReadReceiptChanges lets external consumers tail the receipt changes of
+// every channel.
+func getReadReceiptChanges(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptChanges")
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	writeReadReceiptChanges(c, w, r, "")
+}
+
+// writeReadReceiptChanges writes the page of the receipt changes of the channel
+// that follow the after query parameter.
+func writeReadReceiptChanges(c *Context, w http.ResponseWriter, r *http.Request, channelID string) {
+	var after int64
+	if afterString := r.URL.Query().Get("after"); afterString != "" {
+		var err error
+		after, err = strconv.ParseInt(afterString, 10, 64)
+		if err != nil || after < 0 {
+			c.SetInvalidParamWithErr("after", err)
+			return
+		}
+	}
+
+	page, appErr := c.App.GetReadReceiptChanges(c.AppContext, c.AppContext.Session().UserId, channelID, after, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(page)
+	if err != nil {
+		c.Err = model.NewAppError("writeReadReceiptChanges", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReadCountsForLatestPosts returns the read counters of the per_page most recent
+// posts of a channel.
+func getReadCountsForLatestPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadCountsForLatestPosts")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForChannel(c, c.Params.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	counts, appErr := c.App.GetReadCountsForLatestPosts(c.AppContext, c.Params.ChannelId, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(counts)
+	if err != nil {
+		c.Err = model.NewAppError("getReadCountsForLatestPosts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReadReceiptsForUser lists the user's receipts as a plain array, as the route
+// always did. Clients that need the next page token use getReadReceiptsPageForUser.
+func getReadReceiptsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	page := readReceiptsPageForUser(c, r, "getReadReceiptsForUser")
+	if c.Err != nil {
+		return
+	}
+
+	js, err := json.Marshal(page.Receipts)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptsForUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReadReceiptsPageForUser lists the user's receipts along with the token of
+// the next page.
+func getReadReceiptsPageForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	page := readReceiptsPageForUser(c, r, "getReadReceiptsPageForUser")
+	if c.Err != nil {
+		return
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, page)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptsPageForUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func readReceiptsPageForUser(c *Context, r *http.Request, where string) *model.ReadReceiptsForUserPage {
+	requireReadReceiptsEnabled(c, where)
+	if c.Err != nil {
+		return nil
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return nil
+	}
+
+	opts := readReceiptsPageOptionsFromQuery(c, r)
+	if c.Err != nil {
+		return nil
+	}
+
+	page, appErr := c.App.GetReadReceiptsForUser(c.AppContext, c.Params.UserId, opts)
+	if appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	return page
+}
+
+// getReadReceiptSessionActivity lists the read activity of a user by session and
+// device, for the security settings of the user.
+func getReadReceiptSessionActivity(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptSessionActivity")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	var since int64
+	if sinceString := r.URL.Query().Get("since"); sinceString != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceString, 10, 64)
+		if err != nil {
+			c.SetInvalidParamWithErr("since", err)
+			return
+		}
+	}
+
+	activity, appErr := c.App.GetReadReceiptSessionActivity(c.AppContext, c.Params.UserId, since, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(activity)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptSessionActivity", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getPostsReadState lets clients restore the read state of the posts they show
+// in a single request, typically after a cold start.
+func getPostsReadState(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getPostsReadState")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	postIDs, err := model.SortedArrayFromJSON(r.Body)
+	if err != nil {
+		c.Err = model.NewAppError("getPostsReadState", model.PayloadParseError, nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	} else if len(postIDs) == 0 {
+		c.SetInvalidParam("post_ids")
+		return
+	}
+
+	if len(postIDs) > model.ReadStateMaxPosts {
+		c.Err = model.NewAppError("getPostsReadState", "api.read_receipt.read_state.too_many_posts.app_error", map[string]any{"Max": model.ReadStateMaxPosts}, "", http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeBatchTooLarge)
+		return
+	}
+
+	states, appErr := c.App.ArePostsReadByUser(c.AppContext, c.Params.UserId, postIDs)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(states)
+	if err != nil {
+		c.Err = model.NewAppError("getPostsReadState", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// exportReadReceiptsForUser streams all the receipts of the current user as a
+// download, so that users can take their read history with them.
+func exportReadReceiptsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "exportReadReceiptsForUser")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.Params.UserId != c.AppContext.Session().UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = model.ReadReceiptExportFormatJSON
+	}
+	if format != model.ReadReceiptExportFormatJSON && format != model.ReadReceiptExportFormatCSV {
+		c.SetInvalidURLParam("format")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventExportReadReceipts, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterToAuditRec(auditRec, "format", format)
+
+	if appErr := c.App.ReserveReadReceiptExport(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	contentType := "application/json"
+	if format == model.ReadReceiptExportFormatCSV {
+		contentType = "text/csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment;filename=\"read_receipts."+format+"\"")
+
+	// Once the response started, errors can only be logged.
+	count, err := c.App.ExportReadReceiptsForUser(c.AppContext, c.Params.UserId, format, w)
+	if err != nil {
+		c.Logger.Warn("Error while exporting read receipts", mlog.Err(err))
+		return
+	}
+
+	auditRec.AddMeta("count", count)
+	auditRec.Success()
+}
+
+// readReceiptsPageOptionsFromQuery parses the filters and the page of the receipt
+// listings, setting c.Err on invalid values.
+func readReceiptsPageOptionsFromQuery(c *Context, r *http.Request) model.GetReadReceiptsForUserOptions {
+	query := r.URL.Query()
+	opts := model.GetReadReceiptsForUserOptions{ChannelId: query.Get("channel_id")}
+	if opts.ChannelId != "" && !model.IsValidId(opts.ChannelId) {
+		c.SetInvalidParam("channel_id")
+		return opts
+	}
+
+	if sources := query.Get("source"); sources != "" {
+		opts.Sources = model.RemoveDuplicateStrings(strings.Split(sources, ","))
+		for _, source := range opts.Sources {
+			if !model.IsValidReadReceiptSource(source) {
+				c.SetInvalidParam("source")
+				return opts
+			}
+		}
+	}
+
+	if sinceString := query.Get("since"); sinceString != "" {
+		since, err := strconv.ParseInt(sinceString, 10, 64)
+		if err != nil {
+			c.SetInvalidParamWithErr("since", err)
+			return opts
+		}
+		opts.Since = since
+	}
+
+	if untilString := query.Get("until"); untilString != "" {
+		until, err := strconv.ParseInt(untilString, 10, 64)
+		if err != nil {
+			c.SetInvalidParamWithErr("until", err)
+			return opts
+		}
+		opts.Until = until
+	}
+
+	if limitString := query.Get("limit"); limitString != "" {
+		limit, err := strconv.Atoi(limitString)
+		if err != nil {
+			c.SetInvalidParamWithErr("limit", err)
+			return opts
+		}
+		opts.PerPage = limit
+	}
+
+	cursor, err := model.ReadReceiptCursorFromString(query.Get("page"))
+	if err != nil {
+		c.SetInvalidParamWithErr("page", err)
+		return opts
+	}
+	opts.Cursor = cursor
+
+	return opts
+}
+
+func getReadReceiptsForSession(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptsForSession")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireSessionId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	opts := readReceiptsPageOptionsFromQuery(c, r)
+	if c.Err != nil {
+		return
+	}
+
+	page, appErr := c.App.GetReadReceiptsForSession(c.AppContext, c.Params.SessionId, opts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := marshalReadReceiptResponse(w, r, page)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptsForSession", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getColdStorageReadReceipts answers historical audit queries about the receipts
+// of a channel offloaded to cold storage.
+func getColdStorageReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getColdStorageReadReceipts")
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	opts := readReceiptsPageOptionsFromQuery(c, r)
+	if c.Err != nil {
+		return
+	}
+	if opts.ChannelId == "" {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	query := model.ReadReceiptColdStorageQuery{
+		ChannelId: opts.ChannelId,
+		PostId:    r.URL.Query().Get("post_id"),
+		UserId:    r.URL.Query().Get("user_id"),
+		Since:     opts.Since,
+		Until:     opts.Until,
+	}
+	if query.PostId != "" && !model.IsValidId(query.PostId) {
+		c.SetInvalidParam("post_id")
+		return
+	}
+	if query.UserId != "" && !model.IsValidId(query.UserId) {
+		c.SetInvalidParam("user_id")
+		return
+	}
+
+	result, appErr := c.App.GetColdStorageReadReceipts(c.AppContext, query)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(result)
+	if err != nil {
+		c.Err = model.NewAppError("getColdStorageReadReceipts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getReadReceiptsOverview(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptsOverview")
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadReportingSiteStatistics) {
+		c.SetPermissionError(model.PermissionSysconsoleReadReportingSiteStatistics)
+		return
+	}
+
+	js, err := json.Marshal(c.App.GetReadReceiptsOverview())
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptsOverview", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getReadReceiptTeamUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptTeamUsage")
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadReportingSiteStatistics) {
+		c.SetPermissionError(model.PermissionSysconsoleReadReportingSiteStatistics)
+		return
+	}
+
+	usage, appErr := c.App.GetReadReceiptTeamUsage()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(usage)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptTeamUsage", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getChannelMembersReadActivity(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelMembersReadActivity")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementChannels) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementChannels)
+		return
+	}
+
+	activity, appErr := c.App.GetChannelMembersReadActivity(c.AppContext, c.Params.ChannelId, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(activity)
+	if err != nil {
+		c.Err = model.NewAppError("getChannelMembersReadActivity", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getChannelDailyReaderCounts returns how many distinct users read the channel on
+// each day of the range given by the from and to query parameters.
+func getChannelDailyReaderCounts(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelDailyReaderCounts")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementChannels) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementChannels)
+		return
+	}
+
+	query := r.URL.Query()
+	counts, appErr := c.App.GetChannelDailyReaderCounts(c.AppContext, c.Params.ChannelId, query.Get("from"), query.Get("to"))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(counts)
+	if err != nil {
+		c.Err = model.NewAppError("getChannelDailyReaderCounts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getReadReceiptsHealth(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptsHealth")
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadReportingSiteStatistics) {
+		c.SetPermissionError(model.PermissionSysconsoleReadReportingSiteStatistics)
+		return
+	}
+
+	js, err := json.Marshal(c.App.GetReadReceiptsHealth())
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptsHealth", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func verifyReadReceiptChain(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "verifyReadReceiptChain")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	verification, appErr := c.App.VerifyReadReceiptChain(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(verification)
+	if err != nil {
+		c.Err = model.NewAppError("verifyReadReceiptChain", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getReadReceiptChannelSettings(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptChannelSettings")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	settings, appErr := c.App.GetReadReceiptChannelSettings(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(settings)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptChannelSettings", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// updateReadReceiptChannelSettings lets the admins of a channel change its read
+// receipt settings, with the permissions needed to change its other properties.
+func updateReadReceiptChannelSettings(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "updateReadReceiptChannelSettings")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	// Settings left out of the body keep their current value.
+	settings, appErr := c.App.GetReadReceiptChannelSettings(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+		c.SetInvalidParamWithErr("read_receipt_channel_settings", err)
+		return
+	}
+	settings.ChannelId = c.Params.ChannelId
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateReadReceiptChannelSettings, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", settings.ChannelId)
+	model.AddEventParameterToAuditRec(auditRec, "broadcast_receipts", settings.BroadcastReceipts)
+	model.AddEventParameterToAuditRec(auditRec, "pinned_unread_notice", settings.PinnedUnreadNotice)
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	switch channel.Type {
+	case model.ChannelTypeOpen:
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, model.PermissionManagePublicChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePublicChannelProperties)
+			return
+		}
+	case model.ChannelTypePrivate:
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, model.PermissionManagePrivateChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePrivateChannelProperties)
+			return
+		}
+	default:
+		if _, appErr := c.App.GetChannelMember(c.AppContext, channel.Id, c.AppContext.Session().UserId); appErr != nil {
+			c.SetPermissionError(model.PermissionReadChannelContent)
+			return
+		}
+	}
+
+	saved, appErr := c.App.UpdateReadReceiptChannelSettings(settings)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	js, err := json.Marshal(saved)
+	if err != nil {
+		c.Err = model.NewAppError("updateReadReceiptChannelSettings", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
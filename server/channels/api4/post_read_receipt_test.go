@@ -0,0 +1,1977 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestSavePostReadReceipt(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	t.Run("disabled by config", func(t *testing.T) {
+		_, resp, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.EnableReadReceipts()
+
+	t.Run("save and get", func(t *testing.T) {
+		receipt, _, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.Equal(t, th.BasicPost.Id, receipt.PostId)
+		require.Equal(t, th.BasicUser.Id, receipt.UserId)
+
+		info, _, err := client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Len(t, info.Receipts, 1)
+		require.Equal(t, int64(1), info.ReadCount)
+	})
+
+	t.Run("filter by device type", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableDeviceTracking = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableDeviceTracking = false })
+
+		_, _, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{ReadAt: model.GetMillis()})
+		require.NoError(t, err)
+
+		info, _, err := client.GetPostReadReceiptsForDeviceType(context.Background(), th.BasicPost.Id, model.ReadReceiptDeviceTypeWeb)
+		require.NoError(t, err)
+		require.Len(t, info.Receipts, 1)
+
+		info, _, err = client.GetPostReadReceiptsForDeviceType(context.Background(), th.BasicPost.Id, model.ReadReceiptDeviceTypeMobile)
+		require.NoError(t, err)
+		require.Empty(t, info.Receipts)
+
+		_, resp, err := client.GetPostReadReceiptsForDeviceType(context.Background(), th.BasicPost.Id, "tablet")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("identical receipt is ignored", func(t *testing.T) {
+		req := &model.ReadReceiptRequest{ReadAt: th.BasicPost.CreateAt + 12345}
+		receipt, _, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, req)
+		require.NoError(t, err)
+		require.Equal(t, req.ReadAt, receipt.ReadAt)
+
+		receipt, resp, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, req)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Empty(t, receipt.PostId)
+	})
+
+	t.Run("invalid post id", func(t *testing.T) {
+		_, resp, err := client.SavePostReadReceipt(context.Background(), "junk", &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no access to the post", func(t *testing.T) {
+		_, resp, err := client.SavePostReadReceipt(context.Background(), model.NewId(), &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("post opted out of read receipts", func(t *testing.T) {
+		post := &model.Post{ChannelId: th.BasicChannel.Id, Message: "automated"}
+		post.AddProp(model.PostPropsNoReadReceipts, true)
+		post, _, err := client.CreatePost(context.Background(), post)
+		require.NoError(t, err)
+
+		_, resp, err := client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		batch, _, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{post.Id, th.BasicPost.Id},
+		})
+		require.NoError(t, err)
+		for _, receipt := range batch.Receipts {
+			require.NotEqual(t, post.Id, receipt.PostId)
+		}
+	})
+
+	t.Run("team channels disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = true })
+
+		_, resp, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system messages are not allowed", func(t *testing.T) {
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Type:      model.PostTypeHeaderChange,
+			Message:   "header changed",
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+
+		_, resp, err := client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.read_receipt.post_type_not_allowed.app_error")
+
+		var codeErr *model.AppError
+		require.ErrorAs(t, err, &codeErr)
+		require.Equal(t, model.ReadReceiptErrorCodePostTypeNotAllowed, codeErr.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := client.DeletePostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+
+		resp, err := client.DeletePostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestSavePostReadReceiptIntegrations(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	t.Run("personal access tokens are rejected", func(t *testing.T) {
+		th.AddPermissionToRole(model.PermissionCreateUserAccessToken.Id, model.SystemUserRoleId)
+		defer th.RemovePermissionFromRole(model.PermissionCreateUserAccessToken.Id, model.SystemUserRoleId)
+
+		token, _, err := th.Client.CreateUserAccessToken(context.Background(), th.BasicUser.Id, "read receipts")
+		require.NoError(t, err)
+		client := th.CreateClient()
+		client.AuthToken = token.Token
+		client.AuthType = model.HeaderBearer
+
+		_, resp, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.read_receipt.integration_session.app_error")
+	})
+
+	oauthClient := func(t *testing.T, scope string) *model.Client4 {
+		t.Helper()
+		session, appErr := th.App.CreateSession(th.Context, &model.Session{
+			UserId:  th.BasicUser.Id,
+			Roles:   th.BasicUser.Roles,
+			IsOAuth: true,
+			Props:   model.StringMap{model.SessionPropOAuthScope: scope},
+		})
+		require.Nil(t, appErr)
+		client := th.CreateClient()
+		client.SetOAuthToken(session.Token)
+		return client
+	}
+
+	t.Run("OAuth apps need the write scope", func(t *testing.T) {
+		post := th.CreatePost()
+
+		_, resp, err := oauthClient(t, model.DefaultScope).SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = oauthClient(t, model.DefaultScope).SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{ChannelId: th.BasicChannel.Id, PostIds: []string{post.Id}})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		receipt, _, err := oauthClient(t, model.DefaultScope+" "+model.ReadReceiptsWriteScope).SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.Equal(t, post.Id, receipt.PostId)
+	})
+
+}
+
+func TestReadReceiptsOAuthScope(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	oauthClient := func(t *testing.T, scope string) *model.Client4 {
+		t.Helper()
+		session, appErr := th.App.CreateSession(th.Context, &model.Session{
+			UserId:  th.BasicUser.Id,
+			Roles:   th.BasicUser.Roles,
+			IsOAuth: true,
+			Props:   model.StringMap{model.SessionPropOAuthScope: scope},
+		})
+		require.Nil(t, appErr)
+		client := th.CreateClient()
+		client.SetOAuthToken(session.Token)
+		return client
+	}
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	t.Run("read scope only reads receipts", func(t *testing.T) {
+		client := oauthClient(t, model.ReadReceiptsScope)
+
+		summary, _, err := client.GetPostReadReceiptSummary(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Equal(t, th.BasicPost.Id, summary.PostId)
+
+		_, resp, err := client.GetPost(context.Background(), th.BasicPost.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.context.oauth_scope.app_error")
+
+		_, resp, err = client.SavePostReadReceipt(context.Background(), th.CreatePost().Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("write scope only records reads", func(t *testing.T) {
+		client := oauthClient(t, model.ReadReceiptsWriteScope)
+
+		post := th.CreatePost()
+		receipt, _, err := client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.Equal(t, post.Id, receipt.PostId)
+
+		_, resp, err := client.GetPostReadReceiptSummary(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("other scopes open every endpoint", func(t *testing.T) {
+		for _, scope := range []string{model.DefaultScope, "openid read", "read " + model.ReadReceiptsScope} {
+			client := oauthClient(t, scope)
+
+			_, _, err := client.GetPostReadReceiptSummary(context.Background(), th.BasicPost.Id)
+			require.NoError(t, err)
+			_, _, err = client.GetPost(context.Background(), th.BasicPost.Id, "")
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("read scope reaches every receipt read endpoint", func(t *testing.T) {
+		client := oauthClient(t, model.ReadReceiptsScope)
+
+		_, _, err := client.GetPostSeenState(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		_, _, err = client.GetReadCountsForLatestPosts(context.Background(), th.BasicChannel.Id, 10)
+		require.NoError(t, err)
+	})
+}
+
+func TestUpdateSessionReadReceiptOptOut(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	session, appErr := th.App.GetSession(th.Client.AuthToken)
+	require.Nil(t, appErr)
+
+	t.Run("reads through the session are not recorded", func(t *testing.T) {
+		_, err := th.Client.UpdateSessionReadReceiptOptOut(context.Background(), "me", session.Id, true)
+		require.NoError(t, err)
+
+		post := th.CreatePost()
+		_, _, err = th.Client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		_, err = th.Client.UpdateSessionReadReceiptOptOut(context.Background(), "me", session.Id, false)
+		require.NoError(t, err)
+
+		receipt, _, err := th.Client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.Equal(t, post.Id, receipt.PostId)
+	})
+
+	t.Run("sessions of other users", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+
+		resp, err := client2.UpdateSessionReadReceiptOptOut(context.Background(), th.BasicUser.Id, session.Id, true)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client2.UpdateSessionReadReceiptOptOut(context.Background(), "me", session.Id, true)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.UpdateSessionReadReceiptOptOut(context.Background(), th.BasicUser.Id, session.Id, false)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+	})
+
+	t.Run("missing flag", func(t *testing.T) {
+		resp, err := th.Client.DoAPIPut(context.Background(), "/users/me/sessions/"+session.Id+"/read_receipt_optout", "{}")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(resp))
+	})
+}
+
+func TestSavePostReadReceiptsBatch(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	client := th.Client
+
+	post1 := th.CreatePost()
+	post2 := th.CreatePost()
+	otherPost := th.CreatePostWithClient(client, th.BasicChannel2)
+
+	resp, _, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+		ChannelId: th.BasicChannel.Id,
+		PostIds:   []string{post1.Id, post2.Id, otherPost.Id},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.ProcessedCount)
+
+	_, r, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{ChannelId: th.BasicChannel.Id})
+	require.Error(t, err)
+	CheckBadRequestStatus(t, r)
+
+	t.Run("watermark form", func(t *testing.T) {
+		post3 := th.CreatePost()
+
+		resp, _, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId:  th.BasicChannel.Id,
+			UpToPostId: post3.Id,
+		})
+		require.NoError(t, err)
+		require.NotZero(t, resp.ProcessedCount)
+
+		info, _, err := client.GetPostReadReceipts(context.Background(), post3.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), info.ReadCount)
+	})
+
+	t.Run("channel over the receipt limit only accepts watermarks", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxPerChannel = 1 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxPerChannel = 0 })
+		post4 := th.CreatePost()
+
+		resp, _, err := client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{post4.Id},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Degraded)
+		require.Zero(t, resp.ProcessedCount)
+
+		resp, _, err = client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId:  th.BasicChannel.Id,
+			UpToPostId: post4.Id,
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Degraded)
+		require.Equal(t, 1, resp.ProcessedCount)
+
+		// Single reads are saved as watermarks, marking the earlier posts as read too.
+		post5 := th.CreatePost()
+		post6 := th.CreatePost()
+		_, _, err = client.SavePostReadReceipt(context.Background(), post6.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		info, _, err := client.GetPostReadReceipts(context.Background(), post5.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), info.ReadCount)
+
+		// Threads are explicit lists of posts, which are ignored.
+		root := th.CreatePost()
+		_, _, err = client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, RootId: root.Id, Message: "reply"})
+		require.NoError(t, err)
+		resp, _, err = client.SaveThreadReadReceipts(context.Background(), root.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.True(t, resp.Degraded)
+		require.Zero(t, resp.ProcessedCount)
+
+		policy, _, err := client.GetEffectiveReadReceiptPolicy(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.True(t, policy.Degraded)
+	})
+}
+
+func TestSavePostReadReceiptsOverWebSocket(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	wsClient := th.CreateConnectedWebSocketClient(t)
+	resp := <-wsClient.ResponseChannel
+	require.Equal(t, model.StatusOk, resp.Status)
+
+	post := th.CreatePost()
+	otherPost := th.CreatePostWithClient(th.Client, th.BasicChannel2)
+
+	wsClient.PostRead(&model.ReadReceiptBatchRequest{
+		ChannelId: th.BasicChannel.Id,
+		PostIds:   []string{post.Id, otherPost.Id},
+	})
+	resp = <-wsClient.ResponseChannel
+	require.Nil(t, resp.Error)
+	require.Equal(t, model.StatusOk, resp.Status)
+	require.EqualValues(t, 1, resp.Data["processed_count"])
+
+	info, _, err := th.Client.GetPostReadReceipts(context.Background(), post.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), info.ReadCount)
+
+	t.Run("invalid request", func(t *testing.T) {
+		wsClient.PostRead(&model.ReadReceiptBatchRequest{ChannelId: th.BasicChannel.Id})
+		resp := <-wsClient.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.Error.StatusCode)
+	})
+
+	t.Run("channel without access", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		wsClient.PostRead(&model.ReadReceiptBatchRequest{ChannelId: privateChannel.Id, PostIds: []string{post.Id}})
+		resp := <-wsClient.ResponseChannel
+		require.NotNil(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.Error.StatusCode)
+	})
+}
+
+func TestChannelReadDigestEvent(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	wsClient := th.CreateConnectedWebSocketClient(t)
+	post := th.CreatePost()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	_, _, err := client2.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+		ChannelId:  th.BasicChannel.Id,
+		UpToPostId: post.Id,
+	})
+	require.NoError(t, err)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-wsClient.EventChannel:
+			require.NotEqual(t, model.WebsocketEventPostReadBatch, event.EventType())
+			if event.EventType() != model.WebsocketEventChannelReadDigest {
+				continue
+			}
+			digest, err := model.ChannelReadDigestEventFromWebSocketEvent(event)
+			require.NoError(t, err)
+			require.Equal(t, th.BasicUser2.Id, digest.UserId)
+			require.Equal(t, th.BasicChannel.Id, digest.ChannelId)
+			require.Equal(t, post.Id, digest.UpToPostId)
+			require.NotZero(t, digest.ReadAt)
+			return
+		case <-timeout:
+			require.Fail(t, "the channel members did not receive the read digest")
+			return
+		}
+	}
+}
+
+func TestReadSummaryPushedToAuthor(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	wsClient := th.CreateConnectedWebSocketClient(t)
+	post := th.CreatePost()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, post)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-wsClient.EventChannel:
+			if event.EventType() != model.WebsocketEventReadSummaryUpdated {
+				continue
+			}
+			require.Equal(t, th.BasicUser.Id, event.GetBroadcast().UserId)
+			require.Equal(t, post.Id, event.GetData()["post_id"])
+			require.EqualValues(t, 1, event.GetData()["read_count"])
+			return
+		case <-timeout:
+			require.Fail(t, "the author did not receive the read summary")
+			return
+		}
+	}
+}
+
+func TestReadReceiptChannelSettings(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	t.Run("broadcast is on by default", func(t *testing.T) {
+		settings, _, err := th.Client.GetReadReceiptChannelSettings(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.True(t, settings.BroadcastReceipts)
+	})
+
+	t.Run("requires permission to manage the channel", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+
+		_, resp, err := th.Client.UpdateReadReceiptChannelSettings(context.Background(), th.BasicChannel.Id, &model.ReadReceiptChannelSettings{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("muted channels still count reads without broadcasting them", func(t *testing.T) {
+		settings, _, err := th.Client.UpdateReadReceiptChannelSettings(context.Background(), th.BasicChannel.Id, &model.ReadReceiptChannelSettings{BroadcastReceipts: false})
+		require.NoError(t, err)
+		require.False(t, settings.BroadcastReceipts)
+		require.Equal(t, th.BasicChannel.Id, settings.ChannelId)
+
+		wsClient := th.CreateConnectedWebSocketClient(t)
+		post := th.CreatePost()
+
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		th.MarkPostAsReadWithClient(client2, post)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event := <-wsClient.EventChannel:
+				require.NotEqual(t, model.WebsocketEventReadReceiptSummary, event.EventType())
+				if event.EventType() == model.WebsocketEventReadSummaryUpdated {
+					require.EqualValues(t, 1, event.GetData()["read_count"])
+					return
+				}
+			case <-timeout:
+				require.Fail(t, "the author did not receive the read summary")
+				return
+			}
+		}
+	})
+}
+
+func TestReadReceiptsWithCollapsedThreads(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ThreadAutoFollow = true
+		*cfg.ServiceSettings.CollapsedThreads = model.CollapsedThreadsAlwaysOn
+	})
+
+	root := th.CreatePost()
+	reply, _, err := th.Client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, RootId: root.Id, Message: "reply"})
+	require.NoError(t, err)
+
+	t.Run("batch only marks root posts", func(t *testing.T) {
+		resp, _, err := th.Client.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{root.Id, reply.Id},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, resp.ProcessedCount)
+		require.Equal(t, root.Id, resp.Receipts[0].PostId)
+	})
+
+	t.Run("reading the thread marks the replies", func(t *testing.T) {
+		resp, _, err := th.Client.SaveThreadReadReceipts(context.Background(), root.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+		require.Equal(t, 2, resp.ProcessedCount)
+
+		info, _, err := th.Client.GetPostReadReceipts(context.Background(), reply.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), info.ReadCount)
+	})
+
+	t.Run("thread must be read from its root", func(t *testing.T) {
+		_, resp, err := th.Client.SaveThreadReadReceipts(context.Background(), reply.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetThreadReadReceiptSummary(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+
+	root := th.CreatePost()
+	_, _, err := client2.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, RootId: root.Id, Message: "reply"})
+	require.NoError(t, err)
+	var reply *model.Post
+	for range 2 {
+		reply, _, err = th.Client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, RootId: root.Id, Message: "reply"})
+		require.NoError(t, err)
+	}
+
+	repliesReadBy := func(summary *model.ThreadReadReceiptSummary, userID string) *model.ThreadParticipantReadCount {
+		for _, participant := range summary.Participants {
+			if participant.UserId == userID {
+				return participant
+			}
+		}
+		return nil
+	}
+
+	require.Eventually(t, func() bool {
+		summary, _, err := th.Client.GetThreadReadReceiptSummary(context.Background(), root.Id)
+		require.NoError(t, err)
+		participant := repliesReadBy(summary, th.BasicUser2.Id)
+		return summary.ReplyCount == 3 && summary.ParticipantCount == 2 && participant != nil && participant.RepliesRead == 1 && !participant.CaughtUp
+	}, 5*time.Second, 100*time.Millisecond)
+
+	_, _, err = client2.SaveThreadReadReceipts(context.Background(), root.Id, &model.ReadReceiptRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		summary, _, err := th.Client.GetThreadReadReceiptSummary(context.Background(), root.Id)
+		require.NoError(t, err)
+		participant := repliesReadBy(summary, th.BasicUser2.Id)
+		return participant != nil && participant.RepliesRead == 3 && participant.CaughtUp
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Run("summaries are for root posts", func(t *testing.T) {
+		_, resp, err := th.Client.GetThreadReadReceiptSummary(context.Background(), reply.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires access to the channel", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		privateRoot := th.CreatePostWithClient(th.Client, privateChannel)
+		th.RemoveUserFromChannel(th.BasicUser2, privateChannel)
+
+		_, resp, err := client2.GetThreadReadReceiptSummary(context.Background(), privateRoot.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetChannelBookmarkReadSummaries(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.Srv().SetLicense(model.NewTestLicense())
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "http://localhost:8065" })
+	th.MakeUserChannelAdmin(th.BasicUser, th.BasicChannel)
+
+	post := th.CreatePost()
+	postBookmark, appErr := th.App.CreateChannelBookmark(th.Context, &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "post",
+		Type:        model.ChannelBookmarkLink,
+		LinkUrl:     "http://localhost:8065/" + th.BasicTeam.Name + "/pl/" + post.Id,
+	}, "")
+	require.Nil(t, appErr)
+	_, appErr = th.App.CreateChannelBookmark(th.Context, &model.ChannelBookmark{
+		ChannelId:   th.BasicChannel.Id,
+		DisplayName: "external",
+		Type:        model.ChannelBookmarkLink,
+		LinkUrl:     "https://sample.com",
+	}, "")
+	require.Nil(t, appErr)
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, post)
+
+	require.Eventually(t, func() bool {
+		summaries, _, err := th.Client.GetChannelBookmarkReadSummaries(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		require.Equal(t, postBookmark.Id, summaries[0].BookmarkId)
+		require.Equal(t, post.Id, summaries[0].Summary.PostId)
+		return summaries[0].Summary.ReadCount == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Run("only for channel admins", func(t *testing.T) {
+		_, resp, err := client2.GetChannelBookmarkReadSummaries(context.Background(), th.BasicChannel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("leaves out system posts", func(t *testing.T) {
+		systemPost, err := th.App.Srv().Store().Post().Save(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Type:      model.PostTypeJoinChannel,
+			Message:   "joined",
+		})
+		require.NoError(t, err)
+		_, appErr := th.App.CreateChannelBookmark(th.Context, &model.ChannelBookmark{
+			ChannelId:   th.BasicChannel.Id,
+			DisplayName: "system",
+			Type:        model.ChannelBookmarkLink,
+			LinkUrl:     "http://localhost:8065/" + th.BasicTeam.Name + "/pl/" + systemPost.Id,
+		}, "")
+		require.Nil(t, appErr)
+
+		summaries, _, err := th.Client.GetChannelBookmarkReadSummaries(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+		require.Equal(t, postBookmark.Id, summaries[0].BookmarkId)
+	})
+
+	t.Run("requires viewing read receipts", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionViewReadReceipts.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionViewReadReceipts.Id, model.ChannelUserRoleId)
+
+		_, resp, err := th.Client.GetChannelBookmarkReadSummaries(context.Background(), th.BasicChannel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetReadCountsForLatestPosts(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	post := th.CreatePost()
+	_, _, err := th.Client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+	require.NoError(t, err)
+
+	counts, _, err := th.Client.GetReadCountsForLatestPosts(context.Background(), th.BasicChannel.Id, 1)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	require.Equal(t, post.Id, counts[0].PostId)
+	require.Equal(t, int64(1), counts[0].ReadCount)
+
+	t.Run("requires access to the channel", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel()
+		th.RemoveUserFromChannel(th.BasicUser, privateChannel)
+
+		_, resp, err := th.Client.GetReadCountsForLatestPosts(context.Background(), privateChannel.Id, 1)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestSaveBotPostReadReceipt(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	bot, appErr := th.App.CreateBot(th.Context, &model.Bot{
+		Username:    GenerateTestUsername(),
+		DisplayName: "a bot",
+		OwnerId:     th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+	botUser, appErr := th.App.GetUser(bot.UserId)
+	require.Nil(t, appErr)
+	th.LinkUserToTeam(botUser, th.BasicTeam)
+	th.AddUserToChannel(botUser, th.BasicChannel)
+
+	token, appErr := th.App.CreateUserAccessToken(th.Context, &model.UserAccessToken{UserId: bot.UserId, Description: "read receipts", Scope: model.UserAccessTokenScopeBotReadReceipts})
+	require.Nil(t, appErr)
+	botClient := th.CreateClient()
+	botClient.AuthToken = token.Token
+	botClient.AuthType = model.HeaderBearer
+
+	t.Run("disabled by config", func(t *testing.T) {
+		_, resp, err := botClient.SaveBotPostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableBotReceipts = true })
+
+	t.Run("human sessions are rejected", func(t *testing.T) {
+		_, resp, err := th.Client.SaveBotPostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("bot tokens without the scope are rejected", func(t *testing.T) {
+		unscoped, appErr := th.App.CreateUserAccessToken(th.Context, &model.UserAccessToken{UserId: bot.UserId, Description: "no scope"})
+		require.Nil(t, appErr)
+		unscopedClient := th.CreateClient()
+		unscopedClient.AuthToken = unscoped.Token
+		unscopedClient.AuthType = model.HeaderBearer
+
+		_, resp, err := unscopedClient.SaveBotPostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.read_receipt.bot_token_scope.app_error")
+	})
+
+	t.Run("bots cannot use the human endpoint", func(t *testing.T) {
+		_, resp, err := botClient.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system messages are not allowed", func(t *testing.T) {
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Type:      model.PostTypeHeaderChange,
+			Message:   "header changed",
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+
+		_, resp, err := botClient.SaveBotPostReadReceipt(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.read_receipt.post_type_not_allowed.app_error")
+
+		var codeErr *model.AppError
+		require.ErrorAs(t, err, &codeErr)
+		require.Equal(t, model.ReadReceiptErrorCodePostTypeNotAllowed, codeErr.Code)
+	})
+
+	t.Run("bot receipt is excluded from the human count", func(t *testing.T) {
+		receipt, _, err := botClient.SaveBotPostReadReceipt(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptDeviceTypeBot, receipt.DeviceType)
+
+		info, _, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), info.ReadCount)
+		require.Equal(t, int64(1), info.BotReadCount)
+		require.Zero(t, info.ReadPercentage)
+	})
+}
+
+func TestGetChannelReadReceiptSummaries(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	client := th.Client
+
+	_, _, err := client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		summaries, _, err := client.GetChannelReadReceiptSummaries(context.Background(), th.BasicUser.Id, th.BasicChannel.Id, 0)
+		require.NoError(t, err)
+		return len(summaries) == 1 && summaries[0].PostId == th.BasicPost.Id && summaries[0].ReadCount == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		_, resp, err := client.GetChannelReadReceiptSummaries(context.Background(), th.BasicUser2.Id, th.BasicChannel.Id, 0)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetReadReceiptsForUser(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	client := th.Client
+
+	var posts []*model.Post
+	for i := range 3 {
+		post := th.CreatePost()
+		_, _, err := client.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{ReadAt: int64(1000 + i)})
+		require.NoError(t, err)
+		posts = append(posts, post)
+	}
+
+	t.Run("paginates with the next page token", func(t *testing.T) {
+		page, _, err := client.GetReadReceiptsForUser(context.Background(), th.BasicUser.Id, model.GetReadReceiptsForUserOptions{PerPage: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Receipts, 2)
+		require.Equal(t, posts[2].Id, page.Receipts[0].PostId)
+		require.NotEmpty(t, page.NextPage)
+
+		cursor, err := model.ReadReceiptCursorFromString(page.NextPage)
+		require.NoError(t, err)
+		page, _, err = client.GetReadReceiptsForUser(context.Background(), th.BasicUser.Id, model.GetReadReceiptsForUserOptions{PerPage: 2, Cursor: cursor})
+		require.NoError(t, err)
+		require.Len(t, page.Receipts, 1)
+		require.Equal(t, posts[0].Id, page.Receipts[0].PostId)
+		require.Empty(t, page.NextPage)
+	})
+
+	t.Run("the receipts route still returns an array", func(t *testing.T) {
+		r, err := client.DoAPIGet(context.Background(), "/users/"+th.BasicUser.Id+"/read_receipts?limit=2", "")
+		require.NoError(t, err)
+		defer r.Body.Close()
+
+		var receipts []*model.PostReadReceipt
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&receipts))
+		require.Len(t, receipts, 2)
+		require.Equal(t, posts[2].Id, receipts[0].PostId)
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		page, _, err := client.GetReadReceiptsForUser(context.Background(), th.BasicUser.Id, model.GetReadReceiptsForUserOptions{Since: 1001, Until: 1002})
+		require.NoError(t, err)
+		require.Len(t, page.Receipts, 1)
+		require.Equal(t, posts[1].Id, page.Receipts[0].PostId)
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		_, resp, err := client.GetReadReceiptsForUser(context.Background(), th.BasicUser2.Id, model.GetReadReceiptsForUserOptions{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin can audit a user", func(t *testing.T) {
+		page, _, err := th.SystemAdminClient.GetReadReceiptsForUser(context.Background(), th.BasicUser.Id, model.GetReadReceiptsForUserOptions{})
+		require.NoError(t, err)
+		require.Len(t, page.Receipts, 3)
+	})
+}
+
+func TestGetReadReceiptSessionActivity(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	session, appErr := th.App.GetSession(th.Client.AuthToken)
+	require.Nil(t, appErr)
+
+	_, _, err := th.Client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+	require.NoError(t, err)
+
+	activity, _, err := th.Client.GetReadReceiptSessionActivity(context.Background(), th.BasicUser.Id, 0, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	require.Equal(t, session.Id, activity[0].SessionId)
+	require.True(t, activity[0].SessionActive)
+	require.Equal(t, int64(1), activity[0].ReadCount)
+
+	t.Run("other users cannot see the activity", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptSessionActivity(context.Background(), th.BasicUser2.Id, 0, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetReadReceiptsForSession(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	session, appErr := th.App.GetSession(th.Client.AuthToken)
+	require.Nil(t, appErr)
+
+	receipt, _, err := th.Client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{})
+	require.NoError(t, err)
+	require.Equal(t, session.Id, receipt.SessionId)
+
+	t.Run("requires compliance monitoring permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptsForSession(context.Background(), session.Id, model.GetReadReceiptsForUserOptions{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		page, _, err := th.SystemAdminClient.GetReadReceiptsForSession(context.Background(), session.Id, model.GetReadReceiptsForUserOptions{})
+		require.NoError(t, err)
+		require.Len(t, page.Receipts, 1)
+		require.Equal(t, th.BasicPost.Id, page.Receipts[0].PostId)
+		require.Equal(t, th.BasicUser.Id, page.Receipts[0].UserId)
+	})
+
+	t.Run("other sessions", func(t *testing.T) {
+		page, _, err := th.SystemAdminClient.GetReadReceiptsForSession(context.Background(), model.NewId(), model.GetReadReceiptsForUserOptions{})
+		require.NoError(t, err)
+		require.Empty(t, page.Receipts)
+	})
+}
+
+func TestSaveEmailLinkPostReadReceipt(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, resp, err := th.Client.SaveEmailLinkPostReadReceipt(context.Background(), th.BasicPost.Id, "123.abc")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "api.read_receipt.email_link.invalid_token.app_error")
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, resp, err := th.Client.SaveEmailLinkPostReadReceipt(context.Background(), th.BasicPost.Id, "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetColdStorageReadReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsColdStorageDays = 30
+	})
+
+	for i, user := range []*model.User{th.BasicUser, th.BasicUser2} {
+		_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{
+			PostId:    th.BasicPost.Id,
+			UserId:    user.Id,
+			ChannelId: th.BasicChannel.Id,
+			ReadAt:    int64(1000 + i),
+		})
+		require.NoError(t, err)
+	}
+
+	offloaded, err := th.App.OffloadReadReceiptsToColdStorage(model.ReadReceiptsPageCursor{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), offloaded)
+
+	query := model.ReadReceiptColdStorageQuery{ChannelId: th.BasicChannel.Id}
+
+	t.Run("requires compliance monitoring permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetColdStorageReadReceipts(context.Background(), query)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires a channel", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetColdStorageReadReceipts(context.Background(), model.ReadReceiptColdStorageQuery{})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.GetColdStorageReadReceipts(context.Background(), query)
+		require.NoError(t, err)
+		require.False(t, result.Truncated)
+		require.Len(t, result.Receipts, 2)
+		require.Equal(t, th.BasicUser.Id, result.Receipts[0].UserId)
+		require.Equal(t, th.BasicUser2.Id, result.Receipts[1].UserId)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		result, _, err := th.SystemAdminClient.GetColdStorageReadReceipts(context.Background(), model.ReadReceiptColdStorageQuery{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser2.Id,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Receipts, 1)
+		require.Equal(t, int64(1001), result.Receipts[0].ReadAt)
+
+		result, _, err = th.SystemAdminClient.GetColdStorageReadReceipts(context.Background(), model.ReadReceiptColdStorageQuery{
+			ChannelId: th.BasicChannel.Id,
+			Since:     2000,
+		})
+		require.NoError(t, err)
+		require.Empty(t, result.Receipts)
+	})
+}
+
+func TestGetChannelMembersByLastReadActivity(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("requires read receipts", func(t *testing.T) {
+		_, resp, err := th.Client.GetChannelMembersByLastReadActivity(context.Background(), th.BasicChannel.Id, 0, 60)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.EnableReadReceipts()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, th.BasicPost)
+
+	t.Run("most recent readers first", func(t *testing.T) {
+		members, _, err := th.Client.GetChannelMembersByLastReadActivity(context.Background(), th.BasicChannel.Id, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		require.Equal(t, th.BasicUser2.Id, members[0].UserId)
+		require.Equal(t, th.BasicUser.Id, members[1].UserId)
+
+		th.MarkPostAsRead(th.CreatePost())
+
+		members, _, err = th.Client.GetChannelMembersByLastReadActivity(context.Background(), th.BasicChannel.Id, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		require.Equal(t, th.BasicUser.Id, members[0].UserId)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		resp, err := th.Client.DoAPIGet(context.Background(), "/channels/"+th.BasicChannel.Id+"/members?sort=unknown", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(resp))
+	})
+}
+
+func TestGetReadReceiptsOverview(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	t.Run("requires system console reporting permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptsOverview(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		overview, _, err := th.SystemAdminClient.GetReadReceiptsOverview(context.Background())
+		require.NoError(t, err)
+		require.NotEmpty(t, overview.TopChannels)
+		require.Equal(t, th.BasicChannel.Id, overview.TopChannels[0].ChannelId)
+		require.Equal(t, int64(1), overview.TopChannels[0].Count)
+	})
+}
+
+func TestGetReadReceiptsHealth(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	t.Run("requires system console reporting permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptsHealth(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		health, _, err := th.SystemAdminClient.GetReadReceiptsHealth(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptsHealthOk, health.Status)
+		require.Equal(t, int64(60*1000), health.SummaryStalenessSLA)
+	})
+}
+
+func TestGetReadReceiptTeamUsage(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	th.MarkPostAsRead(th.BasicPost)
+	require.NoError(t, th.App.Srv().Store().PostReadReceipt().RefreshReadReceiptStats())
+
+	t.Run("requires system console reporting permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptTeamUsage(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		usage, _, err := th.SystemAdminClient.GetReadReceiptTeamUsage(context.Background())
+		require.NoError(t, err)
+
+		var teamUsage *model.ReadReceiptTeamUsage
+		for _, u := range usage {
+			if u.TeamId == th.BasicTeam.Id {
+				teamUsage = u
+			}
+		}
+		require.NotNil(t, teamUsage)
+		require.Equal(t, int64(1), teamUsage.ReceiptCount)
+		require.Equal(t, th.BasicTeam.DisplayName, teamUsage.TeamDisplayName)
+	})
+}
+
+func TestVerifyReadReceiptChain(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsEnableIntegrityChain = true
+	})
+
+	post := th.CreatePost()
+	th.MarkPostAsRead(th.BasicPost)
+	th.MarkPostAsRead(post)
+
+	t.Run("requires compliance monitoring permission", func(t *testing.T) {
+		_, resp, err := th.Client.VerifyReadReceiptChain(context.Background(), th.BasicChannel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("intact chain", func(t *testing.T) {
+		verification, _, err := th.SystemAdminClient.VerifyReadReceiptChain(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.True(t, verification.Valid)
+		require.EqualValues(t, 2, verification.EntriesChecked)
+		require.NotEmpty(t, verification.HeadHash)
+	})
+
+	t.Run("deleted receipts are pruned from the chain", func(t *testing.T) {
+		other := th.CreatePost()
+		th.MarkPostAsRead(other)
+		require.Nil(t, th.App.DeleteReadReceiptForPost(th.Context, other.Id, th.BasicUser.Id))
+
+		entries, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptChain(th.BasicChannel.Id, 2, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.True(t, entries[0].IsPruned())
+
+		verification, _, err := th.SystemAdminClient.VerifyReadReceiptChain(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.True(t, verification.Valid)
+		require.EqualValues(t, 3, verification.EntriesChecked)
+	})
+
+	t.Run("receipt altered outside the chain", func(t *testing.T) {
+		receipt, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+		receipt.ReadAt++
+		_, err = th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{receipt})
+		require.NoError(t, err)
+
+		verification, _, err := th.SystemAdminClient.VerifyReadReceiptChain(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.False(t, verification.Valid)
+		require.Zero(t, verification.FirstInvalidSequence)
+		require.Equal(t, []int64{2}, verification.MismatchedSequences)
+
+		receipt.ReadAt--
+		_, err = th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{receipt})
+		require.NoError(t, err)
+	})
+
+	t.Run("forged entry", func(t *testing.T) {
+		head, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptChainHead(th.BasicChannel.Id)
+		require.NoError(t, err)
+
+		forged := &model.ReadReceiptChainEntry{
+			ChannelId: th.BasicChannel.Id,
+			PostId:    post.Id,
+			UserId:    th.BasicUser2.Id,
+			ReadAt:    model.GetMillis(),
+		}
+		forged.Link([]byte("not the server key"), head)
+		require.NoError(t, th.App.Srv().Store().PostReadReceipt().AppendReadReceiptChain([]*model.ReadReceiptChainEntry{forged}))
+
+		verification, _, err := th.SystemAdminClient.VerifyReadReceiptChain(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.False(t, verification.Valid)
+		require.EqualValues(t, 3, verification.EntriesChecked)
+		require.Equal(t, forged.Sequence, verification.FirstInvalidSequence)
+	})
+}
+
+func TestGetReadDevicesForPostUser(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsStoreAllDevices = true })
+
+	for _, deviceID := range []string{"managed-laptop", "unknown-phone"} {
+		_, _, err := th.Client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{DeviceId: deviceID})
+		require.NoError(t, err)
+	}
+
+	t.Run("requires compliance permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadDevicesForPostUser(context.Background(), th.BasicPost.Id, th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("lists every device", func(t *testing.T) {
+		devices, _, err := th.SystemAdminClient.GetReadDevicesForPostUser(context.Background(), th.BasicPost.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, devices, 2)
+
+		receipts, _, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Len(t, receipts.Receipts, 1)
+	})
+}
+
+func TestReadReceiptVisibilityModeration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	th.RemovePermissionFromRole(model.PermissionViewReadReceipts.Id, model.ChannelUserRoleId)
+	defer th.AddPermissionToRole(model.PermissionViewReadReceipts.Id, model.ChannelUserRoleId)
+
+	_, resp, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	t.Run("recording receipts is still allowed", func(t *testing.T) {
+		_, _, err := th.Client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{ReadAt: model.GetMillis()})
+		require.NoError(t, err)
+	})
+}
+
+func TestPinPostUnreadNotice(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	wsClient := th.CreateConnectedWebSocketClient(t)
+
+	waitForNotice := func(t *testing.T) *model.Post {
+		t.Helper()
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case event := <-wsClient.EventChannel:
+				if event.EventType() != model.WebsocketEventEphemeralMessage {
+					continue
+				}
+
+				var post model.Post
+				require.NoError(t, json.Unmarshal([]byte(event.GetData()["post"].(string)), &post))
+				return &post
+			case <-timeout:
+				return nil
+			}
+		}
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		post := th.CreatePost()
+		_, err := th.Client.PinPost(context.Background(), post.Id)
+		require.NoError(t, err)
+
+		// Events reach the client in order, so getting this message first shows
+		// that no notice was sent, without waiting for one.
+		th.App.SendEphemeralPost(th.Context, th.BasicUser.Id, &model.Post{ChannelId: th.BasicChannel.Id, Message: "no notice"})
+		notice := waitForNotice(t)
+		require.NotNil(t, notice)
+		require.Equal(t, "no notice", notice.Message)
+	})
+
+	_, appErr := th.App.UpdateReadReceiptChannelSettings(&model.ReadReceiptChannelSettings{ChannelId: th.BasicChannel.Id, BroadcastReceipts: true, PinnedUnreadNotice: true})
+	require.Nil(t, appErr)
+
+	t.Run("lists the members who have not read the post", func(t *testing.T) {
+		post := th.CreatePost()
+		_, err := th.Client.PinPost(context.Background(), post.Id)
+		require.NoError(t, err)
+
+		notice := waitForNotice(t)
+		require.NotNil(t, notice)
+		require.Equal(t, th.BasicChannel.Id, notice.ChannelId)
+		require.Contains(t, notice.Message, "@"+th.BasicUser2.Username)
+		require.NotContains(t, notice.Message, "@"+th.BasicUser.Username)
+	})
+
+	t.Run("everyone has read the post", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		post := th.CreatePost()
+		th.MarkPostAsReadWithClient(client2, post)
+
+		_, err := th.Client.PinPost(context.Background(), post.Id)
+		require.NoError(t, err)
+
+		notice := waitForNotice(t)
+		require.NotNil(t, notice)
+		require.NotContains(t, notice.Message, "@"+th.BasicUser2.Username)
+	})
+}
+
+func TestExportReadReceiptsForUser(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by config", func(t *testing.T) {
+		_, resp, err := th.Client.ExportReadReceiptsForUser(context.Background(), model.ReadReceiptExportFormatJSON)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.EnableReadReceipts()
+	th.MarkPostAsRead(th.BasicPost)
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, resp, err := th.Client.ExportReadReceiptsForUser(context.Background(), "xml")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, _, err := th.Client.ExportReadReceiptsForUser(context.Background(), model.ReadReceiptExportFormatJSON)
+		require.NoError(t, err)
+
+		var receipts []*model.PostReadReceipt
+		require.NoError(t, json.Unmarshal(data, &receipts))
+		require.Len(t, receipts, 1)
+		require.Equal(t, th.BasicPost.Id, receipts[0].PostId)
+		require.Empty(t, receipts[0].SessionId)
+	})
+
+	t.Run("once per hour", func(t *testing.T) {
+		_, resp, err := th.Client.ExportReadReceiptsForUser(context.Background(), model.ReadReceiptExportFormatCSV)
+		require.Error(t, err)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		th.MarkPostAsReadWithClient(client2, th.BasicPost)
+
+		data, _, err := client2.ExportReadReceiptsForUser(context.Background(), model.ReadReceiptExportFormatCSV)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, "post_id", records[0][0])
+		require.Equal(t, th.BasicPost.Id, records[1][0])
+	})
+}
+
+func TestGetPostReadReceiptExtremes(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by config", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostReadReceiptExtremes(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.EnableReadReceipts()
+
+	t.Run("nobody read the post", func(t *testing.T) {
+		extremes, _, err := th.Client.GetPostReadReceiptExtremes(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Nil(t, extremes.FirstReader)
+		require.Nil(t, extremes.LastReader)
+	})
+
+	t.Run("first and last readers", func(t *testing.T) {
+		firstReadAt := th.BasicPost.CreateAt + 1000
+		_, _, err := th.Client.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{ReadAt: firstReadAt})
+		require.NoError(t, err)
+
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		_, _, err = client2.SavePostReadReceipt(context.Background(), th.BasicPost.Id, &model.ReadReceiptRequest{ReadAt: firstReadAt + 1000})
+		require.NoError(t, err)
+
+		extremes, _, err := th.Client.GetPostReadReceiptExtremes(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Equal(t, &model.ReadReceiptReader{UserId: th.BasicUser.Id, ReadAt: firstReadAt}, extremes.FirstReader)
+		require.Equal(t, &model.ReadReceiptReader{UserId: th.BasicUser2.Id, ReadAt: firstReadAt + 1000}, extremes.LastReader)
+	})
+
+	t.Run("no access to the post", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		post := th.CreatePostWithClient(th.SystemAdminClient, privateChannel)
+		_, resp, err := th.Client.GetPostReadReceiptExtremes(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestIsPostRead(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by config", func(t *testing.T) {
+		_, resp, err := th.Client.IsPostRead(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.EnableReadReceipts()
+
+	t.Run("unread post", func(t *testing.T) {
+		read, resp, err := th.Client.IsPostRead(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		CheckNotFoundStatus(t, resp)
+		require.False(t, read)
+	})
+
+	t.Run("read post", func(t *testing.T) {
+		th.MarkPostAsReadWithClient(th.Client, th.BasicPost)
+
+		read, resp, err := th.Client.IsPostRead(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.True(t, read)
+	})
+
+	t.Run("no access to the post", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		post := th.CreatePostWithClient(th.SystemAdminClient, privateChannel)
+		_, resp, err := th.Client.IsPostRead(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetPostsReadState(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	readPost := th.CreatePost()
+	unreadPost := th.CreatePost()
+	receipt := th.MarkPostAsReadWithClient(th.Client, readPost)
+
+	t.Run("read and unread posts", func(t *testing.T) {
+		states, _, err := th.Client.GetPostsReadState(context.Background(), model.Me, []string{readPost.Id, unreadPost.Id})
+		require.NoError(t, err)
+		require.Equal(t, map[string]*model.PostReadState{
+			readPost.Id:   {Read: true, ReadAt: receipt.ReadAt},
+			unreadPost.Id: {},
+		}, states)
+	})
+
+	t.Run("too many posts", func(t *testing.T) {
+		postIDs := make([]string, model.ReadStateMaxPosts+1)
+		for i := range postIDs {
+			postIDs[i] = model.NewId()
+		}
+		_, resp, err := th.Client.GetPostsReadState(context.Background(), model.Me, postIDs)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostsReadState(context.Background(), th.BasicUser2.Id, []string{readPost.Id})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestRecallDirectMessageAfterRead(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsRestrictDMRecall = true
+	})
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	readPost := th.CreatePostWithClient(th.Client, dm)
+	unreadPost := th.CreatePostWithClient(th.Client, dm)
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, readPost)
+
+	t.Run("read messages can no longer be deleted", func(t *testing.T) {
+		resp, err := th.Client.DeletePost(context.Background(), readPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "app.post.recall.already_read.app_error")
+	})
+
+	t.Run("unread messages can be deleted", func(t *testing.T) {
+		_, err := th.Client.DeletePost(context.Background(), unreadPost.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("channel posts are not restricted", func(t *testing.T) {
+		post := th.CreatePost()
+		th.MarkPostAsReadWithClient(client2, post)
+
+		_, err := th.Client.DeletePost(context.Background(), post.Id)
+		require.NoError(t, err)
+	})
+}
+
+func TestGetPostSeenState(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post := th.CreatePostWithClient(th.Client, dm)
+
+	t.Run("not seen yet", func(t *testing.T) {
+		state, resp, err := th.Client.GetPostSeenState(context.Background(), post.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, post.Id, state.PostId)
+		require.False(t, state.Seen)
+	})
+
+	t.Run("the author reading it does not count", func(t *testing.T) {
+		th.MarkPostAsReadWithClient(th.Client, post)
+
+		state, _, err := th.Client.GetPostSeenState(context.Background(), post.Id)
+		require.NoError(t, err)
+		require.False(t, state.Seen)
+	})
+
+	t.Run("seen by the recipient", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		th.MarkPostAsReadWithClient(client2, post)
+
+		state, _, err := th.Client.GetPostSeenState(context.Background(), post.Id)
+		require.NoError(t, err)
+		require.True(t, state.Seen)
+	})
+
+	t.Run("channel posts have no seen state", func(t *testing.T) {
+		_, resp, err := th.Client.GetPostSeenState(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetPostMentionReadState(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	outsider := th.CreateUser()
+	post, _, err := th.Client.CreatePost(context.Background(), &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "@" + th.BasicUser2.Username + ", @" + outsider.Username + " and @" + th.BasicUser.Username + " please look",
+	})
+	require.NoError(t, err)
+
+	t.Run("not read yet", func(t *testing.T) {
+		state, resp, err := th.Client.GetPostMentionReadState(context.Background(), post.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, post.Id, state.PostId)
+		require.Empty(t, state.Read)
+		require.Equal(t, []string{th.BasicUser2.Id}, state.Unread)
+	})
+
+	t.Run("read by the mentioned member", func(t *testing.T) {
+		client2 := th.CreateClient()
+		th.LoginBasic2WithClient(client2)
+		th.MarkPostAsReadWithClient(client2, post)
+
+		state, _, err := th.Client.GetPostMentionReadState(context.Background(), post.Id)
+		require.NoError(t, err)
+		require.Equal(t, []string{th.BasicUser2.Id}, state.Read)
+		require.Empty(t, state.Unread)
+	})
+
+	t.Run("posts without mentions", func(t *testing.T) {
+		state, _, err := th.Client.GetPostMentionReadState(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Empty(t, state.Read)
+		require.Empty(t, state.Unread)
+	})
+}
+
+func TestGetChannelMembersReadActivity(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	post := th.CreatePost()
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, post)
+
+	t.Run("requires permission to read the channels of the system console", func(t *testing.T) {
+		_, resp, err := th.Client.GetChannelMembersReadActivity(context.Background(), th.BasicChannel.Id, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("lists the members who never read the channel first", func(t *testing.T) {
+		activity, _, err := th.SystemAdminClient.GetChannelMembersReadActivity(context.Background(), th.BasicChannel.Id, 0, 60)
+		require.NoError(t, err)
+		require.NotEmpty(t, activity)
+		require.Nil(t, activity[0].LastPostReadAt)
+
+		last := activity[len(activity)-1]
+		require.Equal(t, th.BasicUser2.Id, last.UserId)
+		require.NotNil(t, last.LastPostReadAt)
+		require.EqualValues(t, 1, last.PostsRead)
+	})
+}
+
+func TestGetChannelDailyReaderCounts(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	post := th.CreatePost()
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, post)
+	today := time.Now().UTC().Format("2006-01-02")
+
+	t.Run("requires permission to read the channels of the system console", func(t *testing.T) {
+		_, resp, err := th.Client.GetChannelDailyReaderCounts(context.Background(), th.BasicChannel.Id, today, today)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("counts the readers of the day", func(t *testing.T) {
+		counts, _, err := th.SystemAdminClient.GetChannelDailyReaderCounts(context.Background(), th.BasicChannel.Id, today, today)
+		require.NoError(t, err)
+		require.Equal(t, []*model.DailyReaderCount{{Day: today, ReaderCount: 1}}, counts)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetChannelDailyReaderCounts(context.Background(), th.BasicChannel.Id, today, "2000-01-01")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetChannelDailyReaderCounts(context.Background(), th.BasicChannel.Id, "2000-01-01", today)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetChannelDailyReaderCounts(context.Background(), th.BasicChannel.Id, "yesterday", today)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetPostReadReceiptsCompact(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	expected, _, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+	require.NoError(t, err)
+	require.Len(t, expected.Receipts, 1)
+
+	t.Run("negotiated through the Accept header", func(t *testing.T) {
+		info, resp, err := th.Client.GetPostReadReceiptsCompact(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptCompactContentType, resp.Header.Get("Content-Type"))
+		require.Equal(t, expected, info)
+	})
+
+	t.Run("negotiated through the format query parameter", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet(context.Background(), "/posts/"+th.BasicPost.Id+"/receipts?format=compact", "")
+		require.NoError(t, err)
+		defer r.Body.Close()
+		require.Equal(t, model.ReadReceiptCompactContentType, r.Header.Get("Content-Type"))
+
+		var raw map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		require.Equal(t, th.BasicPost.Id, raw["p"])
+		require.NotContains(t, raw, "post_id")
+	})
+
+	t.Run("regular encoding by default", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet(context.Background(), "/posts/"+th.BasicPost.Id+"/receipts", "")
+		require.NoError(t, err)
+		defer r.Body.Close()
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	})
+}
+
+func TestGetPostReadReceiptsGuests(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.Enable = true })
+	th.App.Srv().SetLicense(model.NewTestLicense())
+
+	// The guest only belongs to another team than the members.
+	guest, appErr := th.App.CreateGuest(th.Context, &model.User{
+		Email:         th.GenerateTestEmail(),
+		Username:      GenerateTestUsername(),
+		Password:      "Password1",
+		EmailVerified: true,
+	})
+	require.Nil(t, appErr)
+	otherTeam := th.CreateTeam()
+	_, _, appErr = th.App.AddUserToTeam(th.Context, otherTeam.Id, guest.Id, th.SystemAdminUser.Id)
+	require.Nil(t, appErr)
+	guestClient := th.CreateClient()
+	_, _, err := guestClient.Login(context.Background(), guest.Email, "Password1")
+	require.NoError(t, err)
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+
+	readers := func(t *testing.T, client *model.Client4, post *model.Post) []string {
+		t.Helper()
+		info, _, err := client.GetPostReadReceipts(context.Background(), post.Id)
+		require.NoError(t, err)
+		userIds := []string{}
+		for _, receipt := range info.Receipts {
+			userIds = append(userIds, receipt.UserId)
+		}
+		return userIds
+	}
+
+	t.Run("guest in a direct message", func(t *testing.T) {
+		dm := th.CreateDmChannel(guest)
+
+		post := th.CreatePostWithClient(th.Client, dm)
+		th.MarkPostAsReadWithClient(guestClient, post)
+		require.Equal(t, []string{guest.Id}, readers(t, th.Client, post))
+
+		post = th.CreatePostWithClient(guestClient, dm)
+		th.MarkPostAsRead(post)
+		require.Equal(t, []string{th.BasicUser.Id}, readers(t, guestClient, post))
+	})
+
+	t.Run("guest in a group message", func(t *testing.T) {
+		gm, appErr := th.App.CreateGroupChannel(th.Context, []string{th.BasicUser.Id, th.BasicUser2.Id, guest.Id}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		post := th.CreatePostWithClient(guestClient, gm)
+		th.MarkPostAsRead(post)
+		th.MarkPostAsReadWithClient(client2, post)
+		require.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, readers(t, guestClient, post))
+
+		// Once out of the conversation, nothing lets the guest see the reader anymore.
+		require.NoError(t, th.App.Srv().Store().Channel().RemoveMember(th.Context, gm.Id, th.BasicUser2.Id))
+		require.Equal(t, []string{th.BasicUser.Id}, readers(t, guestClient, post))
+
+		// The members still see every reader.
+		require.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, readers(t, th.Client, post))
+
+		t.Run("other read paths", func(t *testing.T) {
+			extremes, _, err := guestClient.GetPostReadReceiptExtremes(context.Background(), post.Id)
+			require.NoError(t, err)
+			require.NotNil(t, extremes.FirstReader)
+			require.Equal(t, th.BasicUser.Id, extremes.FirstReader.UserId)
+			require.Nil(t, extremes.LastReader)
+
+			page, _, err := guestClient.GetChannelReadReceiptChanges(context.Background(), guest.Id, gm.Id, 0, 100)
+			require.NoError(t, err)
+			require.NotEmpty(t, page.Changes)
+			for _, change := range page.Changes {
+				require.NotEqual(t, th.BasicUser2.Id, change.UserId)
+			}
+			require.NotZero(t, page.LastSequence)
+
+			page, _, err = th.Client.GetChannelReadReceiptChanges(context.Background(), th.BasicUser.Id, gm.Id, 0, 100)
+			require.NoError(t, err)
+			changedBy := []string{}
+			for _, change := range page.Changes {
+				changedBy = append(changedBy, change.UserId)
+			}
+			require.Contains(t, changedBy, th.BasicUser2.Id)
+		})
+	})
+}
+
+func TestGetReadReceiptChanges(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	t.Run("channel changes", func(t *testing.T) {
+		page, _, err := th.Client.GetChannelReadReceiptChanges(context.Background(), th.BasicUser.Id, th.BasicChannel.Id, 0, 60)
+		require.NoError(t, err)
+		require.Len(t, page.Changes, 1)
+		require.Equal(t, th.BasicPost.Id, page.Changes[0].PostId)
+		require.Equal(t, model.ReadReceiptChangeOpSave, page.Changes[0].Op)
+
+		page, _, err = th.Client.GetChannelReadReceiptChanges(context.Background(), th.BasicUser.Id, th.BasicChannel.Id, page.LastSequence, 60)
+		require.NoError(t, err)
+		require.Empty(t, page.Changes)
+	})
+
+	t.Run("channel changes of other users", func(t *testing.T) {
+		_, resp, err := th.Client.GetChannelReadReceiptChanges(context.Background(), th.BasicUser2.Id, th.BasicChannel.Id, 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("every channel requires compliance monitoring permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptChanges(context.Background(), 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		page, _, err := th.SystemAdminClient.GetReadReceiptChanges(context.Background(), 0, 200)
+		require.NoError(t, err)
+		require.NotEmpty(t, page.Changes)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitReadReceiptBroadcast() {
+	api.BaseRoutes.Broadcast.Handle("/read_summary", api.APISessionRequired(getReadReceiptBroadcastSummary)).Methods(http.MethodGet)
+	api.BaseRoutes.Team.Handle("/read_receipts/announcement_report", api.APISessionRequired(getReadReceiptAnnouncementReport)).Methods(http.MethodGet)
+}
+
+// getReadReceiptBroadcastSummary aggregates the read receipts of the copies of a
+// broadcast. Only its creator and system admins may follow it.
+func getReadReceiptBroadcastSummary(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptBroadcastSummary")
+	if c.Err != nil {
+		return
+	}
+
+	broadcastID := mux.Vars(r)["broadcast_id"]
+	if !model.IsValidId(broadcastID) {
+		c.SetInvalidURLParam("broadcast_id")
+		return
+	}
+
+	broadcast, appErr := c.App.GetReadReceiptBroadcast(c.AppContext, broadcastID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if broadcast.CreatorId != c.AppContext.Session().UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	summary, appErr := c.App.GetReadReceiptBroadcastSummary(c.AppContext, broadcast)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(summary)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptBroadcastSummary", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getReadReceiptAnnouncementReport aggregates the read receipts of the copies of an
+// announcement cross-posted to channels of the team, given as the comma separated
+// post_ids query parameter. It is restricted to team admins.
+func getReadReceiptAnnouncementReport(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptAnnouncementReport")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	postIDs := model.RemoveDuplicateStrings(strings.Split(r.URL.Query().Get("post_ids"), ","))
+	if len(postIDs) > model.ReadReceiptBroadcastMaxChannels {
+		c.SetInvalidURLParam("post_ids")
+		return
+	}
+	for _, postID := range postIDs {
+		if !model.IsValidId(postID) {
+			c.SetInvalidURLParam("post_ids")
+			return
+		}
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	report, appErr := c.App.GetReadReceiptAnnouncementReport(c.AppContext, c.Params.TeamId, postIDs)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(report)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptAnnouncementReport", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
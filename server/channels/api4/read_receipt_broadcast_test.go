@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetReadReceiptBroadcastSummary(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	broadcast, appErr := th.App.BroadcastWithReadTracking(th.Context, &model.Post{
+		UserId:  th.BasicUser.Id,
+		Message: "announcement",
+	}, []string{th.BasicChannel.Id, th.BasicChannel2.Id, th.BasicChannel.Id})
+	require.Nil(t, appErr)
+	require.Len(t, broadcast.Posts, 2)
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	for _, post := range broadcast.Posts {
+		if post.ChannelId == th.BasicChannel.Id {
+			th.MarkPostAsReadWithClient(client2, &model.Post{Id: post.PostId})
+		}
+	}
+
+	t.Run("aggregates the copies", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			summary, _, err := th.Client.GetReadReceiptBroadcastSummary(context.Background(), broadcast.Id)
+			return err == nil && summary.ReadCount == 1
+		}, 5*time.Second, 100*time.Millisecond)
+
+		summary, _, err := th.Client.GetReadReceiptBroadcastSummary(context.Background(), broadcast.Id)
+		require.NoError(t, err)
+		require.Len(t, summary.Channels, 2)
+		assert.Equal(t, broadcast.Id, summary.BroadcastId)
+		assert.Positive(t, summary.TotalMembers)
+		assert.Equal(t, model.ReadReceiptPercentage(1, summary.TotalMembers), summary.ReadPercentage)
+	})
+
+	t.Run("restricted to the creator and system admins", func(t *testing.T) {
+		_, resp, err := client2.GetReadReceiptBroadcastSummary(context.Background(), broadcast.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, _, err = th.SystemAdminClient.GetReadReceiptBroadcastSummary(context.Background(), broadcast.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown broadcast", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptBroadcastSummary(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("archived channels are rejected", func(t *testing.T) {
+		_, appErr := th.App.BroadcastWithReadTracking(th.Context, &model.Post{
+			UserId:  th.BasicUser.Id,
+			Message: "announcement",
+		}, []string{th.BasicChannel.Id, th.BasicDeletedChannel.Id})
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestGetReadReceiptAnnouncementReport(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	post1 := th.CreatePostWithClient(th.Client, th.BasicChannel)
+	post2 := th.CreatePostWithClient(th.Client, th.BasicChannel2)
+	otherTeam := th.CreateTeamWithClient(th.SystemAdminClient)
+	otherTeamPost := th.CreatePostWithClient(th.SystemAdminClient, th.CreateChannelWithClientAndTeam(th.SystemAdminClient, model.ChannelTypeOpen, otherTeam.Id))
+	postIDs := []string{post1.Id, post2.Id, otherTeamPost.Id}
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+	th.MarkPostAsReadWithClient(client2, post1)
+
+	t.Run("restricted to team admins", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptAnnouncementReport(context.Background(), th.BasicTeam.Id, postIDs)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.UpdateUserToTeamAdmin(th.BasicUser, th.BasicTeam)
+
+	t.Run("aggregates the posts of the team", func(t *testing.T) {
+		report, _, err := th.Client.GetReadReceiptAnnouncementReport(context.Background(), th.BasicTeam.Id, postIDs)
+		require.NoError(t, err)
+		require.Len(t, report.Channels, 2)
+		assert.Equal(t, th.BasicTeam.Id, report.TeamId)
+		assert.Equal(t, int64(1), report.ReadCount)
+		assert.Positive(t, report.TotalMembers)
+		assert.Equal(t, model.ReadReceiptPercentage(1, report.TotalMembers), report.ReadPercentage)
+
+		for _, channel := range report.Channels {
+			assert.Equal(t, model.ReadReceiptPercentage(channel.ReadCount, channel.TotalMembers), channel.ReadPercentage)
+		}
+	})
+
+	t.Run("invalid post ids", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptAnnouncementReport(context.Background(), th.BasicTeam.Id, []string{"junk"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.GetReadReceiptAnnouncementReport(context.Background(), th.BasicTeam.Id, nil)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	readReceiptEventsPollInterval = time.Second
+	readReceiptEventsHeartbeat    = 15 * time.Second
+	readReceiptEventsBatchSize    = 200
+	// readReceiptEventsWriteMargin ends the streams before the write timeout of
+	// the server cuts them, so that an event is never truncated.
+	readReceiptEventsWriteMargin = 10 * time.Second
+)
+
+func (api *API) InitReadReceiptEvents() {
+	// Opening streams is limited per token, since every stream polls the changes.
+	rateLimit := model.RateLimitSettings{
+		PerSec:           model.NewPointer(1),
+		MaxBurst:         model.NewPointer(5),
+		VaryByUser:       model.NewPointer(true),
+		VaryByRemoteAddr: model.NewPointer(true),
+	}
+	api.BaseRoutes.Channel.Handle("/read_receipts/events", api.RateLimitedHandler(api.APISessionRequired(getChannelReadReceiptEvents, handlerParamReadReceiptsScope), rateLimit)).Methods(http.MethodGet)
+}
+
+// getChannelReadReceiptEvents streams the receipt changes of the channel as
+// Server-Sent Events, for integrations that cannot use the websocket. The id of
+// each event is the sequence of the change, so that clients resume through the
+// Last-Event-ID header, or the last_event_id query parameter, after reconnecting.
+// Without either, the stream starts with the oldest change still retained.
+func getChannelReadReceiptEvents(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getChannelReadReceiptEvents")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionViewReadReceipts) {
+		c.SetPermissionError(model.PermissionViewReadReceipts)
+		return
+	}
+
+	requireReadReceiptsVisibleForChannel(c, c.Params.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		var err error
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after < 0 {
+			c.SetInvalidParamWithErr("last_event_id", err)
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		c.Err = model.NewAppError("getChannelReadReceiptEvents", "api.read_receipt.events.streaming_unsupported.app_error", nil, "", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", readReceiptEventsPollInterval.Milliseconds()); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	var deadline <-chan time.Time
+	if writeTimeout := time.Duration(*c.App.Config().ServiceSettings.WriteTimeout) * time.Second; writeTimeout > readReceiptEventsWriteMargin {
+		deadline = time.After(writeTimeout - readReceiptEventsWriteMargin)
+	}
+	poll := time.NewTicker(readReceiptEventsPollInterval)
+	defer poll.Stop()
+	lastWrite := time.Now()
+
+	for {
+		page, appErr := c.App.GetReadReceiptChanges(c.AppContext, c.AppContext.Session().UserId, c.Params.ChannelId, after, readReceiptEventsBatchSize)
+		if appErr != nil {
+			c.Logger.Warn("Failed to get the receipt changes to stream", mlog.String("channel_id", c.Params.ChannelId), mlog.Err(appErr))
+			return
+		}
+		for _, change := range page.Changes {
+			data, err := json.Marshal(change)
+			if err != nil {
+				c.Logger.Warn("Failed to marshal a receipt change", mlog.Int("sequence", change.Sequence), mlog.Err(err))
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", change.Sequence, change.Op, data); err != nil {
+				return
+			}
+		}
+		after = page.LastSequence
+
+		switch {
+		case len(page.Changes) > 0:
+			flusher.Flush()
+			lastWrite = time.Now()
+		case time.Since(lastWrite) >= readReceiptEventsHeartbeat:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+			lastWrite = time.Now()
+		}
+
+		// Keep draining without waiting while a backlog is left.
+		if len(page.Changes) == readReceiptEventsBatchSize {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-deadline:
+				return
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline:
+			return
+		case <-poll.C:
+		}
+	}
+}
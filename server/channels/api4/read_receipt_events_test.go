@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetChannelReadReceiptEvents(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	route := "/channels/" + th.BasicChannel.Id + "/read_receipts/events"
+
+	// nextChange reads the stream up to its next event.
+	nextChange := func(t *testing.T, scanner *bufio.Scanner) *model.ReadReceiptChange {
+		t.Helper()
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var change model.ReadReceiptChange
+			require.NoError(t, json.Unmarshal([]byte(data), &change))
+			return &change
+		}
+		require.NoError(t, scanner.Err())
+		require.Fail(t, "the stream ended")
+		return nil
+	}
+
+	th.MarkPostAsRead(th.BasicPost)
+
+	var first *model.ReadReceiptChange
+	t.Run("streams the changes of the channel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		r, err := th.Client.DoAPIGet(ctx, route, "")
+		require.NoError(t, err)
+		defer r.Body.Close()
+		require.Equal(t, http.StatusOK, r.StatusCode)
+		require.Equal(t, "text/event-stream", r.Header.Get("Content-Type"))
+
+		first = nextChange(t, bufio.NewScanner(r.Body))
+		require.Equal(t, model.ReadReceiptChangeOpSave, first.Op)
+		require.Equal(t, th.BasicPost.Id, first.PostId)
+		require.Equal(t, th.BasicUser.Id, first.UserId)
+	})
+
+	t.Run("resumes after the last event", func(t *testing.T) {
+		require.NotNil(t, first)
+		post := th.CreatePost()
+		th.MarkPostAsRead(post)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		r, err := th.Client.DoAPIRequestWithHeaders(ctx, http.MethodGet, th.Client.APIURL+route, "", map[string]string{"Last-Event-ID": strconv.FormatInt(first.Sequence, 10)})
+		require.NoError(t, err)
+		defer r.Body.Close()
+
+		change := nextChange(t, bufio.NewScanner(r.Body))
+		require.Equal(t, post.Id, change.PostId)
+		require.Greater(t, change.Sequence, first.Sequence)
+	})
+
+	t.Run("invalid last event id", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet(context.Background(), route+"?last_event_id=junk", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(r))
+	})
+
+	t.Run("requires access to the channel", func(t *testing.T) {
+		channel := th.CreatePrivateChannel()
+		th.RemoveUserFromChannel(th.BasicUser, channel)
+
+		r, err := th.Client.DoAPIGet(context.Background(), "/channels/"+channel.Id+"/read_receipts/events", "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, model.BuildResponse(r))
+	})
+}
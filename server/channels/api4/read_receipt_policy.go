@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitReadReceiptPolicy() {
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipt_policies", api.APISessionRequired(getReadReceiptPolicies)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipt_policies", api.APISessionRequired(updateReadReceiptPolicies)).Methods(http.MethodPut)
+	api.BaseRoutes.APIRoot.Handle("/admin/read_receipts/cleanup/preview", api.APISessionRequired(previewReadReceiptCleanup)).Methods(http.MethodPost)
+	api.BaseRoutes.Channel.Handle("/read_receipt_policy", api.APISessionRequired(getEffectiveReadReceiptPolicy)).Methods(http.MethodGet)
+}
+
+func getReadReceiptPolicies(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	policies, appErr := c.App.GetReadReceiptPolicies()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(policies)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptPolicies", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateReadReceiptPolicies(c *Context, w http.ResponseWriter, r *http.Request) {
+	var policies []*model.ReadReceiptPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policies); err != nil {
+		c.SetInvalidParamWithErr("read_receipt_policies", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateReadReceiptPolicies, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "count", len(policies))
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceComplianceMonitoring)
+		return
+	}
+
+	saved, appErr := c.App.UpdateReadReceiptPolicies(policies)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventObjectType("read_receipt_policy")
+	auditRec.Success()
+
+	js, err := json.Marshal(saved)
+	if err != nil {
+		c.Err = model.NewAppError("updateReadReceiptPolicies", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// previewReadReceiptCleanup estimates what enforcing the retention of the policy
+// in the body would delete, so that admins can check it before enabling it.
+func previewReadReceiptCleanup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var policy model.ReadReceiptPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		c.SetInvalidParamWithErr("read_receipt_policy", err)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceComplianceMonitoring) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceComplianceMonitoring)
+		return
+	}
+
+	preview, appErr := c.App.PreviewReadReceiptCleanup(&policy)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(preview)
+	if err != nil {
+		c.Err = model.NewAppError("previewReadReceiptCleanup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// getEffectiveReadReceiptPolicy tells clients whether and how to send receipts in
+// the channel. It answers even when read receipts are disabled, so that clients
+// don't have to guess.
+func getEffectiveReadReceiptPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToReadChannel(c.AppContext, *c.AppContext.Session(), channel) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	policy, appErr := c.App.GetEffectiveReadReceiptPolicy(c.AppContext, c.AppContext.Session().UserId, channel)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(policy)
+	if err != nil {
+		c.Err = model.NewAppError("getEffectiveReadReceiptPolicy", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestReadReceiptPolicies(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	policies := []*model.ReadReceiptPolicy{
+		{Name: "default"},
+		{Name: "legal", TeamIds: model.StringArray{th.BasicTeam.Id}, VisibilityMode: model.ReadReceiptVisibilityHidden},
+	}
+
+	t.Run("requires a license", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateReadReceiptPolicies(context.Background(), policies)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.GetReadReceiptPolicies(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.UpdateReadReceiptPolicies(context.Background(), policies)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("replace and get", func(t *testing.T) {
+		saved, _, err := th.SystemAdminClient.UpdateReadReceiptPolicies(context.Background(), policies)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+
+		fetched, _, err := th.SystemAdminClient.GetReadReceiptPolicies(context.Background())
+		require.NoError(t, err)
+		require.Len(t, fetched, 2)
+		require.Equal(t, model.ReadReceiptVisibilityHidden, fetched[1].VisibilityMode)
+	})
+
+	t.Run("only one default policy", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateReadReceiptPolicies(context.Background(), []*model.ReadReceiptPolicy{{Name: "a"}, {Name: "b"}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestGetEffectiveReadReceiptPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled by config", func(t *testing.T) {
+		policy, _, err := th.Client.GetEffectiveReadReceiptPolicy(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.False(t, policy.Enabled)
+		require.Equal(t, model.ReadReceiptVisibilityEveryone, policy.VisibilityMode)
+	})
+
+	th.EnableReadReceipts()
+
+	t.Run("enabled without policies", func(t *testing.T) {
+		policy, _, err := th.Client.GetEffectiveReadReceiptPolicy(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.True(t, policy.Enabled)
+		require.Equal(t, model.ReadReceiptGranularityUser, policy.Granularity)
+		require.Equal(t, model.ReadReceiptBatchMaxPosts, policy.BatchMaxPosts)
+		require.Empty(t, policy.PolicyId)
+	})
+
+	t.Run("channel policy wins over the team policy", func(t *testing.T) {
+		th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+		defer th.App.Srv().RemoveLicense()
+
+		saved, _, err := th.SystemAdminClient.UpdateReadReceiptPolicies(context.Background(), []*model.ReadReceiptPolicy{
+			{Name: "team", TeamIds: model.StringArray{th.BasicTeam.Id}, VisibilityMode: model.ReadReceiptVisibilityHidden},
+			{Name: "channel", ChannelIds: model.StringArray{th.BasicChannel.Id}, VisibilityMode: model.ReadReceiptVisibilityAuthorOnly},
+		})
+		require.NoError(t, err)
+
+		policy, _, err := th.Client.GetEffectiveReadReceiptPolicy(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptVisibilityAuthorOnly, policy.VisibilityMode)
+		require.Equal(t, saved[1].Id, policy.PolicyId)
+
+		policy, _, err = th.Client.GetEffectiveReadReceiptPolicy(context.Background(), th.BasicChannel2.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptVisibilityHidden, policy.VisibilityMode)
+	})
+
+	t.Run("channel without access", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		_, resp, err := th.Client.GetEffectiveReadReceiptPolicy(context.Background(), privateChannel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestPreviewReadReceiptCleanup(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	oldPost := th.CreatePost()
+	newPost := th.CreatePost()
+	otherPost := th.CreatePostWithClient(th.Client, th.BasicChannel2)
+	expiredAt := model.GetMillis() - 10*24*60*60*1000
+	_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: oldPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: expiredAt},
+		{PostId: oldPost.Id, UserId: th.SystemAdminUser.Id, ChannelId: th.BasicChannel.Id, ReadAt: expiredAt},
+		{PostId: newPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: model.GetMillis()},
+		{PostId: otherPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel2.Id, ReadAt: expiredAt},
+	})
+	require.NoError(t, err)
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.PreviewReadReceiptCleanup(context.Background(), &model.ReadReceiptPolicy{RetentionDays: 7})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("counts the expired receipts in scope", func(t *testing.T) {
+		preview, _, err := th.SystemAdminClient.PreviewReadReceiptCleanup(context.Background(), &model.ReadReceiptPolicy{
+			ChannelIds:    model.StringArray{th.BasicChannel.Id},
+			RetentionDays: 7,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), preview.TotalCount)
+		require.Len(t, preview.Channels, 1)
+		require.Equal(t, th.BasicChannel.Id, preview.Channels[0].ChannelId)
+		require.Equal(t, int64(2), preview.Channels[0].Count)
+
+		preview, _, err = th.SystemAdminClient.PreviewReadReceiptCleanup(context.Background(), &model.ReadReceiptPolicy{
+			TeamIds:       model.StringArray{th.BasicTeam.Id},
+			RetentionDays: 7,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), preview.TotalCount)
+		require.Len(t, preview.Channels, 2)
+		require.Equal(t, th.BasicChannel.Id, preview.Channels[0].ChannelId)
+	})
+
+	t.Run("policies without retention delete nothing", func(t *testing.T) {
+		preview, _, err := th.SystemAdminClient.PreviewReadReceiptCleanup(context.Background(), &model.ReadReceiptPolicy{
+			ChannelIds: model.StringArray{th.BasicChannel.Id},
+		})
+		require.NoError(t, err)
+		require.Zero(t, preview.TotalCount)
+		require.Empty(t, preview.Channels)
+	})
+
+	t.Run("invalid retention", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.PreviewReadReceiptCleanup(context.Background(), &model.ReadReceiptPolicy{RetentionDays: -1})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestReadReceiptPolicyVisibility(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+
+	setVisibility := func(t *testing.T, visibilityMode string) {
+		t.Helper()
+		_, _, err := th.SystemAdminClient.UpdateReadReceiptPolicies(context.Background(), []*model.ReadReceiptPolicy{
+			{Name: "channel", ChannelIds: model.StringArray{th.BasicChannel.Id}, VisibilityMode: visibilityMode},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("author only", func(t *testing.T) {
+		setVisibility(t, model.ReadReceiptVisibilityAuthorOnly)
+
+		th.LoginBasic()
+		_, _, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+		_, _, err = th.Client.GetPostReadReceiptSummary(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+
+		th.LoginBasic2()
+		_, resp, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "app.read_receipt_policy.not_visible.app_error")
+		_, resp, err = th.Client.GetPostReadReceiptSummary(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		_, resp, err = th.Client.GetPostReadReceiptExtremes(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("author only hides the channel read paths", func(t *testing.T) {
+		setVisibility(t, model.ReadReceiptVisibilityAuthorOnly)
+
+		th.LoginBasic()
+		_, resp, err := th.Client.GetReadCountsForLatestPosts(context.Background(), th.BasicChannel.Id, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "app.read_receipt_policy.not_visible.app_error")
+
+		_, _, err = th.SystemAdminClient.GetReadCountsForLatestPosts(context.Background(), th.BasicChannel.Id, 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("hidden", func(t *testing.T) {
+		setVisibility(t, model.ReadReceiptVisibilityHidden)
+
+		th.LoginBasic()
+		_, resp, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		// Compliance monitors still see them.
+		_, _, err = th.SystemAdminClient.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("everyone", func(t *testing.T) {
+		setVisibility(t, model.ReadReceiptVisibilityEveryone)
+
+		th.LoginBasic2()
+		_, _, err := th.Client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitReadReceiptWebhook() {
+	api.BaseRoutes.Channel.Handle("/read_receipt_webhooks", api.APISessionRequired(getReadReceiptWebhooks)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/read_receipt_webhooks", api.APISessionRequired(createReadReceiptWebhook)).Methods(http.MethodPost)
+	api.BaseRoutes.Channel.Handle("/read_receipt_webhooks/{hook_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteReadReceiptWebhook)).Methods(http.MethodDelete)
+}
+
+// requireReadReceiptWebhookPermission restricts the read receipt webhooks of a
+// channel to its admins, and to the team and system admins above them, who are
+// also allowed to manage outgoing webhooks.
+func requireReadReceiptWebhookPermission(c *Context) {
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionManageChannelRoles) {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return
+	}
+}
+
+func getReadReceiptWebhooks(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "getReadReceiptWebhooks")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	requireReadReceiptWebhookPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	hooks, appErr := c.App.GetReadReceiptWebhooksForChannel(c.AppContext, c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(hooks)
+	if err != nil {
+		c.Err = model.NewAppError("getReadReceiptWebhooks", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createReadReceiptWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "createReadReceiptWebhook")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	var hook model.ReadReceiptWebhook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+		c.SetInvalidParamWithErr("read_receipt_webhook", err)
+		return
+	}
+
+	// The server picks the identity and the secret of the webhook.
+	hook = model.ReadReceiptWebhook{
+		ChannelId: c.Params.ChannelId,
+		CreatorId: c.AppContext.Session().UserId,
+		URL:       hook.URL,
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateReadReceiptWebhook, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", hook.ChannelId)
+	model.AddEventParameterToAuditRec(auditRec, "url", hook.URL)
+
+	requireReadReceiptWebhookPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	saved, appErr := c.App.CreateReadReceiptWebhook(c.AppContext, &hook)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddMeta("hook_id", saved.Id)
+	auditRec.Success()
+
+	js, err := json.Marshal(saved)
+	if err != nil {
+		c.Err = model.NewAppError("createReadReceiptWebhook", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteReadReceiptWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireReadReceiptsEnabled(c, "deleteReadReceiptWebhook")
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId().RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteReadReceiptWebhook, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", c.Params.ChannelId)
+	model.AddEventParameterToAuditRec(auditRec, "hook_id", c.Params.HookId)
+
+	requireReadReceiptWebhookPermission(c)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeleteReadReceiptWebhook(c.AppContext, c.Params.ChannelId, c.Params.HookId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
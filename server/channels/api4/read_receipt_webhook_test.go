@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestReadReceiptWebhooks(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	type delivery struct {
+		payload   model.ReadReceiptWebhookPayload
+		signature string
+		body      []byte
+	}
+	deliveries := make(chan delivery, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var payload model.ReadReceiptWebhookPayload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		deliveries <- delivery{payload: payload, signature: r.Header.Get(model.ReadReceiptWebhookSignatureHeader), body: body}
+	}))
+	defer server.Close()
+
+	client2 := th.CreateClient()
+	th.LoginBasic2WithClient(client2)
+
+	t.Run("requires channel admin", func(t *testing.T) {
+		_, resp, err := client2.CreateReadReceiptWebhook(context.Background(), &model.ReadReceiptWebhook{ChannelId: th.BasicChannel.Id, URL: server.URL})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client2.GetReadReceiptWebhooksForChannel(context.Background(), th.BasicChannel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.MakeUserChannelAdmin(th.BasicUser, th.BasicChannel)
+
+	t.Run("requires manage_outgoing_webhooks", func(t *testing.T) {
+		_, resp, err := th.Client.CreateReadReceiptWebhook(context.Background(), &model.ReadReceiptWebhook{ChannelId: th.BasicChannel.Id, URL: server.URL})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.AddPermissionToRole(model.PermissionManageOutgoingWebhooks.Id, model.ChannelAdminRoleId)
+	defer th.RemovePermissionFromRole(model.PermissionManageOutgoingWebhooks.Id, model.ChannelAdminRoleId)
+
+	hook, resp, err := th.Client.CreateReadReceiptWebhook(context.Background(), &model.ReadReceiptWebhook{ChannelId: th.BasicChannel.Id, URL: server.URL})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.Len(t, hook.Secret, model.ReadReceiptWebhookSecretLength)
+	require.Equal(t, th.BasicUser.Id, hook.CreatorId)
+
+	hooks, _, err := th.Client.GetReadReceiptWebhooksForChannel(context.Background(), th.BasicChannel.Id)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, resp, err := th.Client.CreateReadReceiptWebhook(context.Background(), &model.ReadReceiptWebhook{ChannelId: th.BasicChannel.Id, URL: "junk"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("pinned post reaching half of the channel", func(t *testing.T) {
+		post := th.CreatePost()
+		_, err := th.Client.PinPost(context.Background(), post.Id)
+		require.NoError(t, err)
+
+		_, _, err = client2.SavePostReadReceipt(context.Background(), post.Id, &model.ReadReceiptRequest{})
+		require.NoError(t, err)
+
+		select {
+		case d := <-deliveries:
+			require.Len(t, d.payload.Posts, 1)
+			assert.Equal(t, hook.Id, d.payload.WebhookId)
+			assert.Equal(t, post.Id, d.payload.Posts[0].PostId)
+			assert.Equal(t, 50, d.payload.Posts[0].Threshold)
+
+			mac := hmac.New(sha256.New, []byte(hook.Secret))
+			mac.Write(d.body)
+			assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), d.signature)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "read receipt webhook was not delivered")
+		}
+	})
+
+	t.Run("pinned posts reaching half of the channel in a batch", func(t *testing.T) {
+		post1 := th.CreatePost()
+		_, err := th.Client.PinPost(context.Background(), post1.Id)
+		require.NoError(t, err)
+		post2 := th.CreatePost()
+		_, err = th.Client.PinPost(context.Background(), post2.Id)
+		require.NoError(t, err)
+
+		_, _, err = client2.SavePostReadReceiptsBatch(context.Background(), &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{post1.Id, post2.Id},
+		})
+		require.NoError(t, err)
+
+		var postIDs []string
+		for range 2 {
+			select {
+			case d := <-deliveries:
+				require.Len(t, d.payload.Posts, 1)
+				assert.Equal(t, 50, d.payload.Posts[0].Threshold)
+				postIDs = append(postIDs, d.payload.Posts[0].PostId)
+			case <-time.After(10 * time.Second):
+				require.Fail(t, "read receipt webhook was not delivered")
+			}
+		}
+		assert.ElementsMatch(t, []string{post1.Id, post2.Id}, postIDs)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := client2.DeleteReadReceiptWebhook(context.Background(), th.BasicChannel.Id, hook.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteReadReceiptWebhook(context.Background(), th.BasicChannel2.Id, hook.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		_, err = th.Client.DeleteReadReceiptWebhook(context.Background(), th.BasicChannel.Id, hook.Id)
+		require.NoError(t, err)
+
+		hooks, _, err := th.Client.GetReadReceiptWebhooksForChannel(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Empty(t, hooks)
+	})
+}
@@ -18,6 +18,7 @@ func (api *API) InitReports() {
 	api.BaseRoutes.Reports.Handle("/users", api.APISessionRequired(getUsersForReporting)).Methods(http.MethodGet)
 	api.BaseRoutes.Reports.Handle("/users/count", api.APISessionRequired(getUserCountForReporting)).Methods(http.MethodGet)
 	api.BaseRoutes.Reports.Handle("/users/export", api.APISessionRequired(startUsersBatchExport)).Methods(http.MethodPost)
+	api.BaseRoutes.Reports.Handle("/channels/archive_recommendations", api.APISessionRequired(getChannelArchiveRecommendations)).Methods(http.MethodGet)
 }
 
 func getUsersForReporting(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -103,6 +104,53 @@ func startUsersBatchExport(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func getChannelArchiveRecommendations(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementChannels) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementChannels)
+		return
+	}
+
+	if !*c.App.Config().ServiceSettings.EnableReadReceipts {
+		c.Err = model.NewAppError("getChannelArchiveRecommendations", "api.read_receipt.disabled.app_error", nil, "", http.StatusNotImplemented).WithCode(model.ReadReceiptErrorCodeDisabled)
+		return
+	}
+
+	query := r.URL.Query()
+	options := &model.ChannelArchiveReportOptions{
+		TeamId:           query.Get("team_id"),
+		MaxReaderPercent: model.ChannelArchiveReportDefaultMaxReaderPercent,
+		InactiveDays:     model.ChannelArchiveReportDefaultInactiveDays,
+		Page:             c.Params.Page,
+		PerPage:          c.Params.PerPage,
+	}
+	if value := query.Get("max_reader_percent"); value != "" {
+		maxReaderPercent, err := strconv.Atoi(value)
+		if err != nil {
+			c.SetInvalidURLParam("max_reader_percent")
+			return
+		}
+		options.MaxReaderPercent = maxReaderPercent
+	}
+	if value := query.Get("inactive_days"); value != "" {
+		inactiveDays, err := strconv.Atoi(value)
+		if err != nil {
+			c.SetInvalidURLParam("inactive_days")
+			return
+		}
+		options.InactiveDays = inactiveDays
+	}
+
+	recommendations, appErr := c.App.GetChannelArchiveRecommendations(c.AppContext, options)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(recommendations); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
 func fillReportingBaseOptions(values url.Values) model.ReportingBaseOptions {
 	sortColumn := "Username"
 	if values.Get("sort_column") != "" {
@@ -59,6 +59,43 @@ func TestGetUsersForReporting(t *testing.T) {
 	})
 }
 
+func TestGetChannelArchiveRecommendations(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	t.Run("requires the permission to read the channels of the system console", func(t *testing.T) {
+		_, resp, err := th.Client.GetChannelArchiveRecommendations(context.Background(), &model.ChannelArchiveReportOptions{})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("lists the channels nobody read lately", func(t *testing.T) {
+		recommendations, _, err := th.SystemAdminClient.GetChannelArchiveRecommendations(context.Background(), &model.ChannelArchiveReportOptions{
+			TeamId:  th.BasicTeam.Id,
+			PerPage: model.ReportingMaxPageSize,
+		})
+		require.NoError(t, err)
+
+		channelIDs := make([]string, 0, len(recommendations))
+		for _, recommendation := range recommendations {
+			channelIDs = append(channelIDs, recommendation.ChannelId)
+		}
+		require.Contains(t, channelIDs, th.BasicChannel.Id)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetChannelArchiveRecommendations(context.Background(), &model.ChannelArchiveReportOptions{MaxReaderPercent: 101})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetChannelArchiveRecommendations(context.Background(), &model.ChannelArchiveReportOptions{InactiveDays: model.ChannelArchiveReportMaxInactiveDays + 1})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestFillReportingBaseOptions(t *testing.T) {
 	mainHelper.Parallel(t)
 	t.Run("default values", func(t *testing.T) {
@@ -188,6 +188,19 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 			s[model.STATUS] = model.StatusUnhealthy
 		}
 
+		readReceiptsStatusKey := "read_receipts_status"
+		if *c.App.Config().ServiceSettings.EnableReadReceipts {
+			s[readReceiptsStatusKey] = model.StatusOk
+			if err := c.App.ReadReceiptsHealthCheck(c.AppContext); err != nil {
+				c.Logger.Warn("Read receipts health check failed.", mlog.Err(err))
+				s[readReceiptsStatusKey] = model.StatusUnhealthy
+				s[model.STATUS] = model.StatusUnhealthy
+			}
+			// Summaries lagging behind are reported without failing the ping, as they
+			// catch up on their own.
+			s["read_receipts_summary_status"] = c.App.GetReadReceiptsHealth().Status
+		}
+
 		if res, ok := s[model.STATUS].(string); ok {
 			w.Header().Set(model.STATUS, res)
 		}
@@ -197,6 +210,9 @@ func getSystemPing(c *Context, w http.ResponseWriter, r *http.Request) {
 		if res, ok := s[filestoreStatusKey].(string); ok {
 			w.Header().Set(filestoreStatusKey, res)
 		}
+		if res, ok := s[readReceiptsStatusKey].(string); ok {
+			w.Header().Set(readReceiptsStatusKey, res)
+		}
 
 		// Checking if mattermost is running as root, if the user is system admin
 		if c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
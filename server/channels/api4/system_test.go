@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
 
@@ -97,6 +98,40 @@ func TestGetPing(t *testing.T) {
 		assert.Equal(t, false, ok)
 	})
 
+	t.Run("ping read_receipts_status test", func(t *testing.T) {
+		respMap, _, err := th.Client.GetPingWithOptions(context.Background(), model.SystemPingOptions{FullStatus: true})
+		require.NoError(t, err)
+		_, ok := respMap["read_receipts_status"]
+		assert.False(t, ok)
+
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = false })
+
+		// The second ping reuses the probe post of the first one.
+		for range 2 {
+			respMap, resp, err := th.Client.GetPingWithOptions(context.Background(), model.SystemPingOptions{FullStatus: true})
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, model.StatusOk, respMap["read_receipts_status"])
+			assert.Equal(t, model.StatusOk, resp.Header.Get("read_receipts_status"))
+			assert.Equal(t, model.ReadReceiptsHealthOk, respMap["read_receipts_summary_status"])
+		}
+
+		// The probe leaves neither a receipt nor a change behind.
+		bot, appErr := th.App.GetSystemBot(th.Context)
+		require.Nil(t, appErr)
+		probePostID, err := th.App.Srv().Store().System().GetByName("read_receipts_probe_post_id")
+		require.NoError(t, err)
+		_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(probePostID.Value, bot.UserId)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+		probePost, err := th.App.Srv().Store().Post().GetSingle(th.Context, probePostID.Value, false)
+		require.NoError(t, err)
+		changes, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptChanges(probePost.ChannelId, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
 	th.TestForAllClients(t, func(t *testing.T, client *model.Client4) {
 		err := th.App.ReloadConfig()
 		require.NoError(t, err)
@@ -73,6 +73,7 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.User.Handle("/sessions", api.APISessionRequired(getSessions)).Methods(http.MethodGet)
 	api.BaseRoutes.User.Handle("/sessions/revoke", api.APISessionRequired(revokeSession)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/sessions/{session_id:[A-Za-z0-9]+}/read_receipt_optout", api.APISessionRequired(updateSessionReadReceiptOptOut)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsForUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsAllUsers)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/device", api.APISessionRequired(handleDeviceProps)).Methods(http.MethodPut)
@@ -2186,6 +2187,51 @@ func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// updateSessionReadReceiptOptOut stops or resumes recording the reads made through
+// one of the sessions of the user, such as a shared web session.
+func updateSessionReadReceiptOptOut(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireSessionId()
+	if c.Err != nil {
+		return
+	}
+
+	props := model.StringInterfaceFromJSON(r.Body)
+	optOut, ok := props["opt_out"].(bool)
+	if !ok {
+		c.SetInvalidParam("opt_out")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateSessionReadReceiptOptOut, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "session_id", c.Params.SessionId)
+	model.AddEventParameterToAuditRec(auditRec, "opt_out", optOut)
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	session, appErr := c.App.GetSessionById(c.AppContext, c.Params.SessionId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if session.UserId != c.Params.UserId {
+		c.SetInvalidURLParam("session_id")
+		return
+	}
+
+	if appErr := c.App.SetExtraSessionProps(session, map[string]string{model.SessionPropReadReceiptOptOut: strconv.FormatBool(optOut)}); appErr != nil {
+		c.Err = appErr
+		return
+	}
+	c.App.ClearSessionCacheForUser(session.UserId)
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func revokeAllSessionsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2520,6 +2566,7 @@ func createUserAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	accessToken.UserId = c.Params.UserId
+	accessToken.CreatorId = c.AppContext.Session().UserId
 	accessToken.Token = ""
 
 	token, err := c.App.CreateUserAccessToken(c.AppContext, &accessToken)
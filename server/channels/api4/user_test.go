@@ -4995,6 +4995,7 @@ func TestCreateUserAccessToken(t *testing.T) {
 		rtoken, _, err := th.Client.CreateUserAccessToken(context.Background(), th.BasicUser2.Id, "test token")
 		require.NoError(t, err)
 		assert.Equal(t, th.BasicUser2.Id, rtoken.UserId)
+		assert.Equal(t, th.BasicUser.Id, rtoken.CreatorId)
 
 		oldSessionToken := th.Client.AuthToken
 		defer func() { th.Client.AuthToken = oldSessionToken }()
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
@@ -2264,6 +2265,53 @@ func (a *App) GetChannelMembersPage(c request.CTX, channelID string, page, perPa
 	return channelMembers, nil
 }
 
+// GetChannelMembersPageByLastReadActivity returns a page of the channel members,
+// the most recent readers first.
+func (a *App) GetChannelMembersPageByLastReadActivity(c request.CTX, channelID string, page, perPage int) (model.ChannelMembers, *model.AppError) {
+	channelMembers, err := a.Srv().Store().Channel().GetMembersByLastReadActivity(channelID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembersPageByLastReadActivity", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return channelMembers, nil
+}
+
+// GetChannelMembersReadActivity returns a page of the channel members with their
+// read activity over the last ChannelMemberReadActivityDays, the members who read
+// the least recently first.
+func (a *App) GetChannelMembersReadActivity(c request.CTX, channelID string, page, perPage int) ([]*model.ChannelMemberReadActivity, *model.AppError) {
+	since := time.Now().AddDate(0, 0, -model.ChannelMemberReadActivityDays).UnixMilli()
+	activity, err := a.Srv().Store().PostReadReceipt().GetMembersReadActivity(channelID, since, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembersReadActivity", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return activity, nil
+}
+
+// GetChannelDailyReaderCounts returns how many distinct users read posts of the
+// channel on each UTC day from fromDay to toDay, both formatted as 2006-01-02.
+func (a *App) GetChannelDailyReaderCounts(c request.CTX, channelID, fromDay, toDay string) ([]*model.DailyReaderCount, *model.AppError) {
+	from, err := time.Parse("2006-01-02", fromDay)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelDailyReaderCounts", "app.channel.daily_readers.invalid_range.app_error", map[string]any{"MaxDays": model.ChannelDailyReadersMaxDays}, "from="+fromDay, http.StatusBadRequest).Wrap(err)
+	}
+	to, err := time.Parse("2006-01-02", toDay)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelDailyReaderCounts", "app.channel.daily_readers.invalid_range.app_error", map[string]any{"MaxDays": model.ChannelDailyReadersMaxDays}, "to="+toDay, http.StatusBadRequest).Wrap(err)
+	}
+	if to.Before(from) || to.Sub(from) >= model.ChannelDailyReadersMaxDays*24*time.Hour {
+		return nil, model.NewAppError("GetChannelDailyReaderCounts", "app.channel.daily_readers.invalid_range.app_error", map[string]any{"MaxDays": model.ChannelDailyReadersMaxDays}, "from="+fromDay+", to="+toDay, http.StatusBadRequest)
+	}
+
+	counts, err := a.Srv().Store().PostReadReceipt().GetDistinctReaderCounts(channelID, fromDay, toDay)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelDailyReaderCounts", "app.channel.daily_readers.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return counts, nil
+}
+
 func (a *App) GetChannelMembersTimezones(c request.CTX, channelID string) ([]string, *model.AppError) {
 	membersTimezones, err := a.Srv().Store().Channel().GetChannelMembersTimezones(channelID)
 	if err != nil {
@@ -3308,6 +3356,10 @@ func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *mod
 
 	a.Srv().Platform().InvalidateCacheForChannel(channel)
 
+	if err := a.deleteColdStorageReadReceiptsForChannel(channel.Id); err != nil {
+		c.Logger().Warn("Failed to delete the offloaded read receipts of the channel", mlog.String("channel_id", channel.Id), mlog.Err(err))
+	}
+
 	var message *model.WebSocketEvent
 	if channel.Type == model.ChannelTypeOpen {
 		message = model.NewWebSocketEvent(model.WebsocketEventChannelDeleted, channel.TeamId, "", "", nil, "")
@@ -10,9 +10,11 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
@@ -82,11 +84,33 @@ type Channels struct {
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
 
+	readReceiptPauseMut  sync.Mutex
+	readReceiptPauseTask *model.ScheduledTask
+
 	interruptQuitChan     chan struct{}
 	scheduledPostMut      sync.Mutex
 	scheduledPostTask     *model.ScheduledTask
 	emailLoginAttemptsMut sync.Mutex
 	ldapLoginAttemptsMut  sync.Mutex
+
+	readReceiptAggregator *readReceiptAggregator
+	readReceiptBuffer     *readReceiptBuffer
+	// readReceiptWriteThrottle holds back the reads made within
+	// ServiceSettings.ReadReceiptsMinWriteIntervalMs of a previous write.
+	readReceiptWriteThrottle *readReceiptWriteThrottle
+	// readReceiptExporter streams receipts to ServiceSettings.ReadReceiptsExportSink,
+	// it is nil when no sink is set.
+	readReceiptExporter atomic.Pointer[readReceiptExporter]
+	// readReceiptEvents delivers the websocket events of receipts through a
+	// ReadReceiptPublisher.
+	readReceiptEvents *readReceiptEventQueue
+	// readReceiptQuota caches the last check of the cloud receipt quota.
+	readReceiptQuota atomic.Pointer[readReceiptQuotaState]
+	// readReceiptStalenessTask reports the summary staleness to the metrics.
+	readReceiptStalenessTask *model.ScheduledTask
+
+	// readReceiptSummaryGroup collapses concurrent first reads of a post's summary.
+	readReceiptSummaryGroup singleflight.Group
 }
 
 func NewChannels(s *Server) (*Channels, error) {
@@ -98,8 +122,16 @@ func NewChannels(s *Server) (*Channels, error) {
 		exportFilestore:   s.ExportFileBackend(),
 		cfgSvc:            s.Platform(),
 		interruptQuitChan: make(chan struct{}),
+
+		readReceiptAggregator: newReadReceiptAggregator(),
 	}
 
+	ch.readReceiptBuffer = newReadReceiptBuffer(readReceiptBufferSettingsFromConfig(s.Config()), New(ServerConnector(ch)).flushImplicitReadReceipts)
+	ch.readReceiptBuffer.metrics = s.GetMetrics
+	ch.readReceiptWriteThrottle = newReadReceiptWriteThrottle(New(ServerConnector(ch)).writeCoalescedReadReceipt)
+	ch.readReceiptEvents = newReadReceiptEventQueue(&webHubReadReceiptPublisher{publish: ch.Publish}, s.Log())
+	ch.readReceiptEvents.metrics = s.GetMetrics
+
 	// We are passing a partially filled Channels struct so that the enterprise
 	// methods can have access to app methods.
 	// Otherwise, passing server would mean it has to call s.Channels(),
@@ -235,6 +267,17 @@ func (ch *Channels) Start() error {
 		return errors.Wrapf(err, "unable to ensure PostAction cookie secret")
 	}
 
+	ch.AddConfigListener(func(oldCfg, cfg *model.Config) {
+		ch.readReceiptBuffer.configure(readReceiptBufferSettingsFromConfig(cfg))
+		if settings := readReceiptExportSettingsFromConfig(cfg); settings != readReceiptExportSettingsFromConfig(oldCfg) {
+			ch.startReadReceiptExporter(settings)
+		}
+	})
+	ch.readReceiptBuffer.start()
+	ch.readReceiptEvents.start()
+	ch.startReadReceiptExporter(readReceiptExportSettingsFromConfig(ch.cfgSvc.Config()))
+	ch.readReceiptStalenessTask = model.CreateRecurringTask("Read Receipt Summary Staleness", ch.observeReadReceiptSummaryStaleness, readReceiptStalenessObserveInterval)
+
 	return nil
 }
 
@@ -247,8 +290,27 @@ func (ch *Channels) Stop() error {
 	}
 	ch.dndTaskMut.Unlock()
 
+	ch.readReceiptPauseMut.Lock()
+	if ch.readReceiptPauseTask != nil {
+		ch.readReceiptPauseTask.Cancel()
+	}
+	ch.readReceiptPauseMut.Unlock()
+
 	close(ch.interruptQuitChan)
 
+	if ch.readReceiptStalenessTask != nil {
+		ch.readReceiptStalenessTask.Cancel()
+	}
+
+	ch.readReceiptWriteThrottle.stopAndFlush()
+	ch.readReceiptBuffer.stopAndFlush()
+	// The receipts written above may have queued events.
+	ch.readReceiptEvents.stopAndFlush()
+	// The buffer flush above may still have queued receipts for export.
+	if exporter := ch.readReceiptExporter.Swap(nil); exporter != nil {
+		exporter.stopAndFlush()
+	}
+
 	return nil
 }
 
@@ -54,6 +54,18 @@ func (s *Server) clusterPluginEventHandler(msg *model.ClusterMessage) {
 	})
 }
 
+func (s *Server) clusterReadReceiptAggregateHandler(msg *model.ClusterMessage) {
+	var snapshot readReceiptAggregateSnapshot
+	if jsonErr := json.Unmarshal(msg.Data, &snapshot); jsonErr != nil {
+		s.Log().Warn("Failed to decode from JSON", mlog.Err(jsonErr))
+		return
+	}
+	if cluster := s.platform.Cluster(); snapshot.NodeId == "" || (cluster != nil && snapshot.NodeId == cluster.GetClusterId()) {
+		return
+	}
+	s.Channels().readReceiptAggregator.mergeRemote(&snapshot)
+}
+
 // registerClusterHandlers registers the cluster message handlers that are handled by the server.
 //
 // The cluster event handlers are spread across this function and NewLocalCacheLayer.
@@ -62,6 +74,7 @@ func (s *Server) registerClusterHandlers() {
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventInstallPlugin, s.clusterInstallPluginHandler)
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventRemovePlugin, s.clusterRemovePluginHandler)
 	s.platform.RegisterClusterMessageHandler(model.ClusterEventPluginEvent, s.clusterPluginEventHandler)
+	s.platform.RegisterClusterMessageHandler(model.ClusterEventReadReceiptAggregate, s.clusterReadReceiptAggregateHandler)
 
 	s.platform.RegisterClusterHandlers()
 }
@@ -232,9 +232,50 @@ func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler fu
 			continue
 		}
 
-		handler(userID, job.pendingNotifications[userID])
+		unread := job.withoutReadPosts(userID, job.pendingNotifications[userID])
 		delete(job.pendingNotifications, userID)
+		if len(unread) == 0 {
+			mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
+			continue
+		}
+
+		handler(userID, unread)
+	}
+}
+
+// withoutReadPosts drops the notifications of posts the user has a read receipt for,
+// since posts read on another device, such as mobile, do not move LastViewedAt.
+func (job *EmailBatchingJob) withoutReadPosts(userID string, notifications []*batchedNotification) []*batchedNotification {
+	if !*job.service.config().ServiceSettings.EnableReadReceipts {
+		return notifications
+	}
+
+	postIDs := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		postIDs = append(postIDs, notification.post.Id)
+	}
+
+	readPostIDs, err := job.service.store.PostReadReceipt().GetReadPostIdsForUser(userID, postIDs)
+	if err != nil {
+		mlog.Warn("Unable to get read receipts for batched email notifications", mlog.String("user_id", userID), mlog.Err(err))
+		return notifications
+	}
+	if len(readPostIDs) == 0 {
+		return notifications
+	}
+
+	read := make(map[string]bool, len(readPostIDs))
+	for _, postID := range readPostIDs {
+		read[postID] = true
+	}
+
+	unread := make([]*batchedNotification, 0, len(notifications))
+	for _, notification := range notifications {
+		if !read[notification.post.Id] {
+			unread = append(unread, notification)
+		}
 	}
+	return unread
 }
 
 /**
@@ -193,6 +193,65 @@ func TestCheckPendingNotifications(t *testing.T) {
 	}
 }
 
+func TestCheckPendingNotificationsSkipsReadPosts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+	})
+
+	savePost := func(message string) *model.Post {
+		post, err := th.store.Post().Save(th.Context, &model.Post{
+			UserId:    th.BasicUser2.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   message,
+		})
+		require.NoError(t, err)
+		return post
+	}
+	readPost := savePost("read on mobile")
+	unreadPost := savePost("unread")
+
+	_, err := th.store.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{{
+		PostId:    readPost.Id,
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		ReadAt:    model.GetMillis(),
+	}})
+	require.NoError(t, err)
+
+	job := NewEmailBatchingJob(th.service, 128)
+	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
+		{post: readPost, teamName: th.BasicTeam.Name},
+		{post: unreadPost, teamName: th.BasicTeam.Name},
+	}
+
+	var sent []*batchedNotification
+	job.checkPendingNotifications(time.UnixMilli(unreadPost.CreateAt).Add(time.Hour), func(_ string, notifications []*batchedNotification) {
+		sent = notifications
+	})
+
+	require.Len(t, sent, 1)
+	require.Equal(t, unreadPost.Id, sent[0].post.Id)
+	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
+
+	t.Run("nothing is sent when every post was read", func(t *testing.T) {
+		job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
+			{post: readPost, teamName: th.BasicTeam.Name},
+		}
+
+		called := false
+		job.checkPendingNotifications(time.UnixMilli(readPost.CreateAt).Add(time.Hour), func(string, []*batchedNotification) {
+			called = true
+		})
+
+		require.False(t, called)
+		require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
+	})
+}
+
 /**
  * Ensures that email batch interval defaults to 15 minutes for users that haven't explicitly set this preference
  */
@@ -171,7 +171,7 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	profilePictures = append(profilePictures, botPPs...)
 
 	ctx.Logger().Info("Bulk export: exporting posts")
-	attachments, appErr := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	attachments, appErr := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.IncludeReadReceipts)
 	if appErr != nil {
 		return appErr
 	}
@@ -188,7 +188,7 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	ctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, appErr := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	directAttachments, appErr := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.IncludeReadReceipts)
 	if appErr != nil {
 		return appErr
 	}
@@ -704,7 +704,7 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels, withReadReceipts bool) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -728,6 +728,18 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 		cnt += len(posts)
 		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "posts_exported", cnt)
 
+		var readReceipts map[string][]imports.ReadReceiptImportData
+		if withReadReceipts {
+			postsOfBatch := make([]*model.Post, 0, len(posts))
+			for _, post := range posts {
+				postsOfBatch = append(postsOfBatch, &post.Post)
+			}
+			var appErr *model.AppError
+			if readReceipts, appErr = a.buildReadReceipts(ctx, postsOfBatch); appErr != nil {
+				return nil, appErr
+			}
+		}
+
 		for _, post := range posts {
 			afterId = post.Id
 			postProcessCount++
@@ -739,7 +751,7 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 
 			postLine := importLineForPost(post)
 
-			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments)
+			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments, withReadReceipts)
 			if err != nil {
 				return nil, err
 			}
@@ -778,6 +790,10 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 				}
 			}
 
+			if receipts, ok := readReceipts[post.Id]; ok {
+				postLine.Post.ReadReceipts = &receipts
+			}
+
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return nil, err
 			}
@@ -785,7 +801,7 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 	}
 }
 
-func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments bool) ([]imports.ReplyImportData, []imports.AttachmentImportData, *model.AppError) {
+func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments, withReadReceipts bool) ([]imports.ReplyImportData, []imports.AttachmentImportData, *model.AppError) {
 	var replies []imports.ReplyImportData
 	var attachments []imports.AttachmentImportData
 
@@ -794,8 +810,23 @@ func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments b
 		return nil, nil, model.NewAppError("buildPostReplies", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
+	var readReceipts map[string][]imports.ReadReceiptImportData
+	if withReadReceipts && len(replyPosts) > 0 {
+		replies := make([]*model.Post, 0, len(replyPosts))
+		for _, reply := range replyPosts {
+			replies = append(replies, &reply.Post)
+		}
+		var appErr *model.AppError
+		if readReceipts, appErr = a.buildReadReceipts(ctx, replies); appErr != nil {
+			return nil, nil, appErr
+		}
+	}
+
 	for _, reply := range replyPosts {
 		replyImportObject := importReplyFromPost(reply)
+		if receipts, ok := readReceipts[reply.Id]; ok {
+			replyImportObject.ReadReceipts = &receipts
+		}
 		if reply.HasReactions {
 			var appErr *model.AppError
 			replyImportObject.Reactions, appErr = a.BuildPostReactions(ctx, reply.Id)
@@ -859,6 +890,64 @@ func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImp
 	return &reactionsOfPost, nil
 }
 
+// buildReadReceipts returns the read receipts of the posts keyed by post id. The
+// receipts of users that don't exist anymore are skipped, like their reactions,
+// and so are those of channels whose policy leaves receipts out of exports.
+func (a *App) buildReadReceipts(ctx request.CTX, posts []*model.Post) (map[string][]imports.ReadReceiptImportData, *model.AppError) {
+	exportedByChannel := make(map[string]bool)
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		exported, ok := exportedByChannel[post.ChannelId]
+		if !ok {
+			policy, appErr := a.readReceiptPolicyForChannel(ctx, post.ChannelId)
+			if appErr != nil {
+				return nil, appErr
+			}
+			exported = policy == nil || policy.IncludeInExport
+			exportedByChannel[post.ChannelId] = exported
+		}
+		if exported {
+			postIDs = append(postIDs, post.Id)
+		}
+	}
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	receipts, err := a.Srv().Store().PostReadReceipt().GetReadReceiptsForPosts(postIDs)
+	if err != nil {
+		return nil, model.NewAppError("buildReadReceipts", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(receipts) == 0 {
+		return nil, nil
+	}
+
+	userIDs := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		userIDs = append(userIDs, receipt.UserId)
+	}
+	users, err := a.Srv().Store().User().GetProfileByIds(context.Background(), model.RemoveDuplicateStrings(userIDs), nil, false)
+	if err != nil {
+		return nil, model.NewAppError("buildReadReceipts", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.Id] = user.Username
+	}
+
+	receiptsByPost := make(map[string][]imports.ReadReceiptImportData)
+	for _, receipt := range receipts {
+		username, ok := usernames[receipt.UserId]
+		if !ok {
+			ctx.Logger().Info("Skipping read receipt by user since the entity doesn't exist anymore", mlog.String("user_id", receipt.UserId))
+			continue
+		}
+		receiptsByPost[receipt.PostId] = append(receiptsByPost[receipt.PostId], *importReadReceiptFromPost(username, receipt))
+	}
+
+	return receiptsByPost, nil
+}
+
 func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportData, *model.AppError) {
 	infos, nErr := a.Srv().Store().FileInfo().GetForPost(postID, false, false, false)
 	if nErr != nil {
@@ -1109,7 +1198,7 @@ func (a *App) buildShownByList(channel *model.DirectChannelForExport) ([]string,
 	return shownBy, nil
 }
 
-func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels, withReadReceipts bool) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
 	var postProcessCount uint64
@@ -1133,6 +1222,18 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 		cnt += len(posts)
 		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "direct_posts_exported", cnt)
 
+		var readReceipts map[string][]imports.ReadReceiptImportData
+		if withReadReceipts {
+			postsOfBatch := make([]*model.Post, 0, len(posts))
+			for _, post := range posts {
+				postsOfBatch = append(postsOfBatch, &post.Post)
+			}
+			var appErr *model.AppError
+			if readReceipts, appErr = a.buildReadReceipts(ctx, postsOfBatch); appErr != nil {
+				return nil, appErr
+			}
+		}
+
 		channelsToSkip := model.SliceToMapKey(strings.Split(job.Data["skipped_direct_channels"], ",")...)
 		for _, post := range posts {
 			afterId = post.Id
@@ -1162,7 +1263,7 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			}
 
 			// Do the Replies.
-			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments)
+			replies, replyAttachments, err := a.buildPostReplies(ctx, post.Id, withAttachments, withReadReceipts)
 			if err != nil {
 				return nil, err
 			}
@@ -1186,6 +1287,10 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 				postLine.DirectPost.ThreadFollowers = &followers
 			}
 
+			if receipts, ok := readReceipts[post.Id]; ok {
+				postLine.DirectPost.ReadReceipts = &receipts
+			}
+
 			if err := a.exportWriteLine(writer, postLine); err != nil {
 				return nil, err
 			}
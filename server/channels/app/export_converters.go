@@ -320,6 +320,17 @@ func importReactionFromPost(user *model.User, reaction *model.Reaction) *imports
 	}
 }
 
+func importReadReceiptFromPost(username string, receipt *model.PostReadReceipt) *imports.ReadReceiptImportData {
+	data := &imports.ReadReceiptImportData{
+		User:   &username,
+		ReadAt: &receipt.ReadAt,
+	}
+	if receipt.DeviceType != "" {
+		data.DeviceType = &receipt.DeviceType
+	}
+	return data
+}
+
 func importLineFromEmoji(emoji *model.Emoji, filePath string) *imports.LineImportData {
 	return &imports.LineImportData{
 		Type: "emoji",
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -869,6 +870,152 @@ func TestExportPostsWithThread(t *testing.T) {
 	})
 }
 
+func TestExportPostsWithReadReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th1 := Setup(t).InitBasic()
+
+	post := th1.CreatePost(th1.BasicChannel)
+	reply := th1.CreatePostReply(post)
+	dmPost := th1.CreatePost(th1.CreateDmChannel(th1.BasicUser2))
+
+	readAts := map[string]int64{
+		post.Message:   post.CreateAt + 1000,
+		reply.Message:  reply.CreateAt + 2000,
+		dmPost.Message: dmPost.CreateAt + 3000,
+	}
+	_, err := th1.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post.Id, UserId: th1.BasicUser2.Id, ReadAt: readAts[post.Message]},
+		{PostId: reply.Id, UserId: th1.BasicUser2.Id, ReadAt: readAts[reply.Message]},
+		{PostId: dmPost.Id, UserId: th1.BasicUser2.Id, ReadAt: readAts[dmPost.Message]},
+	})
+	require.NoError(t, err)
+
+	// exportedReceipts returns the exported receipts keyed by the message of their post.
+	exportedReceipts := func(t *testing.T, b []byte) map[string][]imports.ReadReceiptImportData {
+		receipts := map[string][]imports.ReadReceiptImportData{}
+		scanner := bufio.NewScanner(bytes.NewReader(b))
+		for scanner.Scan() {
+			var line imports.LineImportData
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+
+			var replies *[]imports.ReplyImportData
+			switch line.Type {
+			case "post":
+				if line.Post.ReadReceipts != nil {
+					receipts[*line.Post.Message] = *line.Post.ReadReceipts
+				}
+				replies = line.Post.Replies
+			case "direct_post":
+				if line.DirectPost.ReadReceipts != nil {
+					receipts[*line.DirectPost.Message] = *line.DirectPost.ReadReceipts
+				}
+				replies = line.DirectPost.Replies
+			}
+			if replies == nil {
+				continue
+			}
+			for _, reply := range *replies {
+				if reply.ReadReceipts != nil {
+					receipts[*reply.Message] = *reply.ReadReceipts
+				}
+			}
+		}
+		return receipts
+	}
+
+	t.Run("not exported by default", func(t *testing.T) {
+		var b bytes.Buffer
+		appErr := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+		require.Nil(t, appErr)
+
+		assert.Empty(t, exportedReceipts(t, b.Bytes()))
+	})
+
+	var b bytes.Buffer
+	appErr := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{IncludeReadReceipts: true})
+	require.Nil(t, appErr)
+	exported := b.Bytes()
+
+	t.Run("exported with the posts, replies and direct posts", func(t *testing.T) {
+		receipts := exportedReceipts(t, exported)
+		require.Len(t, receipts, len(readAts))
+		for message, readAt := range readAts {
+			require.Len(t, receipts[message], 1)
+			assert.Equal(t, th1.BasicUser2.Username, *receipts[message][0].User)
+			assert.Equal(t, readAt, *receipts[message][0].ReadAt)
+		}
+	})
+
+	t.Run("left out when excluded from exports by their policy", func(t *testing.T) {
+		th1.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+		defer th1.App.Srv().SetLicense(nil)
+		_, appErr := th1.App.UpdateReadReceiptPolicies([]*model.ReadReceiptPolicy{
+			{Name: "unexported", ChannelIds: model.StringArray{th1.BasicChannel.Id}, IncludeInExport: false},
+		})
+		require.Nil(t, appErr)
+		defer func() {
+			_, appErr = th1.App.UpdateReadReceiptPolicies([]*model.ReadReceiptPolicy{})
+			require.Nil(t, appErr)
+		}()
+
+		var b bytes.Buffer
+		appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{IncludeReadReceipts: true})
+		require.Nil(t, appErr)
+
+		receipts := exportedReceipts(t, b.Bytes())
+		assert.NotContains(t, receipts, post.Message)
+		assert.NotContains(t, receipts, reply.Message)
+		require.Len(t, receipts[dmPost.Message], 1)
+	})
+
+	th1.TearDown()
+
+	th2 := Setup(t)
+	defer th2.TearDown()
+
+	t.Run("imported back", func(t *testing.T) {
+		i, appErr := th2.App.BulkImport(th2.Context, bytes.NewReader(exported), nil, false, 5)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, i)
+
+		user, appErr := th2.App.GetUserByUsername(th1.BasicUser2.Username)
+		require.Nil(t, appErr)
+
+		var postIDs []string
+		messages := map[string]string{}
+		parents, err := th2.App.Srv().Store().Post().GetParentsForExportAfter(1000, strings.Repeat("0", 26), false)
+		require.NoError(t, err)
+		for _, parent := range parents {
+			postIDs = append(postIDs, parent.Id)
+			messages[parent.Id] = parent.Message
+			replies, err := th2.App.Srv().Store().Post().GetRepliesForExport(parent.Id)
+			require.NoError(t, err)
+			for _, reply := range replies {
+				postIDs = append(postIDs, reply.Id)
+				messages[reply.Id] = reply.Message
+			}
+		}
+		directParents, err := th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, strings.Repeat("0", 26), false)
+		require.NoError(t, err)
+		for _, parent := range directParents {
+			postIDs = append(postIDs, parent.Id)
+			messages[parent.Id] = parent.Message
+		}
+
+		receipts, err := th2.App.Srv().Store().PostReadReceipt().GetReadReceiptsForPosts(postIDs)
+		require.NoError(t, err)
+		require.Len(t, receipts, len(readAts))
+		for _, receipt := range receipts {
+			assert.Equal(t, user.Id, receipt.UserId)
+			assert.Equal(t, readAts[messages[receipt.PostId]], receipt.ReadAt)
+
+			summary, err := th2.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(receipt.PostId)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), summary.ReadCount)
+		}
+	})
+}
+
 func TestExportFileWarnings(t *testing.T) {
 	testCases := []struct {
 		Description string
@@ -1086,7 +1233,7 @@ func TestBuildPostReplies(t *testing.T) {
 	}
 
 	t.Run("basic post", func(t *testing.T) {
-		data, attachments, err := th.App.buildPostReplies(th.Context, th.BasicPost.Id, true)
+		data, attachments, err := th.App.buildPostReplies(th.Context, th.BasicPost.Id, true, false)
 		require.Nil(t, err)
 		require.Empty(t, data)
 		require.Empty(t, attachments)
@@ -1094,7 +1241,7 @@ func TestBuildPostReplies(t *testing.T) {
 
 	t.Run("root post with attachments and no replies", func(t *testing.T) {
 		post := createPostWithAttachments(th, 5, "")
-		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true)
+		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true, false)
 		require.Nil(t, err)
 		require.Empty(t, data)
 		require.Empty(t, attachments)
@@ -1103,7 +1250,7 @@ func TestBuildPostReplies(t *testing.T) {
 	t.Run("root post with attachments and a reply", func(t *testing.T) {
 		post := createPostWithAttachments(th, 5, "")
 		createPostWithAttachments(th, 0, post.Id)
-		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true)
+		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true, false)
 		require.Nil(t, err)
 		require.Len(t, data, 1)
 		require.Empty(t, attachments)
@@ -1113,7 +1260,7 @@ func TestBuildPostReplies(t *testing.T) {
 		post := createPostWithAttachments(th, 5, "")
 		reply1 := createPostWithAttachments(th, 2, post.Id)
 		reply2 := createPostWithAttachments(th, 3, post.Id)
-		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true)
+		data, attachments, err := th.App.buildPostReplies(th.Context, post.Id, true, false)
 		require.Nil(t, err)
 		require.Len(t, data, 2)
 		require.Len(t, attachments, 5)
@@ -666,6 +666,23 @@ func (th *TestHelper) AddReactionToPost(post *model.Post, user *model.User, emoj
 	return reaction
 }
 
+// EnableReadReceipts turns read receipts on for every channel and user.
+func (th *TestHelper) EnableReadReceipts() {
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn
+		*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = true
+	})
+}
+
+func (th *TestHelper) MarkPostAsRead(post *model.Post, user *model.User) *model.PostReadReceipt {
+	receipt, _, err := th.App.SaveReadReceiptForPost(th.Context, user.Id, &model.ReadReceiptRequest{PostId: post.Id})
+	if err != nil {
+		panic(err)
+	}
+	return receipt
+}
+
 func (th *TestHelper) ShutdownApp() {
 	done := make(chan bool)
 	go func() {
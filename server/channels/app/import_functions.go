@@ -1361,6 +1361,49 @@ func (a *App) importReaction(data *imports.ReactionImportData, post *model.Post)
 	return nil
 }
 
+// importReadReceipts saves the read receipts of the post and adds them to its
+// summary. Like reactions, they are saved through the store, so that nobody is
+// notified of them.
+func (a *App) importReadReceipts(data []imports.ReadReceiptImportData, post *model.Post) *model.AppError {
+	usernames := make([]string, 0, len(data))
+	for _, receiptData := range data {
+		if err := imports.ValidateReadReceiptImportData(&receiptData, post.CreateAt); err != nil {
+			return err
+		}
+		usernames = append(usernames, *receiptData.User)
+	}
+
+	users, appErr := a.getUsersByUsernames(usernames)
+	if appErr != nil {
+		return appErr
+	}
+
+	receipts := make([]*model.PostReadReceipt, 0, len(data))
+	for _, receiptData := range data {
+		receipt := &model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    users[strings.ToLower(*receiptData.User)].Id,
+			ChannelId: post.ChannelId,
+			ReadAt:    *receiptData.ReadAt,
+		}
+		if receiptData.DeviceType != nil {
+			receipt.DeviceType = *receiptData.DeviceType
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	saved, err := a.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch(receipts)
+	if err != nil {
+		return model.NewAppError("importReadReceipts", "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, err := a.applyReadReceiptSummaryDeltas(readReceiptSummaryDeltas(saved)); err != nil {
+		return model.NewAppError("importReadReceipts", "app.read_receipt.update_summaries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, post *model.Post, teamID string, extractContent bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1504,6 +1547,12 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 	for _, postWithData := range postsWithData {
 		a.updateFileInfoWithPostId(rctx, postWithData.post)
 
+		if postWithData.replyData.ReadReceipts != nil && len(*postWithData.replyData.ReadReceipts) > 0 {
+			if err := a.importReadReceipts(*postWithData.replyData.ReadReceipts, postWithData.post); err != nil {
+				return err
+			}
+		}
+
 		if postWithData.replyData.FlaggedBy != nil {
 			var preferences model.Preferences
 
@@ -2014,6 +2063,12 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if postWithData.postData.ReadReceipts != nil && len(*postWithData.postData.ReadReceipts) > 0 {
+			if err := a.importReadReceipts(*postWithData.postData.ReadReceipts, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.postData.Replies != nil && len(*postWithData.postData.Replies) > 0 {
 			err := a.importReplies(rctx, *postWithData.postData.Replies, postWithData.post, postWithData.team.Id, extractContent)
 			if err != nil {
@@ -2522,6 +2577,12 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if postWithData.directPostData.ReadReceipts != nil && len(*postWithData.directPostData.ReadReceipts) > 0 {
+			if err := a.importReadReceipts(*postWithData.directPostData.ReadReceipts, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.directPostData.Replies != nil {
 			if err := a.importReplies(rctx, *postWithData.directPostData.Replies, postWithData.post, "noteam", extractContent); err != nil {
 				return postWithData.lineNumber, err
@@ -180,6 +180,12 @@ type ReactionImportData struct {
 	EmojiName *string `json:"emoji_name"`
 }
 
+type ReadReceiptImportData struct {
+	User       *string `json:"user"`
+	ReadAt     *int64  `json:"read_at"`
+	DeviceType *string `json:"device_type,omitempty"`
+}
+
 type ReplyImportData struct {
 	User *string `json:"user"`
 
@@ -189,10 +195,11 @@ type ReplyImportData struct {
 	CreateAt *int64                 `json:"create_at"`
 	EditAt   *int64                 `json:"edit_at"`
 
-	FlaggedBy   *[]string               `json:"flagged_by,omitempty"`
-	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
-	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
-	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	FlaggedBy    *[]string                `json:"flagged_by,omitempty"`
+	Reactions    *[]ReactionImportData    `json:"reactions,omitempty"`
+	Attachments  *[]AttachmentImportData  `json:"attachments,omitempty"`
+	IsPinned     *bool                    `json:"is_pinned,omitempty"`
+	ReadReceipts *[]ReadReceiptImportData `json:"read_receipts,omitempty"`
 }
 
 type PostImportData struct {
@@ -213,6 +220,7 @@ type PostImportData struct {
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
+	ReadReceipts    *[]ReadReceiptImportData    `json:"read_receipts,omitempty"`
 }
 
 type DirectChannelImportData struct {
@@ -241,6 +249,7 @@ type DirectPostImportData struct {
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
+	ReadReceipts    *[]ReadReceiptImportData    `json:"read_receipts,omitempty"`
 }
 
 type SchemeImportData struct {
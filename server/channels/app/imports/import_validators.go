@@ -464,6 +464,24 @@ func ValidateReactionImportData(data *ReactionImportData, parentCreateAt int64)
 	return nil
 }
 
+func ValidateReadReceiptImportData(data *ReadReceiptImportData, parentCreateAt int64) *model.AppError {
+	if data.User == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_read_receipt_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.ReadAt == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_read_receipt_import_data.read_at_missing.error", nil, "", http.StatusBadRequest)
+	} else if *data.ReadAt < parentCreateAt {
+		return model.NewAppError("BulkImport", "app.import.validate_read_receipt_import_data.read_at_before_parent.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DeviceType != nil && !model.IsValidReadReceiptDeviceType(*data.DeviceType) {
+		return model.NewAppError("BulkImport", "app.import.validate_read_receipt_import_data.device_type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPostSize int) *model.AppError {
 	if data.User == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_reply_import_data.user_missing.error", nil, "", http.StatusBadRequest)
@@ -495,6 +513,14 @@ func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPos
 		}
 	}
 
+	if data.ReadReceipts != nil {
+		for _, receipt := range *data.ReadReceipts {
+			if err := ValidateReadReceiptImportData(&receipt, *data.CreateAt); err != nil {
+				return err
+			}
+		}
+	}
+
 	if data.Attachments != nil {
 		for _, attachment := range *data.Attachments {
 			if err := ValidateAttachmentImportData(&attachment); err != nil {
@@ -539,6 +565,14 @@ func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		}
 	}
 
+	if data.ReadReceipts != nil {
+		for _, receipt := range *data.ReadReceipts {
+			if err := ValidateReadReceiptImportData(&receipt, *data.CreateAt); err != nil {
+				return err
+			}
+		}
+	}
+
 	if data.Replies != nil {
 		for _, reply := range *data.Replies {
 			if err := ValidateReplyImportData(&reply, *data.CreateAt, maxPostSize); err != nil {
@@ -664,6 +698,14 @@ func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.ReadReceipts != nil {
+		for _, receipt := range *data.ReadReceipts {
+			if err := ValidateReadReceiptImportData(&receipt, *data.CreateAt); err != nil {
+				return err
+			}
+		}
+	}
+
 	if data.Replies != nil {
 		for _, reply := range *data.Replies {
 			if err := ValidateReplyImportData(&reply, *data.CreateAt, maxPostSize); err != nil {
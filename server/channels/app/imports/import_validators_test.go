@@ -844,6 +844,47 @@ func TestImportValidateUserChannelsImportData(t *testing.T) {
 	require.Nil(t, err, "Should have succeeded with valid notify props.")
 }
 
+func TestImportValidateReadReceiptImportData(t *testing.T) {
+	// Test with minimum required valid properties.
+	parentCreateAt := model.GetMillis() - 100
+	data := ReadReceiptImportData{
+		User:   model.NewPointer("username"),
+		ReadAt: model.NewPointer(model.GetMillis()),
+	}
+	err := ValidateReadReceiptImportData(&data, parentCreateAt)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with missing required properties.
+	data = ReadReceiptImportData{
+		ReadAt: model.NewPointer(model.GetMillis()),
+	}
+	err = ValidateReadReceiptImportData(&data, parentCreateAt)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	data = ReadReceiptImportData{
+		User: model.NewPointer("username"),
+	}
+	err = ValidateReadReceiptImportData(&data, parentCreateAt)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	// Test with a read before the post was created.
+	data = ReadReceiptImportData{
+		User:   model.NewPointer("username"),
+		ReadAt: model.NewPointer(parentCreateAt - 100),
+	}
+	err = ValidateReadReceiptImportData(&data, parentCreateAt)
+	require.NotNil(t, err, "Should have failed due to read_at before parent.")
+
+	// Test with an invalid device type.
+	data = ReadReceiptImportData{
+		User:       model.NewPointer("username"),
+		ReadAt:     model.NewPointer(model.GetMillis()),
+		DeviceType: model.NewPointer("toaster"),
+	}
+	err = ValidateReadReceiptImportData(&data, parentCreateAt)
+	require.NotNil(t, err, "Should have failed due to invalid device type.")
+}
+
 func TestImportValidateReactionImportData(t *testing.T) {
 	// Test with minimum required valid properties.
 	parentCreateAt := model.GetMillis() - 100
@@ -103,7 +103,7 @@ func (a *App) buildEmailNotification(
 	landingURL := a.GetSiteURL() + "/landing#/" + team.Name
 	buttonURL := landingURL
 	if team.Name != "select_team" {
-		buttonURL = landingURL + "/pl/" + post.Id
+		buttonURL = a.addReadReceiptEmailLinkToken(landingURL+"/pl/"+post.Id, user.Id, post.Id)
 	}
 
 	return &model.EmailNotification{
@@ -256,7 +256,7 @@ func (a *App) GetOAuthAccessTokenForImplicitFlow(c request.CTX, userID string, a
 		return nil, err
 	}
 
-	session, err := a.newSession(c, oauthApp, user)
+	session, err := a.newSession(c, oauthApp, user, authRequest.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -339,7 +339,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 		} else {
 			var session *model.Session
 			// Create a new session and return new access token
-			session, err := a.newSession(c, oauthApp, user)
+			session, err := a.newSession(c, oauthApp, user, authData.Scope)
 			if err != nil {
 				return nil, err
 			}
@@ -383,7 +383,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 	return accessRsp, nil
 }
 
-func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User) (*model.Session, *model.AppError) {
+func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User, scope string) (*model.Session, *model.AppError) {
 	if err := a.limitNumberOfSessions(c, user.Id); err != nil {
 		return nil, model.NewAppError("newSession", "api.oauth.get_access_token.internal_session.app_error", nil,
 			"", http.StatusInternalServerError).Wrap(err)
@@ -398,6 +398,10 @@ func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User) (
 	session.AddProp(model.SessionPropMattermostAppID, app.MattermostAppID)
 	session.AddProp(model.SessionPropOs, "OAuth2")
 	session.AddProp(model.SessionPropBrowser, "OAuth2")
+	if scope == "" {
+		scope = model.DefaultScope
+	}
+	session.AddProp(model.SessionPropOAuthScope, scope)
 
 	session, err := a.Srv().Store().Session().Save(c, session)
 	if err != nil {
@@ -417,7 +421,7 @@ func (a *App) newSessionUpdateToken(c request.CTX, app *model.OAuthApp, accessDa
 		c.Logger().Warn("error removing access data token from session", mlog.Err(err))
 	}
 
-	session, err := a.newSession(c, app, user)
+	session, err := a.newSession(c, app, user, accessData.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -1115,6 +1115,15 @@ func (a *App) getAddUploadFilePermissionMigration() (permissionsMap, error) {
 	}, nil
 }
 
+func (a *App) getAddViewReadReceiptsPermissionMigration() (permissionsMap, error) {
+	return permissionsMap{
+		permissionTransformation{
+			On:  permissionExists(model.PermissionReadChannelContent.Id),
+			Add: []string{model.PermissionViewReadReceipts.Id},
+		},
+	}, nil
+}
+
 func (a *App) getFixReadAuditsPermissionMigration() (permissionsMap, error) {
 	transformations := []permissionTransformation{}
 
@@ -1267,6 +1276,7 @@ func (s *Server) doPermissionsMigrations() error {
 		{Key: model.MigrationAddSysconsoleMobileSecurityPermission, Migration: a.addSysConsoleMobileSecurityPermission},
 		{Key: model.MigrationKeyAddChannelBannerPermissions, Migration: a.getAddChannelBannerPermissionMigration},
 		{Key: model.MigrationKeyAddChannelAccessRulesPermission, Migration: a.getAddChannelAccessRulesPermissionMigration},
+		{Key: model.MigrationKeyAddViewReadReceiptsPermission, Migration: a.getAddViewReadReceiptsPermissionMigration},
 	}
 
 	roles, err := s.Store().Role().GetAll()
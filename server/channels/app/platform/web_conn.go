@@ -43,6 +43,11 @@ const (
 	websocketSuppressWarnThreshold = time.Minute
 )
 
+// readReceiptsResyncLookback is how far before the first dropped receipt event
+// the resync hint asks clients to fetch from, covering the time the event spent
+// in the broadcast queue.
+const readReceiptsResyncLookback = 30 * time.Second
+
 const (
 	reconnectFound    = "success"
 	reconnectNotFound = "failure"
@@ -146,6 +151,10 @@ type WebConn struct {
 	// per minute.
 	lastLogTimeSlow time.Time
 	lastLogTimeFull time.Time
+
+	// readReceiptsDroppedSince is when the first receipt event was dropped while
+	// the connection was slow, or zero when no resync hint is pending.
+	readReceiptsDroppedSince atomic.Int64
 }
 
 // CheckConnResult indicates whether a connectionID was present in the hub or not.
@@ -653,6 +662,47 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 	return wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
 }
 
+// coalesceReadReceiptEvent drops receipt events while the connection is slow and
+// queues a WebsocketEventReadReceiptsResync hint once it catches up, so that the
+// client fetches the read state it missed rather than showing stale check marks.
+// It reports whether msg was dropped.
+func (wc *WebConn) coalesceReadReceiptEvent(msg *model.WebSocketEvent) bool {
+	if len(wc.send) >= sendSlowWarn {
+		if !isReadReceiptEvent(msg) {
+			return false
+		}
+		wc.readReceiptsDroppedSince.CompareAndSwap(0, model.GetMillis())
+		return true
+	}
+
+	droppedSince := wc.readReceiptsDroppedSince.Load()
+	if droppedSince == 0 {
+		return false
+	}
+
+	hint := model.NewWebSocketEvent(model.WebsocketEventReadReceiptsResync, "", "", wc.UserId, nil, "")
+	hint.Add("since", droppedSince-readReceiptsResyncLookback.Milliseconds())
+	select {
+	case wc.send <- hint:
+		wc.readReceiptsDroppedSince.Store(0)
+	default:
+	}
+	return false
+}
+
+func isReadReceiptEvent(msg *model.WebSocketEvent) bool {
+	switch msg.EventType() {
+	case model.WebsocketEventPostRead,
+		model.WebsocketEventPostReadBatch,
+		model.WebsocketEventChannelReadDigest,
+		model.WebsocketEventReadReceiptSummary,
+		model.WebsocketEventReadSummaryUpdated:
+		return true
+	default:
+		return false
+	}
+}
+
 // addToDeadQueue appends a message to the dead queue.
 func (wc *WebConn) addToDeadQueue(msg *model.WebSocketEvent) {
 	wc.deadQueue[wc.deadQueuePointer] = msg
@@ -143,6 +143,44 @@ func TestWebConnClearDeadQueue(t *testing.T) {
 	assert.Equal(t, 0, wc.deadQueuePointer)
 }
 
+func TestWebConnCoalesceReadReceiptEvent(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	wc := th.Service.NewWebConn(&WebConnConfig{
+		WebSocket: &websocket.Conn{},
+	}, th.Suite, &hookRunner{})
+
+	receiptEvent := model.NewWebSocketEvent(model.WebsocketEventPostRead, "", model.NewId(), "", nil, "")
+	postedEvent := model.NewWebSocketEvent(model.WebsocketEventPosted, "", model.NewId(), "", nil, "")
+
+	assert.False(t, wc.coalesceReadReceiptEvent(receiptEvent))
+
+	for range sendSlowWarn {
+		wc.send <- postedEvent
+	}
+	before := model.GetMillis()
+	assert.True(t, wc.coalesceReadReceiptEvent(receiptEvent))
+	assert.False(t, wc.coalesceReadReceiptEvent(postedEvent))
+
+	// Nothing is sent until the connection catches up.
+	assert.Len(t, wc.send, sendSlowWarn)
+	for range sendSlowWarn {
+		<-wc.send
+	}
+
+	assert.False(t, wc.coalesceReadReceiptEvent(postedEvent))
+	require.Len(t, wc.send, 1)
+	hint, ok := (<-wc.send).(*model.WebSocketEvent)
+	require.True(t, ok)
+	assert.Equal(t, model.WebsocketEventReadReceiptsResync, hint.EventType())
+	assert.GreaterOrEqual(t, hint.GetData()["since"], before-readReceiptsResyncLookback.Milliseconds())
+
+	// The hint is only sent once.
+	assert.False(t, wc.coalesceReadReceiptEvent(receiptEvent))
+	assert.Empty(t, wc.send)
+}
+
 func TestWebConnDrainDeadQueue(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
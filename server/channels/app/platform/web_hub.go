@@ -691,6 +691,9 @@ func (h *Hub) Start() {
 						return
 					}
 					if webConn.ShouldSendEvent(msg) {
+						if webConn.coalesceReadReceiptEvent(msg) {
+							return
+						}
 						select {
 						case webConn.send <- h.runBroadcastHooks(msg, webConn, broadcastHooks, broadcastHookArgs):
 						default:
@@ -30,10 +30,11 @@ type PluginAPI struct {
 }
 
 func NewPluginAPI(a *App, c request.CTX, manifest *model.Manifest) *PluginAPI {
+	// Plugins act on behalf of users, not as them.
 	return &PluginAPI{
 		id:       manifest.Id,
 		manifest: manifest,
-		ctx:      c,
+		ctx:      request.WithImpersonation(c, manifest.Id),
 		app:      a,
 		logger:   a.Log().Sugar(mlog.String("plugin_id", manifest.Id)),
 	}
@@ -324,6 +325,8 @@ func (api *PluginAPI) GetSession(sessionID string) (*model.Session, *model.AppEr
 }
 
 func (api *PluginAPI) CreateSession(session *model.Session) (*model.Session, *model.AppError) {
+	// The plugin acts on behalf of the user through the session.
+	session.AddProp(model.SessionPropImpersonatedBy, api.id)
 	return api.app.CreateSession(api.ctx, session)
 }
 
@@ -1585,3 +1588,11 @@ func (api *PluginAPI) DeletePropertyValuesForTarget(groupID, targetType, targetI
 func (api *PluginAPI) DeletePropertyValuesForField(groupID, fieldID string) error {
 	return api.app.PropertyService().DeletePropertyValuesForField(groupID, fieldID)
 }
+
+func (api *PluginAPI) GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError) {
+	return api.app.GetReadReceiptInfoForPost(api.ctx, postID, "")
+}
+
+func (api *PluginAPI) GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError) {
+	return api.app.ArePostsReadByUser(api.ctx, userID, postIDs)
+}
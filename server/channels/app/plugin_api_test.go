@@ -2892,3 +2892,25 @@ func TestPluginServeHTTPCompatibility(t *testing.T) {
 		})
 	}
 }
+
+func TestPluginAPIReadReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	api := th.SetupPluginAPI()
+
+	th.EnableReadReceipts()
+	unread := th.CreatePost(th.BasicChannel)
+	th.MarkPostAsRead(th.BasicPost, th.BasicUser2)
+
+	info, appErr := api.GetReadReceiptInfoForPost(th.BasicPost.Id)
+	require.Nil(t, appErr)
+	require.Equal(t, int64(1), info.ReadCount)
+	require.Len(t, info.Receipts, 1)
+	require.Equal(t, th.BasicUser2.Id, info.Receipts[0].UserId)
+
+	states, appErr := api.GetPostsReadStateForUser(th.BasicUser2.Id, []string{th.BasicPost.Id, unread.Id})
+	require.Nil(t, appErr)
+	require.True(t, states[th.BasicPost.Id].Read)
+	require.False(t, states[unread.Id].Read)
+}
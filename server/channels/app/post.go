@@ -437,6 +437,10 @@ func (a *App) CreatePost(c request.CTX, post *model.Post, channel *model.Channel
 		c.Logger().Warn("Failed to handle post events", mlog.Err(err))
 	}
 
+	if rpost.RootId != "" {
+		a.enqueueReplyReadReceipts(c, rpost, user, channel, parentPostList)
+	}
+
 	// Send any ephemeral posts after the post is created to ensure it shows up after the latest post created
 	if ephemeralPost != nil {
 		a.SendEphemeralPost(c, post.UserId, ephemeralPost)
@@ -1455,6 +1459,19 @@ func (a *App) DeletePost(rctx request.CTX, postID, deleteByID string) (*model.Po
 		return nil, model.NewAppError("DeletePost", "api.post.delete_post.can_not_delete_post_in_deleted.error", nil, "", http.StatusBadRequest)
 	}
 
+	// The replies are deleted along with a root post, so their offloaded receipts
+	// go too.
+	deletedPostIDs := []string{post.Id}
+	if post.RootId == "" {
+		thread, threadErr := a.Srv().Store().Post().GetPostsByThread(post.Id, 0)
+		if threadErr != nil {
+			return nil, model.NewAppError("DeletePost", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(threadErr)
+		}
+		for _, reply := range thread {
+			deletedPostIDs = append(deletedPostIDs, reply.Id)
+		}
+	}
+
 	err = a.Srv().Store().Post().Delete(rctx, postID, model.GetMillis(), deleteByID)
 	if err != nil {
 		var nfErr *store.ErrNotFound
@@ -1474,6 +1491,12 @@ func (a *App) DeletePost(rctx request.CTX, postID, deleteByID string) (*model.Po
 		a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(postID, false)
 	}
 
+	a.Srv().Go(func() {
+		if err := a.deleteColdStorageReadReceiptsForPosts(post.ChannelId, deletedPostIDs); err != nil {
+			rctx.Logger().Warn("Failed to delete the offloaded read receipts of the deleted posts", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	})
+
 	appErr = a.CleanUpAfterPostDeletion(rctx, post, deleteByID)
 	if appErr != nil {
 		return nil, appErr
@@ -61,6 +61,7 @@ func (a *App) PreparePostListForClient(c request.CTX, originalList *model.PostLi
 		PrevPostId:                originalList.PrevPostId,
 		HasNext:                   originalList.HasNext,
 		FirstInaccessiblePostTime: originalList.FirstInaccessiblePostTime,
+		FirstUnreadPostId:         originalList.FirstUnreadPostId,
 	}
 
 	for id, originalPost := range originalList.Posts {
@@ -0,0 +1,1722 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+// readReceiptsForUserLimit caps the number of receipts returned when listing
+// a user's own read history.
+const readReceiptsForUserLimit = 200
+
+// readReceiptSessionActivityDays is how far back the read activity of a user by
+// session goes by default.
+const readReceiptSessionActivityDays = 30
+
+// pinnedUnreadNoticeLimit caps the number of members listed in the notice sent
+// when a post is pinned.
+const pinnedUnreadNoticeLimit = 10
+
+const readReceiptEventOmitUsersCacheSize = 10000
+
+// readReceiptSummaryUpdateAttempts caps how many times the summaries a concurrent
+// writer changed are read again and retried.
+const readReceiptSummaryUpdateAttempts = 5
+
+// readReceiptEventOmitUsersCacheExpiry bounds how long receipt events keep going
+// to the members they went to before the reader joined or left the channel.
+var readReceiptEventOmitUsersCacheExpiry = time.Minute
+
+// ReadReceiptsEnabledForChannel reports whether read receipts can be recorded
+// for posts in the given channel under the current server configuration.
+func (a *App) ReadReceiptsEnabledForChannel(c request.CTX, channel *model.Channel) (bool, *model.AppError) {
+	settings := a.Config().ServiceSettings
+	if !*settings.EnableReadReceipts || *settings.ReadReceiptsDefaultSetting == model.ReadReceiptsDisabled {
+		return false, nil
+	}
+
+	switch channel.Type {
+	case model.ChannelTypeDirect:
+		return true, nil
+	case model.ChannelTypeGroup:
+		count, err := a.GetChannelMemberCount(c, channel.Id)
+		if err != nil {
+			return false, err
+		}
+		return count <= int64(*settings.ReadReceiptsMaxGroupSize), nil
+	default:
+		return *settings.ReadReceiptsEnableTeamChannels, nil
+	}
+}
+
+// UserHasReadReceiptsEnabled reports whether the user participates in read
+// receipts, taking the server default setting and the user preference into account.
+func (a *App) UserHasReadReceiptsEnabled(userID string) bool {
+	switch *a.Config().ServiceSettings.ReadReceiptsDefaultSetting {
+	case model.ReadReceiptsAlwaysOn:
+		return true
+	case model.ReadReceiptsEnabledDefaultOn, model.ReadReceiptsEnabledDefaultOff:
+		defaultValue := *a.Config().ServiceSettings.ReadReceiptsDefaultSetting == model.ReadReceiptsEnabledDefaultOn
+		pref, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNamePostReadReceiptsEnabled)
+		if err != nil {
+			return defaultValue
+		}
+		return pref.Value == "true"
+	default:
+		return false
+	}
+}
+
+func (a *App) getPostAndChannelForReadReceipt(c request.CTX, where, postID string) (*model.Post, *model.Channel, *model.AppError) {
+	post, err := a.GetSinglePost(c, postID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channel, err := a.GetChannel(c, post.ChannelId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, nil, model.NewAppError(where, "api.read_receipt.archived_channel.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeChannelArchived)
+	}
+
+	enabled, err := a.ReadReceiptsEnabledForChannel(c, channel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !enabled {
+		return nil, nil, model.NewAppError(where, "api.read_receipt.channel_disabled.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeChannelTypeNotAllowed)
+	}
+
+	return post, channel, nil
+}
+
+func (a *App) readReceiptDeviceType(c request.CTX) string {
+	if !*a.Config().ServiceSettings.ReadReceiptsEnableDeviceTracking {
+		return ""
+	}
+
+	if c.Session().IsMobileApp() {
+		return model.ReadReceiptDeviceTypeMobile
+	}
+
+	return model.ReadReceiptDeviceTypeWeb
+}
+
+// clampReadReceiptReadAt keeps a client supplied read time between the creation of
+// the post and the current time. Read times further in the future than
+// ServiceSettings.ReadReceiptsMaxClockSkewMs are brought back to now, while
+// smaller differences are kept as clock skew. Zero, which lets the server pick
+// the time, is returned unchanged, and is always returned when
+// ServiceSettings.ReadReceiptsServerTimestamps is enabled.
+func (a *App) clampReadReceiptReadAt(readAt, postCreateAt int64) int64 {
+	if readAt == 0 || *a.Config().ServiceSettings.ReadReceiptsServerTimestamps {
+		return 0
+	}
+
+	now := model.GetMillis()
+	if readAt > now+int64(*a.Config().ServiceSettings.ReadReceiptsMaxClockSkewMs) {
+		readAt = now
+	}
+
+	return max(readAt, postCreateAt)
+}
+
+// readReceiptClientReadAt returns the read time the client supplied, to be kept
+// alongside the server time of the receipt when
+// ServiceSettings.ReadReceiptsServerTimestamps is enabled, or 0 otherwise.
+func (a *App) readReceiptClientReadAt(readAt int64) int64 {
+	if !*a.Config().ServiceSettings.ReadReceiptsServerTimestamps {
+		return 0
+	}
+	return readAt
+}
+
+// readReceiptTemplate returns the receipt made by the read of req, without its
+// post, and whether the read can be re-sent identically, which only reads timed by
+// the client can. The read time is clamped to postCreateAt, see
+// clampReadReceiptReadAt; reads the client did not time are timed now.
+func (a *App) readReceiptTemplate(c request.CTX, userID, channelID string, req *model.ReadReceiptRequest, postCreateAt int64) (*model.PostReadReceipt, bool) {
+	readAt := a.clampReadReceiptReadAt(req.ReadAt, postCreateAt)
+	resendable := readAt != 0
+	if readAt == 0 {
+		readAt = model.GetMillis()
+	}
+
+	template := &model.PostReadReceipt{
+		UserId:       userID,
+		ChannelId:    channelID,
+		ReadAt:       readAt,
+		ClientReadAt: a.readReceiptClientReadAt(req.ReadAt),
+		DeviceType:   a.readReceiptDeviceType(c),
+		SessionId:    c.Session().Id,
+		Confidence:   req.Confidence,
+		Source:       req.Source,
+	}
+	if *a.Config().ServiceSettings.ReadReceiptsEnableDeviceTracking {
+		template.DeviceId = req.DeviceId
+	}
+
+	return template, resendable
+}
+
+// readReceiptConfidenceSufficient reports whether a read reported with the given
+// confidence counts as read under ServiceSettings.ReadReceiptsMinimumConfidence.
+// Reads without a confidence only count when no minimum is configured.
+func (a *App) readReceiptConfidenceSufficient(confidence string) bool {
+	minimum := *a.Config().ServiceSettings.ReadReceiptsMinimumConfidence
+	if minimum == "" {
+		return true
+	}
+
+	return model.ReadReceiptConfidenceRank(confidence) >= model.ReadReceiptConfidenceRank(minimum)
+}
+
+// skipImpersonatedReadReceipts reports whether the request is made on behalf of
+// the user by someone else, such as an administrator logged in as the user or a
+// plugin. Such reads don't create receipts; they are only audited.
+func (a *App) skipImpersonatedReadReceipts(c request.CTX, userID, channelID string, postIDs []string) bool {
+	actor := request.ImpersonatedBy(c)
+	if actor == "" {
+		return false
+	}
+
+	auditRec := a.MakeAuditRecord(c, model.AuditEventSkipImpersonatedReadReceipt, model.AuditStatusFail)
+	defer a.LogAuditRec(c, auditRec, nil)
+	auditRec.AddMeta("user_id", userID)
+	auditRec.AddMeta("impersonated_by", actor)
+	auditRec.AddMeta("channel_id", channelID)
+	auditRec.AddMeta("post_ids", postIDs)
+	auditRec.Success()
+	return true
+}
+
+// checkReadReceiptForPost runs the checks of SaveReadReceiptForPost that reject
+// a read, and returns the post read and its channel.
+func (a *App) checkReadReceiptForPost(c request.CTX, where, userID string, req *model.ReadReceiptRequest) (*model.Post, *model.Channel, *model.AppError) {
+	if !a.UserHasReadReceiptsEnabled(userID) {
+		return nil, nil, model.NewAppError(where, "api.read_receipt.user_disabled.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeUserOptedOut)
+	}
+	if req.Confidence != "" && !model.IsValidReadReceiptConfidence(req.Confidence) {
+		return nil, nil, model.NewAppError(where, "model.read_receipt.is_valid.confidence.app_error", nil, "confidence="+req.Confidence, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeInvalidConfidence)
+	}
+	if req.Source != "" && !model.IsValidClientReadReceiptSource(req.Source) {
+		return nil, nil, model.NewAppError(where, "model.read_receipt.is_valid.source.app_error", nil, "source="+req.Source, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeInvalidSource)
+	}
+
+	post, channel, appErr := a.getPostAndChannelForReadReceipt(c, where, req.PostId)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	if post.ReadReceiptsDisabled() {
+		return nil, nil, model.NewAppError(where, "api.read_receipt.post_disabled.app_error", nil, "post_id="+post.Id, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodePostOptedOut)
+	}
+	if post.CreateAt == 0 || !model.IsReadReceiptPostType(post.Type) {
+		return nil, nil, model.NewAppError(where, "api.read_receipt.post_type_not_allowed.app_error", nil, "post_id="+post.Id+", type="+post.Type, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodePostTypeNotAllowed)
+	}
+
+	return post, channel, nil
+}
+
+// SaveReadReceiptForPost records that the user has read the given post. A receipt
+// identical to the stored one (same post, user and ReadAt), as re-sent by clients
+// after reconnecting, is ignored: nothing is written, no event is published and
+// the returned bool is false. The same goes for reads made on behalf of the user,
+// reads through a session opted out of receipts, reads by members left out of the
+// sample of a large channel and ghost reads, for which no receipt is returned. In
+// channels over ReadReceiptsMaxPerChannel the read is saved as a watermark.
+func (a *App) SaveReadReceiptForPost(c request.CTX, userID string, req *model.ReadReceiptRequest) (*model.PostReadReceipt, bool, *model.AppError) {
+	return a.saveReadReceiptForPost(c, userID, req, 0)
+}
+
+// saveReadReceiptForPost is SaveReadReceiptForPost for a read that reached the
+// server at receivedAt, when held back by the write throttle, or just now otherwise.
+// Reads the client did not time are timed at receivedAt.
+func (a *App) saveReadReceiptForPost(c request.CTX, userID string, req *model.ReadReceiptRequest, receivedAt int64) (*model.PostReadReceipt, bool, *model.AppError) {
+	post, channel, appErr := a.checkReadReceiptForPost(c, "SaveReadReceiptForPost", userID, req)
+	if appErr != nil {
+		return nil, false, appErr
+	}
+
+	if a.skipImpersonatedReadReceipts(c, userID, channel.Id, []string{post.Id}) || c.Session().ReadReceiptsOptedOut() || a.readReceiptSampledOut(c, userID, channel) {
+		return nil, false, nil
+	}
+	if !a.readReceiptConfidenceSufficient(req.Confidence) {
+		return nil, false, nil
+	}
+
+	readAt := a.clampReadReceiptReadAt(req.ReadAt, post.CreateAt)
+	if a.readReceiptsPausedUntil(userID) > 0 {
+		if appErr := a.recordGhostRead(c, userID, post, readAt); appErr != nil {
+			return nil, false, appErr
+		}
+		return nil, false, nil
+	}
+	if req.Ghost {
+		if appErr := a.saveGhostRead(c, userID, post, readAt); appErr != nil {
+			return nil, false, appErr
+		}
+		return nil, false, nil
+	}
+
+	receipt, resendable := a.readReceiptTemplate(c, userID, post.ChannelId, req, post.CreateAt)
+	receipt.PostId = post.Id
+	if !resendable && receivedAt != 0 {
+		receipt.ReadAt = max(receivedAt, post.CreateAt)
+	}
+	if resendable {
+		_, resent, appErr := a.withoutResentReadReceipts("SaveReadReceiptForPost", []*model.PostReadReceipt{receipt})
+		if appErr != nil {
+			return nil, false, appErr
+		}
+		if len(resent) > 0 {
+			return resent[0], false, nil
+		}
+	}
+
+	// Channels over ReadReceiptsMaxPerChannel only accept watermarks, so the read
+	// marks every post up to this one as read.
+	if a.ReadReceiptsDegradedForChannel(c, channel.Id) {
+		saved, appErr := a.saveReadReceiptUpToPost(c, "SaveReadReceiptForPost", receipt, channel)
+		if appErr != nil {
+			return nil, false, appErr
+		}
+		return saved, saved != nil, nil
+	}
+
+	saved, appErr := a.saveReadReceipt(c, "SaveReadReceiptForPost", receipt, post, channel)
+	if appErr != nil {
+		return nil, false, appErr
+	}
+
+	return saved, true, nil
+}
+
+// saveGhostRead records a read of the post that nobody is told about. It is kept
+// apart from the receipts, and linked to the receipt that replaces it once the user
+// reads the post without ghost mode, so that the read is only counted once.
+func (a *App) saveGhostRead(c request.CTX, userID string, post *model.Post, readAt int64) *model.AppError {
+	if !*a.Config().ServiceSettings.ReadReceiptsEnableGhostMode {
+		return model.NewAppError("SaveReadReceiptForPost", "api.read_receipt.ghost_disabled.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeGhostModeDisabled)
+	}
+
+	return a.recordGhostRead(c, userID, post, readAt)
+}
+
+// recordGhostRead stores a ghost read of the post, see saveGhostRead.
+func (a *App) recordGhostRead(c request.CTX, userID string, post *model.Post, readAt int64) *model.AppError {
+	saved, err := a.Srv().Store().PostReadReceipt().SaveGhostRead(&model.PostReadReceipt{
+		PostId:    post.Id,
+		UserId:    userID,
+		ChannelId: post.ChannelId,
+		ReadAt:    readAt,
+	})
+	if err != nil {
+		return model.NewAppError("SaveReadReceiptForPost", "app.read_receipt.save_ghost.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if saved && *a.Config().ServiceSettings.ReadReceiptsRequireAuditLog {
+		auditRec := a.MakeAuditRecord(c, model.AuditEventSaveGhostReadReceipt, model.AuditStatusFail)
+		defer a.LogAuditRec(c, auditRec, nil)
+		auditRec.AddMeta("user_id", userID)
+		auditRec.AddMeta("channel_id", post.ChannelId)
+		auditRec.AddMeta("post_id", post.Id)
+		auditRec.Success()
+	}
+
+	return nil
+}
+
+// SaveBotReadReceiptForPost records that a bot has processed the given post.
+// Bot receipts are tagged with the "bot" device type and never count towards
+// the human read percentage of a post.
+func (a *App) SaveBotReadReceiptForPost(c request.CTX, botUserID, postID string) (*model.PostReadReceipt, *model.AppError) {
+	if !*a.Config().ServiceSettings.ReadReceiptsEnableBotReceipts {
+		return nil, model.NewAppError("SaveBotReadReceiptForPost", "api.read_receipt.bot_disabled.app_error", nil, "", http.StatusNotImplemented).WithCode(model.ReadReceiptErrorCodeBotReceiptsDisabled)
+	}
+
+	post, channel, appErr := a.getPostAndChannelForReadReceipt(c, "SaveBotReadReceiptForPost", postID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if post.ReadReceiptsDisabled() {
+		return nil, model.NewAppError("SaveBotReadReceiptForPost", "api.read_receipt.post_disabled.app_error", nil, "post_id="+post.Id, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodePostOptedOut)
+	}
+	if post.CreateAt == 0 || !model.IsReadReceiptPostType(post.Type) {
+		return nil, model.NewAppError("SaveBotReadReceiptForPost", "api.read_receipt.post_type_not_allowed.app_error", nil, "post_id="+post.Id+", type="+post.Type, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodePostTypeNotAllowed)
+	}
+
+	receipt := &model.PostReadReceipt{
+		PostId:     post.Id,
+		UserId:     botUserID,
+		ChannelId:  post.ChannelId,
+		DeviceType: model.ReadReceiptDeviceTypeBot,
+		SessionId:  c.Session().Id,
+	}
+
+	if a.ReadReceiptsDegradedForChannel(c, channel.Id) {
+		saved, appErr := a.saveReadReceiptUpToPost(c, "SaveBotReadReceiptForPost", receipt, channel)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if saved == nil {
+			// The bot had already processed the post.
+			existing, err := a.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, botUserID)
+			if err != nil {
+				return nil, model.NewAppError("SaveBotReadReceiptForPost", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			saved = existing
+		}
+		return saved, nil
+	}
+
+	return a.saveReadReceipt(c, "SaveBotReadReceiptForPost", receipt, post, channel)
+}
+
+// saveReadReceiptUpToPost saves the receipt as a watermark: every unread post of
+// the channel up to and including the one of the receipt is marked as read, like
+// the watermark form of SaveReadReceiptsBatch does. It returns the receipt saved
+// for the post of the receipt, or nil when it had already been read.
+func (a *App) saveReadReceiptUpToPost(c request.CTX, where string, receipt *model.PostReadReceipt, channel *model.Channel) (*model.PostReadReceipt, *model.AppError) {
+	saved, appErr := a.saveReadReceiptsUpToPost(c, where, receipt, channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, s := range saved {
+		if s.PostId == receipt.PostId {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// saveReadReceiptsUpToPost marks every unread post of the channel up to and
+// including template.PostId as read, with the other fields of template, and runs
+// the side effects of the saved receipts.
+func (a *App) saveReadReceiptsUpToPost(c request.CTX, where string, template *model.PostReadReceipt, channel *model.Channel) ([]*model.PostReadReceipt, *model.AppError) {
+	// With collapsed reply threads, replies are only read from their thread, see
+	// SaveThreadReadReceipts, so that reading the channel keeps thread unreads intact.
+	rootPostsOnly := a.IsCRTEnabledForUser(c, template.UserId)
+
+	// The unread posts are marked a page at a time, most recent first, until the
+	// backlog is cleared.
+	saved := []*model.PostReadReceipt{}
+	digest := &model.ChannelReadDigestEvent{
+		UserId:      template.UserId,
+		ChannelId:   channel.Id,
+		UpToPostId:  template.PostId,
+		ReadAt:      template.ReadAt,
+		ChannelType: channel.Type,
+	}
+	for {
+		page, err := a.Srv().Store().PostReadReceipt().SaveReadReceiptsUpToPost(template, model.ReadReceiptWatermarkMaxPosts, rootPostsOnly)
+		if err != nil {
+			// The pages saved so far still need their summaries and events.
+			a.handleSavedReadReceipts(c, channel, saved, nil, digest)
+
+			var appErr *model.AppError
+			switch {
+			case errors.As(err, &appErr):
+				return nil, appErr
+			default:
+				return nil, model.NewAppError(where, "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+
+		saved = append(saved, page...)
+		if len(page) < model.ReadReceiptWatermarkMaxPosts {
+			break
+		}
+	}
+
+	a.handleSavedReadReceipts(c, channel, saved, nil, digest)
+
+	return saved, nil
+}
+
+// saveReadReceipt stores a single receipt and updates the summary of its post. With
+// ServiceSettings.ReadReceiptsTransactionalSummary the summary is updated along with
+// the receipt, in the same transaction, instead of being recomputed asynchronously.
+func (a *App) saveReadReceipt(c request.CTX, where string, receipt *model.PostReadReceipt, post *model.Post, channel *model.Channel) (*model.PostReadReceipt, *model.AppError) {
+	var saved *model.PostReadReceipt
+	var summary *model.PostReadReceiptSummary
+	var previousReadCount int64
+	var nErr error
+	if *a.Config().ServiceSettings.ReadReceiptsTransactionalSummary {
+		saved, summary, previousReadCount, nErr = a.Srv().Store().PostReadReceipt().SaveReadReceiptWithSummary(receipt)
+	} else {
+		saved, nErr = a.Srv().Store().PostReadReceipt().SaveReadReceipt(receipt)
+	}
+	if nErr != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		case errors.As(nErr, &nfErr):
+			return nil, model.NewAppError(where, "app.read_receipt.save.deleted_post.app_error", nil, "", http.StatusNotFound).Wrap(nErr)
+		default:
+			return nil, model.NewAppError(where, "app.read_receipt.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	a.ch.readReceiptAggregator.recordReceipts(post.ChannelId, 1)
+	a.saveReadDevices(c, []*model.PostReadReceipt{saved})
+	a.chainReadReceipts(c, []*model.PostReadReceipt{saved})
+	a.exportReadReceipts(c, channel.Id, []*model.PostReadReceipt{saved})
+	a.sendReadReceiptEvent(c, saved, post, channel)
+	if summary != nil {
+		a.publishTransactionalReadReceiptSummary(c, previousReadCount, summary)
+	} else {
+		a.updateReadReceiptSummariesAsync(c, post.ChannelId, []*model.PostReadReceipt{saved})
+	}
+
+	return saved, nil
+}
+
+// publishTransactionalReadReceiptSummary does for a summary updated along with its
+// receipt what incrementReadReceiptSummariesAsync does once it stored a summary.
+func (a *App) publishTransactionalReadReceiptSummary(c request.CTX, previousReadCount int64, summary *model.PostReadReceiptSummary) {
+	a.invalidatePostReadPresence(c, summary.PostId)
+
+	a.publishReadReceiptSummary(c, summary)
+	a.publishReadSummaryToAuthor(c, summary)
+	a.notifyReadReceiptWebhooks(c, previousReadCount, summary)
+
+	a.Srv().Go(func() {
+		a.updateThreadReadReceiptSummaries(c, []string{summary.PostId})
+	})
+}
+
+// SaveReadReceiptsBatch records that the user has read several posts of the same channel.
+// The posts are either listed explicitly, in which case posts that do not belong to the
+// channel are skipped, or given as a watermark, in which case every unread post up to and
+// including it is marked as read.
+// Explicit lists are ignored, and the response flagged as degraded, once the channel
+// holds more receipts than ReadReceiptsMaxPerChannel. Receipts identical to the
+// stored ones are skipped, like in SaveReadReceiptForPost.
+func (a *App) SaveReadReceiptsBatch(c request.CTX, userID string, req *model.ReadReceiptBatchRequest) (*model.ReadReceiptBatchResponse, *model.AppError) {
+	if appErr := req.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if !a.UserHasReadReceiptsEnabled(userID) {
+		return nil, model.NewAppError("SaveReadReceiptsBatch", "api.read_receipt.user_disabled.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeUserOptedOut)
+	}
+
+	channel, appErr := a.GetChannel(c, req.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("SaveReadReceiptsBatch", "api.read_receipt.archived_channel.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeChannelArchived)
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !enabled {
+		return nil, model.NewAppError("SaveReadReceiptsBatch", "api.read_receipt.channel_disabled.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeChannelTypeNotAllowed)
+	}
+
+	// Channels over ReadReceiptsMaxPerChannel only accept the watermark form.
+	degraded := a.ReadReceiptsDegradedForChannel(c, channel.Id)
+	if degraded && req.UpToPostId == "" {
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Degraded: true}, nil
+	}
+
+	postIDs := req.PostIds
+	if req.UpToPostId != "" {
+		postIDs = []string{req.UpToPostId}
+	}
+	if a.skipImpersonatedReadReceipts(c, userID, channel.Id, postIDs) || c.Session().ReadReceiptsOptedOut() || a.readReceiptSampledOut(c, userID, channel) || !a.readReceiptConfidenceSufficient(req.Confidence) {
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Degraded: degraded}, nil
+	}
+	if a.readReceiptsPausedUntil(userID) > 0 {
+		if appErr := a.saveReadsWhilePaused(c, userID, channel, postIDs, req.ReadAt); appErr != nil {
+			return nil, appErr
+		}
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Degraded: degraded, Paused: true}, nil
+	}
+
+	// Posts created after the read time are clamped per post when the receipts are saved.
+	template, resendable := a.readReceiptTemplate(c, userID, channel.Id, &model.ReadReceiptRequest{
+		ReadAt:     req.ReadAt,
+		DeviceId:   req.DeviceId,
+		Confidence: req.Confidence,
+		Source:     req.Source,
+	}, 0)
+
+	if req.UpToPostId != "" {
+		template.PostId = req.UpToPostId
+		saved, appErr := a.saveReadReceiptsUpToPost(c, "SaveReadReceiptsBatch", template, channel)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		return &model.ReadReceiptBatchResponse{
+			ProcessedCount: len(saved),
+			Receipts:       saved,
+			Degraded:       degraded,
+		}, nil
+	}
+
+	// Clients switching channels quickly send overlapping batches; posts the
+	// user just marked as read are skipped before reaching the database.
+	postIDs = a.ch.readReceiptBuffer.dedupe(userID, req.PostIds)
+	if len(postIDs) == 0 {
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Degraded: degraded}, nil
+	}
+
+	// With collapsed reply threads, replies are only read from their thread, see
+	// SaveThreadReadReceipts, so that reading the channel keeps thread unreads intact.
+	receipts, rootIDs, appErr := a.readReceiptsForPostIds(template, postIDs, a.IsCRTEnabledForUser(c, userID))
+	if appErr == nil && resendable {
+		receipts, _, appErr = a.withoutResentReadReceipts("SaveReadReceiptsBatch", receipts)
+	}
+	if appErr != nil {
+		a.ch.readReceiptBuffer.forget(userID, postIDs)
+		return nil, appErr
+	}
+	saved, nErr := a.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch(receipts)
+	if nErr != nil {
+		a.ch.readReceiptBuffer.forget(userID, postIDs)
+
+		var appErr *model.AppError
+		switch {
+		case errors.As(nErr, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SaveReadReceiptsBatch", "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	a.handleSavedReadReceipts(c, channel, saved, rootIDs, nil)
+
+	return &model.ReadReceiptBatchResponse{
+		ProcessedCount: len(saved),
+		Receipts:       saved,
+		Degraded:       degraded,
+	}, nil
+}
+
+// SaveThreadReadReceipts records that the user has read a thread: its root post
+// and every reply. This is how replies get read when collapsed reply threads are
+// enabled for the user, since reading the channel only marks root posts then.
+// Receipts identical to the stored ones are skipped, like in SaveReadReceiptForPost.
+func (a *App) SaveThreadReadReceipts(c request.CTX, userID string, req *model.ReadReceiptRequest) (*model.ReadReceiptBatchResponse, *model.AppError) {
+	if !a.UserHasReadReceiptsEnabled(userID) {
+		return nil, model.NewAppError("SaveThreadReadReceipts", "api.read_receipt.user_disabled.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeUserOptedOut)
+	}
+	if req.Confidence != "" && !model.IsValidReadReceiptConfidence(req.Confidence) {
+		return nil, model.NewAppError("SaveThreadReadReceipts", "model.read_receipt.is_valid.confidence.app_error", nil, "confidence="+req.Confidence, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeInvalidConfidence)
+	}
+	if req.Source != "" && !model.IsValidClientReadReceiptSource(req.Source) {
+		return nil, model.NewAppError("SaveThreadReadReceipts", "model.read_receipt.is_valid.source.app_error", nil, "source="+req.Source, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeInvalidSource)
+	}
+
+	root, channel, appErr := a.getPostAndChannelForReadReceipt(c, "SaveThreadReadReceipts", req.PostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if root.RootId != "" {
+		return nil, model.NewAppError("SaveThreadReadReceipts", "api.read_receipt.thread.not_root.app_error", nil, "post_id="+root.Id, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeNotThreadRoot)
+	}
+
+	// Like explicit lists of posts, threads are not read in channels over
+	// ReadReceiptsMaxPerChannel, which only accept watermarks.
+	if a.ReadReceiptsDegradedForChannel(c, channel.Id) {
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Degraded: true}, nil
+	}
+
+	if a.skipImpersonatedReadReceipts(c, userID, channel.Id, []string{root.Id}) || c.Session().ReadReceiptsOptedOut() || a.readReceiptSampledOut(c, userID, channel) || !a.readReceiptConfidenceSufficient(req.Confidence) {
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}}, nil
+	}
+	if a.readReceiptsPausedUntil(userID) > 0 {
+		if appErr := a.saveReadsWhilePaused(c, userID, channel, []string{root.Id}, req.ReadAt); appErr != nil {
+			return nil, appErr
+		}
+		return &model.ReadReceiptBatchResponse{Receipts: []*model.PostReadReceipt{}, Paused: true}, nil
+	}
+
+	thread, err := a.Srv().Store().Post().Get(c.Context(), root.Id, model.GetPostsOptions{}, "", a.Config().GetSanitizeOptions())
+	if err != nil {
+		return nil, model.NewAppError("SaveThreadReadReceipts", "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	posts := make([]*model.Post, 0, len(thread.Posts))
+	for _, post := range thread.Posts {
+		posts = append(posts, post)
+	}
+
+	template, resendable := a.readReceiptTemplate(c, userID, channel.Id, req, 0)
+	receipts, rootIDs := readReceiptsForPosts(template, posts, false)
+	if resendable {
+		receipts, _, appErr = a.withoutResentReadReceipts("SaveThreadReadReceipts", receipts)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+	// Like the watermark form of a batch, very long threads are saved a page of
+	// posts at a time.
+	saved := make([]*model.PostReadReceipt, 0, len(receipts))
+	for page := range slices.Chunk(receipts, model.ReadReceiptWatermarkMaxPosts) {
+		pageSaved, err := a.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch(page)
+		if err != nil {
+			// The pages saved so far still need their summaries and events.
+			a.handleSavedReadReceipts(c, channel, saved, rootIDs, nil)
+
+			var appErr *model.AppError
+			switch {
+			case errors.As(err, &appErr):
+				return nil, appErr
+			default:
+				return nil, model.NewAppError("SaveThreadReadReceipts", "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+		saved = append(saved, pageSaved...)
+	}
+
+	a.handleSavedReadReceipts(c, channel, saved, rootIDs, nil)
+
+	return &model.ReadReceiptBatchResponse{
+		ProcessedCount: len(saved),
+		Receipts:       saved,
+	}, nil
+}
+
+// handleSavedReadReceipts runs the side effects of receipts saved together:
+// activity tracking, device history, the integrity chain, the export, the batch
+// event and the summary updates. When the receipts were saved up to a post, digest
+// is sent instead of the batch event, unless ReadReceiptsLegacyBatchEvents keeps it.
+func (a *App) handleSavedReadReceipts(c request.CTX, channel *model.Channel, saved []*model.PostReadReceipt, rootIDs map[string]string, digest *model.ChannelReadDigestEvent) {
+	if len(saved) > 0 {
+		a.ch.readReceiptAggregator.recordReceipts(channel.Id, len(saved))
+		a.saveReadDevices(c, saved)
+		a.chainReadReceipts(c, saved)
+		a.exportReadReceipts(c, channel.Id, saved)
+		if digest != nil {
+			a.publishReaderEvent(c, digest.ToWebSocketEvent(), channel.Id, digest.UserId)
+		}
+		if digest == nil || *a.Config().ServiceSettings.ReadReceiptsLegacyBatchEvents {
+			if rootIDs == nil {
+				rootIDs = a.readReceiptRootIds(c, saved)
+			}
+			a.sendReadReceiptBatchEvent(c, channel, saved, rootIDs)
+		}
+	}
+
+	a.updateReadReceiptSummariesAsync(c, channel.Id, saved)
+}
+
+// withoutResentReadReceipts splits the receipts of a user between the ones to
+// save and the ones identical to the stored receipts (same post and ReadAt), as
+// re-sent by clients after reconnecting. For the latter it returns the stored
+// receipts, which are neither written again nor announced.
+func (a *App) withoutResentReadReceipts(where string, receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, []*model.PostReadReceipt, *model.AppError) {
+	if len(receipts) == 0 {
+		return receipts, nil, nil
+	}
+
+	postIDs := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		postIDs = append(postIDs, receipt.PostId)
+	}
+	stored, err := a.Srv().Store().PostReadReceipt().GetReadReceiptsForUserPosts(receipts[0].UserId, postIDs)
+	if err != nil {
+		return nil, nil, model.NewAppError(where, "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	storedByPost := make(map[string]*model.PostReadReceipt, len(stored))
+	for _, receipt := range stored {
+		storedByPost[receipt.PostId] = receipt
+	}
+
+	fresh := make([]*model.PostReadReceipt, 0, len(receipts))
+	var resent []*model.PostReadReceipt
+	for _, receipt := range receipts {
+		if existing, ok := storedByPost[receipt.PostId]; ok && existing.ReadAt == receipt.ReadAt {
+			resent = append(resent, existing)
+			continue
+		}
+		fresh = append(fresh, receipt)
+	}
+
+	return fresh, resent, nil
+}
+
+// readReceiptsForPostIds builds one receipt per post from the template, see
+// readReceiptsForPosts.
+func (a *App) readReceiptsForPostIds(template *model.PostReadReceipt, postIDs []string, rootPostsOnly bool) ([]*model.PostReadReceipt, map[string]string, *model.AppError) {
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIDs)
+	if err != nil {
+		return nil, nil, model.NewAppError("SaveReadReceiptsBatch", "app.read_receipt.batch_save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	receipts, rootIDs := readReceiptsForPosts(template, posts, rootPostsOnly)
+	return receipts, rootIDs, nil
+}
+
+// readReceiptsForPosts builds one receipt per post from the template, skipping
+// posts that are deleted, opted out of read receipts, of a type that can't be
+// read explicitly or that do not belong to the template's channel, and thread
+// replies when rootPostsOnly is set. It also returns the thread root of every
+// reply among the posts.
+func readReceiptsForPosts(template *model.PostReadReceipt, posts []*model.Post, rootPostsOnly bool) ([]*model.PostReadReceipt, map[string]string) {
+	receipts := make([]*model.PostReadReceipt, 0, len(posts))
+	rootIDs := make(map[string]string)
+	for _, post := range posts {
+		if post.ChannelId != template.ChannelId || post.DeleteAt > 0 || post.ReadReceiptsDisabled() || !model.IsReadReceiptPostType(post.Type) {
+			continue
+		}
+		if rootPostsOnly && post.RootId != "" {
+			continue
+		}
+
+		receipt := *template
+		receipt.PostId = post.Id
+		receipt.ReadAt = max(receipt.ReadAt, post.CreateAt)
+		receipts = append(receipts, &receipt)
+		if post.RootId != "" {
+			rootIDs[post.Id] = post.RootId
+		}
+	}
+
+	return receipts, rootIDs
+}
+
+// readReceiptRootIds looks up, in a single query, the thread root of every reply
+// among the posts of the receipts.
+func (a *App) readReceiptRootIds(c request.CTX, receipts []*model.PostReadReceipt) map[string]string {
+	rootIDs := make(map[string]string)
+
+	postIDs := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		postIDs = append(postIDs, receipt.PostId)
+	}
+
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIDs)
+	if err != nil {
+		c.Logger().Warn("Failed to resolve thread roots for read receipts", mlog.Err(err))
+		return rootIDs
+	}
+
+	for _, post := range posts {
+		if post.RootId != "" {
+			rootIDs[post.Id] = post.RootId
+		}
+	}
+
+	return rootIDs
+}
+
+// implicitReadReceiptsAllowed reports whether receipts may be recorded on behalf
+// of the user in the channel without an explicit request from a client. Actions
+// taken by integrations on behalf of the user, or through a session opted out of
+// receipts, never count as reads.
+func (a *App) implicitReadReceiptsAllowed(c request.CTX, user *model.User, channel *model.Channel) (bool, *model.AppError) {
+	if user.IsBot || !c.Session().CanRecordReadReceipts() || c.Session().ReadReceiptsOptedOut() || !a.UserHasReadReceiptsEnabled(user.Id) || a.readReceiptsPausedUntil(user.Id) > 0 {
+		return false, nil
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil || !enabled {
+		return false, appErr
+	}
+
+	return !a.readReceiptSampledOut(c, user.Id, channel), nil
+}
+
+// saveImplicitReadReceipt records that the user read the post as a side effect of
+// another action, such as reacting to it. Nothing is recorded when the policy does
+// not allow receipts, when the action is made on behalf of the user or when the
+// user already read the post, in which case no event is published and the
+// returned receipt is nil.
+func (a *App) saveImplicitReadReceipt(c request.CTX, userID string, post *model.Post, channel *model.Channel, source string) (*model.PostReadReceipt, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	allowed, appErr := a.implicitReadReceiptsAllowed(c, user, channel)
+	if appErr != nil || !allowed {
+		return nil, appErr
+	}
+	if !model.IsReadReceiptPostType(post.Type) || post.ReadReceiptsDisabled() || a.skipImpersonatedReadReceipts(c, userID, channel.Id, []string{post.Id}) {
+		return nil, nil
+	}
+
+	receipt := &model.PostReadReceipt{
+		PostId:     post.Id,
+		UserId:     userID,
+		ChannelId:  post.ChannelId,
+		DeviceType: a.readReceiptDeviceType(c),
+		SessionId:  c.Session().Id,
+		Source:     source,
+	}
+
+	saved, err := a.Srv().Store().PostReadReceipt().SaveReadReceiptsIfNotExist([]*model.PostReadReceipt{receipt})
+	if err != nil {
+		var invErr *model.AppError
+		if errors.As(err, &invErr) {
+			return nil, invErr
+		}
+		return nil, model.NewAppError("saveImplicitReadReceipt", "app.read_receipt.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(saved) == 0 {
+		return nil, nil
+	}
+
+	a.ch.readReceiptAggregator.recordReceipts(post.ChannelId, 1)
+	a.saveReadDevices(c, saved)
+	a.chainReadReceipts(c, saved)
+	a.sendReadReceiptEvent(c, saved[0], post, channel)
+	a.updateReadReceiptSummariesAsync(c, post.ChannelId, saved)
+
+	return saved[0], nil
+}
+
+// enqueueReplyReadReceipts queues implicit receipts for the root and the earlier
+// replies of the thread the reply was posted in, on behalf of its author. The
+// receipts go through the coalescing buffer so that creating the reply does not
+// wait on them.
+func (a *App) enqueueReplyReadReceipts(c request.CTX, reply *model.Post, user *model.User, channel *model.Channel, thread *model.PostList) {
+	if thread == nil {
+		return
+	}
+
+	a.Srv().Go(func() {
+		if *a.Config().ServiceSettings.EnableReadReceipts {
+			// The reply counts as read by its author in the summary of the thread.
+			a.updateThreadReadReceiptSummaries(c, []string{reply.Id})
+		}
+
+		allowed, appErr := a.implicitReadReceiptsAllowed(c, user, channel)
+		if appErr != nil {
+			c.Logger().Warn("Failed to check read receipt policy for reply", mlog.String("post_id", reply.Id), mlog.Err(appErr))
+			return
+		}
+		if !allowed || a.skipImpersonatedReadReceipts(c, user.Id, channel.Id, []string{reply.RootId}) {
+			return
+		}
+
+		receipts := make([]*model.PostReadReceipt, 0, len(thread.Posts))
+		for _, post := range thread.Posts {
+			if post.Id == reply.Id || post.UserId == user.Id || post.DeleteAt > 0 || post.CreateAt > reply.CreateAt || !model.IsReadReceiptPostType(post.Type) || post.ReadReceiptsDisabled() {
+				continue
+			}
+
+			receipts = append(receipts, &model.PostReadReceipt{
+				PostId:     post.Id,
+				UserId:     user.Id,
+				ChannelId:  channel.Id,
+				ReadAt:     reply.CreateAt,
+				DeviceType: a.readReceiptDeviceType(c),
+				SessionId:  c.Session().Id,
+				Source:     model.ReadReceiptSourceReply,
+			})
+		}
+
+		a.ch.readReceiptBuffer.add(receipts...)
+	})
+}
+
+// flushImplicitReadReceipts writes the receipts collected by the coalescing buffer.
+// Receipts of posts the user already read are skipped, and events are only
+// published for the receipts that were actually inserted.
+func (a *App) flushImplicitReadReceipts(receipts []*model.PostReadReceipt) {
+	rctx := request.EmptyContext(a.Log())
+
+	saved, err := a.Srv().Store().PostReadReceipt().SaveReadReceiptsIfNotExist(receipts)
+	if err != nil {
+		rctx.Logger().Warn("Failed to save buffered read receipts", mlog.Int("count", len(receipts)), mlog.Err(err))
+		return
+	}
+
+	byChannel := make(map[string][]*model.PostReadReceipt)
+	for _, receipt := range saved {
+		byChannel[receipt.ChannelId] = append(byChannel[receipt.ChannelId], receipt)
+	}
+
+	for channelID, channelReceipts := range byChannel {
+		channel, appErr := a.GetChannel(rctx, channelID)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get channel for buffered read receipts", mlog.String("channel_id", channelID), mlog.Err(appErr))
+			continue
+		}
+
+		a.ch.readReceiptAggregator.recordReceipts(channelID, len(channelReceipts))
+		a.saveReadDevices(rctx, channelReceipts)
+		a.chainReadReceipts(rctx, channelReceipts)
+		a.exportReadReceipts(rctx, channelID, channelReceipts)
+		a.sendReadReceiptBatchEvent(rctx, channel, channelReceipts, a.readReceiptRootIds(rctx, channelReceipts))
+		a.updateReadReceiptSummariesAsync(rctx, channelID, channelReceipts)
+	}
+}
+
+// saveReadDevices keeps track of every device the receipts were recorded from when
+// ServiceSettings.ReadReceiptsStoreAllDevices is enabled. Failures are logged only,
+// the receipts themselves are already stored.
+func (a *App) saveReadDevices(c request.CTX, receipts []*model.PostReadReceipt) {
+	if !*a.Config().ServiceSettings.ReadReceiptsStoreAllDevices {
+		return
+	}
+
+	if err := a.Srv().Store().PostReadReceipt().SaveReadDevices(receipts); err != nil {
+		c.Logger().Warn("Failed to save read receipt devices", mlog.Int("count", len(receipts)), mlog.Err(err))
+	}
+}
+
+// GetReadDevicesForPostUser returns every device the user read the post on, as
+// recorded while ServiceSettings.ReadReceiptsStoreAllDevices was enabled. None are
+// returned when the viewer is not allowed to see the user.
+func (a *App) GetReadDevicesForPostUser(c request.CTX, viewerID, postID, userID string) ([]*model.PostReadReceipt, *model.AppError) {
+	devices, err := a.Srv().Store().PostReadReceipt().GetReadDevicesForPostUser(postID, userID)
+	if err != nil {
+		return nil, model.NewAppError("GetReadDevicesForPostUser", "app.read_receipt.get_devices.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.FilterReadReceiptsForViewer(c, viewerID, devices)
+}
+
+// IsPostReadByUser reports whether the user has a receipt for the post.
+func (a *App) IsPostReadByUser(c request.CTX, postID, userID string) (bool, *model.AppError) {
+	if _, err := a.Srv().Store().PostReadReceipt().GetReadReceipt(postID, userID); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return false, nil
+		}
+		return false, model.NewAppError("IsPostReadByUser", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return true, nil
+}
+
+// ArePostsReadByUser returns the read state of the user for each of the posts.
+func (a *App) ArePostsReadByUser(c request.CTX, userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError) {
+	receipts, err := a.Srv().Store().PostReadReceipt().GetReadReceiptsForUserPosts(userID, postIDs)
+	if err != nil {
+		return nil, model.NewAppError("ArePostsReadByUser", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	states := make(map[string]*model.PostReadState, len(postIDs))
+	for _, postID := range postIDs {
+		states[postID] = &model.PostReadState{}
+	}
+	for _, receipt := range receipts {
+		states[receipt.PostId] = &model.PostReadState{Read: true, ReadAt: receipt.ReadAt}
+	}
+
+	return states, nil
+}
+
+// DeleteReadReceiptForPost removes the user's receipt for the given post.
+func (a *App) DeleteReadReceiptForPost(c request.CTX, postID, userID string) *model.AppError {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	if _, nErr := a.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, userID); nErr != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(nErr, &nfErr):
+			return model.NewAppError("DeleteReadReceiptForPost", "app.read_receipt.get.app_error", nil, "", http.StatusNotFound).Wrap(nErr)
+		default:
+			return model.NewAppError("DeleteReadReceiptForPost", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	if nErr := a.Srv().Store().PostReadReceipt().DeleteReadReceipt(post.Id, userID); nErr != nil {
+		return model.NewAppError("DeleteReadReceiptForPost", "app.read_receipt.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	// The store uncounted the receipt along with deleting it, the empty delta only
+	// publishes the summary.
+	a.incrementReadReceiptSummariesAsync(c, post.ChannelId, []*model.PostReadReceiptSummary{{PostId: post.Id, ChannelId: post.ChannelId}})
+
+	return nil
+}
+
+// CheckPostRecallAllowed returns an error when ServiceSettings.ReadReceiptsRestrictDMRecall
+// keeps the author from deleting the post, a direct message someone already read.
+func (a *App) CheckPostRecallAllowed(c request.CTX, post *model.Post) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableReadReceipts || !*a.Config().ServiceSettings.ReadReceiptsRestrictDMRecall {
+		return nil
+	}
+
+	channel, appErr := a.GetChannel(c, post.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+	if channel.Type != model.ChannelTypeDirect {
+		return nil
+	}
+
+	// Neither the cache nor a replica, which may not know about a receipt saved
+	// moments ago.
+	read, _, err := a.Srv().Store().PostReadReceipt().IsPostReadByAnyone(post.Id, true)
+	if err != nil {
+		return model.NewAppError("CheckPostRecallAllowed", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if read {
+		return model.NewAppError("CheckPostRecallAllowed", "app.post.recall.already_read.app_error", nil, "post_id="+post.Id, http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// GetReadReceiptInfoForPost returns the receipts of a post along with the
+// human read percentage relative to the channel's non-bot members. Unless
+// deviceType is empty, only the receipts recorded from that device type are kept.
+func (a *App) GetReadReceiptInfoForPost(c request.CTX, postID, deviceType string) (*model.PostReadReceiptInfo, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	receipts, nErr := a.Srv().Store().PostReadReceipt().GetReadReceiptsForPost(post.Id, deviceType)
+	if nErr != nil {
+		return nil, model.NewAppError("GetReadReceiptInfoForPost", "app.read_receipt.get_for_post.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	humanMembers, nErr := a.Srv().Store().PostReadReceipt().GetHumanMemberCount(post.ChannelId)
+	if nErr != nil {
+		return nil, model.NewAppError("GetReadReceiptInfoForPost", "app.read_receipt.get_for_post.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	return model.NewPostReadReceiptInfo(post.Id, receipts, humanMembers), nil
+}
+
+// FilterReadReceiptsForViewer drops the receipts of the users the viewer is not
+// allowed to see, so that the readers of a post honor the same restrictions as the
+// user lists. This matters for guests, who only see the users they share a channel
+// with: in a direct or group message with members of other teams, the channel
+// checks alone would let them list readers they cannot otherwise see, such as
+// someone removed from the conversation since.
+func (a *App) FilterReadReceiptsForViewer(c request.CTX, viewerID string, receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, *model.AppError) {
+	return filterReadersForViewer(c, a, viewerID, receipts, func(receipt *model.PostReadReceipt) string { return receipt.UserId })
+}
+
+// filterReadersForViewer keeps the items whose user, as returned by userID, the
+// viewer is allowed to see. Every path handing out who read what goes through it,
+// see FilterReadReceiptsForViewer.
+func filterReadersForViewer[T any](c request.CTX, a *App, viewerID string, items []T, userID func(T) string) ([]T, *model.AppError) {
+	restrictions, appErr := a.GetViewUsersRestrictions(c, viewerID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if restrictions == nil {
+		return items, nil
+	}
+
+	visible := map[string]bool{viewerID: true}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		id := userID(item)
+		canSee, checked := visible[id]
+		if !checked {
+			canSee, appErr = a.userVisibleUnderRestrictions(id, restrictions)
+			if appErr != nil {
+				return nil, appErr
+			}
+			visible[id] = canSee
+		}
+		if canSee {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered, nil
+}
+
+// readReceiptEventOmitUsers returns the members of the channel who are not allowed
+// to see the reader, for the receipt events of the reader to skip them. Members of
+// a channel always see each other, so nobody is skipped while the reader is one.
+// The result is cached for a short while since every receipt event needs it.
+func (a *App) readReceiptEventOmitUsers(c request.CTX, channelID, readerID string) (map[string]bool, error) {
+	key := channelID + ":" + readerID
+	var omitUsers map[string]bool
+	err := a.Srv().readReceiptEventOmitUsersCache.Get(key, &omitUsers)
+	if err == nil {
+		return omitUsers, nil
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		c.Logger().Warn("Failed to get the cached users to omit from read receipt events", mlog.String("channel_id", channelID), mlog.String("user_id", readerID), mlog.Err(err))
+	}
+
+	omitUsers, err = a.getReadReceiptEventOmitUsers(c, channelID, readerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv().readReceiptEventOmitUsersCache.SetWithExpiry(key, omitUsers, readReceiptEventOmitUsersCacheExpiry); err != nil {
+		c.Logger().Warn("Failed to cache the users to omit from read receipt events", mlog.String("channel_id", channelID), mlog.String("user_id", readerID), mlog.Err(err))
+	}
+
+	return omitUsers, nil
+}
+
+func (a *App) getReadReceiptEventOmitUsers(c request.CTX, channelID, readerID string) (map[string]bool, error) {
+	_, err := a.Srv().Store().Channel().GetMember(c.Context(), channelID, readerID)
+	if err == nil {
+		return nil, nil
+	}
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return nil, fmt.Errorf("failed to get the channel member of the reader: %w", err)
+	}
+
+	members, err := a.Srv().Store().Channel().GetAllChannelMembersNotifyPropsForChannel(channelID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the channel members: %w", err)
+	}
+
+	omitUsers := make(map[string]bool)
+	for memberID := range members {
+		canSee, appErr := a.UserCanSeeOtherUser(c, memberID, readerID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !canSee {
+			omitUsers[memberID] = true
+		}
+	}
+
+	return omitUsers, nil
+}
+
+// publishReaderEvent publishes a receipt event of the reader to the members of the
+// channel allowed to see them. The event is dropped when that cannot be told.
+func (a *App) publishReaderEvent(c request.CTX, message *model.WebSocketEvent, channelID, readerID string) {
+	omitUsers, err := a.readReceiptEventOmitUsers(c, channelID, readerID)
+	if err != nil {
+		c.Logger().Warn("Failed to check who can see the reader of a read receipt event", mlog.String("channel_id", channelID), mlog.String("user_id", readerID), mlog.Err(err))
+		return
+	}
+	if len(omitUsers) > 0 {
+		message.GetBroadcast().OmitUsers = omitUsers
+	}
+	a.publishReadReceiptEvent(c, message)
+}
+
+// GetUnreadUsersForPost returns at most limit of the human members of the channel
+// of the post, other than its author, who have not read it yet and whom the viewer
+// is allowed to see.
+func (a *App) GetUnreadUsersForPost(c request.CTX, viewerID, postID string, limit int) ([]*model.User, *model.AppError) {
+	users, err := a.Srv().Store().PostReadReceipt().GetUnreadUsersForPost(postID, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetUnreadUsersForPost", "app.read_receipt.get_unread_users.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return filterReadersForViewer(c, a, viewerID, users, func(user *model.User) string { return user.Id })
+}
+
+// GetCaughtUpUsersForChannel returns at most limit of the human members of the
+// channel who read everything up to its latest post and whom the viewer is allowed
+// to see.
+func (a *App) GetCaughtUpUsersForChannel(c request.CTX, viewerID, channelID string, limit int) ([]*model.User, *model.AppError) {
+	users, err := a.Srv().Store().PostReadReceipt().GetCaughtUpUsersForChannel(channelID, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetCaughtUpUsersForChannel", "app.read_receipt.get_caught_up_users.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return filterReadersForViewer(c, a, viewerID, users, func(user *model.User) string { return user.Id })
+}
+
+// SendPinnedPostUnreadNotice sends the user who pinned the post an ephemeral
+// message listing the members who have not read it yet, when the channel admins
+// turned on the pinned unread notice of the channel.
+func (a *App) SendPinnedPostUnreadNotice(c request.CTX, pinnerID string, post *model.Post) {
+	if post.ReadReceiptsDisabled() {
+		return
+	}
+	settings, appErr := a.GetReadReceiptChannelSettings(post.ChannelId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the read receipt settings for the pinned unread notice", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+	if !settings.PinnedUnreadNotice {
+		return
+	}
+
+	channel, appErr := a.GetChannel(c, post.ChannelId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the channel for the pinned unread notice", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+	if enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel); appErr != nil || !enabled {
+		return
+	}
+	if appErr := a.checkReadReceiptsVisible(c, "SendPinnedPostUnreadNotice", pinnerID, post.ChannelId, post.UserId); appErr != nil {
+		return
+	}
+
+	pinner, appErr := a.GetUser(pinnerID)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the user for the pinned unread notice", mlog.String("user_id", pinnerID), mlog.Err(appErr))
+		return
+	}
+
+	// One extra member tells whether the list is complete.
+	users, appErr := a.GetUnreadUsersForPost(c, pinnerID, post.Id, pinnedUnreadNoticeLimit+1)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the unread members for the pinned unread notice", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+
+	T := i18n.GetUserTranslations(pinner.Locale)
+	var message string
+	switch {
+	case len(users) == 0:
+		message = T("app.read_receipt.pinned_unread_notice.all_read")
+	case len(users) > pinnedUnreadNoticeLimit:
+		message = T("app.read_receipt.pinned_unread_notice.unread_more", map[string]any{"Usernames": pinnedUnreadNoticeUsernames(users[:pinnedUnreadNoticeLimit])})
+	default:
+		message = T("app.read_receipt.pinned_unread_notice.unread", map[string]any{"Usernames": pinnedUnreadNoticeUsernames(users)})
+	}
+
+	a.SendEphemeralPost(c, pinnerID, &model.Post{
+		ChannelId: post.ChannelId,
+		RootId:    post.RootId,
+		Message:   message,
+	})
+}
+
+func pinnedUnreadNoticeUsernames(users []*model.User) string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, "@"+user.Username)
+	}
+	return strings.Join(usernames, ", ")
+}
+
+// GetReadReceiptExtremesForPost returns the earliest and the latest human readers
+// of a post, leaving out those the viewer is not allowed to see.
+func (a *App) GetReadReceiptExtremesForPost(c request.CTX, viewerID, postID string) (*model.PostReadReceiptExtremes, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	extremes, err := a.Srv().Store().PostReadReceipt().GetReadReceiptExtremes(post.Id)
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptExtremesForPost", "app.read_receipt.get_extremes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, reader := range []**model.ReadReceiptReader{&extremes.FirstReader, &extremes.LastReader} {
+		if *reader == nil {
+			continue
+		}
+		visible, appErr := filterReadersForViewer(c, a, viewerID, []*model.ReadReceiptReader{*reader}, func(r *model.ReadReceiptReader) string { return r.UserId })
+		if appErr != nil {
+			return nil, appErr
+		}
+		if len(visible) == 0 {
+			*reader = nil
+		}
+	}
+
+	return extremes, nil
+}
+
+// GetReadReceiptSummaryForPost returns the summary of a post. Posts that were
+// never summarized get their summary computed from their receipts, concurrent
+// reads of the same post sharing a single computation. It is not stored, the
+// summaries only change through increments so that none is lost. Posts without
+// receipts get an empty summary.
+func (a *App) GetReadReceiptSummaryForPost(c request.CTX, postID string) (*model.PostReadReceiptSummary, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	summary, appErr := a.readReceiptSummaryForPost(c, post)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	a.readReceiptSampleForChannel(c, post.ChannelId).estimateSummaries(summary)
+
+	return summary, nil
+}
+
+func (a *App) readReceiptSummaryForPost(c request.CTX, post *model.Post) (*model.PostReadReceiptSummary, *model.AppError) {
+	summary, err := a.Srv().Store().PostReadReceipt().GetReadReceiptSummary(post.Id)
+	if err == nil {
+		return summary, nil
+	}
+
+	var nfErr *store.ErrNotFound
+	if !errors.As(err, &nfErr) {
+		return nil, model.NewAppError("GetReadReceiptSummaryForPost", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	_, count, appErr := a.isPostReadByAnyone(c, post.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if count == 0 {
+		return &model.PostReadReceiptSummary{PostId: post.Id, ChannelId: post.ChannelId}, nil
+	}
+
+	v, err, _ := a.ch.readReceiptSummaryGroup.Do(post.Id, func() (any, error) {
+		summary, err := a.Srv().Store().PostReadReceipt().ComputeReadReceiptSummary(post.Id)
+		if err != nil {
+			return nil, err
+		}
+		summary.ChannelId = post.ChannelId
+		return summary, nil
+	})
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptSummaryForPost", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return v.(*model.PostReadReceiptSummary), nil
+}
+
+// GetReadCountsForLatestPosts returns the read counters of the most recent posts of
+// a channel in one query, so a channel can be rendered without fetching the
+// counters of each post separately.
+func (a *App) GetReadCountsForLatestPosts(c request.CTX, channelID string, limit int) ([]*model.PostReadCount, *model.AppError) {
+	counts, err := a.Srv().Store().PostReadReceipt().GetReadCountsForLatestPosts(channelID, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetReadCountsForLatestPosts", "app.read_receipt.get_read_counts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return counts, nil
+}
+
+func (a *App) GetReadReceiptSummariesForChannel(c request.CTX, channelID string, since int64) ([]*model.PostReadReceiptSummary, *model.AppError) {
+	summaries, nErr := a.Srv().Store().PostReadReceipt().GetReadReceiptSummariesForChannel(channelID, since)
+	if nErr != nil {
+		return nil, model.NewAppError("GetReadReceiptSummariesForChannel", "app.read_receipt.get_summaries.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	a.readReceiptSampleForChannel(c, channelID).estimateSummaries(summaries...)
+
+	return summaries, nil
+}
+
+// GetReadReceiptsForUser returns a page of the user's receipts matching opts along
+// with the token of the next page, if any.
+func (a *App) GetReadReceiptsForUser(c request.CTX, userID string, opts model.GetReadReceiptsForUserOptions) (*model.ReadReceiptsForUserPage, *model.AppError) {
+	return a.getReadReceiptsPage(opts, func(opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
+		return a.Srv().Store().PostReadReceipt().GetReadReceiptsForUser(userID, opts)
+	})
+}
+
+// GetReadReceiptsForSession lists the receipts recorded through a session, for
+// forensic investigations. Receipts of revoked sessions are included.
+func (a *App) GetReadReceiptsForSession(c request.CTX, sessionID string, opts model.GetReadReceiptsForUserOptions) (*model.ReadReceiptsForUserPage, *model.AppError) {
+	return a.getReadReceiptsPage(opts, func(opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
+		return a.Srv().Store().PostReadReceipt().GetReadReceiptsForSession(sessionID, opts)
+	})
+}
+
+// GetReadReceiptSessionActivity returns a page of the read activity of the user
+// through each of their sessions and devices since since, or over the last
+// readReceiptSessionActivityDays days when since is 0.
+func (a *App) GetReadReceiptSessionActivity(c request.CTX, userID string, since int64, page, perPage int) ([]*model.ReadReceiptSessionActivity, *model.AppError) {
+	if since <= 0 {
+		since = time.Now().AddDate(0, 0, -readReceiptSessionActivityDays).UnixMilli()
+	}
+	perPage = min(perPage, readReceiptsForUserLimit)
+
+	activity, err := a.Srv().Store().PostReadReceipt().GetReadActivityBySession(userID, since, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptSessionActivity", "app.read_receipt.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return activity, nil
+}
+
+func (a *App) getReadReceiptsPage(opts model.GetReadReceiptsForUserOptions, get func(opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error)) (*model.ReadReceiptsForUserPage, *model.AppError) {
+	if opts.PerPage <= 0 || opts.PerPage > readReceiptsForUserLimit {
+		opts.PerPage = readReceiptsForUserLimit
+	}
+	perPage := opts.PerPage
+
+	// Fetch one extra receipt to know whether there is a next page.
+	opts.PerPage++
+	receipts, nErr := get(opts)
+	if nErr != nil {
+		return nil, model.NewAppError("getReadReceiptsPage", "app.read_receipt.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	page := &model.ReadReceiptsForUserPage{Receipts: receipts}
+	if len(receipts) > perPage {
+		page.Receipts = receipts[:perPage]
+		last := page.Receipts[perPage-1]
+		page.NextPage = model.ReadReceiptCursor{ReadAt: last.ReadAt, PostId: last.PostId}.String()
+	}
+
+	return page, nil
+}
+
+// updateReadReceiptSummariesAsync counts the saved receipts in the summaries of
+// their posts, see incrementReadReceiptSummariesAsync. Receipts that updated an
+// earlier read of the user only move LastReadAt forward.
+func (a *App) updateReadReceiptSummariesAsync(c request.CTX, channelID string, saved []*model.PostReadReceipt) {
+	if len(saved) == 0 {
+		return
+	}
+
+	a.incrementReadReceiptSummariesAsync(c, channelID, readReceiptSummaryDeltas(saved))
+}
+
+// readReceiptSummaryDeltas returns what the saved receipts add to the summaries
+// of their posts: the read counts only grow on the first read of a user.
+func readReceiptSummaryDeltas(saved []*model.PostReadReceipt) []*model.PostReadReceiptSummary {
+	deltas := make([]*model.PostReadReceiptSummary, 0, len(saved))
+	for _, receipt := range saved {
+		delta := &model.PostReadReceiptSummary{PostId: receipt.PostId, ChannelId: receipt.ChannelId}
+		if receipt.DeviceType == model.ReadReceiptDeviceTypeBot {
+			if receipt.FirstRead {
+				delta.BotReadCount = 1
+			}
+		} else {
+			delta.LastReadAt = receipt.ReadAt
+			if receipt.FirstRead {
+				delta.ReadCount = 1
+			}
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
+
+// incrementReadReceiptSummariesAsync adds the deltas to the denormalized
+// summaries of their posts in the background, then updates the summaries of
+// their threads and notifies the channel.
+func (a *App) incrementReadReceiptSummariesAsync(c request.CTX, channelID string, deltas []*model.PostReadReceiptSummary) {
+	postIDs := make([]string, 0, len(deltas))
+	readDeltas := make(map[string]int64, len(deltas))
+	for _, delta := range deltas {
+		if _, ok := readDeltas[delta.PostId]; !ok {
+			postIDs = append(postIDs, delta.PostId)
+		}
+		readDeltas[delta.PostId] += delta.ReadCount
+	}
+
+	a.invalidatePostReadPresence(c, postIDs...)
+
+	summaryID := a.ch.readReceiptAggregator.summaryQueued()
+	a.Srv().Go(func() {
+		defer a.ch.readReceiptAggregator.summaryDone(summaryID)
+
+		stored, err := a.applyReadReceiptSummaryDeltas(deltas)
+		if err != nil {
+			c.Logger().Warn("Failed to update read receipt summaries", mlog.String("channel_id", channelID), mlog.Int("count", len(postIDs)), mlog.Err(err))
+			return
+		}
+
+		for _, summary := range stored {
+			a.publishReadReceiptSummary(c, summary)
+			a.publishReadSummaryToAuthor(c, summary)
+			a.notifyReadReceiptWebhooks(c, summary.ReadCount-readDeltas[summary.PostId], summary)
+		}
+		a.updateThreadReadReceiptSummaries(c, postIDs)
+	})
+}
+
+// applyReadReceiptSummaryDeltas adds the deltas to the stored summaries of their
+// posts, creating the missing ones, and returns the summaries as stored, one per
+// post. The summaries are read from the master and written back in a single batch
+// that only applies to the summaries whose version did not change in between; the
+// others are read again and retried.
+func (a *App) applyReadReceiptSummaryDeltas(deltas []*model.PostReadReceiptSummary) ([]*model.PostReadReceiptSummary, error) {
+	byPostID := make(map[string]*model.PostReadReceiptSummary, len(deltas))
+	pending := make([]string, 0, len(deltas))
+	for _, delta := range deltas {
+		merged, ok := byPostID[delta.PostId]
+		if !ok {
+			merged = &model.PostReadReceiptSummary{PostId: delta.PostId, ChannelId: delta.ChannelId}
+			byPostID[delta.PostId] = merged
+			pending = append(pending, delta.PostId)
+		}
+		merged.ReadCount += delta.ReadCount
+		merged.BotReadCount += delta.BotReadCount
+		merged.LastReadAt = max(merged.LastReadAt, delta.LastReadAt)
+	}
+
+	stored := make([]*model.PostReadReceiptSummary, 0, len(pending))
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt == readReceiptSummaryUpdateAttempts {
+			return stored, store.NewErrConflict("PostReadReceiptSummary", nil, fmt.Sprintf("count=%d", len(pending)))
+		}
+
+		current, err := a.Srv().Store().PostReadReceipt().GetReadReceiptSummariesForPosts(pending, true)
+		if err != nil {
+			return stored, err
+		}
+		currentByPostID := make(map[string]*model.PostReadReceiptSummary, len(current))
+		for _, summary := range current {
+			currentByPostID[summary.PostId] = summary
+		}
+
+		now := model.GetMillis()
+		summaries := make([]*model.PostReadReceiptSummary, 0, len(pending))
+		for _, postID := range pending {
+			delta := byPostID[postID]
+			summary := &model.PostReadReceiptSummary{PostId: postID, ChannelId: delta.ChannelId, LastUpdated: now}
+			if previous, ok := currentByPostID[postID]; ok {
+				*summary = *previous
+				summary.LastUpdated = max(now, previous.LastUpdated+1)
+			}
+			summary.ReadCount += delta.ReadCount
+			summary.BotReadCount += delta.BotReadCount
+			summary.LastReadAt = max(summary.LastReadAt, delta.LastReadAt)
+			summaries = append(summaries, summary)
+		}
+
+		updated, err := a.Srv().Store().PostReadReceipt().UpdateReadReceiptSummaries(summaries)
+		if err != nil {
+			return stored, err
+		}
+		stored = append(stored, updated...)
+
+		done := make(map[string]bool, len(updated))
+		for _, summary := range updated {
+			done[summary.PostId] = true
+		}
+		pending = slices.DeleteFunc(pending, func(postID string) bool { return done[postID] })
+	}
+
+	return stored, nil
+}
+
+// publishReadReceiptSummary broadcasts the new read counters of a post to its
+// channel, unless the channel admins turned the broadcast off.
+func (a *App) publishReadReceiptSummary(c request.CTX, summary *model.PostReadReceiptSummary) {
+	message := model.NewWebSocketEvent(model.WebsocketEventReadReceiptSummary, "", summary.ChannelId, "", nil, "")
+	message.Add("post_id", summary.PostId)
+	message.Add("read_count", summary.ReadCount)
+	message.Add("bot_read_count", summary.BotReadCount)
+	message.Add("last_read_at", summary.LastReadAt)
+	a.publishReadReceiptEvent(c, message)
+}
+
+// publishReadSummaryToAuthor sends the new read count of a post to the connections
+// of its author only, so that authors can follow their posts being read without
+// the whole channel receiving the update.
+func (a *App) publishReadSummaryToAuthor(c request.CTX, summary *model.PostReadReceiptSummary) {
+	post, appErr := a.GetSinglePost(c, summary.PostId, false)
+	if appErr != nil {
+		c.Logger().Debug("Failed to get the post to notify its author of the read summary", mlog.String("post_id", summary.PostId), mlog.Err(appErr))
+		return
+	}
+	policy, appErr := a.readReceiptPolicyForChannel(c, summary.ChannelId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to resolve the read receipt policy to notify the author of the read summary", mlog.String("post_id", summary.PostId), mlog.Err(appErr))
+		return
+	}
+	if policy != nil && policy.VisibilityMode == model.ReadReceiptVisibilityHidden {
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventReadSummaryUpdated, "", "", post.UserId, nil, "")
+	message.Add("post_id", summary.PostId)
+	message.Add("channel_id", summary.ChannelId)
+	message.Add("read_count", summary.ReadCount)
+	a.publishReadReceiptEvent(c, message)
+}
+
+// GetReadReceiptTeamUsage returns the receipt volume and storage of every team,
+// as of the last run of the job refreshing the receipt rollups.
+func (a *App) GetReadReceiptTeamUsage() ([]*model.ReadReceiptTeamUsage, *model.AppError) {
+	usage, err := a.Srv().Store().PostReadReceipt().GetReadReceiptTeamUsage()
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptTeamUsage", "app.read_receipt.get_team_usage.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return usage, nil
+}
+
+// GetReadReceiptsOverview returns the rolling read receipt activity of the
+// cluster for the system console, from the counters of this node and the last
+// ones the other nodes sent.
+func (a *App) GetReadReceiptsOverview() *model.ReadReceiptsOverview {
+	return a.ch.readReceiptAggregator.overview()
+}
+
+// GetReadReceiptsHealth reports whether this node keeps the read receipt
+// summaries up to date within ServiceSettings.ReadReceiptsSummarySLASeconds.
+func (a *App) GetReadReceiptsHealth() *model.ReadReceiptsHealth {
+	staleness := a.ch.readReceiptAggregator.summaryStaleness()
+	sla := time.Duration(*a.Config().ServiceSettings.ReadReceiptsSummarySLASeconds) * time.Second
+
+	health := &model.ReadReceiptsHealth{
+		Status:                model.ReadReceiptsHealthOk,
+		SummaryStaleness:      staleness.Milliseconds(),
+		SummaryStalenessSLA:   sla.Milliseconds(),
+		PendingSummaryUpdates: a.ch.readReceiptAggregator.overview().PendingSummaryUpdates,
+	}
+	if staleness > sla {
+		health.Status = model.ReadReceiptsHealthDegraded
+	}
+
+	return health
+}
+
+func (a *App) sendReadReceiptEvent(rctx request.CTX, receipt *model.PostReadReceipt, post *model.Post, channel *model.Channel) {
+	event := &model.PostReadEvent{
+		ReadReceipt: receipt,
+		RootId:      post.RootId,
+		ChannelType: channel.Type,
+	}
+	message, err := event.ToWebSocketEvent(post.ChannelId)
+	if err != nil {
+		rctx.Logger().Warn("Failed to encode read receipt to JSON", mlog.Err(err))
+		return
+	}
+	a.publishReaderEvent(rctx, message, post.ChannelId, receipt.UserId)
+}
+
+// sendReadReceiptBatchEvent publishes the receipts saved by a batch, one event per
+// reader so that each goes to the members allowed to see its reader. rootIDs maps
+// the posts that are thread replies to their root so clients can route the update
+// to thread views without looking the posts up.
+func (a *App) sendReadReceiptBatchEvent(rctx request.CTX, channel *model.Channel, receipts []*model.PostReadReceipt, rootIDs map[string]string) {
+	var readerIDs []string
+	byReader := make(map[string][]*model.PostReadReceipt)
+	for _, receipt := range receipts {
+		if _, ok := byReader[receipt.UserId]; !ok {
+			readerIDs = append(readerIDs, receipt.UserId)
+		}
+		byReader[receipt.UserId] = append(byReader[receipt.UserId], receipt)
+	}
+
+	for _, readerID := range readerIDs {
+		event := &model.PostReadBatchEvent{
+			ReadReceipts: byReader[readerID],
+			RootIds:      rootIDs,
+			ChannelType:  channel.Type,
+		}
+		message, err := event.ToWebSocketEvent(channel.Id)
+		if err != nil {
+			rctx.Logger().Warn("Failed to encode read receipts to JSON", mlog.Err(err))
+			return
+		}
+		a.publishReaderEvent(rctx, message, channel.Id, readerID)
+	}
+}
@@ -0,0 +1,818 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestReadReceiptsEnabledForChannel(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	gm := th.CreateGroupChannel(th.Context, th.BasicUser2, th.CreateUser())
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn
+		*cfg.ServiceSettings.ReadReceiptsMaxGroupSize = 3
+		*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = false
+	})
+
+	for name, tc := range map[string]struct {
+		channel  *model.Channel
+		expected bool
+	}{
+		"direct channel":   {dm, true},
+		"small group":      {gm, true},
+		"team channel off": {th.BasicChannel, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			enabled, appErr := th.App.ReadReceiptsEnabledForChannel(th.Context, tc.channel)
+			require.Nil(t, appErr)
+			require.Equal(t, tc.expected, enabled)
+		})
+	}
+
+	t.Run("group above max size", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxGroupSize = 2 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxGroupSize = 3 })
+
+		enabled, appErr := th.App.ReadReceiptsEnabledForChannel(th.Context, gm)
+		require.Nil(t, appErr)
+		require.False(t, enabled)
+	})
+
+	t.Run("disabled server wide", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = true })
+
+		enabled, appErr := th.App.ReadReceiptsEnabledForChannel(th.Context, dm)
+		require.Nil(t, appErr)
+		require.False(t, enabled)
+	})
+}
+
+func TestUserHasReadReceiptsEnabled(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	setDefault := func(setting string) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsDefaultSetting = setting })
+	}
+
+	setDefault(model.ReadReceiptsDisabled)
+	require.False(t, th.App.UserHasReadReceiptsEnabled(th.BasicUser.Id))
+
+	setDefault(model.ReadReceiptsAlwaysOn)
+	require.True(t, th.App.UserHasReadReceiptsEnabled(th.BasicUser.Id))
+
+	setDefault(model.ReadReceiptsEnabledDefaultOn)
+	require.True(t, th.App.UserHasReadReceiptsEnabled(th.BasicUser.Id))
+
+	setDefault(model.ReadReceiptsEnabledDefaultOff)
+	require.False(t, th.App.UserHasReadReceiptsEnabled(th.BasicUser.Id))
+
+	appErr := th.App.UpdatePreferences(th.Context, th.BasicUser.Id, model.Preferences{{
+		UserId:   th.BasicUser.Id,
+		Category: model.PreferenceCategoryDisplaySettings,
+		Name:     model.PreferenceNamePostReadReceiptsEnabled,
+		Value:    "true",
+	}})
+	require.Nil(t, appErr)
+	require.True(t, th.App.UserHasReadReceiptsEnabled(th.BasicUser.Id))
+}
+
+func TestSaveBotReadReceiptForPost(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	bot := th.CreateBot()
+
+	_, appErr := th.App.SaveBotReadReceiptForPost(th.Context, bot.UserId, th.BasicPost.Id)
+	require.NotNil(t, appErr)
+	require.Equal(t, "api.read_receipt.bot_disabled.app_error", appErr.Id)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsEnableBotReceipts = true })
+
+	receipt, appErr := th.App.SaveBotReadReceiptForPost(th.Context, bot.UserId, th.BasicPost.Id)
+	require.Nil(t, appErr)
+	require.True(t, receipt.IsBot())
+
+	_, _, appErr = th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id})
+	require.Nil(t, appErr)
+
+	info, appErr := th.App.GetReadReceiptInfoForPost(th.Context, th.BasicPost.Id, "")
+	require.Nil(t, appErr)
+	require.Equal(t, int64(1), info.ReadCount)
+	require.Equal(t, int64(1), info.BotReadCount)
+}
+
+func TestSaveReadReceiptForPostDedupe(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	t.Run("single post", func(t *testing.T) {
+		req := &model.ReadReceiptRequest{PostId: th.BasicPost.Id, ReadAt: th.BasicPost.CreateAt + 1000}
+
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.True(t, changed)
+		require.Equal(t, req.ReadAt, receipt.ReadAt)
+
+		receipt, changed, appErr = th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.False(t, changed)
+		require.Equal(t, req.ReadAt, receipt.ReadAt)
+
+		req.ReadAt += 1000
+		_, changed, appErr = th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.True(t, changed)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		post1 := th.CreatePost(th.BasicChannel)
+		post2 := th.CreatePost(th.BasicChannel)
+		req := &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{post1.Id},
+			ReadAt:    post2.CreateAt + 1000,
+		}
+
+		resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.Len(t, resp.Receipts, 1)
+
+		// The re-sent receipt of post1 is skipped, the one of post2 is new.
+		req.PostIds = []string{post1.Id, post2.Id}
+		resp, appErr = th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.Len(t, resp.Receipts, 1)
+		require.Equal(t, post2.Id, resp.Receipts[0].PostId)
+	})
+
+	t.Run("thread", func(t *testing.T) {
+		root := th.CreatePost(th.BasicChannel)
+		reply := th.CreatePostReply(root)
+		req := &model.ReadReceiptRequest{PostId: root.Id, ReadAt: reply.CreateAt + 1000}
+
+		resp, appErr := th.App.SaveThreadReadReceipts(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.Len(t, resp.Receipts, 2)
+
+		resp, appErr = th.App.SaveThreadReadReceipts(th.Context, th.BasicUser.Id, req)
+		require.Nil(t, appErr)
+		require.Empty(t, resp.Receipts)
+	})
+}
+
+func TestSaveReadReceiptForPostClampsReadAt(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	t.Run("before the post was created", func(t *testing.T) {
+		receipt, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, ReadAt: 1})
+		require.Nil(t, appErr)
+		require.Equal(t, th.BasicPost.CreateAt, receipt.ReadAt)
+	})
+
+	t.Run("in the future beyond the allowed skew", func(t *testing.T) {
+		readAt := model.GetMillis() + time.Hour.Milliseconds()
+		receipt, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, ReadAt: readAt})
+		require.Nil(t, appErr)
+		require.Less(t, receipt.ReadAt, readAt)
+		require.LessOrEqual(t, receipt.ReadAt, model.GetMillis())
+	})
+
+	t.Run("within the allowed skew", func(t *testing.T) {
+		readAt := model.GetMillis() + 1000
+		receipt, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, ReadAt: readAt})
+		require.Nil(t, appErr)
+		require.Equal(t, readAt, receipt.ReadAt)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{th.BasicPost.Id},
+			ReadAt:    1,
+		})
+		require.Nil(t, appErr)
+		require.Len(t, resp.Receipts, 1)
+		require.Equal(t, th.BasicPost.CreateAt, resp.Receipts[0].ReadAt)
+	})
+}
+
+func TestSaveReadReceiptForPostServerTimestamps(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsServerTimestamps = true })
+
+	t.Run("single", func(t *testing.T) {
+		clientReadAt := model.GetMillis() - time.Hour.Milliseconds()
+		before := model.GetMillis()
+		receipt, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, ReadAt: clientReadAt})
+		require.Nil(t, appErr)
+		require.GreaterOrEqual(t, receipt.ReadAt, before)
+		require.Equal(t, clientReadAt, receipt.ClientReadAt)
+
+		stored, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Equal(t, receipt.ReadAt, stored.ReadAt)
+		require.Equal(t, clientReadAt, stored.ClientReadAt)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		clientReadAt := model.GetMillis() + time.Hour.Milliseconds()
+		resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{th.BasicPost.Id},
+			ReadAt:    clientReadAt,
+		})
+		require.Nil(t, appErr)
+		require.Len(t, resp.Receipts, 1)
+		require.Less(t, resp.Receipts[0].ReadAt, clientReadAt)
+		require.Equal(t, clientReadAt, resp.Receipts[0].ClientReadAt)
+	})
+}
+
+func TestSaveReadReceiptForPostImpersonated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	rctx := request.WithImpersonation(th.Context, th.SystemAdminUser.Id)
+
+	receipt, changed, appErr := th.App.SaveReadReceiptForPost(rctx, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id})
+	require.Nil(t, appErr)
+	require.False(t, changed)
+	require.Nil(t, receipt)
+
+	resp, appErr := th.App.SaveReadReceiptsBatch(rctx, th.BasicUser.Id, &model.ReadReceiptBatchRequest{
+		ChannelId: th.BasicChannel.Id,
+		PostIds:   []string{th.BasicPost.Id},
+	})
+	require.Nil(t, appErr)
+	require.Empty(t, resp.Receipts)
+
+	_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func TestSaveReadReceiptForPostMinimumConfidence(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsMinimumConfidence = model.ReadReceiptConfidenceViewportVisible
+	})
+
+	t.Run("invalid confidence", func(t *testing.T) {
+		_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Confidence: "glanced"})
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("below the minimum", func(t *testing.T) {
+		for _, confidence := range []string{"", model.ReadReceiptConfidenceScrolledPast} {
+			receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Confidence: confidence})
+			require.Nil(t, appErr)
+			require.False(t, changed)
+			require.Nil(t, receipt)
+		}
+
+		resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{
+			ChannelId:  th.BasicChannel.Id,
+			PostIds:    []string{th.BasicPost.Id},
+			Confidence: model.ReadReceiptConfidenceScrolledPast,
+		})
+		require.Nil(t, appErr)
+		require.Empty(t, resp.Receipts)
+
+		_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("at or above the minimum", func(t *testing.T) {
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Confidence: model.ReadReceiptConfidenceWindowFocused})
+		require.Nil(t, appErr)
+		require.True(t, changed)
+		require.Equal(t, model.ReadReceiptConfidenceWindowFocused, receipt.Confidence)
+
+		stored, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser2.Id)
+		require.NoError(t, err)
+		require.Equal(t, model.ReadReceiptConfidenceWindowFocused, stored.Confidence)
+	})
+}
+
+func TestSaveReadReceiptForPostGhost(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	t.Run("disabled", func(t *testing.T) {
+		_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Ghost: true})
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsEnableGhostMode = true
+	})
+
+	t.Run("a ghost read records no receipt", func(t *testing.T) {
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Ghost: true})
+		require.Nil(t, appErr)
+		require.False(t, changed)
+		require.Nil(t, receipt)
+
+		_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("a later read is flagged as converted from the ghost read", func(t *testing.T) {
+		_, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id})
+		require.Nil(t, appErr)
+		require.True(t, changed)
+
+		_, _, appErr = th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id})
+		require.Nil(t, appErr)
+
+		info, appErr := th.App.GetReadReceiptInfoForPost(th.Context, th.BasicPost.Id, "")
+		require.Nil(t, appErr)
+		require.Len(t, info.Receipts, 2)
+		for _, receipt := range info.Receipts {
+			require.Equal(t, receipt.UserId == th.BasicUser2.Id, receipt.ConvertedFromGhost, receipt.UserId)
+		}
+	})
+}
+
+func TestSaveReactionRecordsImplicitReadReceipt(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+		UserId:    th.BasicUser2.Id,
+		PostId:    th.BasicPost.Id,
+		EmojiName: "smile",
+	})
+	require.Nil(t, appErr)
+
+	receipt, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(th.BasicPost.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.ReadReceiptSourceReaction, receipt.Source)
+
+	t.Run("existing receipt is kept", func(t *testing.T) {
+		saved, appErr := th.App.saveImplicitReadReceipt(th.Context, th.BasicUser2.Id, th.BasicPost, th.BasicChannel, model.ReadReceiptSourceReaction)
+		require.Nil(t, appErr)
+		require.Nil(t, saved)
+	})
+
+	t.Run("reactions to system messages record no receipt", func(t *testing.T) {
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Type:      model.PostTypeHeaderChange,
+			Message:   "header changed",
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser2.Id,
+			PostId:    post.Id,
+			EmojiName: "smile",
+		})
+		require.Nil(t, appErr)
+
+		_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func TestCreateReplyRecordsImplicitReadReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	root := th.CreatePost(th.BasicChannel)
+	earlier := th.CreatePostReply(root)
+	// System messages in the thread are not marked as read by the reply.
+	_, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		RootId:    root.Id,
+		Type:      model.PostTypeHeaderChange,
+		Message:   "header changed",
+	}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		RootId:    root.Id,
+		Message:   "reply",
+	}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	require.Eventually(t, func() bool {
+		th.App.ch.readReceiptBuffer.flushPending()
+		receipts, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptsForUser(th.BasicUser2.Id, model.GetReadReceiptsForUserOptions{PerPage: 10})
+		if err != nil || len(receipts) != 2 {
+			return false
+		}
+		for _, receipt := range receipts {
+			if receipt.Source != model.ReadReceiptSourceReply || (receipt.PostId != root.Id && receipt.PostId != earlier.Id) {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestGetReadReceiptSummaryForPostComputesOnMiss(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Saved straight to the store so that no summary is written for the post.
+	_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{{
+		PostId:    th.BasicPost.Id,
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		ReadAt:    1000,
+	}})
+	require.NoError(t, err)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(th.BasicPost.Id)
+	require.Error(t, err)
+
+	summaries := make([]*model.PostReadReceiptSummary, 5)
+	appErrs := make([]*model.AppError, 5)
+	var wg sync.WaitGroup
+	for i := range summaries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			summaries[i], appErrs[i] = th.App.GetReadReceiptSummaryForPost(th.Context, th.BasicPost.Id)
+		}()
+	}
+	wg.Wait()
+
+	for i, summary := range summaries {
+		require.Nil(t, appErrs[i])
+		require.Equal(t, int64(1), summary.ReadCount)
+		require.Equal(t, int64(1000), summary.LastReadAt)
+	}
+
+	// Storing it could count the receipts whose increments are still pending twice.
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(th.BasicPost.Id)
+	require.Error(t, err)
+}
+
+func TestGetReadReceiptSummaryForPostWithoutReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	summary, appErr := th.App.GetReadReceiptSummaryForPost(th.Context, th.BasicPost.Id)
+	require.Nil(t, appErr)
+	require.Equal(t, th.BasicPost.Id, summary.PostId)
+	require.Equal(t, th.BasicChannel.Id, summary.ChannelId)
+	require.Zero(t, summary.ReadCount)
+
+	// The summary of a post nobody read is not stored.
+	_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(th.BasicPost.Id)
+	require.Error(t, err)
+}
+
+func TestSaveReadReceiptsBatchUpdatesSummaries(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	posts := []*model.Post{th.CreatePost(th.BasicChannel), th.CreatePost(th.BasicChannel), th.CreatePost(th.BasicChannel)}
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.Id)
+	}
+
+	_, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{
+		ChannelId: th.BasicChannel.Id,
+		PostIds:   postIDs,
+	})
+	require.Nil(t, appErr)
+
+	require.Eventually(t, func() bool {
+		for _, postID := range postIDs {
+			summary, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(postID)
+			if err != nil || summary.ReadCount != 1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestSaveReadReceiptsBatchSkipsSystemPosts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	post := th.CreatePost(th.BasicChannel)
+	systemPost, err := th.App.Srv().Store().Post().Save(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+		Type:      model.PostTypeJoinChannel,
+		Message:   "joined",
+	})
+	require.NoError(t, err)
+
+	resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{
+		ChannelId: th.BasicChannel.Id,
+		PostIds:   []string{post.Id, systemPost.Id},
+	})
+	require.Nil(t, appErr)
+	require.Len(t, resp.Receipts, 1)
+	require.Equal(t, post.Id, resp.Receipts[0].PostId)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(systemPost.Id, th.BasicUser2.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func TestSaveReadReceiptForPostConcurrentSummaries(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	post := th.CreatePost(th.BasicChannel)
+	readers := make([]*model.User, 5)
+	for i := range readers {
+		readers[i] = th.CreateUser()
+		th.LinkUserToTeam(readers[i], th.BasicTeam)
+		th.AddUserToChannel(readers[i], th.BasicChannel)
+	}
+
+	appErrs := make([]*model.AppError, len(readers))
+	var wg sync.WaitGroup
+	for i, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, appErrs[i] = th.App.SaveReadReceiptForPost(th.Context, reader.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		}()
+	}
+	wg.Wait()
+	for _, appErr := range appErrs {
+		require.Nil(t, appErr)
+	}
+
+	// Every summary update is an increment, none of them is lost.
+	require.Eventually(t, func() bool {
+		summary, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(post.Id)
+		return err == nil && summary.Version == int64(len(readers))
+	}, 5*time.Second, 50*time.Millisecond)
+
+	summary, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(post.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(readers)), summary.ReadCount)
+}
+
+func TestSaveReadReceiptForPostTransactionalSummary(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsTransactionalSummary = true })
+
+	post := th.CreatePost(th.BasicChannel)
+
+	_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id, ReadAt: post.CreateAt + 1000})
+	require.Nil(t, appErr)
+
+	// The summary is stored along with the receipt, without waiting for an update.
+	summary, err := th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(post.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.ReadCount)
+	require.Equal(t, post.CreateAt+1000, summary.LastReadAt)
+
+	_, _, appErr = th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id, ReadAt: post.CreateAt + 2000})
+	require.Nil(t, appErr)
+
+	summary, err = th.App.Srv().Store().PostReadReceipt().GetReadReceiptSummary(post.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.ReadCount, "reading the post again does not count twice")
+	require.Equal(t, post.CreateAt+2000, summary.LastReadAt)
+}
+
+func TestSaveReadReceiptForPostSource(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Source: model.ReadReceiptSourceReaction})
+	require.NotNil(t, appErr)
+	require.Equal(t, model.ReadReceiptErrorCodeInvalidSource, appErr.Code)
+
+	receipt, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: th.BasicPost.Id, Source: model.ReadReceiptSourceExplicitClick})
+	require.Nil(t, appErr)
+	require.Equal(t, model.ReadReceiptSourceExplicitClick, receipt.Source)
+
+	page, appErr := th.App.GetReadReceiptsForUser(th.Context, th.BasicUser.Id, model.GetReadReceiptsForUserOptions{Sources: []string{model.ReadReceiptSourceExplicitClick}, PerPage: 10})
+	require.Nil(t, appErr)
+	require.Len(t, page.Receipts, 1)
+	require.Equal(t, th.BasicPost.Id, page.Receipts[0].PostId)
+}
+
+func TestReadReceiptEventOmitUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.GuestAccountsSettings.Enable = true })
+	th.App.Srv().SetLicense(model.NewTestLicense())
+
+	guest := th.CreateGuest()
+	th.LinkUserToTeam(guest, th.BasicTeam)
+	th.AddUserToChannel(guest, th.BasicChannel)
+
+	t.Run("members see the members reading", func(t *testing.T) {
+		omitUsers, err := th.App.readReceiptEventOmitUsers(th.Context, th.BasicChannel.Id, th.BasicUser2.Id)
+		require.NoError(t, err)
+		require.Empty(t, omitUsers)
+	})
+
+	t.Run("guests do not see outsiders reading", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		omitUsers, err := th.App.readReceiptEventOmitUsers(th.Context, th.BasicChannel.Id, outsider.Id)
+		require.NoError(t, err)
+		require.True(t, omitUsers[guest.Id])
+		require.False(t, omitUsers[th.BasicUser.Id])
+	})
+
+	t.Run("cached for the channel and the reader", func(t *testing.T) {
+		outsider := th.CreateUser()
+
+		_, err := th.App.readReceiptEventOmitUsers(th.Context, th.BasicChannel.Id, outsider.Id)
+		require.NoError(t, err)
+
+		var cached map[string]bool
+		require.NoError(t, th.App.Srv().readReceiptEventOmitUsersCache.Get(th.BasicChannel.Id+":"+outsider.Id, &cached))
+		require.True(t, cached[guest.Id])
+	})
+}
+
+func TestReadReceiptEventsCarryThreadAndChannelType(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	publisher := &testReadReceiptPublisher{}
+	th.App.SetReadReceiptPublisher(publisher)
+
+	root := th.CreatePost(th.BasicChannel)
+	reply := th.CreatePostReply(root)
+
+	// published waits for an event of eventType and returns it.
+	published := func(t *testing.T, eventType model.WebsocketEventType) *model.WebSocketEvent {
+		var event *model.WebSocketEvent
+		require.Eventually(t, func() bool {
+			publisher.mut.Lock()
+			defer publisher.mut.Unlock()
+			for _, candidate := range publisher.published {
+				if candidate.EventType() == eventType {
+					event = candidate
+					return true
+				}
+			}
+			return false
+		}, 5*time.Second, 50*time.Millisecond)
+		return event
+	}
+
+	t.Run("single post", func(t *testing.T) {
+		_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: reply.Id})
+		require.Nil(t, appErr)
+
+		event, err := model.PostReadEventFromWebSocketEvent(published(t, model.WebsocketEventPostRead))
+		require.NoError(t, err)
+		require.Equal(t, reply.Id, event.ReadReceipt.PostId)
+		require.Equal(t, root.Id, event.RootId)
+		require.Equal(t, model.ChannelTypeOpen, event.ChannelType)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		_, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser.Id, &model.ReadReceiptBatchRequest{
+			ChannelId: th.BasicChannel.Id,
+			PostIds:   []string{root.Id, reply.Id},
+		})
+		require.Nil(t, appErr)
+
+		event, err := model.PostReadBatchEventFromWebSocketEvent(published(t, model.WebsocketEventPostReadBatch))
+		require.NoError(t, err)
+		require.Len(t, event.ReadReceipts, 2)
+		require.Equal(t, map[string]string{reply.Id: root.Id}, event.RootIds)
+		require.Equal(t, model.ChannelTypeOpen, event.ChannelType)
+	})
+}
+
+func TestApplyReadReceiptSummaryDeltasRetriesConflicts(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockStore := th.App.Srv().Store().(*storemocks.Store)
+	mockReceiptStore := storemocks.PostReadReceiptStore{}
+	mockStore.On("PostReadReceipt").Return(&mockReceiptStore)
+
+	existingID, newID := model.NewId(), model.NewId()
+	channelID := model.NewId()
+
+	// The existing summary is changed by another writer between the first read
+	// and the first write, only the new one is stored on the first attempt.
+	mockReceiptStore.On("GetReadReceiptSummariesForPosts", []string{existingID, newID}, true).Return([]*model.PostReadReceiptSummary{
+		{PostId: existingID, ChannelId: channelID, ReadCount: 5, LastReadAt: 1000, LastUpdated: 10, Version: 3},
+	}, nil).Once()
+	mockReceiptStore.On("GetReadReceiptSummariesForPosts", []string{existingID}, true).Return([]*model.PostReadReceiptSummary{
+		{PostId: existingID, ChannelId: channelID, ReadCount: 6, LastReadAt: 1500, LastUpdated: 20, Version: 4},
+	}, nil).Once()
+
+	var attempts [][]*model.PostReadReceiptSummary
+	mockReceiptStore.On("UpdateReadReceiptSummaries", mock.Anything).Return(func(summaries []*model.PostReadReceiptSummary) ([]*model.PostReadReceiptSummary, error) {
+		attempts = append(attempts, summaries)
+		if len(attempts) == 1 {
+			return summaries[1:], nil
+		}
+		return summaries, nil
+	}, nil)
+
+	stored, err := th.App.applyReadReceiptSummaryDeltas([]*model.PostReadReceiptSummary{
+		{PostId: existingID, ChannelId: channelID, ReadCount: 1, LastReadAt: 2000},
+		{PostId: newID, ChannelId: channelID, ReadCount: 1, LastReadAt: 3000},
+	})
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	require.Len(t, attempts, 2)
+
+	require.Equal(t, newID, attempts[0][1].PostId)
+	require.Equal(t, int64(1), attempts[0][1].ReadCount)
+	require.Zero(t, attempts[0][1].Version)
+
+	retried := attempts[1][0]
+	require.Equal(t, existingID, retried.PostId)
+	require.Equal(t, int64(7), retried.ReadCount)
+	require.Equal(t, int64(2000), retried.LastReadAt)
+	require.Equal(t, int64(4), retried.Version)
+	require.Greater(t, retried.LastUpdated, int64(20))
+}
@@ -92,6 +92,11 @@ func (a *App) SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*mod
 
 	a.sendReactionEvent(c, model.WebsocketEventReactionAdded, reaction, post)
 
+	// Reacting to a post implies having read it.
+	if _, appErr := a.saveImplicitReadReceipt(c, reaction.UserId, post, channel, model.ReadReceiptSourceReaction); appErr != nil {
+		c.Logger().Warn("Failed to save implicit read receipt for reaction", mlog.String("post_id", post.Id), mlog.Err(appErr))
+	}
+
 	return reaction, nil
 }
 
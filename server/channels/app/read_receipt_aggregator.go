@@ -0,0 +1,297 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// readReceiptStalenessObserveInterval is how often the summary staleness is
+// reported to the metrics, and the counters sent to the other cluster nodes.
+const readReceiptStalenessObserveInterval = 15 * time.Second
+
+const (
+	// readReceiptAggregatorWindow is the number of one minute buckets kept in memory.
+	readReceiptAggregatorWindow = 15
+	// readReceiptAggregatorTopChannels is the number of channels reported by the overview.
+	readReceiptAggregatorTopChannels = 10
+)
+
+type readReceiptBucket struct {
+	minute   int64
+	total    int64
+	channels map[string]int64
+}
+
+// readReceiptAggregateSnapshot holds the counters of a node, as sent to the other
+// nodes of the cluster so that the overview of each node covers all of them.
+type readReceiptAggregateSnapshot struct {
+	NodeId           string                      `json:"node_id"`
+	Buckets          []readReceiptBucketSnapshot `json:"buckets"`
+	PendingSummaries int64                       `json:"pending_summaries"`
+	SummaryLagLast   int64                       `json:"summary_lag_last"`
+	SummaryLagMax    int64                       `json:"summary_lag_max"`
+}
+
+type readReceiptBucketSnapshot struct {
+	Minute   int64            `json:"minute"`
+	Total    int64            `json:"total"`
+	Channels map[string]int64 `json:"channels"`
+}
+
+// readReceiptRemoteNode is the last snapshot received from another node.
+type readReceiptRemoteNode struct {
+	snapshot   *readReceiptAggregateSnapshot
+	receivedAt time.Time
+}
+
+// readReceiptAggregator keeps rolling counters of the receipts saved and of the
+// summary updates they trigger, for the system console overview. The counters of
+// this node are merged with the last ones received from the other cluster nodes.
+type readReceiptAggregator struct {
+	mut     sync.Mutex
+	buckets [readReceiptAggregatorWindow]readReceiptBucket
+
+	// remoteNodes maps the other cluster nodes to their last snapshot.
+	remoteNodes map[string]readReceiptRemoteNode
+
+	// pendingSummaries maps the summary updates in progress to when they were queued.
+	pendingSummaries map[uint64]time.Time
+	nextSummaryID    uint64
+	summaryLagLast   time.Duration
+	summaryLagMax    time.Duration
+	summaryLagMaxAt  int64
+
+	now func() time.Time
+}
+
+func newReadReceiptAggregator() *readReceiptAggregator {
+	return &readReceiptAggregator{
+		remoteNodes:      make(map[string]readReceiptRemoteNode),
+		pendingSummaries: make(map[uint64]time.Time),
+		now:              time.Now,
+	}
+}
+
+// bucketFor returns the bucket of the given minute, resetting it if it still
+// holds the counters of an older minute. The caller must hold the lock.
+func (agg *readReceiptAggregator) bucketFor(minute int64) *readReceiptBucket {
+	bucket := &agg.buckets[minute%readReceiptAggregatorWindow]
+	if bucket.minute != minute {
+		bucket.minute = minute
+		bucket.total = 0
+		bucket.channels = make(map[string]int64)
+	}
+	return bucket
+}
+
+func (agg *readReceiptAggregator) recordReceipts(channelID string, count int) {
+	if count <= 0 {
+		return
+	}
+
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	bucket := agg.bucketFor(agg.now().Unix() / 60)
+	bucket.total += int64(count)
+	bucket.channels[channelID] += int64(count)
+}
+
+// summaryQueued records a summary update and returns its id, to be passed to
+// summaryDone once the update completes.
+func (agg *readReceiptAggregator) summaryQueued() uint64 {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	agg.nextSummaryID++
+	agg.pendingSummaries[agg.nextSummaryID] = agg.now()
+	return agg.nextSummaryID
+}
+
+func (agg *readReceiptAggregator) summaryDone(id uint64) {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	now := agg.now()
+	lag := now.Sub(agg.pendingSummaries[id])
+
+	delete(agg.pendingSummaries, id)
+	agg.summaryLagLast = lag
+	// The maximum only covers the overview window so a single slow update
+	// does not dominate the panel forever.
+	if lag > agg.summaryLagMax || now.Unix()-agg.summaryLagMaxAt > readReceiptAggregatorWindow*60 {
+		agg.summaryLagMax = lag
+		agg.summaryLagMaxAt = now.Unix()
+	}
+}
+
+// summaryStaleness returns how long the oldest summary update in progress has
+// been waiting, or zero when there is none.
+func (agg *readReceiptAggregator) summaryStaleness() time.Duration {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	var staleness time.Duration
+	now := agg.now()
+	for _, queuedAt := range agg.pendingSummaries {
+		staleness = max(staleness, now.Sub(queuedAt))
+	}
+	return staleness
+}
+
+// snapshot returns the counters of this node, to be sent to the other nodes.
+func (agg *readReceiptAggregator) snapshot(nodeID string) *readReceiptAggregateSnapshot {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	currentMinute := agg.now().Unix() / 60
+	snapshot := &readReceiptAggregateSnapshot{
+		NodeId:           nodeID,
+		Buckets:          []readReceiptBucketSnapshot{},
+		PendingSummaries: int64(len(agg.pendingSummaries)),
+		SummaryLagLast:   agg.summaryLagLast.Milliseconds(),
+		SummaryLagMax:    agg.summaryLagMax.Milliseconds(),
+	}
+	for i := range agg.buckets {
+		bucket := &agg.buckets[i]
+		if bucket.channels == nil || currentMinute-bucket.minute >= readReceiptAggregatorWindow {
+			continue
+		}
+		snapshot.Buckets = append(snapshot.Buckets, readReceiptBucketSnapshot{
+			Minute:   bucket.minute,
+			Total:    bucket.total,
+			Channels: maps.Clone(bucket.channels),
+		})
+	}
+
+	return snapshot
+}
+
+// mergeRemote keeps the snapshot of another node, replacing the previous one it
+// sent, so that repeated snapshots are never counted twice.
+func (agg *readReceiptAggregator) mergeRemote(snapshot *readReceiptAggregateSnapshot) {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	agg.remoteNodes[snapshot.NodeId] = readReceiptRemoteNode{snapshot: snapshot, receivedAt: agg.now()}
+}
+
+// overview returns the counters of the cluster. The receipts of nodes that
+// stopped sending snapshots stay counted until they fall out of the window, while
+// their summary updates only count as long as the snapshots keep coming. The
+// summary lags are the highest of the nodes.
+func (agg *readReceiptAggregator) overview() *model.ReadReceiptsOverview {
+	agg.mut.Lock()
+	defer agg.mut.Unlock()
+
+	now := agg.now()
+	currentMinute := now.Unix() / 60
+
+	var total int64
+	channels := make(map[string]int64)
+	overview := &model.ReadReceiptsOverview{
+		WindowMinutes:         readReceiptAggregatorWindow,
+		SummaryLagLast:        agg.summaryLagLast.Milliseconds(),
+		SummaryLagMax:         agg.summaryLagMax.Milliseconds(),
+		PendingSummaryUpdates: int64(len(agg.pendingSummaries)),
+		GeneratedAt:           model.GetMillisForTime(now),
+	}
+
+	addBucket := func(minute, bucketTotal int64, bucketChannels map[string]int64) {
+		if currentMinute-minute >= readReceiptAggregatorWindow {
+			return
+		}
+
+		total += bucketTotal
+		// The current minute is still filling up, so the last full minute is reported.
+		if minute == currentMinute-1 {
+			overview.ReceiptsLastMinute += bucketTotal
+		}
+		for channelID, count := range bucketChannels {
+			channels[channelID] += count
+		}
+	}
+
+	for i := range agg.buckets {
+		bucket := &agg.buckets[i]
+		if bucket.channels == nil {
+			continue
+		}
+		addBucket(bucket.minute, bucket.total, bucket.channels)
+	}
+
+	for nodeID, node := range agg.remoteNodes {
+		age := now.Sub(node.receivedAt)
+		if age >= readReceiptAggregatorWindow*time.Minute {
+			delete(agg.remoteNodes, nodeID)
+			continue
+		}
+
+		for _, bucket := range node.snapshot.Buckets {
+			addBucket(bucket.Minute, bucket.Total, bucket.Channels)
+		}
+		if age < 2*readReceiptStalenessObserveInterval {
+			overview.PendingSummaryUpdates += node.snapshot.PendingSummaries
+			overview.SummaryLagLast = max(overview.SummaryLagLast, node.snapshot.SummaryLagLast)
+			overview.SummaryLagMax = max(overview.SummaryLagMax, node.snapshot.SummaryLagMax)
+		}
+	}
+	overview.ReceiptsPerMinute = float64(total) / readReceiptAggregatorWindow
+
+	overview.TopChannels = make([]*model.ReadReceiptChannelVolume, 0, len(channels))
+	for channelID, count := range channels {
+		overview.TopChannels = append(overview.TopChannels, &model.ReadReceiptChannelVolume{ChannelId: channelID, Count: count})
+	}
+	sort.Slice(overview.TopChannels, func(i, j int) bool {
+		if overview.TopChannels[i].Count != overview.TopChannels[j].Count {
+			return overview.TopChannels[i].Count > overview.TopChannels[j].Count
+		}
+		return overview.TopChannels[i].ChannelId < overview.TopChannels[j].ChannelId
+	})
+	if len(overview.TopChannels) > readReceiptAggregatorTopChannels {
+		overview.TopChannels = overview.TopChannels[:readReceiptAggregatorTopChannels]
+	}
+
+	return overview
+}
+
+// observeReadReceiptSummaryStaleness reports the summary staleness to the
+// metrics, periodically so that a stuck update keeps growing the gauge. It also
+// sends the counters of this node to the other cluster nodes.
+func (ch *Channels) observeReadReceiptSummaryStaleness() {
+	if metrics := ch.srv.GetMetrics(); metrics != nil {
+		metrics.ObserveReadReceiptSummaryStaleness(ch.readReceiptAggregator.summaryStaleness().Seconds())
+	}
+
+	ch.broadcastReadReceiptAggregate()
+}
+
+// broadcastReadReceiptAggregate sends the counters of this node to the other
+// cluster nodes, which merge them into their overview.
+func (ch *Channels) broadcastReadReceiptAggregate() {
+	cluster := ch.srv.platform.Cluster()
+	if cluster == nil {
+		return
+	}
+
+	data, err := json.Marshal(ch.readReceiptAggregator.snapshot(cluster.GetClusterId()))
+	if err != nil {
+		ch.srv.Log().Warn("Failed to encode the read receipt counters", mlog.Err(err))
+		return
+	}
+
+	cluster.SendClusterMessage(&model.ClusterMessage{
+		Event:    model.ClusterEventReadReceiptAggregate,
+		SendType: model.ClusterSendBestEffort,
+		Data:     data,
+	})
+}
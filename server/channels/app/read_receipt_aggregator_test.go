@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReceiptAggregator(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+	agg := newReadReceiptAggregator()
+	agg.now = func() time.Time { return now }
+
+	agg.recordReceipts("channel1", 3)
+	agg.recordReceipts("channel2", 5)
+
+	now = now.Add(time.Minute)
+	agg.recordReceipts("channel1", 4)
+
+	overview := agg.overview()
+	assert.Equal(t, int64(8), overview.ReceiptsLastMinute)
+	assert.InDelta(t, 12.0/readReceiptAggregatorWindow, overview.ReceiptsPerMinute, 0.0001)
+	require.Len(t, overview.TopChannels, 2)
+	assert.Equal(t, "channel1", overview.TopChannels[0].ChannelId)
+	assert.Equal(t, int64(7), overview.TopChannels[0].Count)
+
+	t.Run("old buckets fall out of the window", func(t *testing.T) {
+		now = now.Add(readReceiptAggregatorWindow * time.Minute)
+		overview := agg.overview()
+		assert.Zero(t, overview.ReceiptsPerMinute)
+		assert.Empty(t, overview.TopChannels)
+	})
+
+	t.Run("summary lag", func(t *testing.T) {
+		id := agg.summaryQueued()
+		assert.Equal(t, int64(1), agg.overview().PendingSummaryUpdates)
+
+		now = now.Add(250 * time.Millisecond)
+		assert.Equal(t, 250*time.Millisecond, agg.summaryStaleness())
+
+		agg.summaryDone(id)
+		overview := agg.overview()
+		assert.Zero(t, overview.PendingSummaryUpdates)
+		assert.Zero(t, agg.summaryStaleness())
+		assert.Equal(t, int64(250), overview.SummaryLagLast)
+		assert.Equal(t, int64(250), overview.SummaryLagMax)
+	})
+
+	t.Run("other cluster nodes", func(t *testing.T) {
+		remote := newReadReceiptAggregator()
+		remote.now = func() time.Time { return now }
+		remote.recordReceipts("channel1", 2)
+		remote.recordReceipts("channel3", 6)
+		remote.summaryQueued()
+
+		// Snapshots replace the previous one of their node, so a node sending
+		// the same counters again doesn't count them twice.
+		for range 2 {
+			data, err := json.Marshal(remote.snapshot("node2"))
+			require.NoError(t, err)
+			var snapshot readReceiptAggregateSnapshot
+			require.NoError(t, json.Unmarshal(data, &snapshot))
+			agg.mergeRemote(&snapshot)
+		}
+		agg.recordReceipts("channel1", 1)
+
+		overview := agg.overview()
+		assert.InDelta(t, 9.0/readReceiptAggregatorWindow, overview.ReceiptsPerMinute, 0.0001)
+		require.Len(t, overview.TopChannels, 2)
+		assert.Equal(t, "channel3", overview.TopChannels[0].ChannelId)
+		assert.Equal(t, int64(6), overview.TopChannels[0].Count)
+		assert.Equal(t, "channel1", overview.TopChannels[1].ChannelId)
+		assert.Equal(t, int64(3), overview.TopChannels[1].Count)
+		assert.Equal(t, int64(1), overview.PendingSummaryUpdates)
+
+		// A node that stopped sending snapshots no longer has updates in progress,
+		// but its receipts count until they fall out of the window.
+		now = now.Add(2 * readReceiptStalenessObserveInterval)
+		overview = agg.overview()
+		assert.Zero(t, overview.PendingSummaryUpdates)
+		assert.InDelta(t, 9.0/readReceiptAggregatorWindow, overview.ReceiptsPerMinute, 0.0001)
+
+		now = now.Add(readReceiptAggregatorWindow * time.Minute)
+		assert.Zero(t, agg.overview().ReceiptsPerMinute)
+		assert.Empty(t, agg.remoteNodes)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const readReceiptCompactionBatchSize = 1000
+
+// CompactReadReceipts moves the human receipts read more than
+// ReadReceiptsBitmapStorageDays ago, in the channels holding at least
+// ReadReceiptsBitmapMinReceipts receipts, to per user bitmaps, a batch at a time,
+// and returns how many it moved. Without ReadReceiptsBitmapStorageDays nothing is
+// compacted. Compacted receipts still answer who read a post, but lose their
+// device and keep only the latest read time of their user in the channel.
+func (a *App) CompactReadReceipts() (int64, error) {
+	days := *a.Config().ServiceSettings.ReadReceiptsBitmapStorageDays
+	if days <= 0 {
+		return 0, nil
+	}
+	readBefore := time.Now().Add(-time.Duration(days) * 24 * time.Hour).UnixMilli()
+	minReceipts := int64(*a.Config().ServiceSettings.ReadReceiptsBitmapMinReceipts)
+
+	var compacted int64
+	afterChannelID := ""
+	for {
+		channelIDs, err := a.Srv().Store().PostReadReceipt().GetChannelsForReadReceiptCompaction(minReceipts, afterChannelID, readReceiptCompactionBatchSize)
+		if err != nil {
+			return compacted, errors.Wrap(err, "failed to get the channels to compact the receipts of")
+		}
+
+		for _, channelID := range channelIDs {
+			for {
+				count, err := a.Srv().Store().PostReadReceipt().CompactReadReceipts(channelID, readBefore, readReceiptCompactionBatchSize)
+				if err != nil {
+					return compacted, errors.Wrapf(err, "failed to compact the receipts of channel %s", channelID)
+				}
+				compacted += count
+				if count < readReceiptCompactionBatchSize {
+					break
+				}
+			}
+		}
+
+		if len(channelIDs) < readReceiptCompactionBatchSize {
+			return compacted, nil
+		}
+		afterChannelID = channelIDs[len(channelIDs)-1]
+	}
+}
+
+// deleteExpiredCompactedReadReceipts deletes the bitmaps whose latest read expired
+// under the retention of their channel.
+func (a *App) deleteExpiredCompactedReadReceipts(expiredBeforeForChannel func(channelID string) int64) error {
+	afterChannelID := ""
+	for {
+		channelIDs, err := a.Srv().Store().PostReadReceipt().GetCompactedReadReceiptChannels(afterChannelID, readReceiptCleanupPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the channels with compacted receipts")
+		}
+
+		for _, channelID := range channelIDs {
+			expiredBefore := expiredBeforeForChannel(channelID)
+			if expiredBefore <= 0 {
+				continue
+			}
+			if _, err := a.Srv().Store().PostReadReceipt().DeleteExpiredCompactedReadReceipts(channelID, expiredBefore); err != nil {
+				return errors.Wrapf(err, "failed to delete the expired compacted receipts of channel %s", channelID)
+			}
+		}
+
+		if len(channelIDs) < readReceiptCleanupPageSize {
+			return nil
+		}
+		afterChannelID = channelIDs[len(channelIDs)-1]
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestCompactReadReceipts(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	mockStore := th.App.Srv().Store().(*storemocks.Store)
+	mockReceiptStore := storemocks.PostReadReceiptStore{}
+	mockStore.On("PostReadReceipt").Return(&mockReceiptStore)
+
+	t.Run("disabled without storage days", func(t *testing.T) {
+		compacted, err := th.App.CompactReadReceipts()
+		require.NoError(t, err)
+		require.Zero(t, compacted)
+		mockReceiptStore.AssertNotCalled(t, "GetChannelsForReadReceiptCompaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("compacts each large channel a batch at a time", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsBitmapStorageDays = 90
+			*cfg.ServiceSettings.ReadReceiptsBitmapMinReceipts = 500
+		})
+
+		readBefore := time.Now().Add(-90 * 24 * time.Hour).UnixMilli()
+		beforeStorageDays := mock.MatchedBy(func(before int64) bool {
+			return before >= readBefore && before < readBefore+time.Minute.Milliseconds()
+		})
+		mockReceiptStore.On("GetChannelsForReadReceiptCompaction", int64(500), "", readReceiptCompactionBatchSize).Return([]string{"channel1", "channel2"}, nil).Once()
+		mockReceiptStore.On("CompactReadReceipts", "channel1", beforeStorageDays, readReceiptCompactionBatchSize).Return(int64(readReceiptCompactionBatchSize), nil).Once()
+		mockReceiptStore.On("CompactReadReceipts", "channel1", beforeStorageDays, readReceiptCompactionBatchSize).Return(int64(3), nil).Once()
+		mockReceiptStore.On("CompactReadReceipts", "channel2", beforeStorageDays, readReceiptCompactionBatchSize).Return(int64(0), nil).Once()
+
+		compacted, err := th.App.CompactReadReceipts()
+		require.NoError(t, err)
+		require.Equal(t, int64(readReceiptCompactionBatchSize+3), compacted)
+		mockReceiptStore.AssertNumberOfCalls(t, "CompactReadReceipts", 3)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+var bookmarkPermalinkPath = regexp.MustCompile(`^[0-9a-z_-]{1,64}/pl/([a-z0-9]{26})$`)
+
+// bookmarkTargetPostID returns the post a link bookmark points at through its
+// permalink, if it does.
+func bookmarkTargetPostID(bookmark *model.ChannelBookmark, siteURL string) (string, bool) {
+	if bookmark.Type != model.ChannelBookmarkLink || siteURL == "" {
+		return "", false
+	}
+
+	path, ok := strings.CutPrefix(strings.TrimSpace(bookmark.LinkUrl), siteURL)
+	if !ok {
+		return "", false
+	}
+	match := bookmarkPermalinkPath.FindStringSubmatch(strings.TrimPrefix(path, "/"))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// GetChannelBookmarkReadSummaries returns the read summaries of the posts the
+// bookmarks of the channel link to, in a single lookup of the stored summaries.
+// Bookmarks are left out when they link to posts that can't be read explicitly,
+// that the user cannot read or see the readers of, or whose channel is archived
+// or has read receipts disabled. Posts that were never summarized get an empty
+// summary.
+func (a *App) GetChannelBookmarkReadSummaries(c request.CTX, channelID string) ([]*model.ChannelBookmarkReadSummary, *model.AppError) {
+	bookmarks, appErr := a.GetChannelBookmarks(channelID, 0)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	siteURL := a.GetSiteURL()
+	postIDs := make([]string, 0, len(bookmarks))
+	bookmarkPostIDs := make(map[string]string, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		if postID, ok := bookmarkTargetPostID(bookmark.ChannelBookmark, siteURL); ok {
+			postIDs = append(postIDs, postID)
+			bookmarkPostIDs[bookmark.Id] = postID
+		}
+	}
+
+	result := []*model.ChannelBookmarkReadSummary{}
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIDs)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarkReadSummaries", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	summaries, err := a.Srv().Store().PostReadReceipt().GetReadReceiptSummariesForPosts(postIDs, false)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelBookmarkReadSummaries", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	readableChannels := make(map[string]bool)
+	readablePosts := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		if post.DeleteAt != 0 || post.ReadReceiptsDisabled() || !model.IsReadReceiptPostType(post.Type) {
+			continue
+		}
+		readable, checked := readableChannels[post.ChannelId]
+		if !checked {
+			readable = a.bookmarkReadSummariesAllowedForChannel(c, post.ChannelId)
+			readableChannels[post.ChannelId] = readable
+		}
+		if readable && a.checkReadReceiptsVisible(c, "GetChannelBookmarkReadSummaries", c.Session().UserId, post.ChannelId, post.UserId) == nil {
+			readablePosts[post.Id] = post
+		}
+	}
+
+	summariesByPostID := make(map[string]*model.PostReadReceiptSummary, len(summaries))
+	for _, summary := range summaries {
+		summariesByPostID[summary.PostId] = summary
+	}
+
+	samples := make(map[string]readReceiptSample)
+	for _, bookmark := range bookmarks {
+		post, ok := readablePosts[bookmarkPostIDs[bookmark.Id]]
+		if !ok {
+			continue
+		}
+		summary, ok := summariesByPostID[post.Id]
+		if !ok {
+			summary = &model.PostReadReceiptSummary{PostId: post.Id, ChannelId: post.ChannelId}
+		}
+		sample, ok := samples[post.ChannelId]
+		if !ok {
+			sample = a.readReceiptSampleForChannel(c, post.ChannelId)
+			samples[post.ChannelId] = sample
+		}
+		sample.estimateSummaries(summary)
+		result = append(result, &model.ChannelBookmarkReadSummary{BookmarkId: bookmark.Id, Summary: summary})
+	}
+
+	return result, nil
+}
+
+// bookmarkReadSummariesAllowedForChannel reports whether the summaries of the
+// posts of the channel can be shown to the user of the session, as the summary
+// endpoints of single posts would. Errors are logged and leave the posts out.
+func (a *App) bookmarkReadSummariesAllowedForChannel(c request.CTX, channelID string) bool {
+	session := *c.Session()
+	if !a.SessionHasPermissionToChannel(c, session, channelID, model.PermissionReadChannelContent) ||
+		!a.SessionHasPermissionToChannel(c, session, channelID, model.PermissionViewReadReceipts) {
+		return false
+	}
+
+	channel, appErr := a.GetChannel(c, channelID)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the channel of a bookmarked post", mlog.String("channel_id", channelID), mlog.Err(appErr))
+		return false
+	}
+	if channel.DeleteAt > 0 {
+		return false
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil {
+		c.Logger().Warn("Failed to check whether read receipts are enabled for the channel of a bookmarked post", mlog.String("channel_id", channelID), mlog.Err(appErr))
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBookmarkTargetPostID(t *testing.T) {
+	postID := model.NewId()
+	siteURL := "https://chat.example.com"
+
+	for name, tc := range map[string]struct {
+		bookmark *model.ChannelBookmark
+		postID   string
+	}{
+		"permalink": {
+			bookmark: &model.ChannelBookmark{Type: model.ChannelBookmarkLink, LinkUrl: siteURL + "/team/pl/" + postID},
+			postID:   postID,
+		},
+		"external link": {
+			bookmark: &model.ChannelBookmark{Type: model.ChannelBookmarkLink, LinkUrl: "https://other.example.com/team/pl/" + postID},
+		},
+		"link to a channel": {
+			bookmark: &model.ChannelBookmark{Type: model.ChannelBookmarkLink, LinkUrl: siteURL + "/team/channels/town-square"},
+		},
+		"file": {
+			bookmark: &model.ChannelBookmark{Type: model.ChannelBookmarkFile, FileId: model.NewId()},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, ok := bookmarkTargetPostID(tc.bookmark, siteURL)
+			assert.Equal(t, tc.postID != "", ok)
+			assert.Equal(t, tc.postID, got)
+		})
+	}
+}
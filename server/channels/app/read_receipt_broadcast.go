@@ -0,0 +1,165 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// BroadcastWithReadTracking posts a copy of the announcement to every channel on
+// behalf of its author, and records a broadcast so that the read receipts of the
+// copies can be followed together. The caller is responsible for checking that
+// the author may post in those channels. Every channel is checked before the
+// first copy is posted. When a copy cannot be posted or the broadcast cannot be
+// recorded, the copies already posted are deleted, so that no copy is left
+// untracked.
+func (a *App) BroadcastWithReadTracking(c request.CTX, post *model.Post, channelIDs []string) (*model.ReadReceiptBroadcast, *model.AppError) {
+	channelIDs = model.RemoveDuplicateStrings(channelIDs)
+	if len(channelIDs) == 0 || len(channelIDs) > model.ReadReceiptBroadcastMaxChannels {
+		return nil, model.NewAppError("BroadcastWithReadTracking", "model.read_receipt_broadcast.is_valid.posts.app_error", map[string]any{"Max": model.ReadReceiptBroadcastMaxChannels}, "", http.StatusBadRequest)
+	}
+
+	channels, err := a.Srv().Store().Channel().GetChannelsByIds(channelIDs, false)
+	if err != nil {
+		return nil, model.NewAppError("BroadcastWithReadTracking", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(channels) != len(channelIDs) {
+		return nil, model.NewAppError("BroadcastWithReadTracking", "app.read_receipt_broadcast.channel_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	channelsByID := make(map[string]*model.Channel, len(channels))
+	for _, channel := range channels {
+		channelsByID[channel.Id] = channel
+	}
+
+	broadcast := &model.ReadReceiptBroadcast{
+		CreatorId: post.UserId,
+		Posts:     make([]*model.ReadReceiptBroadcastPost, 0, len(channels)),
+	}
+	// The copies are posted in the order of the channels given.
+	for _, channelID := range channelIDs {
+		channel := channelsByID[channelID]
+		postCopy := post.Clone()
+		postCopy.Id = ""
+		postCopy.ChannelId = channel.Id
+		postCopy.RootId = ""
+		postCopy.CreateAt = 0
+
+		saved, appErr := a.CreatePost(c, postCopy, channel, model.CreatePostFlags{SetOnline: true})
+		if appErr != nil {
+			a.deleteBroadcastCopies(c, broadcast.Posts, post.UserId)
+			return nil, appErr
+		}
+		broadcast.Posts = append(broadcast.Posts, &model.ReadReceiptBroadcastPost{PostId: saved.Id, ChannelId: channel.Id})
+	}
+
+	saved, err := a.Srv().Store().ReadReceiptBroadcast().Save(broadcast)
+	if err != nil {
+		a.deleteBroadcastCopies(c, broadcast.Posts, post.UserId)
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("BroadcastWithReadTracking", "app.read_receipt_broadcast.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return saved, nil
+}
+
+// deleteBroadcastCopies deletes the copies of a broadcast that could not be
+// completed.
+func (a *App) deleteBroadcastCopies(c request.CTX, posts []*model.ReadReceiptBroadcastPost, deleterID string) {
+	for _, post := range posts {
+		if _, appErr := a.DeletePost(c, post.PostId, deleterID); appErr != nil {
+			c.Logger().Warn("Failed to delete the copy of an incomplete broadcast", mlog.String("post_id", post.PostId), mlog.Err(appErr))
+		}
+	}
+}
+
+func (a *App) GetReadReceiptBroadcast(c request.CTX, broadcastID string) (*model.ReadReceiptBroadcast, *model.AppError) {
+	broadcast, err := a.Srv().Store().ReadReceiptBroadcast().Get(broadcastID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetReadReceiptBroadcast", "app.read_receipt_broadcast.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetReadReceiptBroadcast", "app.read_receipt_broadcast.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return broadcast, nil
+}
+
+// GetReadReceiptBroadcastSummary aggregates the read progress of the copies of the
+// broadcast. Copies that were deleted since are left out.
+func (a *App) GetReadReceiptBroadcastSummary(c request.CTX, broadcast *model.ReadReceiptBroadcast) (*model.ReadReceiptBroadcastSummary, *model.AppError) {
+	summary := &model.ReadReceiptBroadcastSummary{
+		BroadcastId: broadcast.Id,
+		Channels:    make([]*model.ReadReceiptBroadcastChannelSummary, 0, len(broadcast.Posts)),
+	}
+
+	for _, post := range broadcast.Posts {
+		postSummary, appErr := a.GetReadReceiptSummaryForPost(c, post.PostId)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, appErr
+		}
+
+		totalMembers, err := a.Srv().Store().PostReadReceipt().GetHumanMemberCount(post.ChannelId)
+		if err != nil {
+			return nil, model.NewAppError("GetReadReceiptBroadcastSummary", "app.read_receipt_broadcast.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		sample := a.readReceiptSampleForChannel(c, post.ChannelId)
+		readCount := sample.readCount(postSummary.ReadCount)
+		summary.Channels = append(summary.Channels, &model.ReadReceiptBroadcastChannelSummary{
+			ChannelId:      post.ChannelId,
+			PostId:         post.PostId,
+			ReadCount:      readCount,
+			TotalMembers:   totalMembers,
+			ReadPercentage: model.ReadReceiptPercentage(readCount, totalMembers),
+			Estimated:      sample.estimated(),
+		})
+		summary.ReadCount += readCount
+		summary.TotalMembers += totalMembers
+		summary.Estimated = summary.Estimated || sample.estimated()
+	}
+	summary.ReadPercentage = model.ReadReceiptPercentage(summary.ReadCount, summary.TotalMembers)
+
+	return summary, nil
+}
+
+// GetReadReceiptAnnouncementReport aggregates the read progress of the copies of an
+// announcement posted to channels of the team. Posts that were deleted or belong
+// to another team are left out.
+func (a *App) GetReadReceiptAnnouncementReport(c request.CTX, teamID string, postIDs []string) (*model.ReadReceiptAnnouncementReport, *model.AppError) {
+	channels, err := a.Srv().Store().PostReadReceipt().GetAnnouncementReadCounts(teamID, postIDs)
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptAnnouncementReport", "app.read_receipt_broadcast.get_announcement_report.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	report := &model.ReadReceiptAnnouncementReport{
+		TeamId:   teamID,
+		Channels: channels,
+	}
+	for _, channel := range channels {
+		sample := a.readReceiptSampleForChannel(c, channel.ChannelId)
+		channel.ReadCount = sample.readCount(channel.ReadCount)
+		channel.Estimated = sample.estimated()
+		channel.ReadPercentage = model.ReadReceiptPercentage(channel.ReadCount, channel.TotalMembers)
+		report.ReadCount += channel.ReadCount
+		report.TotalMembers += channel.TotalMembers
+		report.Estimated = report.Estimated || channel.Estimated
+	}
+	report.ReadPercentage = model.ReadReceiptPercentage(report.ReadCount, report.TotalMembers)
+
+	return report, nil
+}
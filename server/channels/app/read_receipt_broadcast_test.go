@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBroadcastWithReadTrackingRollsBack(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Shared direct channels do not accept posts, so the second copy fails.
+	dm := th.CreateDmChannel(th.BasicUser2)
+	dm.Shared = model.NewPointer(true)
+	_, err := th.App.Srv().Store().Channel().Update(th.Context, dm)
+	require.NoError(t, err)
+
+	message := "announcement " + model.NewId()
+	_, appErr := th.App.BroadcastWithReadTracking(th.Context, &model.Post{
+		UserId:  th.BasicUser.Id,
+		Message: message,
+	}, []string{th.BasicChannel.Id, dm.Id})
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+	posts, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, PerPage: 10})
+	require.Nil(t, appErr)
+	for _, post := range posts.Posts {
+		require.NotEqual(t, message, post.Message, "the copy already posted is deleted")
+	}
+}
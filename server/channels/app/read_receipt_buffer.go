@@ -0,0 +1,298 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// readReceiptDedupeWindow is how long the posts a user marked as read are
+// remembered, so that the overlapping batches clients send when switching
+// channels quickly are only saved once.
+const readReceiptDedupeWindow = 5 * time.Second
+
+// readReceiptBufferSettings are the limits of the buffer that operators can tune
+// at runtime.
+type readReceiptBufferSettings struct {
+	// flushInterval is how long implicit receipts wait in the buffer before
+	// being written.
+	flushInterval time.Duration
+	// maxSize triggers an early flush when that many receipts are pending.
+	maxSize int
+	// maxPendingPerUser triggers an early flush when a single user has that
+	// many receipts pending.
+	maxPendingPerUser int
+}
+
+func readReceiptBufferSettingsFromConfig(cfg *model.Config) readReceiptBufferSettings {
+	return readReceiptBufferSettings{
+		flushInterval:     time.Duration(*cfg.ServiceSettings.ReadReceiptsBufferFlushIntervalMs) * time.Millisecond,
+		maxSize:           *cfg.ServiceSettings.ReadReceiptsBufferMaxSize,
+		maxPendingPerUser: *cfg.ServiceSettings.ReadReceiptsBufferMaxPendingPerUser,
+	}
+}
+
+type readReceiptBufferKey struct {
+	postID string
+	userID string
+}
+
+// readReceiptBuffer coalesces implicit read receipts so that the actions that
+// produce them, like replying in a thread, never wait on the database. Pending
+// receipts are handed to flush periodically, or as soon as the buffer is full.
+// Only the first receipt queued for a given post and user is kept.
+//
+// The buffer also remembers, per user, the posts recently marked as read through
+// dedupe so that duplicates never reach the database or the websocket.
+type readReceiptBuffer struct {
+	mut            sync.Mutex
+	settings       readReceiptBufferSettings
+	pending        map[readReceiptBufferKey]*model.PostReadReceipt
+	pendingPerUser map[string]int
+	// recent maps user ids to the posts they marked as read and when they did.
+	recent map[string]map[string]time.Time
+
+	flush func(receipts []*model.PostReadReceipt)
+	// metrics returns the metrics interface, or nil when metrics are disabled.
+	metrics func() einterfaces.MetricsInterface
+
+	startOnce sync.Once
+	started   bool
+	wake      chan struct{}
+	reset     chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newReadReceiptBuffer(settings readReceiptBufferSettings, flush func(receipts []*model.PostReadReceipt)) *readReceiptBuffer {
+	return &readReceiptBuffer{
+		settings:       settings,
+		pending:        make(map[readReceiptBufferKey]*model.PostReadReceipt),
+		pendingPerUser: make(map[string]int),
+		recent:         make(map[string]map[string]time.Time),
+		flush:          flush,
+		metrics:        func() einterfaces.MetricsInterface { return nil },
+		wake:           make(chan struct{}, 1),
+		reset:          make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// configure applies new limits to the running buffer, flushing right away if
+// the pending receipts already exceed them.
+func (b *readReceiptBuffer) configure(settings readReceiptBufferSettings) {
+	b.mut.Lock()
+	intervalChanged := b.settings.flushInterval != settings.flushInterval
+	b.settings = settings
+	full := b.isFull()
+	b.mut.Unlock()
+
+	if intervalChanged {
+		select {
+		case b.reset <- struct{}{}:
+		default:
+		}
+	}
+	if full {
+		b.wakeUp()
+	}
+}
+
+func (b *readReceiptBuffer) wakeUp() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// isFull reports whether a flush is due before the next tick. The caller must
+// hold the lock.
+func (b *readReceiptBuffer) isFull() bool {
+	if len(b.pending) >= b.settings.maxSize {
+		return true
+	}
+	for _, count := range b.pendingPerUser {
+		if count >= b.settings.maxPendingPerUser {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *readReceiptBuffer) observeSize(size int) {
+	if metrics := b.metrics(); metrics != nil {
+		metrics.ObserveReadReceiptBufferSize(int64(size))
+	}
+}
+
+func (b *readReceiptBuffer) start() {
+	b.startOnce.Do(func() {
+		b.started = true
+		go b.loop()
+	})
+}
+
+// stopAndFlush stops the flush loop and writes the receipts still pending.
+func (b *readReceiptBuffer) stopAndFlush() {
+	if !b.started {
+		b.flushPending()
+		return
+	}
+
+	close(b.stop)
+	<-b.done
+}
+
+func (b *readReceiptBuffer) add(receipts ...*model.PostReadReceipt) {
+	b.mut.Lock()
+	full := false
+	for _, receipt := range receipts {
+		key := readReceiptBufferKey{postID: receipt.PostId, userID: receipt.UserId}
+		if _, ok := b.pending[key]; !ok {
+			b.pending[key] = receipt
+			b.pendingPerUser[receipt.UserId]++
+			if b.pendingPerUser[receipt.UserId] >= b.settings.maxPendingPerUser {
+				full = true
+			}
+		}
+	}
+	full = full || len(b.pending) >= b.settings.maxSize
+	size := len(b.pending)
+	b.mut.Unlock()
+
+	b.observeSize(size)
+	if full {
+		b.wakeUp()
+	}
+}
+
+// dedupe returns the posts among postIDs that the user did not mark as read in the
+// last readReceiptDedupeWindow, and remembers them.
+func (b *readReceiptBuffer) dedupe(userID string, postIDs []string) []string {
+	now := time.Now()
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	seen, ok := b.recent[userID]
+	if !ok {
+		seen = make(map[string]time.Time)
+		b.recent[userID] = seen
+	}
+
+	fresh := make([]string, 0, len(postIDs))
+	for _, postID := range postIDs {
+		if at, ok := seen[postID]; ok && now.Sub(at) < readReceiptDedupeWindow {
+			continue
+		}
+		seen[postID] = now
+		fresh = append(fresh, postID)
+	}
+
+	return fresh
+}
+
+// forget lets the posts go through dedupe again, for when saving their receipts
+// failed.
+func (b *readReceiptBuffer) forget(userID string, postIDs []string) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	for _, postID := range postIDs {
+		delete(b.recent[userID], postID)
+	}
+}
+
+// pruneRecent drops the posts remembered by dedupe for longer than the window.
+func (b *readReceiptBuffer) pruneRecent() {
+	now := time.Now()
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	for userID, seen := range b.recent {
+		for postID, at := range seen {
+			if now.Sub(at) >= readReceiptDedupeWindow {
+				delete(seen, postID)
+			}
+		}
+		if len(seen) == 0 {
+			delete(b.recent, userID)
+		}
+	}
+}
+
+// pendingCount returns the number of receipts waiting to be flushed.
+func (b *readReceiptBuffer) pendingCount() int {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return len(b.pending)
+}
+
+func (b *readReceiptBuffer) drain() []*model.PostReadReceipt {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	receipts := make([]*model.PostReadReceipt, 0, len(b.pending))
+	for _, receipt := range b.pending {
+		receipts = append(receipts, receipt)
+	}
+	b.pending = make(map[readReceiptBufferKey]*model.PostReadReceipt)
+	b.pendingPerUser = make(map[string]int)
+
+	return receipts
+}
+
+func (b *readReceiptBuffer) flushPending() {
+	receipts := b.drain()
+	if len(receipts) == 0 {
+		return
+	}
+	b.observeSize(0)
+
+	start := time.Now()
+	b.flush(receipts)
+	if metrics := b.metrics(); metrics != nil {
+		metrics.ObserveReadReceiptBufferFlushDuration(time.Since(start).Seconds())
+	}
+}
+
+func (b *readReceiptBuffer) flushInterval() time.Duration {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return b.settings.flushInterval
+}
+
+func (b *readReceiptBuffer) loop() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.flushPending()
+			b.pruneRecent()
+		case <-b.reset:
+			ticker.Reset(b.flushInterval())
+		case <-b.wake:
+			b.flushPending()
+		case <-b.stop:
+			b.flushPending()
+			return
+		}
+	}
+}
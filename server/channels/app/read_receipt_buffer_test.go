@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func defaultReadReceiptBufferSettings() readReceiptBufferSettings {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	return readReceiptBufferSettingsFromConfig(cfg)
+}
+
+func TestReadReceiptBuffer(t *testing.T) {
+	var mut sync.Mutex
+	var flushed [][]*model.PostReadReceipt
+	buffer := newReadReceiptBuffer(defaultReadReceiptBufferSettings(), func(receipts []*model.PostReadReceipt) {
+		mut.Lock()
+		defer mut.Unlock()
+		flushed = append(flushed, receipts)
+	})
+
+	postID := model.NewId()
+	userID := model.NewId()
+	buffer.add(
+		&model.PostReadReceipt{PostId: postID, UserId: userID, ReadAt: 1000},
+		&model.PostReadReceipt{PostId: postID, UserId: userID, ReadAt: 2000},
+		&model.PostReadReceipt{PostId: model.NewId(), UserId: userID, ReadAt: 2000},
+	)
+
+	buffer.start()
+	buffer.stopAndFlush()
+
+	mut.Lock()
+	defer mut.Unlock()
+	require.Len(t, flushed, 1)
+	require.Len(t, flushed[0], 2)
+	for _, receipt := range flushed[0] {
+		if receipt.PostId == postID {
+			assert.Equal(t, int64(1000), receipt.ReadAt)
+		}
+	}
+
+	assert.Empty(t, buffer.drain())
+}
+
+func TestReadReceiptBufferDedupe(t *testing.T) {
+	buffer := newReadReceiptBuffer(defaultReadReceiptBufferSettings(), func(receipts []*model.PostReadReceipt) {})
+
+	userID := model.NewId()
+	post1 := model.NewId()
+	post2 := model.NewId()
+
+	require.Equal(t, []string{post1}, buffer.dedupe(userID, []string{post1}))
+	require.Equal(t, []string{post2}, buffer.dedupe(userID, []string{post1, post2}))
+	require.Empty(t, buffer.dedupe(userID, []string{post1, post2}))
+
+	// Other users are tracked separately.
+	require.Equal(t, []string{post1}, buffer.dedupe(model.NewId(), []string{post1}))
+
+	buffer.forget(userID, []string{post2})
+	require.Equal(t, []string{post2}, buffer.dedupe(userID, []string{post1, post2}))
+
+	buffer.mut.Lock()
+	for postID := range buffer.recent[userID] {
+		buffer.recent[userID][postID] = time.Now().Add(-readReceiptDedupeWindow)
+	}
+	buffer.mut.Unlock()
+
+	require.Equal(t, []string{post1, post2}, buffer.dedupe(userID, []string{post1, post2}))
+
+	buffer.mut.Lock()
+	for postID := range buffer.recent[userID] {
+		buffer.recent[userID][postID] = time.Now().Add(-readReceiptDedupeWindow)
+	}
+	buffer.mut.Unlock()
+
+	buffer.pruneRecent()
+	assert.NotContains(t, buffer.recent, userID)
+}
+
+func TestReadReceiptBufferConfigure(t *testing.T) {
+	flushed := make(chan []*model.PostReadReceipt, 10)
+	buffer := newReadReceiptBuffer(defaultReadReceiptBufferSettings(), func(receipts []*model.PostReadReceipt) {
+		flushed <- receipts
+	})
+	buffer.start()
+	defer buffer.stopAndFlush()
+
+	userID := model.NewId()
+	buffer.add(&model.PostReadReceipt{PostId: model.NewId(), UserId: userID})
+	buffer.add(&model.PostReadReceipt{PostId: model.NewId(), UserId: userID})
+	assert.Equal(t, 2, buffer.pendingCount())
+
+	t.Run("lowering the limits flushes the pending receipts", func(t *testing.T) {
+		settings := defaultReadReceiptBufferSettings()
+		settings.maxPendingPerUser = 2
+		buffer.configure(settings)
+
+		select {
+		case receipts := <-flushed:
+			require.Len(t, receipts, 2)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "pending receipts were not flushed")
+		}
+	})
+
+	t.Run("a user reaching the per user limit triggers a flush", func(t *testing.T) {
+		buffer.add(&model.PostReadReceipt{PostId: model.NewId(), UserId: model.NewId()})
+		buffer.add(
+			&model.PostReadReceipt{PostId: model.NewId(), UserId: userID},
+			&model.PostReadReceipt{PostId: model.NewId(), UserId: userID},
+		)
+
+		select {
+		case receipts := <-flushed:
+			require.Len(t, receipts, 3)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "pending receipts were not flushed")
+		}
+	})
+
+	t.Run("the flush interval is reloaded", func(t *testing.T) {
+		settings := defaultReadReceiptBufferSettings()
+		settings.flushInterval = 10 * time.Millisecond
+		buffer.configure(settings)
+
+		buffer.add(&model.PostReadReceipt{PostId: model.NewId(), UserId: model.NewId()})
+
+		select {
+		case receipts := <-flushed:
+			require.Len(t, receipts, 1)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "pending receipts were not flushed")
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// readReceiptChainAppendAttempts bounds how many times receipts are relinked
+	// when concurrent saves in the channel extended the chain first.
+	readReceiptChainAppendAttempts = 5
+	readReceiptChainVerifyPageSize = 1000
+)
+
+// readReceiptChainKey derives the key signing the receipt chains from the
+// cluster wide post action secret, so that every server computes the same hashes.
+func (a *App) readReceiptChainKey() []byte {
+	mac := hmac.New(sha256.New, a.PostActionCookieSecret())
+	mac.Write([]byte("read_receipt_chain"))
+	return mac.Sum(nil)
+}
+
+// chainReadReceipts appends saved receipts to the integrity chain of their channel
+// when ServiceSettings.ReadReceiptsEnableIntegrityChain is enabled. Failures are
+// logged only, the receipts themselves are already stored.
+func (a *App) chainReadReceipts(c request.CTX, receipts []*model.PostReadReceipt) {
+	if !*a.Config().ServiceSettings.ReadReceiptsEnableIntegrityChain || len(receipts) == 0 {
+		return
+	}
+
+	byChannel := make(map[string][]*model.PostReadReceipt)
+	for _, receipt := range receipts {
+		byChannel[receipt.ChannelId] = append(byChannel[receipt.ChannelId], receipt)
+	}
+
+	for channelID, channelReceipts := range byChannel {
+		if err := a.appendReadReceiptChain(channelID, channelReceipts); err != nil {
+			c.Logger().Error("Failed to append read receipts to the integrity chain", mlog.String("channel_id", channelID), mlog.Int("count", len(channelReceipts)), mlog.Err(err))
+		}
+	}
+}
+
+func (a *App) appendReadReceiptChain(channelID string, receipts []*model.PostReadReceipt) error {
+	key := a.readReceiptChainKey()
+
+	var err error
+	for range readReceiptChainAppendAttempts {
+		var head *model.ReadReceiptChainEntry
+		head, err = a.Srv().Store().PostReadReceipt().GetReadReceiptChainHead(channelID)
+		var nfErr *store.ErrNotFound
+		if err != nil && !errors.As(err, &nfErr) {
+			return err
+		}
+
+		entries := make([]*model.ReadReceiptChainEntry, 0, len(receipts))
+		for _, receipt := range receipts {
+			entry := &model.ReadReceiptChainEntry{
+				ChannelId: channelID,
+				PostId:    receipt.PostId,
+				UserId:    receipt.UserId,
+				ReadAt:    receipt.ReadAt,
+			}
+			entry.Link(key, head)
+			entries = append(entries, entry)
+			head = entry
+		}
+
+		err = a.Srv().Store().PostReadReceipt().AppendReadReceiptChain(entries)
+		var cErr *store.ErrConflict
+		if !errors.As(err, &cErr) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// VerifyReadReceiptChain walks the integrity chain of the channel from its first
+// entry and reports the first entry that was altered, removed or reordered. An
+// intact chain is then checked against the stored receipts, reporting the readers
+// whose receipt was removed or altered without going through the store.
+func (a *App) VerifyReadReceiptChain(c request.CTX, channelID string) (*model.ReadReceiptChainVerification, *model.AppError) {
+	key := a.readReceiptChainKey()
+	verification := &model.ReadReceiptChainVerification{
+		ChannelId: channelID,
+		Valid:     true,
+	}
+
+	var prev *model.ReadReceiptChainEntry
+	for {
+		var afterSequence int64
+		if prev != nil {
+			afterSequence = prev.Sequence
+		}
+
+		entries, err := a.Srv().Store().PostReadReceipt().GetReadReceiptChain(channelID, afterSequence, readReceiptChainVerifyPageSize)
+		if err != nil {
+			return nil, model.NewAppError("VerifyReadReceiptChain", "app.read_receipt.chain.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, entry := range entries {
+			if !entry.Verify(key, prev) {
+				verification.Valid = false
+				verification.FirstInvalidSequence = afterSequence + 1
+				return verification, nil
+			}
+			verification.EntriesChecked++
+			prev = entry
+			afterSequence = entry.Sequence
+		}
+
+		if len(entries) < readReceiptChainVerifyPageSize {
+			break
+		}
+	}
+
+	if prev != nil {
+		verification.HeadHash = prev.Hash
+	}
+
+	mismatches, err := a.Srv().Store().PostReadReceipt().GetReadReceiptChainMismatches(channelID, readReceiptChainVerifyPageSize)
+	if err != nil {
+		return nil, model.NewAppError("VerifyReadReceiptChain", "app.read_receipt.chain.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, entry := range mismatches {
+		verification.Valid = false
+		verification.MismatchedSequences = append(verification.MismatchedSequences, entry.Sequence)
+	}
+
+	return verification, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const readReceiptChangesPruneBatchSize = 1000
+
+// GetReadReceiptChanges returns the receipt changes of the channel, or of every
+// channel when channelID is empty, that follow afterSequence. The changes of the
+// users the viewer is not allowed to see are left out. Consumers resume from the
+// LastSequence of the page; those that fell behind the retention of the changes
+// must reload the receipts instead.
+func (a *App) GetReadReceiptChanges(c request.CTX, viewerID, channelID string, afterSequence int64, limit int) (*model.ReadReceiptChangesPage, *model.AppError) {
+	changes, err := a.Srv().Store().PostReadReceipt().GetReadReceiptChanges(channelID, afterSequence, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptChanges", "app.read_receipt.get_changes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// The page resumes after the last change read, even when it is filtered out.
+	page := &model.ReadReceiptChangesPage{LastSequence: afterSequence}
+	if len(changes) > 0 {
+		page.LastSequence = changes[len(changes)-1].Sequence
+	}
+
+	var appErr *model.AppError
+	page.Changes, appErr = filterReadersForViewer(c, a, viewerID, changes, func(change *model.ReadReceiptChange) string { return change.UserId })
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return page, nil
+}
+
+// DeleteExpiredReadReceiptChanges deletes the receipt changes written more than
+// ReadReceiptsChangesRetentionHours ago, a batch at a time, and returns how many
+// it deleted.
+func (a *App) DeleteExpiredReadReceiptChanges() (int64, error) {
+	retention := time.Duration(*a.Config().ServiceSettings.ReadReceiptsChangesRetentionHours) * time.Hour
+	expiredBefore := time.Now().Add(-retention).UnixMilli()
+
+	var deleted int64
+	for {
+		count, err := a.Srv().Store().PostReadReceipt().DeleteReadReceiptChangesBefore(expiredBefore, readReceiptChangesPruneBatchSize)
+		if err != nil {
+			return deleted, errors.Wrap(err, "failed to delete the expired receipt changes")
+		}
+		deleted += count
+		if count < readReceiptChangesPruneBatchSize {
+			return deleted, nil
+		}
+	}
+}
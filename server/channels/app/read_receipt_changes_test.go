@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestDeleteExpiredReadReceiptChanges(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsChangesRetentionHours = 24
+	})
+
+	mockStore := th.App.Srv().Store().(*storemocks.Store)
+	mockReceiptStore := storemocks.PostReadReceiptStore{}
+	mockStore.On("PostReadReceipt").Return(&mockReceiptStore)
+
+	expiredBefore := time.Now().Add(-24 * time.Hour).UnixMilli()
+	beforeRetention := mock.MatchedBy(func(before int64) bool {
+		return before >= expiredBefore && before < expiredBefore+time.Minute.Milliseconds()
+	})
+	mockReceiptStore.On("DeleteReadReceiptChangesBefore", beforeRetention, readReceiptChangesPruneBatchSize).Return(int64(readReceiptChangesPruneBatchSize), nil).Twice()
+	mockReceiptStore.On("DeleteReadReceiptChangesBefore", beforeRetention, readReceiptChangesPruneBatchSize).Return(int64(3), nil).Once()
+
+	deleted, err := th.App.DeleteExpiredReadReceiptChanges()
+	require.NoError(t, err)
+	require.Equal(t, int64(2*readReceiptChangesPruneBatchSize+3), deleted)
+	mockReceiptStore.AssertNumberOfCalls(t, "DeleteReadReceiptChangesBefore", 3)
+}
+
+func TestGetReadReceiptChanges(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	page, appErr := th.App.GetReadReceiptChanges(th.Context, th.BasicUser.Id, th.BasicChannel.Id, 0, 100)
+	require.Nil(t, appErr)
+	require.Empty(t, page.Changes)
+	require.Zero(t, page.LastSequence)
+
+	th.MarkPostAsRead(th.BasicPost, th.BasicUser)
+	page, appErr = th.App.GetReadReceiptChanges(th.Context, th.BasicUser.Id, th.BasicChannel.Id, 0, 100)
+	require.Nil(t, appErr)
+	require.Len(t, page.Changes, 1)
+	require.Equal(t, model.ReadReceiptChangeOpSave, page.Changes[0].Op)
+	require.Equal(t, page.Changes[0].Sequence, page.LastSequence)
+
+	next, appErr := th.App.GetReadReceiptChanges(th.Context, th.BasicUser.Id, th.BasicChannel.Id, page.LastSequence, 100)
+	require.Nil(t, appErr)
+	require.Empty(t, next.Changes)
+	require.Equal(t, page.LastSequence, next.LastSequence)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const readReceiptChannelSettingsCacheSize = 10000
+
+// readReceiptChannelSettingsCacheExpiry bounds how long other nodes of a cluster
+// keep using the previous settings of a channel after they change.
+var readReceiptChannelSettingsCacheExpiry = time.Minute
+
+// GetReadReceiptChannelSettings returns the read receipt settings of the channel,
+// which are the defaults until its admins change them.
+func (a *App) GetReadReceiptChannelSettings(channelID string) (*model.ReadReceiptChannelSettings, *model.AppError) {
+	settings, err := a.Srv().Store().PostReadReceipt().GetChannelSettings(channelID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.DefaultReadReceiptChannelSettings(channelID), nil
+		}
+		return nil, model.NewAppError("GetReadReceiptChannelSettings", "app.read_receipt.channel_settings.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return settings, nil
+}
+
+func (a *App) UpdateReadReceiptChannelSettings(settings *model.ReadReceiptChannelSettings) (*model.ReadReceiptChannelSettings, *model.AppError) {
+	saved, err := a.Srv().Store().PostReadReceipt().SaveChannelSettings(settings)
+	if err != nil {
+		return nil, model.NewAppError("UpdateReadReceiptChannelSettings", "app.read_receipt.channel_settings.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().readReceiptChannelSettingsCache.Remove(settings.ChannelId); err != nil {
+		a.Log().Warn("Failed to clear the cached read receipt settings of the channel", mlog.String("channel_id", settings.ChannelId), mlog.Err(err))
+	}
+
+	return saved, nil
+}
+
+// readReceiptsBroadcastForChannel reports whether changes to the read counters of
+// the channel are broadcast to its members. Errors are logged and keep the
+// broadcast on.
+func (a *App) readReceiptsBroadcastForChannel(c request.CTX, channelID string) bool {
+	var broadcast bool
+	err := a.Srv().readReceiptChannelSettingsCache.Get(channelID, &broadcast)
+	if err == nil {
+		return broadcast
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		c.Logger().Warn("Failed to get the cached read receipt settings of the channel", mlog.String("channel_id", channelID), mlog.Err(err))
+	}
+
+	settings, appErr := a.GetReadReceiptChannelSettings(channelID)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the read receipt settings of the channel", mlog.String("channel_id", channelID), mlog.Err(appErr))
+		return true
+	}
+
+	if err := a.Srv().readReceiptChannelSettingsCache.SetWithExpiry(channelID, settings.BroadcastReceipts, readReceiptChannelSettingsCacheExpiry); err != nil {
+		c.Logger().Warn("Failed to cache the read receipt settings of the channel", mlog.String("channel_id", channelID), mlog.Err(err))
+	}
+
+	return settings.BroadcastReceipts
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const readReceiptCleanupPageSize = 1000
+
+// DeleteExpiredReadReceipts deletes the receipts read more than
+// ReadReceiptsRetentionDays ago, or than the retention of the read receipt policy
+// governing their channel, walking the receipts that follow cursor a page at
+// a time. Without ReadReceiptsRetentionDays only the policies expire receipts.
+// The cursor to resume from is handed to checkpoint after each page. The
+// expired receipts offloaded to cold storage and compacted into bitmaps are
+// deleted once the database is done, followed by the broadcasts created more
+// than ReadReceiptsRetentionDays ago. The read counters of the posts are left
+// untouched.
+func (a *App) DeleteExpiredReadReceipts(cursor model.ReadReceiptsPageCursor, checkpoint func(cursor model.ReadReceiptsPageCursor) error) (int64, error) {
+	var retentionCutoff int64
+	if days := *a.Config().ServiceSettings.ReadReceiptsRetentionDays; days > 0 {
+		retentionCutoff = time.Now().Add(-time.Duration(days) * 24 * time.Hour).UnixMilli()
+	}
+	expiredBefore := a.readReceiptRetentionCutoffs(request.EmptyContext(a.Log()), retentionCutoff)
+
+	iterator := &readReceiptPageIterator[model.ReadReceiptsPageCursor, *model.PostReadReceipt]{
+		fetch: a.Srv().Store().PostReadReceipt().GetReadReceiptsPage,
+		cursorAfter: func(receipt *model.PostReadReceipt) model.ReadReceiptsPageCursor {
+			return model.ReadReceiptsPageCursor{PostId: receipt.PostId, UserId: receipt.UserId}
+		},
+		limit: readReceiptCleanupPageSize,
+	}
+
+	var deleted int64
+	_, err := iterator.run(cursor, func(receipts []*model.PostReadReceipt) error {
+		expired := make([]*model.PostReadReceipt, 0, len(receipts))
+		for _, receipt := range receipts {
+			if receipt.ReadAt < expiredBefore(receipt.ChannelId) {
+				expired = append(expired, receipt)
+			}
+		}
+
+		count, err := a.Srv().Store().PostReadReceipt().DeleteReadReceipts(expired)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete the expired receipts")
+		}
+		deleted += count
+		return nil
+	}, checkpoint)
+	if err != nil {
+		return deleted, err
+	}
+
+	if err := a.deleteExpiredColdStorageReadReceipts(expiredBefore); err != nil {
+		return deleted, errors.Wrap(err, "failed to delete the expired receipts of cold storage")
+	}
+
+	if err := a.deleteExpiredCompactedReadReceipts(expiredBefore); err != nil {
+		return deleted, err
+	}
+
+	if retentionCutoff > 0 {
+		if err := a.deleteReadReceiptBroadcastsBefore(retentionCutoff); err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// deleteReadReceiptBroadcastsBefore deletes the broadcasts created before the given
+// time, a batch at a time. Their copies are left to the retention of the posts.
+func (a *App) deleteReadReceiptBroadcastsBefore(before int64) error {
+	for {
+		count, err := a.Srv().Store().ReadReceiptBroadcast().DeleteBefore(before, readReceiptCleanupPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete the expired broadcasts")
+		}
+		if count < readReceiptCleanupPageSize {
+			return nil
+		}
+	}
+}
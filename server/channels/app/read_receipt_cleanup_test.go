@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestDeleteExpiredReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsRetentionDays = 30
+	})
+
+	expiredPost := th.CreatePost(th.BasicChannel)
+	recentPost := th.CreatePost(th.BasicChannel)
+	_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: expiredPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: time.Now().Add(-31 * 24 * time.Hour).UnixMilli()},
+		{PostId: recentPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: model.GetMillis()},
+	})
+	require.NoError(t, err)
+
+	var checkpoints []model.ReadReceiptsPageCursor
+	deleted, err := th.App.DeleteExpiredReadReceipts(model.ReadReceiptsPageCursor{}, func(cursor model.ReadReceiptsPageCursor) error {
+		checkpoints = append(checkpoints, cursor)
+		return nil
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+	require.NotEmpty(t, checkpoints)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(expiredPost.Id, th.BasicUser2.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(recentPost.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+}
+
+func TestDeleteExpiredReadReceiptsWithPolicies(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsRetentionDays = 30
+	})
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+
+	shortChannel := th.CreateChannel(th.Context, th.BasicTeam)
+	foreverChannel := th.CreateChannel(th.Context, th.BasicTeam)
+	_, appErr := th.App.UpdateReadReceiptPolicies([]*model.ReadReceiptPolicy{
+		{Name: "short", ChannelIds: model.StringArray{shortChannel.Id}, RetentionDays: 7},
+		{Name: "forever", ChannelIds: model.StringArray{foreverChannel.Id}},
+	})
+	require.Nil(t, appErr)
+
+	tenDaysAgo := time.Now().Add(-10 * 24 * time.Hour).UnixMilli()
+	longAgo := time.Now().Add(-365 * 24 * time.Hour).UnixMilli()
+	shortPost := th.CreatePost(shortChannel)
+	foreverPost := th.CreatePost(foreverChannel)
+	defaultPost := th.CreatePost(th.BasicChannel)
+	_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: shortPost.Id, UserId: th.BasicUser2.Id, ChannelId: shortChannel.Id, ReadAt: tenDaysAgo},
+		{PostId: foreverPost.Id, UserId: th.BasicUser2.Id, ChannelId: foreverChannel.Id, ReadAt: longAgo},
+		{PostId: defaultPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: tenDaysAgo},
+	})
+	require.NoError(t, err)
+
+	_, err = th.App.DeleteExpiredReadReceipts(model.ReadReceiptsPageCursor{}, func(model.ReadReceiptsPageCursor) error { return nil })
+	require.NoError(t, err)
+
+	// The policy retention wins over the configured one, both ways.
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(shortPost.Id, th.BasicUser2.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(foreverPost.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(defaultPost.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+}
+
+func TestDeleteExpiredReadReceiptsWithPoliciesOnly(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsRetentionDays = 0
+	})
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+	require.False(t, th.App.ReadReceiptPoliciesExpireReceipts())
+
+	shortChannel := th.CreateChannel(th.Context, th.BasicTeam)
+	_, appErr := th.App.UpdateReadReceiptPolicies([]*model.ReadReceiptPolicy{
+		{Name: "short", ChannelIds: model.StringArray{shortChannel.Id}, RetentionDays: 7},
+	})
+	require.Nil(t, appErr)
+	// The cleanup job runs for the policy even though receipts are otherwise kept forever.
+	require.True(t, th.App.ReadReceiptPoliciesExpireReceipts())
+
+	tenDaysAgo := time.Now().Add(-10 * 24 * time.Hour).UnixMilli()
+	shortPost := th.CreatePost(shortChannel)
+	defaultPost := th.CreatePost(th.BasicChannel)
+	_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: shortPost.Id, UserId: th.BasicUser2.Id, ChannelId: shortChannel.Id, ReadAt: tenDaysAgo},
+		{PostId: defaultPost.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: tenDaysAgo},
+	})
+	require.NoError(t, err)
+
+	_, err = th.App.DeleteExpiredReadReceipts(model.ReadReceiptsPageCursor{}, func(model.ReadReceiptsPageCursor) error { return nil })
+	require.NoError(t, err)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(shortPost.Id, th.BasicUser2.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(defaultPost.Id, th.BasicUser2.Id)
+	require.NoError(t, err)
+}
+
+func TestDeleteReadReceiptBroadcastsBefore(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	before := model.GetMillis()
+	mockStore := th.App.Srv().Store().(*storemocks.Store)
+	mockBroadcastStore := storemocks.ReadReceiptBroadcastStore{}
+	mockBroadcastStore.On("DeleteBefore", before, readReceiptCleanupPageSize).Return(int64(readReceiptCleanupPageSize), nil).Twice()
+	mockBroadcastStore.On("DeleteBefore", before, readReceiptCleanupPageSize).Return(int64(3), nil).Once()
+	mockStore.On("ReadReceiptBroadcast").Return(&mockBroadcastStore)
+
+	require.NoError(t, th.App.deleteReadReceiptBroadcastsBefore(before))
+	mockBroadcastStore.AssertNumberOfCalls(t, "DeleteBefore", 3)
+}
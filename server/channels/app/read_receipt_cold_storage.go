@@ -0,0 +1,371 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	readReceiptColdStorageDir      = "read_receipts/cold_storage"
+	readReceiptColdStorageExt      = ".jsonl.gz"
+	readReceiptColdStoragePageSize = 1000
+)
+
+// readReceiptColdStorageObject is an object holding offloaded receipts of a
+// channel. Its name records the range of the read times of the receipts it holds,
+// so that queries skip the objects outside of the range they ask for.
+type readReceiptColdStorageObject struct {
+	path      string
+	firstRead int64
+	lastRead  int64
+}
+
+func newReadReceiptColdStorageObject(channelID string, receipts []*model.PostReadReceipt) readReceiptColdStorageObject {
+	object := readReceiptColdStorageObject{firstRead: receipts[0].ReadAt, lastRead: receipts[0].ReadAt}
+	for _, receipt := range receipts[1:] {
+		object.firstRead = min(object.firstRead, receipt.ReadAt)
+		object.lastRead = max(object.lastRead, receipt.ReadAt)
+	}
+	name := fmt.Sprintf("%d_%d_%s%s", object.firstRead, object.lastRead, model.NewId(), readReceiptColdStorageExt)
+	object.path = path.Join(readReceiptColdStorageDir, channelID, name)
+	return object
+}
+
+func parseReadReceiptColdStorageObject(objectPath string) (readReceiptColdStorageObject, bool) {
+	name, ok := strings.CutSuffix(path.Base(objectPath), readReceiptColdStorageExt)
+	if !ok {
+		return readReceiptColdStorageObject{}, false
+	}
+
+	parts := strings.Split(name, "_")
+	if len(parts) != 3 {
+		return readReceiptColdStorageObject{}, false
+	}
+	firstRead, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return readReceiptColdStorageObject{}, false
+	}
+	lastRead, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return readReceiptColdStorageObject{}, false
+	}
+
+	return readReceiptColdStorageObject{path: objectPath, firstRead: firstRead, lastRead: lastRead}, true
+}
+
+// OffloadReadReceiptsToColdStorage moves the receipts read more than
+// ReadReceiptsColdStorageDays ago to gzipped JSON lines objects in the file store,
+// one per channel and page, and deletes them from the database. It walks the
+// receipts that follow cursor a page at a time, handing the cursor to resume from
+// to checkpoint after each page. The read counters of the posts are left untouched.
+func (a *App) OffloadReadReceiptsToColdStorage(cursor model.ReadReceiptsPageCursor, checkpoint func(cursor model.ReadReceiptsPageCursor) error) (int64, error) {
+	age := time.Duration(*a.Config().ServiceSettings.ReadReceiptsColdStorageDays) * 24 * time.Hour
+	readBefore := time.Now().Add(-age).UnixMilli()
+
+	iterator := &readReceiptPageIterator[model.ReadReceiptsPageCursor, *model.PostReadReceipt]{
+		fetch: a.Srv().Store().PostReadReceipt().GetReadReceiptsPage,
+		cursorAfter: func(receipt *model.PostReadReceipt) model.ReadReceiptsPageCursor {
+			return model.ReadReceiptsPageCursor{PostId: receipt.PostId, UserId: receipt.UserId}
+		},
+		limit: readReceiptColdStoragePageSize,
+	}
+
+	var offloaded int64
+	_, err := iterator.run(cursor, func(receipts []*model.PostReadReceipt) error {
+		byChannel := make(map[string][]*model.PostReadReceipt)
+		for _, receipt := range receipts {
+			if receipt.ReadAt < readBefore {
+				byChannel[receipt.ChannelId] = append(byChannel[receipt.ChannelId], receipt)
+			}
+		}
+
+		for channelID, channelReceipts := range byChannel {
+			// The receipts are only deleted once they are safely stored.
+			if err := a.writeReadReceiptColdStorageObject(channelID, channelReceipts); err != nil {
+				return err
+			}
+
+			count, err := a.Srv().Store().PostReadReceipt().DeleteReadReceipts(channelReceipts)
+			if err != nil {
+				return errors.Wrap(err, "failed to delete the offloaded receipts")
+			}
+			offloaded += count
+		}
+		return nil
+	}, checkpoint)
+
+	return offloaded, err
+}
+
+func (a *App) writeReadReceiptColdStorageObject(channelID string, receipts []*model.PostReadReceipt) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	for _, receipt := range receipts {
+		if err := encoder.Encode(receipt); err != nil {
+			return errors.Wrap(err, "failed to encode the receipt")
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress the receipts")
+	}
+
+	object := newReadReceiptColdStorageObject(channelID, receipts)
+	if _, appErr := a.WriteFile(&buf, object.path); appErr != nil {
+		return errors.Wrapf(appErr, "failed to write the cold storage object %s", object.path)
+	}
+	return nil
+}
+
+// GetColdStorageReadReceipts returns the receipts of a channel offloaded to cold
+// storage that match the query, at most model.ReadReceiptColdStorageMaxResults of
+// them, ordered by read time. It reads every object of the channel overlapping the
+// requested range, so it is meant for the occasional audit only.
+func (a *App) GetColdStorageReadReceipts(c request.CTX, query model.ReadReceiptColdStorageQuery) (*model.ReadReceiptColdStorageResult, *model.AppError) {
+	paths, appErr := a.ListDirectory(path.Join(readReceiptColdStorageDir, query.ChannelId))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var objects []readReceiptColdStorageObject
+	for _, objectPath := range paths {
+		object, ok := parseReadReceiptColdStorageObject(objectPath)
+		if !ok {
+			continue
+		}
+		if (query.Since > 0 && object.lastRead < query.Since) || (query.Until > 0 && object.firstRead > query.Until) {
+			continue
+		}
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].firstRead < objects[j].firstRead })
+
+	result := &model.ReadReceiptColdStorageResult{Receipts: []*model.PostReadReceipt{}}
+	for _, object := range objects {
+		if err := a.readReadReceiptColdStorageObject(object.path, func(receipt *model.PostReadReceipt) bool {
+			if (query.PostId != "" && receipt.PostId != query.PostId) ||
+				(query.UserId != "" && receipt.UserId != query.UserId) ||
+				(query.Since > 0 && receipt.ReadAt < query.Since) ||
+				(query.Until > 0 && receipt.ReadAt > query.Until) {
+				return true
+			}
+			if len(result.Receipts) == model.ReadReceiptColdStorageMaxResults {
+				result.Truncated = true
+				return false
+			}
+			result.Receipts = append(result.Receipts, receipt)
+			return true
+		}); err != nil {
+			return nil, model.NewAppError("GetColdStorageReadReceipts", "app.read_receipt.cold_storage.read.app_error", nil, "path="+object.path, http.StatusInternalServerError).Wrap(err)
+		}
+		if result.Truncated {
+			break
+		}
+	}
+
+	sort.Slice(result.Receipts, func(i, j int) bool { return result.Receipts[i].ReadAt < result.Receipts[j].ReadAt })
+	return result, nil
+}
+
+// readReadReceiptColdStorageObject hands the receipts of the object to fn until it
+// returns false.
+func (a *App) readReadReceiptColdStorageObject(objectPath string, fn func(receipt *model.PostReadReceipt) bool) error {
+	reader, appErr := a.FileReader(objectPath)
+	if appErr != nil {
+		return appErr
+	}
+	defer reader.Close()
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to decompress the object")
+	}
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+	for {
+		var receipt model.PostReadReceipt
+		if err := decoder.Decode(&receipt); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "failed to decode the receipt")
+		}
+		if !fn(&receipt) {
+			return nil
+		}
+	}
+}
+
+// rewriteReadReceiptColdStorage rewrites the cold storage objects of the channels,
+// or of every channel when channelIDs is nil, keeping the receipts as returned by
+// edit. Receipts edit returns nil for are dropped, and objects left empty are
+// removed. Offloaded receipts are otherwise never touched again, so this is how the
+// deletions of the database reach them.
+func (a *App) rewriteReadReceiptColdStorage(channelIDs []string, edit func(receipt *model.PostReadReceipt) *model.PostReadReceipt) error {
+	if channelIDs == nil {
+		dirs, appErr := a.ListDirectory(readReceiptColdStorageDir)
+		if appErr != nil {
+			return appErr
+		}
+		channelIDs = make([]string, 0, len(dirs))
+		for _, dir := range dirs {
+			channelIDs = append(channelIDs, path.Base(dir))
+		}
+	}
+
+	for _, channelID := range channelIDs {
+		paths, appErr := a.ListDirectory(path.Join(readReceiptColdStorageDir, channelID))
+		if appErr != nil {
+			return appErr
+		}
+		for _, objectPath := range paths {
+			object, ok := parseReadReceiptColdStorageObject(objectPath)
+			if !ok {
+				continue
+			}
+			if err := a.rewriteReadReceiptColdStorageObject(channelID, object, edit); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rewriteReadReceiptColdStorageObject replaces the object with one holding the
+// receipts as returned by edit, unless edit changed none of them. The new object is
+// written before the old one is removed, so that no receipt is lost on failure.
+func (a *App) rewriteReadReceiptColdStorageObject(channelID string, object readReceiptColdStorageObject, edit func(receipt *model.PostReadReceipt) *model.PostReadReceipt) error {
+	var kept []*model.PostReadReceipt
+	changed := false
+	if err := a.readReadReceiptColdStorageObject(object.path, func(receipt *model.PostReadReceipt) bool {
+		edited := edit(receipt)
+		if edited != receipt {
+			changed = true
+		}
+		if edited != nil {
+			kept = append(kept, edited)
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to read the cold storage object %s", object.path)
+	}
+
+	if !changed {
+		return nil
+	}
+	if len(kept) > 0 {
+		if err := a.writeReadReceiptColdStorageObject(channelID, kept); err != nil {
+			return err
+		}
+	}
+	if appErr := a.RemoveFile(object.path); appErr != nil {
+		return errors.Wrapf(appErr, "failed to remove the cold storage object %s", object.path)
+	}
+	return nil
+}
+
+// deleteExpiredColdStorageReadReceipts removes the offloaded receipts read before
+// the time expiredBeforeForChannel returns for their channel. Objects holding only
+// expired receipts are removed without being read.
+func (a *App) deleteExpiredColdStorageReadReceipts(expiredBeforeForChannel func(channelID string) int64) error {
+	dirs, appErr := a.ListDirectory(readReceiptColdStorageDir)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, dir := range dirs {
+		channelID := path.Base(dir)
+		expiredBefore := expiredBeforeForChannel(channelID)
+		dropExpired := func(receipt *model.PostReadReceipt) *model.PostReadReceipt {
+			if receipt.ReadAt < expiredBefore {
+				return nil
+			}
+			return receipt
+		}
+
+		paths, appErr := a.ListDirectory(dir)
+		if appErr != nil {
+			return appErr
+		}
+		for _, objectPath := range paths {
+			object, ok := parseReadReceiptColdStorageObject(objectPath)
+			if !ok || object.firstRead >= expiredBefore {
+				continue
+			}
+			if object.lastRead < expiredBefore {
+				if appErr := a.RemoveFile(object.path); appErr != nil {
+					return errors.Wrapf(appErr, "failed to remove the cold storage object %s", object.path)
+				}
+				continue
+			}
+			if err := a.rewriteReadReceiptColdStorageObject(channelID, object, dropExpired); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteColdStorageReadReceiptsForUser removes the offloaded receipts of the user.
+func (a *App) deleteColdStorageReadReceiptsForUser(userID string) error {
+	return a.rewriteReadReceiptColdStorage(nil, func(receipt *model.PostReadReceipt) *model.PostReadReceipt {
+		if receipt.UserId == userID {
+			return nil
+		}
+		return receipt
+	})
+}
+
+// scrubColdStorageReadReceiptsForUser clears the device and session of the
+// offloaded receipts of the user, as ScrubUserReceipts does in the database.
+func (a *App) scrubColdStorageReadReceiptsForUser(userID string) error {
+	return a.rewriteReadReceiptColdStorage(nil, func(receipt *model.PostReadReceipt) *model.PostReadReceipt {
+		if receipt.UserId != userID || (receipt.DeviceId == "" && receipt.SessionId == "") {
+			return receipt
+		}
+		scrubbed := *receipt
+		scrubbed.DeviceId = ""
+		scrubbed.SessionId = ""
+		return &scrubbed
+	})
+}
+
+// deleteColdStorageReadReceiptsForPosts removes the offloaded receipts of the
+// posts of the channel.
+func (a *App) deleteColdStorageReadReceiptsForPosts(channelID string, postIDs []string) error {
+	return a.rewriteReadReceiptColdStorage([]string{channelID}, func(receipt *model.PostReadReceipt) *model.PostReadReceipt {
+		if slices.Contains(postIDs, receipt.PostId) {
+			return nil
+		}
+		return receipt
+	})
+}
+
+// deleteColdStorageReadReceiptsForChannel removes every offloaded receipt of the
+// channel.
+func (a *App) deleteColdStorageReadReceiptsForChannel(channelID string) error {
+	if appErr := a.RemoveDirectory(path.Join(readReceiptColdStorageDir, channelID)); appErr != nil {
+		return appErr
+	}
+	return nil
+}
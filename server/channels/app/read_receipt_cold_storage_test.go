@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOffloadReadReceiptsToColdStorage(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsColdStorageDays = 30
+	})
+
+	oldReadAt := time.Now().Add(-60 * 24 * time.Hour).UnixMilli()
+	saveReceipt := func(post *model.Post, readAt int64) {
+		_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    th.BasicUser.Id,
+			ChannelId: post.ChannelId,
+			ReadAt:    readAt,
+			DeviceId:  "laptop",
+		})
+		require.NoError(t, err)
+	}
+
+	oldPost := th.CreatePost(th.BasicChannel)
+	recentPost := th.CreatePost(th.BasicChannel)
+	saveReceipt(oldPost, oldReadAt)
+	saveReceipt(recentPost, model.GetMillis())
+
+	offloaded, err := th.App.OffloadReadReceiptsToColdStorage(model.ReadReceiptsPageCursor{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), offloaded)
+
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(oldPost.Id, th.BasicUser.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+	_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(recentPost.Id, th.BasicUser.Id)
+	require.NoError(t, err)
+
+	result, appErr := th.App.GetColdStorageReadReceipts(th.Context, model.ReadReceiptColdStorageQuery{ChannelId: th.BasicChannel.Id})
+	require.Nil(t, appErr)
+	require.Len(t, result.Receipts, 1)
+	assert.Equal(t, oldPost.Id, result.Receipts[0].PostId)
+	assert.Equal(t, oldReadAt, result.Receipts[0].ReadAt)
+	assert.Equal(t, "laptop", result.Receipts[0].DeviceId)
+
+	t.Run("objects outside the range are skipped", func(t *testing.T) {
+		result, appErr := th.App.GetColdStorageReadReceipts(th.Context, model.ReadReceiptColdStorageQuery{
+			ChannelId: th.BasicChannel.Id,
+			Until:     oldReadAt - 1,
+		})
+		require.Nil(t, appErr)
+		assert.Empty(t, result.Receipts)
+	})
+
+	t.Run("other channels", func(t *testing.T) {
+		result, appErr := th.App.GetColdStorageReadReceipts(th.Context, model.ReadReceiptColdStorageQuery{ChannelId: model.NewId()})
+		require.Nil(t, appErr)
+		assert.Empty(t, result.Receipts)
+	})
+}
+
+func TestParseReadReceiptColdStorageObject(t *testing.T) {
+	receipts := []*model.PostReadReceipt{{ReadAt: 3000}, {ReadAt: 1000}, {ReadAt: 2000}}
+	object := newReadReceiptColdStorageObject("channel", receipts)
+
+	parsed, ok := parseReadReceiptColdStorageObject(object.path)
+	require.True(t, ok)
+	assert.Equal(t, object, parsed)
+	assert.Equal(t, int64(1000), parsed.firstRead)
+	assert.Equal(t, int64(3000), parsed.lastRead)
+
+	_, ok = parseReadReceiptColdStorageObject("read_receipts/cold_storage/channel/notes.txt")
+	assert.False(t, ok)
+	_, ok = parseReadReceiptColdStorageObject("read_receipts/cold_storage/channel/a_b_c.jsonl.gz")
+	assert.False(t, ok)
+}
+
+func TestDeleteColdStorageReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	oldReadAt := time.Now().Add(-60 * 24 * time.Hour).UnixMilli()
+	offload := func(receipts ...*model.PostReadReceipt) {
+		require.NoError(t, th.App.writeReadReceiptColdStorageObject(receipts[0].ChannelId, receipts))
+	}
+	newReceipt := func(post *model.Post, user *model.User, readAt int64) *model.PostReadReceipt {
+		return &model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    user.Id,
+			ChannelId: post.ChannelId,
+			ReadAt:    readAt,
+			DeviceId:  "laptop",
+			SessionId: model.NewId(),
+		}
+	}
+	coldReceipts := func(channelID string) []*model.PostReadReceipt {
+		result, appErr := th.App.GetColdStorageReadReceipts(th.Context, model.ReadReceiptColdStorageQuery{ChannelId: channelID})
+		require.Nil(t, appErr)
+		return result.Receipts
+	}
+
+	t.Run("retention", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsRetentionDays = 30
+		})
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		expiredPost := th.CreatePost(channel)
+		keptPost := th.CreatePost(channel)
+		offload(newReceipt(expiredPost, th.BasicUser, oldReadAt))
+		offload(newReceipt(expiredPost, th.BasicUser2, oldReadAt), newReceipt(keptPost, th.BasicUser, time.Now().Add(-10*24*time.Hour).UnixMilli()))
+
+		_, err := th.App.DeleteExpiredReadReceipts(model.ReadReceiptsPageCursor{}, nil)
+		require.NoError(t, err)
+
+		receipts := coldReceipts(channel.Id)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, keptPost.Id, receipts[0].PostId)
+	})
+
+	t.Run("user deletion", func(t *testing.T) {
+		user := th.CreateUser()
+		post := th.CreatePost(th.BasicChannel)
+		offload(newReceipt(post, user, oldReadAt), newReceipt(post, th.BasicUser, oldReadAt))
+
+		require.Nil(t, th.App.PermanentDeleteUser(th.Context, user))
+
+		for _, receipt := range coldReceipts(th.BasicChannel.Id) {
+			assert.NotEqual(t, user.Id, receipt.UserId)
+		}
+	})
+
+	t.Run("scrub", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsDeactivationScrubDays = 7
+		})
+		user := th.CreateUser()
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		post := th.CreatePost(channel)
+		offload(newReceipt(post, user, oldReadAt))
+
+		_, appErr := th.App.UpdateActive(th.Context, user, false)
+		require.Nil(t, appErr)
+		err := th.App.Srv().Store().PostReadReceipt().FlagUserReceiptsForScrub(user.Id, time.Now().Add(-8*24*time.Hour).UnixMilli())
+		require.NoError(t, err)
+
+		_, err = th.App.ScrubDeactivatedUsersReadReceipts()
+		require.NoError(t, err)
+
+		receipts := coldReceipts(channel.Id)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, user.Id, receipts[0].UserId)
+		assert.Empty(t, receipts[0].DeviceId)
+		assert.Empty(t, receipts[0].SessionId)
+	})
+
+	t.Run("post deletion", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		root := th.CreatePost(channel)
+		reply := th.CreatePost(channel, func(p *model.Post) {
+			p.RootId = root.Id
+		})
+		other := th.CreatePost(channel)
+		offload(newReceipt(root, th.BasicUser, oldReadAt), newReceipt(reply, th.BasicUser, oldReadAt), newReceipt(other, th.BasicUser, oldReadAt))
+
+		_, appErr := th.App.DeletePost(th.Context, root.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			receipts := coldReceipts(channel.Id)
+			return len(receipts) == 1 && receipts[0].PostId == other.Id
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("channel deletion", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		post := th.CreatePost(channel)
+		offload(newReceipt(post, th.BasicUser, oldReadAt))
+
+		require.Nil(t, th.App.PermanentDeleteChannel(th.Context, channel))
+
+		assert.Empty(t, coldReceipts(channel.Id))
+	})
+}
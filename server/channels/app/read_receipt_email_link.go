@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// readReceiptEmailLinkTokenTTL is how long the permalink of a notification email
+// records a receipt when opened.
+const readReceiptEmailLinkTokenTTL = 30 * 24 * time.Hour
+
+// readReceiptEmailLinkKey derives the key signing the email link tokens from the
+// cluster wide post action secret, so that every server accepts them.
+func (a *App) readReceiptEmailLinkKey() []byte {
+	mac := hmac.New(sha256.New, a.PostActionCookieSecret())
+	mac.Write([]byte("read_receipt_email_link"))
+	return mac.Sum(nil)
+}
+
+func (a *App) signReadReceiptEmailLink(userID, postID string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, a.readReceiptEmailLinkKey())
+	mac.Write([]byte(userID + ":" + postID + ":" + strconv.FormatInt(expiresAt, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// readReceiptEmailLinkToken returns the token the permalink of the post carries
+// in the notification email sent to the user.
+func (a *App) readReceiptEmailLinkToken(userID, postID string) string {
+	expiresAt := time.Now().Add(readReceiptEmailLinkTokenTTL).UnixMilli()
+	return strconv.FormatInt(expiresAt, 10) + "." + a.signReadReceiptEmailLink(userID, postID, expiresAt)
+}
+
+// addReadReceiptEmailLinkToken appends the email link token to the permalink of
+// the post in the notification email sent to the user, when receipts are enabled.
+func (a *App) addReadReceiptEmailLinkToken(permalink, userID, postID string) string {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return permalink
+	}
+
+	return permalink + "?" + model.ReadReceiptEmailLinkTokenParam + "=" + url.QueryEscape(a.readReceiptEmailLinkToken(userID, postID))
+}
+
+func (a *App) validReadReceiptEmailLinkToken(userID, postID, token string) bool {
+	expiresAtString, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expiresAtString, 10, 64)
+	if err != nil || expiresAt < model.GetMillis() {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(a.signReadReceiptEmailLink(userID, postID, expiresAt)))
+}
+
+// SaveEmailLinkReadReceipt records that the user opened the post from the
+// permalink of a notification email sent to them. Like the other implicit reads,
+// nothing is recorded when the user already read the post, in which case the
+// returned receipt is nil.
+func (a *App) SaveEmailLinkReadReceipt(c request.CTX, userID, postID, token string) (*model.PostReadReceipt, *model.AppError) {
+	if !a.validReadReceiptEmailLinkToken(userID, postID, token) {
+		return nil, model.NewAppError("SaveEmailLinkReadReceipt", "api.read_receipt.email_link.invalid_token.app_error", nil, "post_id="+postID, http.StatusBadRequest)
+	}
+
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+	channel, appErr := a.GetChannel(c, post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.saveImplicitReadReceipt(c, userID, post, channel, model.ReadReceiptSourceEmailLink)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSaveEmailLinkReadReceipt(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	t.Run("the permalink carries the token", func(t *testing.T) {
+		permalink := th.App.addReadReceiptEmailLinkToken("http://localhost/landing#/team/pl/"+th.BasicPost.Id, th.BasicUser.Id, th.BasicPost.Id)
+		parsed, err := url.Parse(permalink)
+		require.NoError(t, err)
+
+		fragment, err := url.Parse(parsed.Fragment)
+		require.NoError(t, err)
+		assert.Equal(t, "/team/pl/"+th.BasicPost.Id, fragment.Path)
+		assert.True(t, th.App.validReadReceiptEmailLinkToken(th.BasicUser.Id, th.BasicPost.Id, fragment.Query().Get(model.ReadReceiptEmailLinkTokenParam)))
+	})
+
+	t.Run("records the receipt once", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		token := th.App.readReceiptEmailLinkToken(th.BasicUser.Id, post.Id)
+
+		receipt, appErr := th.App.SaveEmailLinkReadReceipt(th.Context, th.BasicUser.Id, post.Id, token)
+		require.Nil(t, appErr)
+		require.NotNil(t, receipt)
+		assert.Equal(t, model.ReadReceiptSourceEmailLink, receipt.Source)
+
+		receipt, appErr = th.App.SaveEmailLinkReadReceipt(th.Context, th.BasicUser.Id, post.Id, token)
+		require.Nil(t, appErr)
+		assert.Nil(t, receipt)
+	})
+
+	t.Run("tokens of other users are rejected", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		token := th.App.readReceiptEmailLinkToken(th.BasicUser2.Id, post.Id)
+
+		_, appErr := th.App.SaveEmailLinkReadReceipt(th.Context, th.BasicUser.Id, post.Id, token)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("expired tokens are rejected", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		expiresAt := time.Now().Add(-time.Minute).UnixMilli()
+		token := strconv.FormatInt(expiresAt, 10) + "." + th.App.signReadReceiptEmailLink(th.BasicUser.Id, post.Id, expiresAt)
+
+		_, appErr := th.App.SaveEmailLinkReadReceipt(th.Context, th.BasicUser.Id, post.Id, token)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.read_receipt.email_link.invalid_token.app_error", appErr.Id)
+	})
+
+	t.Run("malformed tokens are rejected", func(t *testing.T) {
+		for _, token := range []string{"", "garbage", "123.", ".abc"} {
+			assert.False(t, th.App.validReadReceiptEmailLinkToken(th.BasicUser.Id, th.BasicPost.Id, token), token)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const (
+	readReceiptExportsCacheSize = 10000
+	readReceiptExportPageSize   = 1000
+)
+
+// readReceiptExportInterval is how long a user waits between two exports of
+// their receipts.
+var readReceiptExportInterval = time.Hour
+
+var readReceiptExportCSVHeader = []string{"post_id", "channel_id", "read_at", "device_type", "device_id", "source"}
+
+// ReserveReadReceiptExport records that the user is exporting their receipts,
+// failing when they already did within the last readReceiptExportInterval.
+func (a *App) ReserveReadReceiptExport(c request.CTX, userID string) *model.AppError {
+	var exportedAt int64
+	err := a.Srv().readReceiptExportsCache.Get(userID, &exportedAt)
+	if err == nil {
+		return model.NewAppError("ReserveReadReceiptExport", "app.read_receipt.export.too_many_requests.app_error", nil, "", http.StatusTooManyRequests)
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		return model.NewAppError("ReserveReadReceiptExport", "app.read_receipt.export.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().readReceiptExportsCache.SetWithExpiry(userID, model.GetMillis(), readReceiptExportInterval); err != nil {
+		return model.NewAppError("ReserveReadReceiptExport", "app.read_receipt.export.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// ExportReadReceiptsForUser writes every receipt of the user to w in the given
+// ReadReceiptExportFormat, most recent first. Receipts are read and written a
+// page at a time so that large histories are never held in memory.
+func (a *App) ExportReadReceiptsForUser(c request.CTX, userID, format string, w io.Writer) (int, error) {
+	var writeReceipts func(receipts []*model.PostReadReceipt) error
+	var finish func() error
+
+	switch format {
+	case model.ReadReceiptExportFormatCSV:
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(readReceiptExportCSVHeader); err != nil {
+			return 0, errors.Wrap(err, "failed to write the csv header")
+		}
+		writeReceipts = func(receipts []*model.PostReadReceipt) error {
+			for _, receipt := range receipts {
+				if err := csvWriter.Write([]string{
+					receipt.PostId,
+					receipt.ChannelId,
+					strconv.FormatInt(receipt.ReadAt, 10),
+					receipt.DeviceType,
+					receipt.DeviceId,
+					receipt.Source,
+				}); err != nil {
+					return err
+				}
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+		finish = func() error { return nil }
+	default:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, errors.Wrap(err, "failed to start the json array")
+		}
+		first := true
+		writeReceipts = func(receipts []*model.PostReadReceipt) error {
+			for _, receipt := range receipts {
+				js, err := json.Marshal(receipt)
+				if err != nil {
+					return err
+				}
+				if !first {
+					js = append([]byte(","), js...)
+				}
+				first = false
+				if _, err := w.Write(js); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		finish = func() error {
+			_, err := io.WriteString(w, "]")
+			return err
+		}
+	}
+
+	iterator := &readReceiptPageIterator[model.ReadReceiptCursor, *model.PostReadReceipt]{
+		fetch: func(cursor model.ReadReceiptCursor, limit int) ([]*model.PostReadReceipt, error) {
+			return a.Srv().Store().PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{Cursor: cursor, PerPage: limit})
+		},
+		cursorAfter: func(receipt *model.PostReadReceipt) model.ReadReceiptCursor {
+			return model.ReadReceiptCursor{ReadAt: receipt.ReadAt, PostId: receipt.PostId}
+		},
+		limit: readReceiptExportPageSize,
+	}
+
+	count, err := iterator.run(model.ReadReceiptCursor{}, func(receipts []*model.PostReadReceipt) error {
+		// Sessions are not part of the user's own data.
+		for _, receipt := range receipts {
+			receipt.SessionId = ""
+		}
+
+		if err := writeReceipts(receipts); err != nil {
+			return errors.Wrap(err, "failed to write the exported receipts")
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return count, errors.Wrap(err, "failed to export the receipts")
+	}
+
+	if err := finish(); err != nil {
+		return count, errors.Wrap(err, "failed to finish the export")
+	}
+
+	return count, nil
+}
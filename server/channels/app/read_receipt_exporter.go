@@ -0,0 +1,472 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// readReceiptExportBatchSize is the maximum number of receipts handed to the
+// sink at once.
+const readReceiptExportBatchSize = 100
+
+// readReceiptExportBackoff is how long to wait after each failed export before
+// trying again. Receipts still failing afterwards go to the dead letter file.
+var readReceiptExportBackoff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+// ReadReceiptSink receives the receipts streamed to an external system, like a
+// data warehouse, as selected by ServiceSettings.ReadReceiptsExportSink. Failed
+// exports are retried, so sinks must accept the same receipts more than once.
+type ReadReceiptSink interface {
+	Export(receipts []*model.PostReadReceipt) error
+}
+
+// ReadReceiptSinkFactory builds a sink from the configuration of the app.
+type ReadReceiptSinkFactory func(a *App) (ReadReceiptSink, error)
+
+var readReceiptSinkFactories = map[string]ReadReceiptSinkFactory{
+	model.ReadReceiptsExportSinkWebhook: newReadReceiptWebhookSink,
+	model.ReadReceiptsExportSinkFile:    newReadReceiptFileSink,
+}
+
+// RegisterReadReceiptSink makes a sink, like a Kafka producer, available to
+// ServiceSettings.ReadReceiptsExportSink under name. It must be called before the
+// server starts.
+func RegisterReadReceiptSink(name string, factory ReadReceiptSinkFactory) {
+	readReceiptSinkFactories[name] = factory
+}
+
+type readReceiptExportSettings struct {
+	sink           string
+	webhookURL     string
+	filePath       string
+	bufferSize     int
+	deadLetterPath string
+}
+
+func readReceiptExportSettingsFromConfig(cfg *model.Config) readReceiptExportSettings {
+	return readReceiptExportSettings{
+		sink:           *cfg.ServiceSettings.ReadReceiptsExportSink,
+		webhookURL:     *cfg.ServiceSettings.ReadReceiptsExportWebhookURL,
+		filePath:       *cfg.ServiceSettings.ReadReceiptsExportFilePath,
+		bufferSize:     *cfg.ServiceSettings.ReadReceiptsExportBufferSize,
+		deadLetterPath: *cfg.ServiceSettings.ReadReceiptsExportDeadLetterPath,
+	}
+}
+
+// readReceiptExportSpoolMut guards the spool files of the exporters and their
+// checkpoints, which an exporter replacing another one after a configuration
+// change shares with it.
+var readReceiptExportSpoolMut sync.Mutex
+
+// readReceiptExportSpoolPath is the spool file of the exporters writing to the
+// dead letter file at deadLetterPath. Its checkpoint is next to it.
+func readReceiptExportSpoolPath(deadLetterPath string) string {
+	return deadLetterPath + ".pending"
+}
+
+// readReceiptExporter streams the saved receipts to a sink in the background,
+// so that saving receipts never waits on the external system. Receipts are
+// delivered at least once: they are appended to a spool file before enqueue
+// returns, and the offset of the spool exported so far is only checkpointed once
+// the sink accepted them, so that a restarted server resumes where the previous
+// one stopped. Failed exports are retried with backoff, and the receipts that
+// still cannot be exported, or that do not fit in the buffer, are appended to the
+// dead letter file, one JSON receipt per line, to be replayed.
+type readReceiptExporter struct {
+	sink           ReadReceiptSink
+	deadLetterPath string
+	spoolPath      string
+	bufferSize     int
+	backoff        []time.Duration
+	logger         mlog.LoggerIFace
+
+	// pending is the number of spooled receipts not exported yet.
+	pending       atomic.Int64
+	wake          chan struct{}
+	deadLetterMut sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newReadReceiptExporter(sink ReadReceiptSink, bufferSize int, deadLetterPath string, logger mlog.LoggerIFace) *readReceiptExporter {
+	return &readReceiptExporter{
+		sink:           sink,
+		deadLetterPath: deadLetterPath,
+		spoolPath:      readReceiptExportSpoolPath(deadLetterPath),
+		bufferSize:     bufferSize,
+		backoff:        readReceiptExportBackoff,
+		logger:         logger,
+		wake:           make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// start resumes the export of the spool from its checkpoint.
+func (e *readReceiptExporter) start() {
+	offset := e.loadCheckpoint()
+	go e.loop(offset)
+}
+
+// stopAndFlush stops the export loop once the spooled receipts were handed to the
+// sink, without retrying them. Those the sink rejects stay in the spool for the
+// next exporter.
+func (e *readReceiptExporter) stopAndFlush() {
+	close(e.stop)
+	<-e.done
+}
+
+// enqueue spools copies of the receipts for export, sending those that do not fit
+// in the buffer straight to the dead letter file.
+func (e *readReceiptExporter) enqueue(receipts []*model.PostReadReceipt) {
+	copies := make([]*model.PostReadReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		copied := *receipt
+		copies = append(copies, &copied)
+	}
+
+	fit := max(int64(e.bufferSize)-e.pending.Load(), 0)
+	if int64(len(copies)) > fit {
+		e.deadLetter(copies[fit:], errors.New("the export buffer is full"))
+		copies = copies[:fit]
+	}
+	if len(copies) == 0 {
+		return
+	}
+
+	readReceiptExportSpoolMut.Lock()
+	err := appendReadReceiptsToFile(e.spoolPath, copies)
+	readReceiptExportSpoolMut.Unlock()
+	if err != nil {
+		e.deadLetter(copies, errors.Wrap(err, "failed to spool the receipts"))
+		return
+	}
+	e.pending.Add(int64(len(copies)))
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (e *readReceiptExporter) loop(offset int64) {
+	defer close(e.done)
+
+	for {
+		offset = e.drain(offset, e.backoff)
+		select {
+		case <-e.wake:
+		case <-e.stop:
+			// A single attempt, not to hold the shutdown.
+			e.drain(offset, []time.Duration{0})
+			return
+		}
+	}
+}
+
+// drain exports the spool from offset, a batch at a time, and returns the offset
+// it stopped at. Once the whole spool is exported, it is emptied and the offset
+// starts over.
+func (e *readReceiptExporter) drain(offset int64, backoff []time.Duration) int64 {
+	for {
+		batch, next, err := e.readSpool(offset)
+		if err != nil {
+			e.logger.Error("Failed to read the read receipt export spool", mlog.String("path", e.spoolPath), mlog.Err(err))
+			return offset
+		}
+		if next == 0 || len(batch) == 0 {
+			return next
+		}
+
+		if !e.export(batch, backoff) {
+			return offset
+		}
+		e.pending.Add(-int64(len(batch)))
+		offset = next
+		if err := e.saveCheckpoint(offset); err != nil {
+			e.logger.Warn("Failed to checkpoint the read receipt export, the receipts will be exported again", mlog.String("path", e.spoolPath), mlog.Err(err))
+		}
+	}
+}
+
+// readSpool returns the receipts spooled from offset, up to
+// readReceiptExportBatchSize, along with the offset that follows them. When
+// offset is the end of the spool, the spool is emptied and 0 is returned.
+func (e *readReceiptExporter) readSpool(offset int64) ([]*model.PostReadReceipt, int64, error) {
+	readReceiptExportSpoolMut.Lock()
+	defer readReceiptExportSpoolMut.Unlock()
+
+	file, err := os.Open(e.spoolPath)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, offset, errors.Wrapf(err, "failed to open %s", e.spoolPath)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, offset, errors.Wrapf(err, "failed to stat %s", e.spoolPath)
+	}
+	if offset >= info.Size() {
+		if err := os.Truncate(e.spoolPath, 0); err != nil {
+			return nil, offset, errors.Wrapf(err, "failed to empty %s", e.spoolPath)
+		}
+		return nil, 0, e.saveCheckpoint(0)
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, errors.Wrapf(err, "failed to seek %s", e.spoolPath)
+	}
+	reader := bufio.NewReader(file)
+	var batch []*model.PostReadReceipt
+	for len(batch) < readReceiptExportBatchSize {
+		line, err := reader.ReadBytes('\n')
+		offset += int64(len(line))
+		if len(line) > 0 {
+			var receipt model.PostReadReceipt
+			if jsonErr := json.Unmarshal(line, &receipt); jsonErr != nil {
+				// Only a crash in the middle of an append leaves such a line behind.
+				e.logger.Warn("Skipping a corrupted line of the read receipt export spool", mlog.String("path", e.spoolPath), mlog.Err(jsonErr))
+			} else {
+				batch = append(batch, &receipt)
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, offset, errors.Wrapf(err, "failed to read %s", e.spoolPath)
+		}
+	}
+
+	return batch, offset, nil
+}
+
+// checkpointPath is the file holding the offset of the spool exported so far.
+func (e *readReceiptExporter) checkpointPath() string {
+	return e.spoolPath + ".offset"
+}
+
+func (e *readReceiptExporter) saveCheckpoint(offset int64) error {
+	return os.WriteFile(e.checkpointPath(), []byte(strconv.FormatInt(offset, 10)), 0600)
+}
+
+// loadCheckpoint returns the offset of the spool to resume from, and counts the
+// receipts left to export after it.
+func (e *readReceiptExporter) loadCheckpoint() int64 {
+	readReceiptExportSpoolMut.Lock()
+	defer readReceiptExportSpoolMut.Unlock()
+
+	var offset int64
+	if data, err := os.ReadFile(e.checkpointPath()); err == nil {
+		offset, _ = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+
+	spool, err := os.ReadFile(e.spoolPath)
+	if err != nil || offset < 0 || offset > int64(len(spool)) {
+		offset = 0
+	}
+	if err == nil {
+		e.pending.Store(int64(bytes.Count(spool[offset:], []byte("\n"))))
+	}
+
+	return offset
+}
+
+// export hands the batch to the sink, retrying with backoff, and sends it to the
+// dead letter file when the sink keeps failing. It reports whether the batch is
+// safely out of the spool.
+func (e *readReceiptExporter) export(batch []*model.PostReadReceipt, backoff []time.Duration) bool {
+	err := utils.CustomProgressiveRetry(func() error {
+		return e.sink.Export(batch)
+	}, backoff)
+	if err == nil {
+		return true
+	}
+
+	select {
+	case <-e.stop:
+		// The next exporter retries them.
+		return false
+	default:
+	}
+	return e.deadLetter(batch, err)
+}
+
+// deadLetter appends the receipts to the dead letter file and reports whether it
+// succeeded.
+func (e *readReceiptExporter) deadLetter(receipts []*model.PostReadReceipt, reason error) bool {
+	e.logger.Warn("Failed to export read receipts, writing them to the dead letter file", mlog.Int("count", len(receipts)), mlog.String("path", e.deadLetterPath), mlog.Err(reason))
+
+	e.deadLetterMut.Lock()
+	defer e.deadLetterMut.Unlock()
+
+	if err := appendReadReceiptsToFile(e.deadLetterPath, receipts); err != nil {
+		e.logger.Error("Failed to write read receipts to the dead letter file", mlog.Int("count", len(receipts)), mlog.String("path", e.deadLetterPath), mlog.Err(err))
+		return false
+	}
+	return true
+}
+
+// appendReadReceiptsToFile appends the receipts to the file, one JSON receipt
+// per line, creating the file if needed.
+func appendReadReceiptsToFile(path string, receipts []*model.PostReadReceipt) (err error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = errors.Wrapf(closeErr, "failed to close %s", path)
+		}
+	}()
+
+	encoder := json.NewEncoder(file)
+	for _, receipt := range receipts {
+		if err := encoder.Encode(receipt); err != nil {
+			return errors.Wrapf(err, "failed to write to %s", path)
+		}
+	}
+
+	return nil
+}
+
+// startReadReceiptExporter replaces the running exporter with one for the given
+// settings, or none when no sink is set, handing the receipts still queued in
+// the previous one to its sink first.
+func (ch *Channels) startReadReceiptExporter(settings readReceiptExportSettings) {
+	var exporter *readReceiptExporter
+	if settings.sink != "" {
+		exporter = ch.newReadReceiptExporter(settings)
+	}
+
+	if previous := ch.readReceiptExporter.Swap(exporter); previous != nil {
+		previous.stopAndFlush()
+	}
+	// Started once the previous exporter stopped, so that both do not export
+	// the same spool.
+	if exporter != nil {
+		exporter.start()
+	}
+}
+
+func (ch *Channels) newReadReceiptExporter(settings readReceiptExportSettings) *readReceiptExporter {
+	logger := ch.srv.Log().With(mlog.String("sink", settings.sink))
+
+	factory, ok := readReceiptSinkFactories[settings.sink]
+	if !ok {
+		logger.Error("Unknown read receipt export sink, receipts are not exported")
+		return nil
+	}
+
+	sink, err := factory(New(ServerConnector(ch)))
+	if err != nil {
+		logger.Error("Failed to create the read receipt export sink, receipts are not exported", mlog.Err(err))
+		return nil
+	}
+
+	return newReadReceiptExporter(sink, settings.bufferSize, settings.deadLetterPath, logger)
+}
+
+// exportReadReceipts queues receipts newly saved in the channel for the export
+// sink, if any, unless the policy governing the channel leaves them out of exports.
+func (a *App) exportReadReceipts(c request.CTX, channelID string, receipts []*model.PostReadReceipt) {
+	exporter := a.ch.readReceiptExporter.Load()
+	if exporter == nil {
+		return
+	}
+
+	policy, appErr := a.readReceiptPolicyForChannel(c, channelID)
+	if appErr != nil {
+		// Rather export receipts a policy excludes than lose them.
+		c.Logger().Warn("Failed to resolve the read receipt policy of the exported receipts", mlog.String("channel_id", channelID), mlog.Err(appErr))
+	} else if policy != nil && !policy.IncludeInExport {
+		return
+	}
+
+	exporter.enqueue(receipts)
+}
+
+// readReceiptWebhookSink POSTs each batch of receipts as a JSON array to
+// ServiceSettings.ReadReceiptsExportWebhookURL.
+type readReceiptWebhookSink struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+func newReadReceiptWebhookSink(a *App) (ReadReceiptSink, error) {
+	return &readReceiptWebhookSink{
+		url:     *a.Config().ServiceSettings.ReadReceiptsExportWebhookURL,
+		client:  a.Srv().outgoingWebhookClient,
+		timeout: time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout) * time.Second,
+	}, nil
+}
+
+func (s *readReceiptWebhookSink) Export(receipts []*model.PostReadReceipt) error {
+	body, err := json.Marshal(receipts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, MaxIntegrationResponseSize)); err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// readReceiptFileSink appends the receipts to ServiceSettings.ReadReceiptsExportFilePath,
+// one JSON receipt per line, for collectors tailing the file.
+type readReceiptFileSink struct {
+	path string
+}
+
+func newReadReceiptFileSink(a *App) (ReadReceiptSink, error) {
+	return &readReceiptFileSink{path: *a.Config().ServiceSettings.ReadReceiptsExportFilePath}, nil
+}
+
+func (s *readReceiptFileSink) Export(receipts []*model.PostReadReceipt) error {
+	return appendReadReceiptsToFile(s.path, receipts)
+}
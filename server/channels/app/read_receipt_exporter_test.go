@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type testReadReceiptSink struct {
+	mut      sync.Mutex
+	err      error
+	exported []*model.PostReadReceipt
+}
+
+func (s *testReadReceiptSink) Export(receipts []*model.PostReadReceipt) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.exported = append(s.exported, receipts...)
+	return nil
+}
+
+func readReceiptsFromFile(t *testing.T, path string) []*model.PostReadReceipt {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var receipts []*model.PostReadReceipt
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var receipt model.PostReadReceipt
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &receipt))
+		receipts = append(receipts, &receipt)
+	}
+	require.NoError(t, scanner.Err())
+
+	return receipts
+}
+
+func TestReadReceiptExporter(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	receipts := []*model.PostReadReceipt{
+		{PostId: model.NewId(), UserId: model.NewId(), ReadAt: 1000},
+		{PostId: model.NewId(), UserId: model.NewId(), ReadAt: 2000},
+	}
+
+	t.Run("exports the queued receipts", func(t *testing.T) {
+		sink := &testReadReceiptSink{}
+		deadLetterPath := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+		exporter := newReadReceiptExporter(sink, 10, deadLetterPath, logger)
+		exporter.start()
+
+		exporter.enqueue(receipts)
+		exporter.stopAndFlush()
+
+		assert.Equal(t, receipts, sink.exported)
+		assert.NoFileExists(t, deadLetterPath)
+	})
+
+	t.Run("failed exports go to the dead letter file", func(t *testing.T) {
+		sink := &testReadReceiptSink{err: errors.New("unavailable")}
+		deadLetterPath := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+		exporter := newReadReceiptExporter(sink, 10, deadLetterPath, logger)
+		exporter.backoff = []time.Duration{0, 0}
+		exporter.start()
+
+		exporter.enqueue(receipts)
+		require.Eventually(t, func() bool {
+			return exporter.pending.Load() == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		exporter.stopAndFlush()
+
+		assert.Empty(t, sink.exported)
+		assert.Equal(t, receipts, readReceiptsFromFile(t, deadLetterPath))
+	})
+
+	t.Run("spooled receipts are exported after a crash", func(t *testing.T) {
+		deadLetterPath := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+		// Never started, like a server crashing before the export.
+		crashed := newReadReceiptExporter(&testReadReceiptSink{}, 10, deadLetterPath, logger)
+		crashed.enqueue(receipts)
+
+		sink := &testReadReceiptSink{}
+		exporter := newReadReceiptExporter(sink, 10, deadLetterPath, logger)
+		exporter.start()
+		exporter.stopAndFlush()
+
+		assert.Equal(t, receipts, sink.exported)
+		assert.NoFileExists(t, deadLetterPath)
+
+		// The exported receipts are not exported again.
+		sink = &testReadReceiptSink{}
+		exporter = newReadReceiptExporter(sink, 10, deadLetterPath, logger)
+		exporter.start()
+		exporter.stopAndFlush()
+
+		assert.Empty(t, sink.exported)
+	})
+
+	t.Run("receipts over the buffer go to the dead letter file", func(t *testing.T) {
+		sink := &testReadReceiptSink{}
+		deadLetterPath := filepath.Join(t.TempDir(), "dead_letter.jsonl")
+		exporter := newReadReceiptExporter(sink, 1, deadLetterPath, logger)
+
+		exporter.enqueue(receipts)
+		exporter.start()
+		exporter.stopAndFlush()
+
+		assert.Equal(t, receipts[:1], sink.exported)
+		assert.Equal(t, receipts[1:], readReceiptsFromFile(t, deadLetterPath))
+	})
+}
+
+func TestReadReceiptFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "receipts.jsonl")
+	sink := &readReceiptFileSink{path: path}
+
+	first := &model.PostReadReceipt{PostId: model.NewId(), UserId: model.NewId(), ReadAt: 1000}
+	second := &model.PostReadReceipt{PostId: model.NewId(), UserId: model.NewId(), ReadAt: 2000}
+	require.NoError(t, sink.Export([]*model.PostReadReceipt{first}))
+	require.NoError(t, sink.Export([]*model.PostReadReceipt{second}))
+
+	assert.Equal(t, []*model.PostReadReceipt{first, second}, readReceiptsFromFile(t, path))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/pkg/errors"
+)
+
+// readReceiptPageIterator walks rows a bounded page at a time using keyset
+// pagination, so that the maintenance jobs never run a single statement over a
+// whole table. C is the cursor type of the store method and T the row type.
+type readReceiptPageIterator[C, T any] struct {
+	// fetch returns at most limit rows following cursor.
+	fetch func(cursor C, limit int) ([]T, error)
+	// cursorAfter returns the cursor following row.
+	cursorAfter func(row T) C
+	limit       int
+}
+
+// run processes every row following cursor and returns how many were processed.
+// After each page, checkpoint, if set, is given the cursor the iteration would
+// resume from, so that an interrupted job does not start over. Since the cursor
+// moves past the processed rows, process may delete them.
+func (it *readReceiptPageIterator[C, T]) run(cursor C, process func(rows []T) error, checkpoint func(cursor C) error) (int, error) {
+	count := 0
+	for {
+		rows, err := it.fetch(cursor, it.limit)
+		if err != nil {
+			return count, errors.Wrap(err, "failed to fetch the next page")
+		}
+
+		if len(rows) > 0 {
+			if err := process(rows); err != nil {
+				return count, err
+			}
+			count += len(rows)
+
+			cursor = it.cursorAfter(rows[len(rows)-1])
+			if checkpoint != nil {
+				if err := checkpoint(cursor); err != nil {
+					return count, errors.Wrap(err, "failed to checkpoint the iteration")
+				}
+			}
+		}
+
+		if len(rows) < it.limit {
+			return count, nil
+		}
+	}
+}
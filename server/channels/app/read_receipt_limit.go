@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const readReceiptWatermarkChannelsCacheSize = 10000
+
+// readReceiptWatermarkCheckInterval is how long the mode of a channel is cached
+// before its receipts are counted against ReadReceiptsMaxPerChannel again.
+var readReceiptWatermarkCheckInterval = time.Minute
+
+// readReceiptWatermarkRecoveryPercent is the share of ReadReceiptsMaxPerChannel a
+// channel in watermark mode has to fall back to before it accepts per-post receipts
+// again, so that a channel around the limit doesn't keep switching.
+const readReceiptWatermarkRecoveryPercent = 90
+
+// ReadReceiptsDegradedForChannel reports whether the channel holds more receipts
+// than ReadReceiptsMaxPerChannel allows, in which case it only accepts watermark
+// receipts and clients should stop sending per-post ones. The channel leaves that
+// mode once retention or a raised limit brings it back under
+// readReceiptWatermarkRecoveryPercent of the limit. Every channel is in that mode
+// while the workspace is over its cloud receipt quota. Errors are logged and leave
+// the channel in its normal mode.
+func (a *App) ReadReceiptsDegradedForChannel(c request.CTX, channelID string) bool {
+	if a.ReadReceiptsOverCloudQuota(c) {
+		return true
+	}
+
+	limit := *a.Config().ServiceSettings.ReadReceiptsMaxPerChannel
+	if limit == 0 {
+		return false
+	}
+
+	var degraded bool
+	err := a.Srv().readReceiptWatermarkChannelsCache.Get(channelID, &degraded)
+	if err == nil {
+		return degraded
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		c.Logger().Warn("Failed to get the cached read receipt mode of the channel", mlog.String("channel_id", channelID), mlog.Err(err))
+	}
+
+	recoverAt := int64(limit) * readReceiptWatermarkRecoveryPercent / 100
+	degraded, err = a.Srv().Store().PostReadReceipt().UpdateChannelWatermarkMode(channelID, int64(limit), recoverAt)
+	if err != nil {
+		c.Logger().Warn("Failed to check the read receipt limit of the channel", mlog.String("channel_id", channelID), mlog.Err(err))
+		return false
+	}
+
+	if err := a.Srv().readReceiptWatermarkChannelsCache.SetWithExpiry(channelID, degraded, readReceiptWatermarkCheckInterval); err != nil {
+		c.Logger().Warn("Failed to cache the read receipt mode of the channel", mlog.String("channel_id", channelID), mlog.Err(err))
+	}
+
+	return degraded
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestReadReceiptsDegradedForChannel(t *testing.T) {
+	th := SetupWithStoreMock(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxPerChannel = 100 })
+
+	channelID := model.NewId()
+	mockStore := th.App.Srv().Store().(*storemocks.Store)
+	mockReceiptStore := storemocks.PostReadReceiptStore{}
+	mockReceiptStore.On("UpdateChannelWatermarkMode", channelID, int64(100), int64(90)).Return(true, nil).Once()
+	mockReceiptStore.On("UpdateChannelWatermarkMode", channelID, int64(100), int64(90)).Return(false, nil).Once()
+	mockStore.On("PostReadReceipt").Return(&mockReceiptStore)
+
+	require.True(t, th.App.ReadReceiptsDegradedForChannel(th.Context, channelID))
+
+	// The mode is cached until readReceiptWatermarkCheckInterval expires.
+	require.True(t, th.App.ReadReceiptsDegradedForChannel(th.Context, channelID))
+	require.NoError(t, th.App.Srv().readReceiptWatermarkChannelsCache.Remove(channelID))
+
+	// Once the channel is back under the recovery count, it accepts per-post receipts again.
+	require.False(t, th.App.ReadReceiptsDegradedForChannel(th.Context, channelID))
+	mockReceiptStore.AssertExpectations(t)
+
+	t.Run("without a limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsMaxPerChannel = 0 })
+
+		require.False(t, th.App.ReadReceiptsDegradedForChannel(th.Context, model.NewId()))
+	})
+}
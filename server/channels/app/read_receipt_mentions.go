@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// GetMentionReadStateForPost returns which of the users the post mentions by
+// username read it. Only the members of the channel of the post the viewer is
+// allowed to see are reported, and the author is left out, so that clients can
+// show how many of the people they mentioned have seen the post.
+func (a *App) GetMentionReadStateForPost(c request.CTX, viewerID, postID string) (*model.PostMentionReadState, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	state := &model.PostMentionReadState{PostId: post.Id, Read: []string{}, Unread: []string{}}
+
+	// Like the mention parser, try the mentions without their trailing punctuation
+	// too, so that "@user." mentions user.
+	var usernames []string
+	for _, mention := range possibleAtMentions(post.Message) {
+		usernames = append(usernames, mention)
+		for trimmed, ok := trimUsernameSpecialChar(mention); ok; trimmed, ok = trimUsernameSpecialChar(trimmed) {
+			usernames = append(usernames, trimmed)
+		}
+	}
+	if len(usernames) == 0 {
+		return state, nil
+	}
+
+	users, err := a.Srv().Store().User().GetProfilesByUsernames(usernames, nil)
+	if err != nil {
+		return nil, model.NewAppError("GetMentionReadStateForPost", "app.read_receipt.get_mention_read_state.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	users, appErr = filterReadersForViewer(c, a, viewerID, users, func(user *model.User) string { return user.Id })
+	if appErr != nil {
+		return nil, appErr
+	}
+	usersByName := make(map[string]*model.User, len(users))
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		if user.Id == post.UserId {
+			continue
+		}
+		usersByName[user.Username] = user
+		userIDs = append(userIDs, user.Id)
+	}
+	if len(userIDs) == 0 {
+		return state, nil
+	}
+
+	members, err := a.Srv().Store().Channel().GetMembersByIds(post.ChannelId, userIDs)
+	if err != nil {
+		return nil, model.NewAppError("GetMentionReadStateForPost", "app.read_receipt.get_mention_read_state.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	inChannel := make(map[string]bool, len(members))
+	for _, member := range members {
+		inChannel[member.UserId] = true
+	}
+
+	receipts, err := a.Srv().Store().PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+	if err != nil {
+		return nil, model.NewAppError("GetMentionReadStateForPost", "app.read_receipt.get_mention_read_state.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	readers := make(map[string]bool, len(receipts))
+	for _, receipt := range receipts {
+		readers[receipt.UserId] = true
+	}
+
+	sample := a.readReceiptSampleForChannel(c, post.ChannelId)
+	reported := make(map[string]bool, len(userIDs))
+	for _, username := range usernames {
+		user, ok := usersByName[username]
+		if !ok || reported[user.Id] || !inChannel[user.Id] {
+			continue
+		}
+		reported[user.Id] = true
+
+		if readers[user.Id] {
+			state.Read = append(state.Read, user.Id)
+		} else if !sample.includes(user.Id) {
+			state.NotSampled = append(state.NotSampled, user.Id)
+		} else {
+			state.Unread = append(state.Unread, user.Id)
+		}
+	}
+
+	return state, nil
+}
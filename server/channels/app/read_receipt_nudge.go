@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// unreadDMNudgeCheckpointKey is the System key of the cutoff the last nudges were
+// sent up to.
+const unreadDMNudgeCheckpointKey = "unread_dm_nudge_last_cutoff"
+
+// unreadDMNudgeFirstWindow is how far back the first run nudges about, when no
+// checkpoint was stored yet.
+const unreadDMNudgeFirstWindow = time.Hour
+
+// SendUnreadDirectMessageNudges is called periodically from the job server to
+// remind users of the direct messages they left unread for more than
+// ReadReceiptsUnreadDMNudgeHours. Only messages crossing that threshold since the
+// previous run trigger a nudge, so that each message is reminded about once even
+// when runs are late or skipped, and a user gets a single nudge covering all of
+// their direct channels.
+func (a *App) SendUnreadDirectMessageNudges() error {
+	hours := *a.Config().ServiceSettings.ReadReceiptsUnreadDMNudgeHours
+	cutoff := time.Now().Add(-time.Duration(hours) * time.Hour).UnixMilli()
+
+	since, err := a.unreadDMNudgeCheckpoint()
+	if err != nil {
+		return err
+	}
+	if since == 0 {
+		since = cutoff - unreadDMNudgeFirstWindow.Milliseconds()
+	}
+	if since >= cutoff {
+		return nil
+	}
+
+	unread, err := a.Srv().Store().PostReadReceipt().GetUnreadDirectChannels(since, cutoff)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the direct channels with unread messages")
+	}
+
+	// The channels are ordered by user.
+	rctx := request.EmptyContext(a.Log())
+	for start := 0; start < len(unread); {
+		end := start
+		channelIDs := []string{}
+		for end < len(unread) && unread[end].UserId == unread[start].UserId {
+			channelIDs = append(channelIDs, unread[end].ChannelId)
+			end++
+		}
+		a.sendUnreadDirectMessageNudge(rctx, unread[start].UserId, channelIDs, cutoff, hours)
+		start = end
+	}
+
+	if err := a.Srv().Store().System().SaveOrUpdate(&model.System{
+		Name:  unreadDMNudgeCheckpointKey,
+		Value: strconv.FormatInt(cutoff, 10),
+	}); err != nil {
+		return errors.Wrap(err, "failed to save the unread direct messages nudge checkpoint")
+	}
+
+	return nil
+}
+
+// unreadDMNudgeCheckpoint returns the cutoff the last nudges were sent up to, or 0
+// before the first run.
+func (a *App) unreadDMNudgeCheckpoint() (int64, error) {
+	system, err := a.Srv().Store().System().GetByName(unreadDMNudgeCheckpointKey)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Wrap(err, "failed to get the unread direct messages nudge checkpoint")
+	}
+
+	checkpoint, err := strconv.ParseInt(system.Value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse the unread direct messages nudge checkpoint")
+	}
+	return checkpoint, nil
+}
+
+func (a *App) sendUnreadDirectMessageNudge(rctx request.CTX, userID string, channelIDs []string, cutoff int64, hours int) {
+	logger := rctx.Logger().With(mlog.String("user_id", userID))
+
+	// Without receipts from the user every message would look unread.
+	if !a.UserHasReadReceiptsEnabled(userID) {
+		return
+	}
+	if pref, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameUnreadDMNudge); err == nil && pref.Value == "false" {
+		return
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		logger.Warn("Failed to get the user to nudge about unread direct messages", mlog.Err(appErr))
+		return
+	}
+
+	// Like the notifications of the messages themselves, the nudge honors the
+	// notification settings and the status of the user.
+	if status, appErr := a.GetStatus(userID); appErr == nil && (status.Status == model.StatusDnd || status.Status == model.StatusOutOfOffice) {
+		return
+	}
+	sendPush := a.canSendPushNotifications() && user.NotifyProps[model.PushNotifyProp] != model.UserNotifyNone
+	sendEmail := *a.Config().EmailSettings.SendEmailNotifications && user.Email != "" && user.NotifyProps[model.EmailNotifyProp] != "false"
+	if !sendPush && !sendEmail {
+		return
+	}
+
+	var count int64
+	senders := make([]string, 0, len(channelIDs))
+	for _, channelID := range channelIDs {
+		unreadCount, err := a.Srv().Store().PostReadReceipt().GetUnreadPostsCount(channelID, userID, cutoff)
+		if err != nil {
+			logger.Warn("Failed to count the unread direct messages", mlog.String("channel_id", channelID), mlog.Err(err))
+			continue
+		}
+		if unreadCount == 0 {
+			continue
+		}
+
+		channel, err := a.Srv().Store().Channel().Get(channelID, true)
+		if err != nil {
+			logger.Warn("Failed to get the direct channel", mlog.String("channel_id", channelID), mlog.Err(err))
+			continue
+		}
+		sender, appErr := a.GetUser(channel.GetOtherUserIdForDM(userID))
+		if appErr != nil {
+			logger.Warn("Failed to get the sender of the direct messages", mlog.String("channel_id", channelID), mlog.Err(appErr))
+			continue
+		}
+
+		count += unreadCount
+		senders = append(senders, "@"+sender.Username)
+	}
+	if count == 0 {
+		return
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	message := T("app.read_receipt.unread_dm_nudge.message", map[string]any{
+		"Count":   count,
+		"Senders": strings.Join(senders, ", "),
+		"Hours":   hours,
+	})
+
+	if sendPush {
+		msg := &model.PushNotification{
+			Version:   model.PushMessageV2,
+			Type:      model.PushTypeMessage,
+			ChannelId: channelIDs[0],
+			Message:   message,
+		}
+		if appErr := a.sendPushNotificationToAllSessions(rctx, msg, userID, ""); appErr != nil {
+			logger.Warn("Failed to push the unread direct messages nudge", mlog.Err(appErr))
+		}
+	}
+
+	if sendEmail {
+		subject := T("app.read_receipt.unread_dm_nudge.subject", map[string]any{"SiteName": *a.Config().TeamSettings.SiteName})
+		if err := a.Srv().EmailService.SendNotificationMail(user.Email, subject, "<p>"+html.EscapeString(message)+"</p>"); err != nil {
+			logger.Warn("Failed to email the unread direct messages nudge", mlog.Err(err))
+		}
+	}
+}
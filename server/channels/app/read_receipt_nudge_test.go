@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	emailmocks "github.com/mattermost/mattermost/server/v8/channels/app/email/mocks"
+)
+
+func TestSendUnreadDirectMessageNudges(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn
+		*cfg.ServiceSettings.ReadReceiptsEnableUnreadDMNudge = true
+		*cfg.ServiceSettings.ReadReceiptsUnreadDMNudgeHours = 24
+		*cfg.EmailSettings.SendEmailNotifications = true
+	})
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	createAt := time.Now().Add(-24*time.Hour - 30*time.Minute).UnixMilli()
+	for range 2 {
+		_, err := th.App.Srv().Store().Post().Save(th.Context, &model.Post{
+			ChannelId: dm.Id,
+			UserId:    th.BasicUser2.Id,
+			Message:   "unread",
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		createAt++
+	}
+
+	emailServiceMock := emailmocks.ServiceInterface{}
+	emailServiceMock.On("SendNotificationMail", th.BasicUser.Email, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	emailServiceMock.On("Stop").Return()
+	th.App.Srv().EmailService = &emailServiceMock
+
+	require.NoError(t, th.App.SendUnreadDirectMessageNudges())
+	emailServiceMock.AssertNumberOfCalls(t, "SendNotificationMail", 1)
+
+	// Messages before the checkpoint were already nudged about.
+	require.NoError(t, th.App.SendUnreadDirectMessageNudges())
+	emailServiceMock.AssertNumberOfCalls(t, "SendNotificationMail", 1)
+
+	resendNudges := func(t *testing.T) {
+		t.Helper()
+		_, err := th.App.Srv().Store().System().PermanentDeleteByName(unreadDMNudgeCheckpointKey)
+		require.NoError(t, err)
+		require.NoError(t, th.App.SendUnreadDirectMessageNudges())
+	}
+
+	t.Run("email notifications turned off", func(t *testing.T) {
+		user := th.BasicUser.DeepCopy()
+		user.NotifyProps[model.EmailNotifyProp] = "false"
+		_, appErr := th.App.UpdateUser(th.Context, user, false)
+		require.Nil(t, appErr)
+		defer func() {
+			user.NotifyProps[model.EmailNotifyProp] = "true"
+			_, appErr = th.App.UpdateUser(th.Context, user, false)
+			require.Nil(t, appErr)
+		}()
+
+		resendNudges(t)
+		emailServiceMock.AssertNumberOfCalls(t, "SendNotificationMail", 1)
+	})
+
+	t.Run("do not disturb", func(t *testing.T) {
+		th.App.SetStatusDoNotDisturb(th.BasicUser.Id)
+		defer th.App.SetStatusOnline(th.BasicUser.Id, true)
+
+		resendNudges(t)
+		emailServiceMock.AssertNumberOfCalls(t, "SendNotificationMail", 1)
+	})
+
+	t.Run("nudges turned off", func(t *testing.T) {
+		appErr := th.App.UpdatePreferences(th.Context, th.BasicUser.Id, model.Preferences{{
+			UserId:   th.BasicUser.Id,
+			Category: model.PreferenceCategoryNotifications,
+			Name:     model.PreferenceNameUnreadDMNudge,
+			Value:    "false",
+		}})
+		require.Nil(t, appErr)
+
+		resendNudges(t)
+		emailServiceMock.AssertNumberOfCalls(t, "SendNotificationMail", 1)
+	})
+}
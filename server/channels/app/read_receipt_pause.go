@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// readReceiptPauseExpiryInterval is how often the expired receipt pauses are
+// lifted.
+const readReceiptPauseExpiryInterval = 1 * time.Minute
+
+// readReceiptsPausedUntil returns the time until which the user paused their
+// receipts, or 0 when they are not paused.
+func (a *App) readReceiptsPausedUntil(userID string) int64 {
+	pref, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameReadReceiptsPausedUntil)
+	if err != nil {
+		return 0
+	}
+
+	pausedUntil, err := strconv.ParseInt(pref.Value, 10, 64)
+	if err != nil || pausedUntil <= model.GetMillis() {
+		return 0
+	}
+	return pausedUntil
+}
+
+// saveReadsWhilePaused keeps the reads of a user who paused their receipts as ghost
+// reads, for their own unread tracking, whether or not ghost mode is enabled.
+// Posts outside of the channel are skipped.
+func (a *App) saveReadsWhilePaused(c request.CTX, userID string, channel *model.Channel, postIDs []string, readAt int64) *model.AppError {
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIDs)
+	if err != nil {
+		return model.NewAppError("saveReadsWhilePaused", "app.read_receipt.save_ghost.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, post := range posts {
+		if post.ChannelId != channel.Id || post.DeleteAt != 0 || post.ReadReceiptsDisabled() {
+			continue
+		}
+		if appErr := a.recordGhostRead(c, userID, post, a.clampReadReceiptReadAt(readAt, post.CreateAt)); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
+
+// ResumeExpiredReadReceiptPauses lifts the receipt pauses that expired and tells
+// the clients of their users that receipts are sent again.
+func (a *App) ResumeExpiredReadReceiptPauses() {
+	prefs, err := a.Srv().Store().Preference().GetCategoryAndName(model.PreferenceCategoryDisplaySettings, model.PreferenceNameReadReceiptsPausedUntil)
+	if err != nil {
+		mlog.Warn("Failed to get the paused read receipts", mlog.Err(err))
+		return
+	}
+
+	c := request.EmptyContext(a.Log())
+	now := model.GetMillis()
+	for _, pref := range prefs {
+		pausedUntil, err := strconv.ParseInt(pref.Value, 10, 64)
+		if err == nil && pausedUntil > now {
+			continue
+		}
+
+		if appErr := a.DeletePreferences(c, pref.UserId, model.Preferences{pref}); appErr != nil {
+			mlog.Warn("Failed to resume the read receipts of the user", mlog.String("user_id", pref.UserId), mlog.Err(appErr))
+			continue
+		}
+
+		a.Publish(model.NewWebSocketEvent(model.WebsocketEventReadReceiptsResumed, "", "", pref.UserId, nil, ""))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReadReceiptsPause(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+
+	pause := func(until time.Time) {
+		err := th.App.Srv().Store().Preference().Save(model.Preferences{{
+			UserId:   th.BasicUser2.Id,
+			Category: model.PreferenceCategoryDisplaySettings,
+			Name:     model.PreferenceNameReadReceiptsPausedUntil,
+			Value:    strconv.FormatInt(until.UnixMilli(), 10),
+		}})
+		require.NoError(t, err)
+	}
+
+	pausedUntil := time.Now().Add(2 * time.Hour)
+	pause(pausedUntil)
+
+	t.Run("the effective policy reports the pause", func(t *testing.T) {
+		policy, appErr := th.App.GetEffectiveReadReceiptPolicy(th.Context, th.BasicUser2.Id, th.BasicChannel)
+		require.Nil(t, appErr)
+		assert.Equal(t, pausedUntil.UnixMilli(), policy.PausedUntil)
+	})
+
+	t.Run("reads are kept as ghost reads even without ghost mode", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		require.False(t, changed)
+		require.Nil(t, receipt)
+
+		_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("batches are flagged as paused", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		resp, appErr := th.App.SaveReadReceiptsBatch(th.Context, th.BasicUser2.Id, &model.ReadReceiptBatchRequest{ChannelId: th.BasicChannel.Id, PostIds: []string{post.Id}})
+		require.Nil(t, appErr)
+		assert.True(t, resp.Paused)
+		assert.Empty(t, resp.Receipts)
+	})
+
+	t.Run("expired pauses are lifted", func(t *testing.T) {
+		pause(time.Now().Add(-time.Minute))
+		assert.Zero(t, th.App.readReceiptsPausedUntil(th.BasicUser2.Id))
+
+		th.App.ResumeExpiredReadReceiptPauses()
+
+		_, err := th.App.Srv().Store().Preference().Get(th.BasicUser2.Id, model.PreferenceCategoryDisplaySettings, model.PreferenceNameReadReceiptsPausedUntil)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		post := th.CreatePost(th.BasicChannel)
+		_, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		assert.True(t, changed)
+	})
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func (a *App) checkReadReceiptPoliciesLicense(where string) *model.AppError {
+	license := a.License()
+	if license == nil || !*license.Features.Compliance {
+		return model.NewAppError(where, "app.read_receipt_policy.license.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+func (a *App) GetReadReceiptPolicies() ([]*model.ReadReceiptPolicy, *model.AppError) {
+	if appErr := a.checkReadReceiptPoliciesLicense("GetReadReceiptPolicies"); appErr != nil {
+		return nil, appErr
+	}
+
+	policies, err := a.Srv().Store().ReadReceiptPolicy().GetAll()
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptPolicies", "app.read_receipt_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return policies, nil
+}
+
+// UpdateReadReceiptPolicies replaces the configured policies with the given set.
+// Policy names must be unique and at most one policy may leave its scope empty
+// to act as the default.
+func (a *App) UpdateReadReceiptPolicies(policies []*model.ReadReceiptPolicy) ([]*model.ReadReceiptPolicy, *model.AppError) {
+	if appErr := a.checkReadReceiptPoliciesLicense("UpdateReadReceiptPolicies"); appErr != nil {
+		return nil, appErr
+	}
+
+	if len(policies) > model.ReadReceiptPoliciesMax {
+		return nil, model.NewAppError("UpdateReadReceiptPolicies", "app.read_receipt_policy.update.too_many.app_error", map[string]any{"Max": model.ReadReceiptPoliciesMax}, "", http.StatusBadRequest)
+	}
+
+	names := make(map[string]bool, len(policies))
+	hasDefault := false
+	for _, policy := range policies {
+		name := strings.ToLower(strings.TrimSpace(policy.Name))
+		if names[name] {
+			return nil, model.NewAppError("UpdateReadReceiptPolicies", "app.read_receipt_policy.update.duplicate_name.app_error", nil, "name="+policy.Name, http.StatusBadRequest)
+		}
+		names[name] = true
+
+		if len(policy.TeamIds) == 0 && len(policy.ChannelIds) == 0 {
+			if hasDefault {
+				return nil, model.NewAppError("UpdateReadReceiptPolicies", "app.read_receipt_policy.update.multiple_defaults.app_error", nil, "", http.StatusBadRequest)
+			}
+			hasDefault = true
+		}
+	}
+
+	saved, err := a.Srv().Store().ReadReceiptPolicy().ReplaceAll(policies)
+	if err != nil {
+		var appErr *model.AppError
+		var conflictErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &conflictErr):
+			return nil, model.NewAppError("UpdateReadReceiptPolicies", "app.read_receipt_policy.update.duplicate_name.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("UpdateReadReceiptPolicies", "app.read_receipt_policy.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+// PreviewReadReceiptCleanup counts, without deleting anything, the receipts that
+// enforcing the retention of the policy would delete. Only the scope and the
+// retention of the policy are considered, regardless of the other policies.
+func (a *App) PreviewReadReceiptCleanup(policy *model.ReadReceiptPolicy) (*model.ReadReceiptCleanupPreview, *model.AppError) {
+	if policy.RetentionDays < 0 {
+		return nil, model.NewAppError("PreviewReadReceiptCleanup", "model.read_receipt_policy.is_valid.retention_days.app_error", nil, "", http.StatusBadRequest)
+	}
+	for _, ids := range []model.StringArray{policy.TeamIds, policy.ChannelIds} {
+		for _, id := range ids {
+			if !model.IsValidId(id) {
+				return nil, model.NewAppError("PreviewReadReceiptCleanup", "model.read_receipt_policy.is_valid.scope.app_error", nil, "", http.StatusBadRequest)
+			}
+		}
+	}
+
+	// Policies without retention keep receipts forever.
+	if policy.RetentionDays == 0 {
+		return &model.ReadReceiptCleanupPreview{Channels: []*model.ReadReceiptCleanupChannelCount{}}, nil
+	}
+
+	expiredBefore := time.Now().AddDate(0, 0, -policy.RetentionDays).UnixMilli()
+	preview, err := a.Srv().Store().PostReadReceipt().GetExpiredReadReceiptCounts(expiredBefore, policy.TeamIds, policy.ChannelIds, model.ReadReceiptCleanupPreviewMaxChannels)
+	if err != nil {
+		return nil, model.NewAppError("PreviewReadReceiptCleanup", "app.read_receipt_policy.preview_cleanup.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return preview, nil
+}
+
+// resolveReadReceiptPolicy returns the configured policy governing the channel,
+// or nil when none applies or policies are not licensed.
+func (a *App) resolveReadReceiptPolicy(channel *model.Channel) (*model.ReadReceiptPolicy, *model.AppError) {
+	if a.checkReadReceiptPoliciesLicense("resolveReadReceiptPolicy") != nil {
+		return nil, nil
+	}
+
+	policies, err := a.Srv().Store().ReadReceiptPolicy().GetAll()
+	if err != nil {
+		return nil, model.NewAppError("resolveReadReceiptPolicy", "app.read_receipt_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return model.ResolveReadReceiptPolicy(policies, channel.TeamId, channel.Id), nil
+}
+
+// readReceiptPolicyForChannel is resolveReadReceiptPolicy for a channel id.
+func (a *App) readReceiptPolicyForChannel(c request.CTX, channelID string) (*model.ReadReceiptPolicy, *model.AppError) {
+	channel, appErr := a.GetChannel(c, channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.resolveReadReceiptPolicy(channel)
+}
+
+// readReceiptsBroadcastByPolicy reports whether the policy governing the channel
+// lets its members see who read its posts. Receipts are still recorded in
+// channels whose policy hides them, but no event reveals them to the members.
+func (a *App) readReceiptsBroadcastByPolicy(c request.CTX, channelID string) bool {
+	policy, appErr := a.readReceiptPolicyForChannel(c, channelID)
+	if appErr != nil {
+		// Rather drop an event than leak receipts a policy hides.
+		c.Logger().Warn("Failed to resolve the read receipt policy of the channel", mlog.String("channel_id", channelID), mlog.Err(appErr))
+		return false
+	}
+
+	return policy == nil || policy.VisibilityMode == model.ReadReceiptVisibilityEveryone
+}
+
+// CheckReadReceiptsVisibleForPost enforces the visibility mode of the policy
+// governing the channel of the post: with author_only only the author of the post
+// sees who read it, and with hidden nobody does. Compliance monitors see the
+// receipts regardless.
+func (a *App) CheckReadReceiptsVisibleForPost(c request.CTX, userID, postID string) *model.AppError {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.checkReadReceiptsVisible(c, "CheckReadReceiptsVisibleForPost", userID, post.ChannelId, post.UserId)
+}
+
+// CheckReadReceiptsVisibleForChannel is CheckReadReceiptsVisibleForPost for the
+// receipts of every post of the channel, which only the everyone visibility mode
+// shows to its members.
+func (a *App) CheckReadReceiptsVisibleForChannel(c request.CTX, userID, channelID string) *model.AppError {
+	return a.checkReadReceiptsVisible(c, "CheckReadReceiptsVisibleForChannel", userID, channelID, "")
+}
+
+// checkReadReceiptsVisible checks that the user may see who read the posts of
+// authorID in the channel, or of everyone when authorID is empty.
+func (a *App) checkReadReceiptsVisible(c request.CTX, where, userID, channelID, authorID string) *model.AppError {
+	policy, appErr := a.readReceiptPolicyForChannel(c, channelID)
+	if appErr != nil {
+		return appErr
+	}
+
+	switch {
+	case policy == nil || policy.VisibilityMode == model.ReadReceiptVisibilityEveryone:
+		return nil
+	case policy.VisibilityMode == model.ReadReceiptVisibilityAuthorOnly && authorID != "" && authorID == userID:
+		return nil
+	case a.HasPermissionTo(userID, model.PermissionSysconsoleReadComplianceComplianceMonitoring):
+		return nil
+	}
+
+	return model.NewAppError(where, "app.read_receipt_policy.not_visible.app_error", nil, "channel_id="+channelID+", policy_id="+policy.Id, http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeHiddenByPolicy)
+}
+
+// readReceiptRetentionCutoffs returns the time before which the receipts of a
+// channel expire, following the retention of the policy governing the channel, or
+// globalCutoff when no policy applies. A policy keeping receipts forever yields 0.
+// The policies and channels are resolved once per channel.
+func (a *App) readReceiptRetentionCutoffs(c request.CTX, globalCutoff int64) func(channelID string) int64 {
+	var policies []*model.ReadReceiptPolicy
+	if a.checkReadReceiptPoliciesLicense("readReceiptRetentionCutoffs") == nil {
+		var err error
+		if policies, err = a.Srv().Store().ReadReceiptPolicy().GetAll(); err != nil {
+			// Rather keep receipts a policy retains longer than delete them.
+			c.Logger().Warn("Failed to get the read receipt policies, receipts are kept", mlog.Err(err))
+			return func(string) int64 { return 0 }
+		}
+	}
+	if len(policies) == 0 {
+		return func(string) int64 { return globalCutoff }
+	}
+
+	now := time.Now()
+	cutoffs := make(map[string]int64)
+	return func(channelID string) int64 {
+		if cutoff, ok := cutoffs[channelID]; ok {
+			return cutoff
+		}
+
+		cutoff := globalCutoff
+		if channel, err := a.Srv().Store().Channel().Get(channelID, true); err != nil {
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				c.Logger().Warn("Failed to get the channel to resolve its read receipt retention, receipts are kept", mlog.String("channel_id", channelID), mlog.Err(err))
+				cutoff = 0
+			}
+		} else if policy := model.ResolveReadReceiptPolicy(policies, channel.TeamId, channel.Id); policy != nil {
+			cutoff = 0
+			if policy.RetentionDays > 0 {
+				cutoff = now.AddDate(0, 0, -policy.RetentionDays).UnixMilli()
+			}
+		}
+
+		cutoffs[channelID] = cutoff
+		return cutoff
+	}
+}
+
+// ReadReceiptPoliciesExpireReceipts reports whether a licensed policy sets a
+// retention, which the cleanup job enforces even when ReadReceiptsRetentionDays
+// keeps the other receipts forever.
+func (a *App) ReadReceiptPoliciesExpireReceipts() bool {
+	if a.checkReadReceiptPoliciesLicense("ReadReceiptPoliciesExpireReceipts") != nil {
+		return false
+	}
+
+	policies, err := a.Srv().Store().ReadReceiptPolicy().GetAll()
+	if err != nil {
+		a.Log().Warn("Failed to get the read receipt policies", mlog.Err(err))
+		return false
+	}
+
+	for _, policy := range policies {
+		if policy.RetentionDays > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEffectiveReadReceiptPolicy resolves how read receipts behave for the user in
+// the channel, combining the server configuration, the user preference and the
+// policy governing the channel.
+func (a *App) GetEffectiveReadReceiptPolicy(c request.CTX, userID string, channel *model.Channel) (*model.ReadReceiptEffectivePolicy, *model.AppError) {
+	settings := a.Config().ServiceSettings
+	effective := &model.ReadReceiptEffectivePolicy{
+		ChannelId:        channel.Id,
+		VisibilityMode:   model.ReadReceiptVisibilityEveryone,
+		Granularity:      model.ReadReceiptGranularityUser,
+		BatchWindowMs:    *settings.ReadReceiptsBatchWindowMs,
+		BatchMaxWaitMs:   *settings.ReadReceiptsBatchMaxWaitMs,
+		BatchMaxPosts:    model.ReadReceiptBatchMaxPosts,
+		ClientDebounceMs: *settings.ReadReceiptsClientDebounceMs,
+	}
+	if *settings.ReadReceiptsStoreAllDevices {
+		effective.Granularity = model.ReadReceiptGranularityDevice
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	effective.Enabled = enabled && a.UserHasReadReceiptsEnabled(userID)
+	effective.PausedUntil = a.readReceiptsPausedUntil(userID)
+	effective.Degraded = a.ReadReceiptsDegradedForChannel(c, channel.Id)
+
+	policy, appErr := a.resolveReadReceiptPolicy(channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if policy != nil {
+		effective.PolicyId = policy.Id
+		effective.VisibilityMode = policy.VisibilityMode
+	}
+
+	return effective, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// TestReadReceiptPolicyMatrix saves a receipt for every combination of the
+// settings that decide whether receipts are recorded, so that the checks spread
+// across the read receipt code paths keep agreeing with each other.
+func TestReadReceiptPolicyMatrix(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	const (
+		userEnabled   = "enabled"
+		userDisabled  = "disabled"
+		userGhost     = "ghost"
+		userGhostDeny = "ghost without ghost mode"
+	)
+
+	archived := th.CreateChannel(th.Context, th.BasicTeam)
+	archivedPost := th.CreatePost(archived)
+	require.Nil(t, th.App.DeleteChannel(th.Context, archived, th.BasicUser.Id))
+
+	channels := map[string]*model.Channel{
+		"direct":   th.CreateDmChannel(th.BasicUser2),
+		"group":    th.CreateGroupChannel(th.Context, th.BasicUser2, th.CreateUser()),
+		"open":     th.BasicChannel,
+		"private":  th.CreatePrivateChannel(th.Context, th.BasicTeam),
+		"archived": archived,
+	}
+
+	// expectedCode is the error code the save must fail with, or empty when the
+	// read must be recorded. The checks apply in this order.
+	expectedCode := func(enabled, teamChannels bool, userMode, channelName string) string {
+		switch {
+		case userMode == userDisabled:
+			return model.ReadReceiptErrorCodeUserOptedOut
+		case channelName == "archived":
+			return model.ReadReceiptErrorCodeChannelArchived
+		case !enabled:
+			return model.ReadReceiptErrorCodeChannelTypeNotAllowed
+		case !teamChannels && (channelName == "open" || channelName == "private"):
+			return model.ReadReceiptErrorCodeChannelTypeNotAllowed
+		case userMode == userGhostDeny:
+			return model.ReadReceiptErrorCodeGhostModeDisabled
+		}
+		return ""
+	}
+
+	for _, enabled := range []bool{true, false} {
+		for _, teamChannels := range []bool{true, false} {
+			for _, userMode := range []string{userEnabled, userDisabled, userGhost, userGhostDeny} {
+				for channelName, channel := range channels {
+					name := fmt.Sprintf("enabled=%t/team channels=%t/user %s/%s channel", enabled, teamChannels, userMode, channelName)
+					t.Run(name, func(t *testing.T) {
+						th.App.UpdateConfig(func(cfg *model.Config) {
+							*cfg.ServiceSettings.EnableReadReceipts = enabled
+							*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = teamChannels
+							*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsEnabledDefaultOff
+							*cfg.ServiceSettings.ReadReceiptsMaxGroupSize = 8
+							*cfg.ServiceSettings.ReadReceiptsEnableGhostMode = userMode != userGhostDeny
+						})
+
+						preference := "true"
+						if userMode == userDisabled {
+							preference = "false"
+						}
+						appErr := th.App.UpdatePreferences(th.Context, th.BasicUser.Id, model.Preferences{{
+							UserId:   th.BasicUser.Id,
+							Category: model.PreferenceCategoryDisplaySettings,
+							Name:     model.PreferenceNamePostReadReceiptsEnabled,
+							Value:    preference,
+						}})
+						require.Nil(t, appErr)
+
+						post := archivedPost
+						if channelName != "archived" {
+							post = th.CreatePost(channel)
+						}
+
+						ghost := userMode == userGhost || userMode == userGhostDeny
+						receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser.Id, &model.ReadReceiptRequest{PostId: post.Id, Ghost: ghost})
+
+						if code := expectedCode(enabled, teamChannels, userMode, channelName); code != "" {
+							require.NotNil(t, appErr)
+							require.Equal(t, code, appErr.Code)
+							require.Nil(t, receipt)
+							require.False(t, changed)
+						} else {
+							require.Nil(t, appErr)
+							require.Equal(t, !ghost, changed)
+						}
+
+						_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser.Id)
+						if !changed {
+							var nfErr *store.ErrNotFound
+							require.True(t, errors.As(err, &nfErr), "no receipt must be visible")
+
+							appErr = th.App.DeleteReadReceiptForPost(th.Context, post.Id, th.BasicUser.Id)
+							require.NotNil(t, appErr)
+							require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+							return
+						}
+						require.NoError(t, err)
+						require.Equal(t, post.Id, receipt.PostId)
+
+						require.Nil(t, th.App.DeleteReadReceiptForPost(th.Context, post.Id, th.BasicUser.Id))
+						_, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser.Id)
+						var nfErr *store.ErrNotFound
+						require.True(t, errors.As(err, &nfErr), "the receipt must be gone once deleted")
+					})
+				}
+			}
+		}
+	}
+}
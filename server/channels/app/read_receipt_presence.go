@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const readReceiptPostReadCacheSize = 50000
+
+// readReceiptPostReadCacheExpiry bounds how long other nodes of a cluster keep
+// reporting a post as unread after one of its receipts is saved.
+var readReceiptPostReadCacheExpiry = 30 * time.Second
+
+// postReadPresence is the cached result of PostReadReceiptStore.IsPostReadByAnyone.
+type postReadPresence struct {
+	Read  bool
+	Count int64
+}
+
+// isPostReadByAnyone reports whether anyone other than its author read the post,
+// along with the number of receipts of the post.
+func (a *App) isPostReadByAnyone(c request.CTX, postID string) (bool, int64, *model.AppError) {
+	var presence postReadPresence
+	err := a.Srv().readReceiptPostReadCache.Get(postID, &presence)
+	if err == nil {
+		return presence.Read, presence.Count, nil
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		c.Logger().Warn("Failed to get the cached read state of the post", mlog.String("post_id", postID), mlog.Err(err))
+	}
+
+	presence.Read, presence.Count, err = a.Srv().Store().PostReadReceipt().IsPostReadByAnyone(postID, false)
+	if err != nil {
+		return false, 0, model.NewAppError("isPostReadByAnyone", "app.read_receipt.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().readReceiptPostReadCache.SetWithExpiry(postID, presence, readReceiptPostReadCacheExpiry); err != nil {
+		c.Logger().Warn("Failed to cache the read state of the post", mlog.String("post_id", postID), mlog.Err(err))
+	}
+
+	return presence.Read, presence.Count, nil
+}
+
+// invalidatePostReadPresence drops the cached read state of the posts once their
+// receipts change.
+func (a *App) invalidatePostReadPresence(c request.CTX, postIDs ...string) {
+	for _, postID := range postIDs {
+		if err := a.Srv().readReceiptPostReadCache.Remove(postID); err != nil {
+			c.Logger().Warn("Failed to clear the cached read state of the post", mlog.String("post_id", postID), mlog.Err(err))
+		}
+	}
+}
+
+// GetPostSeenState returns whether the direct message was seen by its recipient.
+func (a *App) GetPostSeenState(c request.CTX, postID string) (*model.PostSeenState, *model.AppError) {
+	post, appErr := a.GetSinglePost(c, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channel, appErr := a.GetChannel(c, post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if channel.Type != model.ChannelTypeDirect {
+		return nil, model.NewAppError("GetPostSeenState", "app.read_receipt.seen.not_direct.app_error", nil, "post_id="+post.Id, http.StatusBadRequest)
+	}
+
+	seen, _, appErr := a.isPostReadByAnyone(c, post.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model.PostSeenState{PostId: post.Id, Seen: seen}, nil
+}
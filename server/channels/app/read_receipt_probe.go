@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// readReceiptsProbePostKey is the System key of the post the health check of
+// the read receipts marks as read.
+const readReceiptsProbePostKey = "read_receipts_probe_post_id"
+
+// ReadReceiptsHealthCheck writes a receipt of the system bot for a probe post of
+// its direct channel with itself and deletes it, like DBHealthCheckWrite and
+// DBHealthCheckDelete do. The receipt bypasses the changes and the summaries, so
+// that the check is invisible to the receipt consumers. How far behind the
+// summaries are is reported by GetReadReceiptsHealth instead.
+func (a *App) ReadReceiptsHealthCheck(rctx request.CTX) error {
+	bot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	post, err := a.readReceiptsProbePost(rctx, bot.UserId)
+	if err != nil {
+		return err
+	}
+
+	receipt := &model.PostReadReceipt{
+		PostId:     post.Id,
+		UserId:     bot.UserId,
+		ChannelId:  post.ChannelId,
+		ReadAt:     model.GetMillis(),
+		DeviceType: model.ReadReceiptDeviceTypeBot,
+	}
+	readAt, err := a.Srv().Store().PostReadReceipt().SaveHealthCheckReceipt(receipt)
+	if err != nil {
+		return errors.Wrap(err, "failed to write the probe receipt")
+	}
+	if readAt != receipt.ReadAt {
+		return errors.Errorf("the probe receipt was read back with ReadAt=%d instead of %d", readAt, receipt.ReadAt)
+	}
+	if err := a.Srv().Store().PostReadReceipt().DeleteHealthCheckReceipt(post.Id, bot.UserId); err != nil {
+		return errors.Wrap(err, "failed to delete the probe receipt")
+	}
+
+	return nil
+}
+
+// readReceiptsProbePost returns the probe post of the health check, creating it
+// the first time or once it was deleted.
+func (a *App) readReceiptsProbePost(rctx request.CTX, botUserID string) (*model.Post, error) {
+	var nfErr *store.ErrNotFound
+	system, err := a.Srv().Store().System().GetByName(readReceiptsProbePostKey)
+	if err != nil && !errors.As(err, &nfErr) {
+		return nil, errors.Wrap(err, "failed to get the probe post id")
+	}
+	if system != nil {
+		post, err := a.Srv().Store().Post().GetSingle(rctx, system.Value, false)
+		if err == nil {
+			return post, nil
+		}
+		if !errors.As(err, &nfErr) {
+			return nil, errors.Wrap(err, "failed to get the probe post")
+		}
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(rctx, botUserID, botUserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// The post is saved through the store so that nobody gets notified of it.
+	post, err := a.Srv().Store().Post().Save(rctx, &model.Post{
+		ChannelId: channel.Id,
+		UserId:    botUserID,
+		Message:   "Read receipts health check",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save the probe post")
+	}
+
+	if err := a.Srv().Store().System().SaveOrUpdate(&model.System{Name: readReceiptsProbePostKey, Value: post.Id}); err != nil {
+		return nil, errors.Wrap(err, "failed to save the probe post id")
+	}
+
+	return post, nil
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// readReceiptEventQueueSize is how many receipt events can wait for delivery.
+// Events that do not fit are delivered right away by the caller.
+const readReceiptEventQueueSize = 10000
+
+// readReceiptEventBatchSize is the maximum number of events handed to the
+// publisher at once.
+const readReceiptEventBatchSize = 100
+
+// readReceiptPublishBackoff is how long to wait after each failed delivery before
+// trying again. Events still failing afterwards are dropped.
+var readReceiptPublishBackoff = []time.Duration{100 * time.Millisecond, time.Second}
+
+// ReadReceiptPublisher delivers the websocket events of read receipts, like the
+// events of new receipts and of updated summaries, to the clients. Failed
+// deliveries are retried, so publishers must accept the same events more than once.
+type ReadReceiptPublisher interface {
+	Publish(events []*model.WebSocketEvent) error
+}
+
+// webHubReadReceiptPublisher is the default publisher, sending the events through
+// the web hub like every other websocket event.
+type webHubReadReceiptPublisher struct {
+	publish func(*model.WebSocketEvent)
+}
+
+func (p *webHubReadReceiptPublisher) Publish(events []*model.WebSocketEvent) error {
+	for _, event := range events {
+		p.publish(event)
+	}
+	return nil
+}
+
+// readReceiptEventQueue delivers the receipt events through the publisher in the
+// background, in batches, so that saving receipts never waits on the delivery.
+// Until the queue is started and after it is stopped, events are delivered by the
+// caller.
+type readReceiptEventQueue struct {
+	mut       sync.RWMutex
+	publisher ReadReceiptPublisher
+	backoff   []time.Duration
+	logger    mlog.LoggerIFace
+	// metrics returns the metrics interface, or nil when metrics are disabled.
+	metrics func() einterfaces.MetricsInterface
+
+	queue chan *model.WebSocketEvent
+
+	startOnce sync.Once
+	started   bool
+	stopped   bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newReadReceiptEventQueue(publisher ReadReceiptPublisher, logger mlog.LoggerIFace) *readReceiptEventQueue {
+	return &readReceiptEventQueue{
+		publisher: publisher,
+		backoff:   readReceiptPublishBackoff,
+		logger:    logger,
+		metrics:   func() einterfaces.MetricsInterface { return nil },
+		queue:     make(chan *model.WebSocketEvent, readReceiptEventQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// setPublisher replaces the publisher the events are delivered through.
+func (q *readReceiptEventQueue) setPublisher(publisher ReadReceiptPublisher) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	q.publisher = publisher
+}
+
+func (q *readReceiptEventQueue) start() {
+	q.startOnce.Do(func() {
+		q.mut.Lock()
+		q.started = true
+		q.mut.Unlock()
+		go q.loop()
+	})
+}
+
+// stopAndFlush stops the delivery loop once the queued events were delivered.
+func (q *readReceiptEventQueue) stopAndFlush() {
+	q.mut.Lock()
+	started := q.started && !q.stopped
+	q.stopped = true
+	q.mut.Unlock()
+
+	if started {
+		close(q.stop)
+		<-q.done
+	}
+}
+
+func (q *readReceiptEventQueue) enqueue(event *model.WebSocketEvent) {
+	q.mut.RLock()
+	running := q.started && !q.stopped
+	q.mut.RUnlock()
+
+	if running {
+		select {
+		case q.queue <- event:
+			return
+		default:
+		}
+	}
+
+	q.deliver([]*model.WebSocketEvent{event}, nil)
+}
+
+func (q *readReceiptEventQueue) loop() {
+	defer close(q.done)
+
+	for {
+		select {
+		case event := <-q.queue:
+			q.deliver(q.takeBatch(event), q.backoff)
+		case <-q.stop:
+			for {
+				select {
+				case event := <-q.queue:
+					q.deliver(q.takeBatch(event), nil)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// takeBatch returns the event along with the events queued after it, up to
+// readReceiptEventBatchSize.
+func (q *readReceiptEventQueue) takeBatch(event *model.WebSocketEvent) []*model.WebSocketEvent {
+	batch := []*model.WebSocketEvent{event}
+	for len(batch) < readReceiptEventBatchSize {
+		select {
+		case next := <-q.queue:
+			batch = append(batch, next)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// deliver hands the events to the publisher, retrying after each of the backoff
+// durations, and records the outcome in the metrics.
+func (q *readReceiptEventQueue) deliver(events []*model.WebSocketEvent, backoff []time.Duration) {
+	q.mut.RLock()
+	publisher := q.publisher
+	q.mut.RUnlock()
+
+	metrics := q.metrics()
+	err := publisher.Publish(events)
+	for _, wait := range backoff {
+		if err == nil {
+			break
+		}
+		if metrics != nil {
+			metrics.IncrementReadReceiptEventPublishRetries()
+		}
+		time.Sleep(wait)
+		err = publisher.Publish(events)
+	}
+
+	if err != nil {
+		q.logger.Warn("Failed to publish read receipt events", mlog.Int("count", len(events)), mlog.Err(err))
+		if metrics != nil {
+			metrics.IncrementReadReceiptEventsDropped(float64(len(events)))
+		}
+		return
+	}
+
+	if metrics != nil {
+		metrics.IncrementReadReceiptEventsPublished(float64(len(events)))
+	}
+}
+
+// publishReadReceiptEvent delivers a receipt event through the read receipt
+// publisher rather than straight to the web hub. Events broadcast to a channel
+// whose admins turned the broadcast off, or whose read receipt policy does not
+// show receipts to every member, are dropped; those sent to a single user are not.
+func (a *App) publishReadReceiptEvent(c request.CTX, event *model.WebSocketEvent) {
+	if channelID := event.GetBroadcast().ChannelId; channelID != "" && (!a.readReceiptsBroadcastForChannel(c, channelID) || !a.readReceiptsBroadcastByPolicy(c, channelID)) {
+		return
+	}
+	a.ch.readReceiptEvents.enqueue(event)
+}
+
+// SetReadReceiptPublisher makes the receipt events go through publisher, like a
+// message queue shared by several servers, instead of the web hub.
+func (a *App) SetReadReceiptPublisher(publisher ReadReceiptPublisher) {
+	a.ch.readReceiptEvents.setPublisher(publisher)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+type testReadReceiptPublisher struct {
+	mut       sync.Mutex
+	failures  int
+	calls     int
+	published []*model.WebSocketEvent
+}
+
+func (p *testReadReceiptPublisher) Publish(events []*model.WebSocketEvent) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	p.calls++
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.published = append(p.published, events...)
+	return nil
+}
+
+func TestReadReceiptEventQueue(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	newEvents := func(count int) []*model.WebSocketEvent {
+		events := make([]*model.WebSocketEvent, 0, count)
+		for range count {
+			events = append(events, model.NewWebSocketEvent(model.WebsocketEventReadReceiptSummary, "", model.NewId(), "", nil, ""))
+		}
+		return events
+	}
+
+	t.Run("delivers the queued events in batches", func(t *testing.T) {
+		publisher := &testReadReceiptPublisher{}
+		queue := newReadReceiptEventQueue(publisher, logger)
+		queue.start()
+
+		events := newEvents(readReceiptEventBatchSize + 1)
+		for _, event := range events {
+			queue.enqueue(event)
+		}
+		queue.stopAndFlush()
+
+		assert.Equal(t, events, publisher.published)
+		assert.GreaterOrEqual(t, publisher.calls, 2)
+	})
+
+	t.Run("delivers right away until started and once stopped", func(t *testing.T) {
+		publisher := &testReadReceiptPublisher{}
+		queue := newReadReceiptEventQueue(publisher, logger)
+
+		events := newEvents(2)
+		queue.enqueue(events[0])
+		require.Equal(t, events[:1], publisher.published)
+
+		queue.start()
+		queue.stopAndFlush()
+		queue.enqueue(events[1])
+		require.Equal(t, events, publisher.published)
+	})
+
+	t.Run("retries failed deliveries and records them", func(t *testing.T) {
+		publisher := &testReadReceiptPublisher{failures: 1}
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementReadReceiptEventPublishRetries").Return()
+		metrics.On("IncrementReadReceiptEventsPublished", mock.Anything).Return()
+
+		queue := newReadReceiptEventQueue(publisher, logger)
+		queue.backoff = []time.Duration{time.Millisecond}
+		queue.metrics = func() einterfaces.MetricsInterface { return metrics }
+		queue.start()
+
+		events := newEvents(1)
+		queue.enqueue(events[0])
+		queue.stopAndFlush()
+
+		assert.Equal(t, events, publisher.published)
+		metrics.AssertCalled(t, "IncrementReadReceiptEventPublishRetries")
+		metrics.AssertCalled(t, "IncrementReadReceiptEventsPublished", float64(1))
+	})
+
+	t.Run("drops the events still failing", func(t *testing.T) {
+		publisher := &testReadReceiptPublisher{failures: 2}
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementReadReceiptEventPublishRetries").Return()
+		metrics.On("IncrementReadReceiptEventsDropped", mock.Anything).Return()
+
+		queue := newReadReceiptEventQueue(publisher, logger)
+		queue.backoff = []time.Duration{time.Millisecond}
+		queue.metrics = func() einterfaces.MetricsInterface { return metrics }
+		queue.start()
+
+		queue.enqueue(newEvents(1)[0])
+		queue.stopAndFlush()
+
+		assert.Empty(t, publisher.published)
+		metrics.AssertCalled(t, "IncrementReadReceiptEventsDropped", float64(1))
+	})
+
+	t.Run("publisher can be replaced", func(t *testing.T) {
+		queue := newReadReceiptEventQueue(&testReadReceiptPublisher{}, logger)
+		publisher := &testReadReceiptPublisher{}
+		queue.setPublisher(publisher)
+
+		events := newEvents(1)
+		queue.enqueue(events[0])
+		assert.Equal(t, events, publisher.published)
+	})
+}
+
+func TestPublishReadReceiptEventBroadcastSetting(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	publisher := &testReadReceiptPublisher{}
+	th.App.SetReadReceiptPublisher(publisher)
+
+	post := th.CreatePost(th.BasicChannel)
+	receipt := &model.PostReadReceipt{PostId: post.Id, UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, ReadAt: model.GetMillis()}
+
+	// publish sends a read, a batch and a digest event, then an event to a single
+	// user, and returns the types of the events published once the latter is.
+	publish := func(t *testing.T) []model.WebsocketEventType {
+		publisher.mut.Lock()
+		publisher.published = nil
+		publisher.mut.Unlock()
+
+		th.App.sendReadReceiptEvent(th.Context, receipt, post, th.BasicChannel)
+		th.App.sendReadReceiptBatchEvent(th.Context, th.BasicChannel, []*model.PostReadReceipt{receipt}, nil)
+		digest := &model.ChannelReadDigestEvent{UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id, UpToPostId: post.Id, ReadAt: receipt.ReadAt}
+		th.App.publishReaderEvent(th.Context, digest.ToWebSocketEvent(), th.BasicChannel.Id, th.BasicUser2.Id)
+		th.App.publishReadReceiptEvent(th.Context, model.NewWebSocketEvent(model.WebsocketEventReadSummaryUpdated, "", "", th.BasicUser.Id, nil, ""))
+
+		var types []model.WebsocketEventType
+		require.Eventually(t, func() bool {
+			publisher.mut.Lock()
+			defer publisher.mut.Unlock()
+			types = nil
+			for _, event := range publisher.published {
+				types = append(types, event.EventType())
+			}
+			return len(types) > 0 && types[len(types)-1] == model.WebsocketEventReadSummaryUpdated
+		}, 5*time.Second, 20*time.Millisecond)
+		return types
+	}
+
+	t.Run("broadcast on", func(t *testing.T) {
+		assert.Equal(t, []model.WebsocketEventType{
+			model.WebsocketEventPostRead,
+			model.WebsocketEventPostReadBatch,
+			model.WebsocketEventChannelReadDigest,
+			model.WebsocketEventReadSummaryUpdated,
+		}, publish(t))
+	})
+
+	t.Run("broadcast off", func(t *testing.T) {
+		_, appErr := th.App.UpdateReadReceiptChannelSettings(&model.ReadReceiptChannelSettings{ChannelId: th.BasicChannel.Id, BroadcastReceipts: false})
+		require.Nil(t, appErr)
+
+		assert.Equal(t, []model.WebsocketEventType{model.WebsocketEventReadSummaryUpdated}, publish(t))
+	})
+}
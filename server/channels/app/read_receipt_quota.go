@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// readReceiptQuotaCheckInterval is how long the receipt count of the workspace is
+// cached before it is checked against the cloud quota again.
+var readReceiptQuotaCheckInterval = time.Minute
+
+// readReceiptQuotaMinWriteInterval is the minimum interval between two receipts of
+// a user in a channel while the workspace is over its cloud quota.
+const readReceiptQuotaMinWriteInterval = 5 * time.Second
+
+type readReceiptQuotaState struct {
+	checkedAt time.Time
+	limit     int64
+	used      int64
+}
+
+func (s *readReceiptQuotaState) overQuota() bool {
+	return s.limit > 0 && s.used >= s.limit
+}
+
+// getCloudReadReceiptsLimit returns the number of receipts the cloud workspace
+// may store, or 0 when it is not limited.
+func (a *App) getCloudReadReceiptsLimit() (int64, *model.AppError) {
+	license := a.Srv().License()
+	if license == nil || !license.IsCloud() || a.Cloud() == nil {
+		return 0, nil
+	}
+
+	limits, err := a.Cloud().GetCloudLimits("")
+	if err != nil {
+		return 0, model.NewAppError("getCloudReadReceiptsLimit", "api.cloud.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if limits == nil || limits.ReadReceipts == nil || limits.ReadReceipts.Receipts == nil {
+		// Cloud limit is not applicable
+		return 0, nil
+	}
+
+	return *limits.ReadReceipts.Receipts, nil
+}
+
+// readReceiptQuota returns the cloud receipt quota of the workspace along with the
+// number of receipts it stores, checking them at most once per
+// readReceiptQuotaCheckInterval. Errors are logged and leave the last known state.
+func (a *App) readReceiptQuota(c request.CTX) *readReceiptQuotaState {
+	state := a.ch.readReceiptQuota.Load()
+	if state != nil && time.Since(state.checkedAt) < readReceiptQuotaCheckInterval {
+		return state
+	}
+
+	next := &readReceiptQuotaState{checkedAt: time.Now()}
+	if state != nil {
+		next.limit, next.used = state.limit, state.used
+	}
+
+	limit, appErr := a.getCloudReadReceiptsLimit()
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the cloud read receipt limit", mlog.Err(appErr))
+	} else {
+		next.limit = limit
+	}
+
+	if next.limit > 0 {
+		used, err := a.Srv().Store().PostReadReceipt().EstimateReadReceiptCount()
+		if err != nil {
+			c.Logger().Warn("Failed to count the read receipts of the workspace", mlog.Err(err))
+		} else {
+			next.used = used
+		}
+	}
+
+	if !next.overQuota() && state != nil && state.overQuota() {
+		c.Logger().Info("Read receipts are back under the cloud quota", mlog.Int("used", next.used), mlog.Int("limit", next.limit))
+	} else if next.overQuota() && (state == nil || !state.overQuota()) {
+		c.Logger().Warn("Read receipts are over the cloud quota, switching every channel to watermark receipts", mlog.Int("used", next.used), mlog.Int("limit", next.limit))
+	}
+
+	a.ch.readReceiptQuota.Store(next)
+	return next
+}
+
+// ReadReceiptsOverCloudQuota reports whether the workspace stores more receipts
+// than its cloud tier allows. The quota is a soft limit: receipts are still
+// recorded, but only in the watermark form and coalesced with
+// readReceiptQuotaMinWriteInterval, until the retention brings the workspace back
+// under it.
+func (a *App) ReadReceiptsOverCloudQuota(c request.CTX) bool {
+	return a.readReceiptQuota(c).overQuota()
+}
+
+// AddReadReceiptsUsageToCloudLimits fills in the number of receipts stored by the
+// workspace when its cloud limits include a receipt quota.
+func (a *App) AddReadReceiptsUsageToCloudLimits(c request.CTX, limits *model.ProductLimits) {
+	if limits == nil || limits.ReadReceipts == nil {
+		return
+	}
+
+	used, err := a.Srv().Store().PostReadReceipt().EstimateReadReceiptCount()
+	if err != nil {
+		c.Logger().Warn("Failed to count the read receipts of the workspace", mlog.Err(err))
+		return
+	}
+	limits.ReadReceipts.Used = model.NewPointer(used)
+}
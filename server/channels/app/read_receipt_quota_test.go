@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	storemocks "github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	eMocks "github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+func TestReadReceiptsOverCloudQuota(t *testing.T) {
+	setup := func(t *testing.T, limits *model.ProductLimits, used int64) *TestHelper {
+		th := SetupWithStoreMock(t)
+		t.Cleanup(th.TearDown)
+
+		th.App.Srv().SetLicense(model.NewTestLicense("cloud"))
+
+		cloud := &eMocks.CloudInterface{}
+		th.App.Srv().Cloud = cloud
+		cloud.Mock.On("GetCloudLimits", mock.Anything).Return(limits, nil)
+
+		mockStore := th.App.Srv().Store().(*storemocks.Store)
+		mockReceiptStore := storemocks.PostReadReceiptStore{}
+		mockReceiptStore.On("EstimateReadReceiptCount").Return(used, nil)
+		mockStore.On("PostReadReceipt").Return(&mockReceiptStore)
+
+		return th
+	}
+
+	t.Run("over the quota", func(t *testing.T) {
+		th := setup(t, &model.ProductLimits{
+			ReadReceipts: &model.ReadReceiptsLimits{Receipts: model.NewPointer(int64(100))},
+		}, 150)
+
+		require.True(t, th.App.ReadReceiptsOverCloudQuota(th.Context))
+		require.True(t, th.App.ReadReceiptsDegradedForChannel(th.Context, model.NewId()))
+	})
+
+	t.Run("under the quota", func(t *testing.T) {
+		th := setup(t, &model.ProductLimits{
+			ReadReceipts: &model.ReadReceiptsLimits{Receipts: model.NewPointer(int64(100))},
+		}, 50)
+
+		require.False(t, th.App.ReadReceiptsOverCloudQuota(th.Context))
+	})
+
+	t.Run("without a receipt limit", func(t *testing.T) {
+		th := setup(t, &model.ProductLimits{}, 150)
+
+		require.False(t, th.App.ReadReceiptsOverCloudQuota(th.Context))
+	})
+
+	t.Run("usage is added to the limits", func(t *testing.T) {
+		th := setup(t, nil, 42)
+
+		limits := &model.ProductLimits{
+			ReadReceipts: &model.ReadReceiptsLimits{Receipts: model.NewPointer(int64(100))},
+		}
+		th.App.AddReadReceiptsUsageToCloudLimits(th.Context, limits)
+		require.NotNil(t, limits.ReadReceipts.Used)
+		require.Equal(t, int64(42), *limits.ReadReceipts.Used)
+
+		limits = &model.ProductLimits{}
+		th.App.AddReadReceiptsUsageToCloudLimits(th.Context, limits)
+		require.Nil(t, limits.ReadReceipts)
+	})
+}
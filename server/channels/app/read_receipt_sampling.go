@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"hash/fnv"
+	"math"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// readReceiptSampleRate returns the share of the members of the channel whose
+// receipts are stored, along with the number of members. Above
+// ServiceSettings.ReadReceiptsSamplingThreshold members, only about that many
+// members are sampled, so that the receipts of a post stay bounded however large
+// the channel. Direct and group messages are never sampled.
+func (a *App) readReceiptSampleRate(c request.CTX, channel *model.Channel) (float64, int64, *model.AppError) {
+	threshold := *a.Config().ServiceSettings.ReadReceiptsSamplingThreshold
+	if threshold <= 0 || channel.IsGroupOrDirect() {
+		return 1, 0, nil
+	}
+
+	count, appErr := a.GetChannelMemberCount(c, channel.Id)
+	if appErr != nil {
+		return 1, 0, appErr
+	}
+	if count <= int64(threshold) {
+		return 1, count, nil
+	}
+
+	return float64(threshold) / float64(count), count, nil
+}
+
+// readReceiptUserSampled reports whether the user belongs to the sample of the
+// channel for the given rate. The sample is derived from a hash of the channel and
+// the user, so that the same members are kept for every post of the channel.
+func readReceiptUserSampled(channelID, userID string, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(channelID))
+	h.Write([]byte(userID))
+	return float64(h.Sum64())/math.MaxUint64 < rate
+}
+
+// readReceiptSampledOut reports whether the receipts of the user in the channel
+// are left out of the sample, in which case they are dropped without an error.
+func (a *App) readReceiptSampledOut(c request.CTX, userID string, channel *model.Channel) bool {
+	rate, _, appErr := a.readReceiptSampleRate(c, channel)
+	if appErr != nil {
+		// Rather record a receipt too many than lose one.
+		c.Logger().Warn("Failed to get the read receipt sample rate", mlog.String("channel_id", channel.Id), mlog.Err(appErr))
+		return false
+	}
+
+	return !readReceiptUserSampled(channel.Id, userID, rate)
+}
+
+// readReceiptSample is the share of the members of a channel whose receipts are
+// stored. Every feature counting or listing the readers of a channel goes through
+// it, so that none takes the members left out of the sample for members who did
+// not read.
+type readReceiptSample struct {
+	channelID string
+	rate      float64
+	members   int64
+}
+
+// readReceiptSampleForChannel returns the sample of the channel, or the whole
+// channel when its sample rate cannot be determined.
+func (a *App) readReceiptSampleForChannel(c request.CTX, channelID string) readReceiptSample {
+	sample := readReceiptSample{channelID: channelID, rate: 1}
+
+	channel, appErr := a.GetChannel(c, channelID)
+	if appErr == nil {
+		sample.rate, sample.members, appErr = a.readReceiptSampleRate(c, channel)
+	}
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the read receipt sample rate", mlog.String("channel_id", channelID), mlog.Err(appErr))
+		return readReceiptSample{channelID: channelID, rate: 1}
+	}
+
+	return sample
+}
+
+// estimated reports whether the counts of the channel only cover a sample.
+func (s readReceiptSample) estimated() bool {
+	return s.rate < 1
+}
+
+// includes reports whether the receipts of the user are stored.
+func (s readReceiptSample) includes(userID string) bool {
+	return readReceiptUserSampled(s.channelID, userID, s.rate)
+}
+
+// readCount scales the number of readers found in the sample up to the whole
+// channel.
+func (s readReceiptSample) readCount(sampled int64) int64 {
+	if !s.estimated() {
+		return sampled
+	}
+
+	return min(int64(math.Round(float64(sampled)/s.rate)), s.members)
+}
+
+// estimateSummaries flags the summaries of a sampled channel and estimates the
+// share of its members who read each post from the sample.
+func (s readReceiptSample) estimateSummaries(summaries ...*model.PostReadReceiptSummary) {
+	if !s.estimated() {
+		return
+	}
+
+	for _, summary := range summaries {
+		summary.EstimateFromSample(s.rate, s.members)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReadReceiptUserSampled(t *testing.T) {
+	channelID := model.NewId()
+
+	t.Run("everyone without sampling", func(t *testing.T) {
+		for range 100 {
+			require.True(t, readReceiptUserSampled(channelID, model.NewId(), 1))
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		userID := model.NewId()
+		sampled := readReceiptUserSampled(channelID, userID, 0.5)
+		for range 10 {
+			require.Equal(t, sampled, readReceiptUserSampled(channelID, userID, 0.5))
+		}
+	})
+
+	t.Run("close to the rate", func(t *testing.T) {
+		sampled := 0
+		for range 10000 {
+			if readReceiptUserSampled(channelID, model.NewId(), 0.1) {
+				sampled++
+			}
+		}
+		require.InDelta(t, 1000, sampled, 200)
+	})
+}
+
+func TestReadReceiptSampleReadCount(t *testing.T) {
+	t.Run("not scaled without sampling", func(t *testing.T) {
+		sample := readReceiptSample{channelID: model.NewId(), rate: 1, members: 10}
+		require.False(t, sample.estimated())
+		require.Equal(t, int64(3), sample.readCount(3))
+	})
+
+	t.Run("scaled up to the members", func(t *testing.T) {
+		sample := readReceiptSample{channelID: model.NewId(), rate: 0.1, members: 1000}
+		require.True(t, sample.estimated())
+		require.Equal(t, int64(30), sample.readCount(3))
+		require.Equal(t, int64(1000), sample.readCount(200))
+	})
+}
+
+func TestReadReceiptSampling(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsSamplingThreshold = 2
+	})
+
+	channel := th.CreateChannel(th.Context, th.BasicTeam)
+	var sampledUser, skippedUser *model.User
+	for sampledUser == nil || skippedUser == nil {
+		user := th.CreateUser()
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, channel)
+
+		rate, _, appErr := th.App.readReceiptSampleRate(th.Context, channel)
+		require.Nil(t, appErr)
+		if rate == 1 {
+			continue
+		}
+		if readReceiptUserSampled(channel.Id, user.Id, rate) {
+			sampledUser = user
+		} else {
+			skippedUser = user
+		}
+	}
+
+	post := th.CreatePost(channel)
+
+	t.Run("receipts of the sample are stored", func(t *testing.T) {
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, sampledUser.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		require.True(t, changed)
+		require.NotNil(t, receipt)
+	})
+
+	t.Run("receipts out of the sample are dropped", func(t *testing.T) {
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, skippedUser.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		require.False(t, changed)
+		require.Nil(t, receipt)
+
+		_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, skippedUser.Id)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("summaries are estimated", func(t *testing.T) {
+		summary, appErr := th.App.GetReadReceiptSummaryForPost(th.Context, post.Id)
+		require.Nil(t, appErr)
+		require.True(t, summary.Estimated)
+		require.Positive(t, summary.EstimatedReadPercentage)
+		require.Positive(t, summary.MarginOfError)
+
+		summary, appErr = th.App.GetReadReceiptSummaryForPost(th.Context, th.BasicPost.Id)
+		require.Nil(t, appErr)
+		require.False(t, summary.Estimated)
+	})
+
+	t.Run("reports scale the sampled counts", func(t *testing.T) {
+		report, appErr := th.App.GetReadReceiptAnnouncementReport(th.Context, th.BasicTeam.Id, []string{post.Id})
+		require.Nil(t, appErr)
+		require.True(t, report.Estimated)
+		require.Len(t, report.Channels, 1)
+		require.True(t, report.Channels[0].Estimated)
+		require.Greater(t, report.ReadCount, int64(1))
+	})
+
+	t.Run("mentioned users out of the sample are not reported as unread", func(t *testing.T) {
+		mentionPost := th.CreatePost(channel, func(p *model.Post) {
+			p.Message = "@" + skippedUser.Username + " @" + sampledUser.Username
+		})
+
+		state, appErr := th.App.GetMentionReadStateForPost(th.Context, th.BasicUser.Id, mentionPost.Id)
+		require.Nil(t, appErr)
+		require.Equal(t, []string{skippedUser.Id}, state.NotSampled)
+		require.Equal(t, []string{sampledUser.Id}, state.Unread)
+	})
+
+	t.Run("direct messages are never sampled", func(t *testing.T) {
+		dm := th.CreateDmChannel(skippedUser)
+		dmPost := th.CreatePost(dm)
+
+		receipt, changed, appErr := th.App.SaveReadReceiptForPost(th.Context, skippedUser.Id, &model.ReadReceiptRequest{PostId: dmPost.Id})
+		require.Nil(t, appErr)
+		require.True(t, changed)
+		require.NotNil(t, receipt)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const readReceiptScrubBatchSize = 100
+
+// flagReadReceiptsForScrub schedules the scrub of the device and session data of the
+// receipts of a deactivated user, so that reactivating the user within
+// ReadReceiptsDeactivationScrubDays keeps them intact.
+func (a *App) flagReadReceiptsForScrub(c request.CTX, user *model.User) {
+	if !*a.Config().ServiceSettings.EnableReadReceipts || *a.Config().ServiceSettings.ReadReceiptsDeactivationScrubDays == 0 {
+		return
+	}
+
+	if err := a.Srv().Store().PostReadReceipt().FlagUserReceiptsForScrub(user.Id, user.DeleteAt); err != nil {
+		c.Logger().Warn("Failed to flag the read receipts of the deactivated user for a scrub", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+}
+
+// unflagReadReceiptsForScrub cancels the pending scrub of the receipts of a
+// reactivated user. It runs regardless of the configuration, so that a flag left over
+// from a previous configuration does not outlive the reactivation.
+func (a *App) unflagReadReceiptsForScrub(c request.CTX, userID string) {
+	if err := a.Srv().Store().PostReadReceipt().UnflagUserReceiptsForScrub(userID); err != nil {
+		c.Logger().Warn("Failed to cancel the scrub of the read receipts of the reactivated user", mlog.String("user_id", userID), mlog.Err(err))
+	}
+}
+
+// ScrubDeactivatedUsersReadReceipts clears the device and session data of the
+// receipts of the users deactivated more than ReadReceiptsDeactivationScrubDays ago
+// and returns how many users were scrubbed. Users reactivated since they were
+// flagged are unflagged instead.
+func (a *App) ScrubDeactivatedUsersReadReceipts() (int64, error) {
+	grace := time.Duration(*a.Config().ServiceSettings.ReadReceiptsDeactivationScrubDays) * 24 * time.Hour
+	deactivatedBefore := time.Now().Add(-grace).UnixMilli()
+
+	var scrubbed int64
+	for {
+		userIDs, err := a.Srv().Store().PostReadReceipt().GetUsersDueForReceiptScrub(deactivatedBefore, readReceiptScrubBatchSize)
+		if err != nil {
+			return scrubbed, errors.Wrap(err, "failed to get the users due for a read receipt scrub")
+		}
+
+		for _, userID := range userIDs {
+			user, err := a.Srv().Store().User().Get(context.Background(), userID)
+			var nfErr *store.ErrNotFound
+			if err != nil && !errors.As(err, &nfErr) {
+				return scrubbed, errors.Wrapf(err, "failed to get user with id=%s", userID)
+			}
+
+			if user != nil && user.DeleteAt == 0 {
+				if err := a.Srv().Store().PostReadReceipt().UnflagUserReceiptsForScrub(userID); err != nil {
+					return scrubbed, errors.Wrapf(err, "failed to unflag the receipts of the reactivated user with id=%s", userID)
+				}
+				continue
+			}
+
+			if err := a.Srv().Store().PostReadReceipt().ScrubUserReceipts(userID); err != nil {
+				return scrubbed, errors.Wrapf(err, "failed to scrub the receipts of user with id=%s", userID)
+			}
+			if err := a.scrubColdStorageReadReceiptsForUser(userID); err != nil {
+				return scrubbed, errors.Wrapf(err, "failed to scrub the offloaded receipts of user with id=%s", userID)
+			}
+			scrubbed++
+		}
+
+		if len(userIDs) < readReceiptScrubBatchSize {
+			return scrubbed, nil
+		}
+	}
+}
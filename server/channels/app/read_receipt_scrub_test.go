@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestScrubDeactivatedUsersReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsDeactivationScrubDays = 7
+	})
+
+	post := th.CreatePost(th.BasicChannel)
+	saveReceipt := func(user *model.User) {
+		_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    user.Id,
+			ChannelId: th.BasicChannel.Id,
+			ReadAt:    model.GetMillis(),
+			DeviceId:  "laptop",
+			SessionId: model.NewId(),
+		})
+		require.NoError(t, err)
+	}
+	dueBefore := time.Now().Add(24 * time.Hour).UnixMilli()
+
+	t.Run("reactivating within the window keeps the receipts intact", func(t *testing.T) {
+		user := th.CreateUser()
+		saveReceipt(user)
+
+		deactivated, appErr := th.App.UpdateActive(th.Context, user, false)
+		require.Nil(t, appErr)
+		userIDs, err := th.App.Srv().Store().PostReadReceipt().GetUsersDueForReceiptScrub(dueBefore, 100)
+		require.NoError(t, err)
+		require.Contains(t, userIDs, user.Id)
+
+		_, appErr = th.App.UpdateActive(th.Context, deactivated, true)
+		require.Nil(t, appErr)
+		userIDs, err = th.App.Srv().Store().PostReadReceipt().GetUsersDueForReceiptScrub(dueBefore, 100)
+		require.NoError(t, err)
+		require.NotContains(t, userIDs, user.Id)
+
+		receipt, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, user.Id)
+		require.NoError(t, err)
+		require.Equal(t, "laptop", receipt.DeviceId)
+		require.NotEmpty(t, receipt.SessionId)
+	})
+
+	t.Run("users deactivated past the window are scrubbed", func(t *testing.T) {
+		user := th.CreateUser()
+		saveReceipt(user)
+
+		_, appErr := th.App.UpdateActive(th.Context, user, false)
+		require.Nil(t, appErr)
+		err := th.App.Srv().Store().PostReadReceipt().FlagUserReceiptsForScrub(user.Id, time.Now().Add(-8*24*time.Hour).UnixMilli())
+		require.NoError(t, err)
+
+		scrubbed, err := th.App.ScrubDeactivatedUsersReadReceipts()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, scrubbed, int64(1))
+
+		receipt, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, user.Id)
+		require.NoError(t, err)
+		require.Empty(t, receipt.DeviceId)
+		require.Empty(t, receipt.SessionId)
+	})
+
+	t.Run("active users left flagged are not scrubbed", func(t *testing.T) {
+		user := th.CreateUser()
+		saveReceipt(user)
+
+		err := th.App.Srv().Store().PostReadReceipt().FlagUserReceiptsForScrub(user.Id, time.Now().Add(-8*24*time.Hour).UnixMilli())
+		require.NoError(t, err)
+
+		_, err = th.App.ScrubDeactivatedUsersReadReceipts()
+		require.NoError(t, err)
+
+		receipt, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, user.Id)
+		require.NoError(t, err)
+		require.Equal(t, "laptop", receipt.DeviceId)
+
+		userIDs, err := th.App.Srv().Store().PostReadReceipt().GetUsersDueForReceiptScrub(dueBefore, 100)
+		require.NoError(t, err)
+		require.NotContains(t, userIDs, user.Id)
+	})
+}
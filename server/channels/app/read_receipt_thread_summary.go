@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// updateThreadReadReceiptSummaries recomputes the read counts of the threads the
+// posts are replies of. It runs along with the summary updates of the posts.
+func (a *App) updateThreadReadReceiptSummaries(c request.CTX, postIDs []string) {
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIDs)
+	if err != nil {
+		c.Logger().Warn("Failed to get the posts to update their thread read summaries", mlog.Err(err))
+		return
+	}
+
+	channelIDs := make(map[string]string)
+	for _, post := range posts {
+		if post.RootId != "" {
+			channelIDs[post.RootId] = post.ChannelId
+		}
+	}
+
+	for rootID, channelID := range channelIDs {
+		counts, err := a.Srv().Store().PostReadReceipt().ComputeThreadReadReceiptSummary(rootID)
+		if err != nil {
+			c.Logger().Warn("Failed to compute thread read summary", mlog.String("root_id", rootID), mlog.Err(err))
+			continue
+		}
+		if err := a.Srv().Store().PostReadReceipt().SaveThreadReadReceiptSummary(rootID, channelID, counts); err != nil {
+			c.Logger().Warn("Failed to update thread read summary", mlog.String("root_id", rootID), mlog.Err(err))
+		}
+	}
+}
+
+// GetThreadReadReceiptSummary returns how far the participants of the thread
+// read its replies, from the read counts kept up to date by the summary updates.
+func (a *App) GetThreadReadReceiptSummary(c request.CTX, rootID string) (*model.ThreadReadReceiptSummary, *model.AppError) {
+	root, appErr := a.GetSinglePost(c, rootID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if root.RootId != "" {
+		return nil, model.NewAppError("GetThreadReadReceiptSummary", "api.read_receipt.thread.not_root.app_error", nil, "post_id="+root.Id, http.StatusBadRequest).WithCode(model.ReadReceiptErrorCodeNotThreadRoot)
+	}
+
+	summary := &model.ThreadReadReceiptSummary{
+		RootId:       root.Id,
+		ChannelId:    root.ChannelId,
+		Participants: []*model.ThreadParticipantReadCount{},
+	}
+
+	thread, err := a.Srv().Store().Thread().Get(root.Id)
+	if err != nil {
+		return nil, model.NewAppError("GetThreadReadReceiptSummary", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if thread == nil {
+		// Posts without replies have no thread.
+		return summary, nil
+	}
+
+	counts, err := a.Srv().Store().PostReadReceipt().GetThreadReadReceiptSummary(root.Id)
+	if err != nil {
+		return nil, model.NewAppError("GetThreadReadReceiptSummary", "app.read_receipt.get_summary.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	repliesRead := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		repliesRead[count.UserId] = count.RepliesRead
+	}
+
+	sample := a.readReceiptSampleForChannel(c, root.ChannelId)
+	summary.Estimated = sample.estimated()
+	summary.ReplyCount = thread.ReplyCount
+	for _, userID := range thread.Participants {
+		participant := &model.ThreadParticipantReadCount{
+			UserId:      userID,
+			RepliesRead: repliesRead[userID],
+		}
+		if !sample.includes(userID) {
+			participant.NotSampled = true
+			summary.Participants = append(summary.Participants, participant)
+			continue
+		}
+		participant.CaughtUp = participant.RepliesRead >= thread.ReplyCount
+		if participant.CaughtUp {
+			summary.CaughtUpCount++
+		}
+		summary.Participants = append(summary.Participants, participant)
+	}
+	summary.ParticipantCount = int64(len(summary.Participants))
+
+	return summary, nil
+}
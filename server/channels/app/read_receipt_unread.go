@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// SetFirstUnreadPostIdForPostList sets FirstUnreadPostId on the list to the oldest
+// post of the list the user has not read yet, so that every client places the new
+// messages line on the same post. Posts of the user and deleted posts never count.
+//
+// A post is unread when it is newer than the LastViewedAt of the user in the
+// channel. With ServiceSettings.ReadReceiptsPreciseUnread, the posts the user could
+// have a receipt for are unread when they have none instead, so that posts scrolled
+// past without being read are not skipped. Posts older than the receipt retention
+// fall back to LastViewedAt, since their receipts may be gone.
+func (a *App) SetFirstUnreadPostIdForPostList(c request.CTX, list *model.PostList, channel *model.Channel, userID string) *model.AppError {
+	list.FirstUnreadPostId = ""
+	if len(list.Order) == 0 {
+		return nil
+	}
+
+	member, appErr := a.GetChannelMember(c, channel.Id, userID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return appErr
+	}
+
+	precise, appErr := a.preciseUnreadForChannel(c, channel, userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	var retentionCutoff int64
+	if days := *a.Config().ServiceSettings.ReadReceiptsRetentionDays; days > 0 {
+		retentionCutoff = model.GetMillisForTime(time.Now().Add(-time.Duration(days) * 24 * time.Hour))
+	}
+	trackedByReceipts := func(post *model.Post) bool {
+		return precise && post.CreateAt > retentionCutoff && model.IsReadReceiptPostType(post.Type) && !post.ReadReceiptsDisabled()
+	}
+
+	// Order lists the newest post first.
+	var candidates []*model.Post
+	var trackedIDs []string
+	for i := len(list.Order) - 1; i >= 0; i-- {
+		post, ok := list.Posts[list.Order[i]]
+		if !ok || post.UserId == userID || post.DeleteAt != 0 {
+			continue
+		}
+		candidates = append(candidates, post)
+		if trackedByReceipts(post) {
+			trackedIDs = append(trackedIDs, post.Id)
+		}
+	}
+
+	read := make(map[string]bool, len(trackedIDs))
+	if len(trackedIDs) > 0 {
+		readIDs, err := a.Srv().Store().PostReadReceipt().GetReadPostIdsForUser(userID, trackedIDs)
+		if err != nil {
+			return model.NewAppError("SetFirstUnreadPostIdForPostList", "app.read_receipt.get_read_post_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, id := range readIDs {
+			read[id] = true
+		}
+	}
+
+	for _, post := range candidates {
+		unread := post.CreateAt > member.LastViewedAt
+		if trackedByReceipts(post) {
+			unread = !read[post.Id]
+		}
+		if unread {
+			list.FirstUnreadPostId = post.Id
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// preciseUnreadForChannel reports whether the unread posts of the user in the
+// channel are told by their receipts, see SetFirstUnreadPostIdForPostList.
+func (a *App) preciseUnreadForChannel(c request.CTX, channel *model.Channel, userID string) (bool, *model.AppError) {
+	if !*a.Config().ServiceSettings.ReadReceiptsPreciseUnread || channel.DeleteAt != 0 {
+		return false, nil
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil || !enabled {
+		return false, appErr
+	}
+
+	return a.UserHasReadReceiptsEnabled(userID), nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSetFirstUnreadPostIdForPostList(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	th.EnableReadReceipts()
+
+	channel := th.CreateChannel(th.Context, th.BasicTeam)
+	th.AddUserToChannel(th.BasicUser2, channel)
+
+	base := model.GetMillis() - 10000
+	createdAt := func(createAt int64) PostOptions {
+		return func(post *model.Post) { post.CreateAt = createAt }
+	}
+	post1 := th.CreatePost(channel, createdAt(base))
+	post2 := th.CreatePost(channel, createdAt(base+1))
+	post3 := th.CreatePost(channel, createdAt(base+2))
+
+	_, appErr := th.App.MarkChannelAsUnreadFromPost(th.Context, post2.Id, th.BasicUser2.Id, true)
+	require.Nil(t, appErr)
+
+	firstUnread := func(t *testing.T, userID string) string {
+		t.Helper()
+		list, appErr := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: channel.Id, PerPage: 10})
+		require.Nil(t, appErr)
+		require.Nil(t, th.App.SetFirstUnreadPostIdForPostList(th.Context, list, channel, userID))
+		return list.FirstUnreadPostId
+	}
+
+	t.Run("from the last viewed time", func(t *testing.T) {
+		require.Equal(t, post2.Id, firstUnread(t, th.BasicUser2.Id))
+	})
+
+	t.Run("own posts are never unread", func(t *testing.T) {
+		require.Empty(t, firstUnread(t, th.BasicUser.Id))
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		require.Empty(t, firstUnread(t, th.CreateUser().Id))
+	})
+
+	t.Run("from the receipts in precise mode", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsPreciseUnread = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsPreciseUnread = false
+		})
+
+		// The first post was viewed but never read.
+		require.Equal(t, post1.Id, firstUnread(t, th.BasicUser2.Id))
+
+		th.MarkPostAsRead(post1, th.BasicUser2)
+		th.MarkPostAsRead(post2, th.BasicUser2)
+		require.Equal(t, post3.Id, firstUnread(t, th.BasicUser2.Id))
+	})
+
+	t.Run("precise mode without receipts in the channel", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsPreciseUnread = true
+			*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = false
+		})
+		defer th.EnableReadReceipts()
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsPreciseUnread = false
+		})
+
+		require.Equal(t, post2.Id, firstUnread(t, th.BasicUser2.Id))
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// readReceiptWebhookBackoff is how long to wait after each failed delivery
+// before trying again.
+var readReceiptWebhookBackoff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}
+
+func (a *App) GetReadReceiptWebhooksForChannel(c request.CTX, channelID string) ([]*model.ReadReceiptWebhook, *model.AppError) {
+	hooks, err := a.Srv().Store().ReadReceiptWebhook().GetForChannel(channelID)
+	if err != nil {
+		return nil, model.NewAppError("GetReadReceiptWebhooksForChannel", "app.read_receipt_webhook.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return hooks, nil
+}
+
+func (a *App) CreateReadReceiptWebhook(c request.CTX, hook *model.ReadReceiptWebhook) (*model.ReadReceiptWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("CreateReadReceiptWebhook", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hooks, appErr := a.GetReadReceiptWebhooksForChannel(c, hook.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(hooks) >= model.ReadReceiptWebhooksPerChannelMax {
+		return nil, model.NewAppError("CreateReadReceiptWebhook", "app.read_receipt_webhook.create.too_many.app_error", map[string]any{"Max": model.ReadReceiptWebhooksPerChannelMax}, "channel_id="+hook.ChannelId, http.StatusBadRequest)
+	}
+
+	saved, err := a.Srv().Store().ReadReceiptWebhook().Save(hook)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateReadReceiptWebhook", "app.read_receipt_webhook.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+// DeleteReadReceiptWebhook removes a webhook of the channel. Webhooks of other
+// channels are reported as not found.
+func (a *App) DeleteReadReceiptWebhook(c request.CTX, channelID, hookID string) *model.AppError {
+	hook, err := a.Srv().Store().ReadReceiptWebhook().Get(hookID)
+	var nfErr *store.ErrNotFound
+	switch {
+	case errors.As(err, &nfErr) || (err == nil && hook.ChannelId != channelID):
+		return model.NewAppError("DeleteReadReceiptWebhook", "app.read_receipt_webhook.get.not_found.app_error", nil, "id="+hookID, http.StatusNotFound)
+	case err != nil:
+		return model.NewAppError("DeleteReadReceiptWebhook", "app.read_receipt_webhook.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().ReadReceiptWebhook().Delete(hook.Id); err != nil {
+		return model.NewAppError("DeleteReadReceiptWebhook", "app.read_receipt_webhook.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// isKeyPostForReadReceipts reports whether the post is pinned or has a priority,
+// the posts read receipt webhooks are notified about.
+func (a *App) isKeyPostForReadReceipts(post *model.Post) (bool, *model.AppError) {
+	if post.IsPinned {
+		return true, nil
+	}
+
+	if !a.IsPostPriorityEnabled() {
+		return false, nil
+	}
+
+	priority, appErr := a.GetPriorityForPost(post.Id)
+	if appErr != nil {
+		return false, appErr
+	}
+	return priority != nil && priority.Priority != nil && *priority.Priority != "", nil
+}
+
+// notifyReadReceiptWebhooks delivers a digest to the webhooks of the channel of
+// the post when its read count went from previousReadCount to the one of the
+// summary and crossed one of model.ReadReceiptWebhookThresholds.
+func (a *App) notifyReadReceiptWebhooks(c request.CTX, previousReadCount int64, summary *model.PostReadReceiptSummary) {
+	if summary.ReadCount <= previousReadCount || !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return
+	}
+
+	hooks, err := a.Srv().Store().ReadReceiptWebhook().GetForChannel(summary.ChannelId)
+	if err != nil {
+		c.Logger().Warn("Failed to get read receipt webhooks", mlog.String("channel_id", summary.ChannelId), mlog.Err(err))
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	totalMembers, err := a.Srv().Store().PostReadReceipt().GetHumanMemberCount(summary.ChannelId)
+	if err != nil {
+		c.Logger().Warn("Failed to count channel members for read receipt webhooks", mlog.String("channel_id", summary.ChannelId), mlog.Err(err))
+		return
+	}
+
+	sample := a.readReceiptSampleForChannel(c, summary.ChannelId)
+	readCount := sample.readCount(summary.ReadCount)
+	thresholds := model.ReadReceiptThresholdsCrossed(sample.readCount(previousReadCount), readCount, totalMembers)
+	if len(thresholds) == 0 {
+		return
+	}
+
+	post, appErr := a.GetSinglePost(c, summary.PostId, false)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get post for read receipt webhooks", mlog.String("post_id", summary.PostId), mlog.Err(appErr))
+		return
+	}
+	isKeyPost, appErr := a.isKeyPostForReadReceipts(post)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get post priority for read receipt webhooks", mlog.String("post_id", summary.PostId), mlog.Err(appErr))
+		return
+	}
+	if !isKeyPost {
+		return
+	}
+
+	readPercentage := model.ReadReceiptPercentage(readCount, totalMembers)
+	payload := model.ReadReceiptWebhookPayload{
+		ChannelId: summary.ChannelId,
+		Timestamp: model.GetMillis(),
+		Posts:     make([]*model.ReadReceiptWebhookPost, 0, len(thresholds)),
+	}
+	for _, threshold := range thresholds {
+		payload.Posts = append(payload.Posts, &model.ReadReceiptWebhookPost{
+			PostId:         summary.PostId,
+			Threshold:      threshold,
+			ReadCount:      readCount,
+			TotalMembers:   totalMembers,
+			ReadPercentage: readPercentage,
+			Estimated:      sample.estimated(),
+		})
+	}
+
+	for _, hook := range hooks {
+		a.Srv().Go(func() {
+			a.deliverReadReceiptWebhook(c, hook, payload)
+		})
+	}
+}
+
+// deliverReadReceiptWebhook POSTs the payload to the webhook, signed with its
+// secret, retrying with readReceiptWebhookBackoff until it is accepted.
+func (a *App) deliverReadReceiptWebhook(c request.CTX, hook *model.ReadReceiptWebhook, payload model.ReadReceiptWebhookPayload) {
+	payload.WebhookId = hook.Id
+	body, err := json.Marshal(payload)
+	if err != nil {
+		c.Logger().Warn("Failed to encode read receipt webhook payload", mlog.String("hook_id", hook.Id), mlog.Err(err))
+		return
+	}
+
+	mac := hmac.New(sha256.New, []byte(hook.Secret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	err = utils.CustomProgressiveRetry(func() error {
+		return a.doReadReceiptWebhookRequest(hook.URL, body, signature)
+	}, readReceiptWebhookBackoff)
+	if err != nil {
+		c.Logger().Warn("Failed to deliver read receipt webhook", mlog.String("hook_id", hook.Id), mlog.String("channel_id", hook.ChannelId), mlog.Err(err))
+	}
+}
+
+func (a *App) doReadReceiptWebhookRequest(url string, body []byte, signature string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(model.ReadReceiptWebhookSignatureHeader, signature)
+
+	resp, err := a.Srv().outgoingWebhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, MaxIntegrationResponseSize)); err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
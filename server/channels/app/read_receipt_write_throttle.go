@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+type readReceiptWriteKey struct {
+	userID    string
+	channelID string
+}
+
+// pendingReadReceiptWrite is a read held back by the throttle, along with the
+// context of the request that made it, detached from its cancellation.
+type pendingReadReceiptWrite struct {
+	c      request.CTX
+	userID string
+	req    model.ReadReceiptRequest
+	// receivedAt is when the read reached the server, which times the receipt
+	// of reads the client did not time once it is written.
+	receivedAt int64
+}
+
+// readAt returns the time the read was made, by the clock of the client when
+// it timed the read.
+func (p *pendingReadReceiptWrite) readAt() int64 {
+	if p.req.ReadAt != 0 {
+		return p.req.ReadAt
+	}
+	return p.receivedAt
+}
+
+type readReceiptWriteSlot struct {
+	timer *time.Timer
+	// pending holds the newest read of each post held back, in the order the posts
+	// were first read.
+	pending []*pendingReadReceiptWrite
+}
+
+// readReceiptWriteThrottle spaces out the receipt writes of a user in a channel,
+// for clients that report a read for every post they scroll past. Once a write
+// goes through, the reads that follow within the interval are held back and
+// written together when the interval elapses, one per post.
+type readReceiptWriteThrottle struct {
+	mut     sync.Mutex
+	slots   map[readReceiptWriteKey]*readReceiptWriteSlot
+	stopped bool
+
+	write func(c request.CTX, userID string, req *model.ReadReceiptRequest, receivedAt int64)
+}
+
+func newReadReceiptWriteThrottle(write func(c request.CTX, userID string, req *model.ReadReceiptRequest, receivedAt int64)) *readReceiptWriteThrottle {
+	return &readReceiptWriteThrottle{
+		slots: make(map[readReceiptWriteKey]*readReceiptWriteSlot),
+		write: write,
+	}
+}
+
+// coalesce reports whether the read must wait because the user had a receipt
+// written in the channel less than interval ago. Otherwise the caller writes the
+// read right away and the following reads are held back for interval.
+func (t *readReceiptWriteThrottle) coalesce(c request.CTX, userID, channelID string, req *model.ReadReceiptRequest, interval time.Duration) bool {
+	key := readReceiptWriteKey{userID: userID, channelID: channelID}
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if t.stopped {
+		return false
+	}
+
+	slot, ok := t.slots[key]
+	if !ok {
+		t.slots[key] = &readReceiptWriteSlot{
+			timer: time.AfterFunc(interval, func() { t.release(key, interval) }),
+		}
+		return false
+	}
+
+	pending := &pendingReadReceiptWrite{
+		c:          c.WithContext(context.WithoutCancel(c.Context())),
+		userID:     userID,
+		req:        *req,
+		receivedAt: model.GetMillis(),
+	}
+	for i, held := range slot.pending {
+		if held.req.PostId == pending.req.PostId {
+			if pending.readAt() >= held.readAt() {
+				slot.pending[i] = pending
+			}
+			return true
+		}
+	}
+	slot.pending = append(slot.pending, pending)
+
+	return true
+}
+
+// release writes the reads held back for the user in the channel, if any, and
+// holds back the following ones for another interval. Without any, the channel
+// is open to writes again.
+func (t *readReceiptWriteThrottle) release(key readReceiptWriteKey, interval time.Duration) {
+	t.mut.Lock()
+	slot, ok := t.slots[key]
+	if !ok || t.stopped {
+		t.mut.Unlock()
+		return
+	}
+	if len(slot.pending) == 0 {
+		delete(t.slots, key)
+		t.mut.Unlock()
+		return
+	}
+
+	pending := slot.pending
+	slot.pending = nil
+	slot.timer = time.AfterFunc(interval, func() { t.release(key, interval) })
+	t.mut.Unlock()
+
+	for _, p := range pending {
+		t.write(p.c, p.userID, &p.req, p.receivedAt)
+	}
+}
+
+// stopAndFlush writes the reads still held back. Reads made afterwards are never
+// coalesced.
+func (t *readReceiptWriteThrottle) stopAndFlush() {
+	t.mut.Lock()
+	t.stopped = true
+	var pending []*pendingReadReceiptWrite
+	for _, slot := range t.slots {
+		slot.timer.Stop()
+		pending = append(pending, slot.pending...)
+	}
+	t.slots = make(map[readReceiptWriteKey]*readReceiptWriteSlot)
+	t.mut.Unlock()
+
+	for _, p := range pending {
+		t.write(p.c, p.userID, &p.req, p.receivedAt)
+	}
+}
+
+// CoalesceReadReceiptForPost reports whether the read is held back because the
+// user had a receipt written in the channel of the post less than
+// ServiceSettings.ReadReceiptsMinWriteIntervalMs ago, or readReceiptQuotaMinWriteInterval
+// while the workspace is over its cloud receipt quota. Every post read while held
+// back gets its receipt once the interval elapses, with the newest read of it.
+// Reads SaveReadReceiptForPost would reject are rejected right away with the same
+// error, and reads that make no receipt are never held back.
+func (a *App) CoalesceReadReceiptForPost(c request.CTX, userID string, req *model.ReadReceiptRequest) (bool, *model.AppError) {
+	interval := time.Duration(*a.Config().ServiceSettings.ReadReceiptsMinWriteIntervalMs) * time.Millisecond
+	if a.ReadReceiptsOverCloudQuota(c) {
+		interval = max(interval, readReceiptQuotaMinWriteInterval)
+	}
+	if interval == 0 || req.Ghost {
+		return false, nil
+	}
+
+	_, channel, appErr := a.checkReadReceiptForPost(c, "CoalesceReadReceiptForPost", userID, req)
+	if appErr != nil {
+		return false, appErr
+	}
+	// SaveReadReceiptForPost audits, skips or records as ghost reads the reads made
+	// on behalf of the user, through an opted out session or while paused.
+	if request.ImpersonatedBy(c) != "" || c.Session().ReadReceiptsOptedOut() || a.readReceiptsPausedUntil(userID) > 0 {
+		return false, nil
+	}
+
+	return a.ch.readReceiptWriteThrottle.coalesce(c, userID, channel.Id, req, interval), nil
+}
+
+// writeCoalescedReadReceipt saves a read the throttle held back.
+func (a *App) writeCoalescedReadReceipt(c request.CTX, userID string, req *model.ReadReceiptRequest, receivedAt int64) {
+	if _, _, appErr := a.saveReadReceiptForPost(c, userID, req, receivedAt); appErr != nil {
+		c.Logger().Warn("Failed to save a coalesced read receipt", mlog.String("post_id", req.PostId), mlog.String("user_id", userID), mlog.Err(appErr))
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func TestReadReceiptWriteThrottle(t *testing.T) {
+	c := request.TestContext(t)
+
+	var mut sync.Mutex
+	var written []model.ReadReceiptRequest
+	var receivedAt []int64
+	throttle := newReadReceiptWriteThrottle(func(c request.CTX, userID string, req *model.ReadReceiptRequest, at int64) {
+		mut.Lock()
+		defer mut.Unlock()
+		written = append(written, *req)
+		receivedAt = append(receivedAt, at)
+	})
+	getWritten := func() []model.ReadReceiptRequest {
+		mut.Lock()
+		defer mut.Unlock()
+		return append([]model.ReadReceiptRequest(nil), written...)
+	}
+
+	userID := model.NewId()
+	channelID := model.NewId()
+	interval := 50 * time.Millisecond
+
+	t.Run("every post read within the interval is written", func(t *testing.T) {
+		require.False(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post1", ReadAt: 1000}, interval))
+		require.True(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post2", ReadAt: 2000}, interval))
+		require.True(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post3", ReadAt: 3000}, interval))
+		require.True(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post2", ReadAt: 4000}, interval))
+
+		// Other channels and users are not held back.
+		require.False(t, throttle.coalesce(c, userID, model.NewId(), &model.ReadReceiptRequest{PostId: "post4", ReadAt: 1000}, interval))
+		require.False(t, throttle.coalesce(c, model.NewId(), channelID, &model.ReadReceiptRequest{PostId: "post5", ReadAt: 1000}, interval))
+
+		require.Eventually(t, func() bool { return len(getWritten()) == 2 }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, "post2", getWritten()[0].PostId)
+		assert.Equal(t, int64(4000), getWritten()[0].ReadAt)
+		assert.Equal(t, "post3", getWritten()[1].PostId)
+		assert.Equal(t, int64(3000), getWritten()[1].ReadAt)
+	})
+
+	t.Run("the channel opens again once nothing is held back", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			throttle.mut.Lock()
+			defer throttle.mut.Unlock()
+			return len(throttle.slots) == 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.False(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post6"}, interval))
+	})
+
+	t.Run("stopping writes the reads held back", func(t *testing.T) {
+		otherChannelID := model.NewId()
+		require.False(t, throttle.coalesce(c, userID, otherChannelID, &model.ReadReceiptRequest{PostId: "post7"}, time.Hour))
+		require.True(t, throttle.coalesce(c, userID, otherChannelID, &model.ReadReceiptRequest{PostId: "post8"}, time.Hour))
+
+		throttle.stopAndFlush()
+
+		writes := getWritten()
+		require.NotEmpty(t, writes)
+		last := writes[len(writes)-1]
+		assert.Equal(t, "post8", last.PostId)
+		// The read was not timed by the client, which is kept so that it is not
+		// taken for one that was.
+		assert.Zero(t, last.ReadAt)
+		mut.Lock()
+		assert.NotZero(t, receivedAt[len(receivedAt)-1])
+		mut.Unlock()
+
+		assert.False(t, throttle.coalesce(c, userID, channelID, &model.ReadReceiptRequest{PostId: "post9"}, interval))
+	})
+}
+
+func TestCoalesceReadReceiptForPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.EnableReadReceipts()
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.ReadReceiptsMinWriteIntervalMs = 100
+	})
+
+	first := th.CreatePost(th.BasicChannel)
+	_, _, appErr := th.App.SaveReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: first.Id})
+	require.Nil(t, appErr)
+	coalesced, appErr := th.App.CoalesceReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: first.Id})
+	require.Nil(t, appErr)
+	require.False(t, coalesced)
+
+	var posts []*model.Post
+	for range 3 {
+		post := th.CreatePost(th.BasicChannel)
+		coalesced, appErr := th.App.CoalesceReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		require.True(t, coalesced)
+		posts = append(posts, post)
+	}
+
+	for _, post := range posts {
+		require.Eventually(t, func() bool {
+			_, err := th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser2.Id)
+			return err == nil
+		}, 5*time.Second, 20*time.Millisecond, "post %s was never marked as read", post.Id)
+	}
+
+	t.Run("reads timed by the server stay timed by the server", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsServerTimestamps = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsServerTimestamps = false })
+
+		post := th.CreatePost(th.BasicChannel)
+		coalesced, appErr := th.App.CoalesceReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.Nil(t, appErr)
+		require.True(t, coalesced)
+
+		var receipt *model.PostReadReceipt
+		require.Eventually(t, func() bool {
+			var err error
+			receipt, err = th.App.Srv().Store().PostReadReceipt().GetReadReceipt(post.Id, th.BasicUser2.Id)
+			return err == nil
+		}, 5*time.Second, 20*time.Millisecond)
+		require.Zero(t, receipt.ClientReadAt)
+		require.NotZero(t, receipt.ReadAt)
+	})
+
+	t.Run("rejected reads are not held back", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		_, appErr := th.App.CoalesceReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id, Source: "junk"})
+		require.NotNil(t, appErr)
+		require.Equal(t, model.ReadReceiptErrorCodeInvalidSource, appErr.Code)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsEnabledDefaultOff
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn })
+
+		_, appErr = th.App.CoalesceReadReceiptForPost(th.Context, th.BasicUser2.Id, &model.ReadReceiptRequest{PostId: post.Id})
+		require.NotNil(t, appErr)
+		require.Equal(t, model.ReadReceiptErrorCodeUserOptedOut, appErr.Code)
+	})
+}
@@ -189,6 +189,27 @@ func (a *App) GetUsersForReporting(filter *model.UserReportOptions) ([]*model.Us
 	return userReports, nil
 }
 
+// GetChannelArchiveRecommendations returns the channels their members stopped
+// reading, according to the daily read receipt rollup, to guide the cleanup of
+// the workspace.
+func (a *App) GetChannelArchiveRecommendations(rctx request.CTX, opts *model.ChannelArchiveReportOptions) ([]*model.ChannelArchiveRecommendation, *model.AppError) {
+	if appErr := opts.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	opts.SamplingThreshold = *a.Config().ServiceSettings.ReadReceiptsSamplingThreshold
+	recommendations, err := a.Srv().Store().PostReadReceipt().GetChannelArchiveRecommendations(opts)
+	if err != nil {
+		return nil, model.NewAppError("GetChannelArchiveRecommendations", "app.report.get_channel_archive_recommendations.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, recommendation := range recommendations {
+		recommendation.Estimated = a.readReceiptSampleForChannel(rctx, recommendation.ChannelId).estimated()
+	}
+
+	return recommendations, nil
+}
+
 func (a *App) GetUserCountForReport(filter *model.UserReportOptions) (*int64, *model.AppError) {
 	count, err := a.Srv().Store().User().GetUserCountForReport(filter)
 	if err != nil {
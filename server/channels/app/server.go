@@ -60,9 +60,15 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/read_receipts_changes_prune"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/read_receipts_cleanup"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/read_receipts_compaction"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/read_receipts_offload"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/read_receipts_scrub"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_materialized_views"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/unread_dm_nudge"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
@@ -117,11 +123,16 @@ type Server struct {
 
 	timezones *timezones.Timezones
 
-	htmlTemplateWatcher     *templates.Container
-	seenPendingPostIdsCache cache.Cache
-	openGraphDataCache      cache.Cache
-	clusterLeaderListenerId string
-	loggerLicenseListenerId string
+	htmlTemplateWatcher               *templates.Container
+	seenPendingPostIdsCache           cache.Cache
+	openGraphDataCache                cache.Cache
+	readReceiptExportsCache           cache.Cache
+	readReceiptWatermarkChannelsCache cache.Cache
+	readReceiptChannelSettingsCache   cache.Cache
+	readReceiptPostReadCache          cache.Cache
+	readReceiptEventOmitUsersCache    cache.Cache
+	clusterLeaderListenerId           string
+	loggerLicenseListenerId           string
 
 	platform         *platform.PlatformService
 	platformOptions  []platform.Option
@@ -316,6 +327,36 @@ func NewServer(options ...Option) (*Server, error) {
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create opengraphdata cache")
 	}
+	if s.readReceiptExportsCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "read_receipt_exports",
+		Size: readReceiptExportsCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create read receipt exports cache")
+	}
+	if s.readReceiptWatermarkChannelsCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "read_receipt_watermark_channels",
+		Size: readReceiptWatermarkChannelsCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create read receipt watermark channels cache")
+	}
+	if s.readReceiptChannelSettingsCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "read_receipt_channel_settings",
+		Size: readReceiptChannelSettingsCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create read receipt channel settings cache")
+	}
+	if s.readReceiptPostReadCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "read_receipt_post_read",
+		Size: readReceiptPostReadCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create read receipt post read cache")
+	}
+	if s.readReceiptEventOmitUsersCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "read_receipt_event_omit_users",
+		Size: readReceiptEventOmitUsersCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create read receipt event omit users cache")
+	}
 
 	s.createPushNotificationsHub(request.EmptyContext(s.Log()))
 
@@ -479,6 +520,7 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		appInstance := New(ServerConnector(s.Channels()))
 		runDNDStatusExpireJob(appInstance)
+		runReadReceiptPauseExpireJob(appInstance)
 		runPostReminderJob(appInstance)
 		runScheduledPostJob(appInstance)
 	})
@@ -1486,6 +1528,42 @@ func (s *Server) initJobs() {
 		expirynotify.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeUnreadDMNudge,
+		unread_dm_nudge.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())).SendUnreadDirectMessageNudges),
+		unread_dm_nudge.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReadReceiptsCleanup,
+		read_receipts_cleanup.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		read_receipts_cleanup.MakeScheduler(s.Jobs, New(ServerConnector(s.Channels()))),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReadReceiptsScrub,
+		read_receipts_scrub.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		read_receipts_scrub.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReadReceiptsOffload,
+		read_receipts_offload.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		read_receipts_offload.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReadReceiptsChangesPrune,
+		read_receipts_changes_prune.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		read_receipts_changes_prune.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReadReceiptsCompaction,
+		read_receipts_compaction.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		read_receipts_compaction.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeProductNotices,
 		product_notices.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
@@ -1760,6 +1838,24 @@ func runDNDStatusExpireJob(a *App) {
 	})
 }
 
+func runReadReceiptPauseExpireJob(a *App) {
+	if a.IsLeader() {
+		withMut(&a.ch.readReceiptPauseMut, func() {
+			a.ch.readReceiptPauseTask = model.CreateRecurringTaskFromNextIntervalTime("Resume Read Receipts", a.ResumeExpiredReadReceiptPauses, readReceiptPauseExpiryInterval)
+		})
+	}
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if resume read receipts task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		if a.IsLeader() {
+			withMut(&a.ch.readReceiptPauseMut, func() {
+				a.ch.readReceiptPauseTask = model.CreateRecurringTaskFromNextIntervalTime("Resume Read Receipts", a.ResumeExpiredReadReceiptPauses, readReceiptPauseExpiryInterval)
+			})
+		} else {
+			cancelTask(&a.ch.readReceiptPauseMut, &a.ch.readReceiptPauseTask)
+		}
+	})
+}
+
 func runPostReminderJob(a *App) {
 	if a.IsLeader() {
 		rctx := request.EmptyContext(a.Log())
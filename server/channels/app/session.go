@@ -466,6 +466,9 @@ func (a *App) createSessionForUserAccessToken(c request.CTX, tokenString string)
 
 	session.AddProp(model.SessionPropUserAccessTokenId, token.Id)
 	session.AddProp(model.SessionPropType, model.SessionTypeUserAccessToken)
+	if token.Scope != "" {
+		session.AddProp(model.SessionPropUserAccessTokenScope, token.Scope)
+	}
 	if user.IsBot {
 		session.AddProp(model.SessionPropIsBot, model.SessionPropIsBotValue)
 	}
@@ -474,6 +477,11 @@ func (a *App) createSessionForUserAccessToken(c request.CTX, tokenString string)
 	} else {
 		session.AddProp(model.SessionPropIsGuest, "false")
 	}
+	// A token another user created for this one, typically an administrator,
+	// lets them act as the user.
+	if token.CreatorId != "" && token.CreatorId != user.Id && !user.IsBot {
+		session.AddProp(model.SessionPropImpersonatedBy, token.CreatorId)
+	}
 	a.ch.srv.platform.SetSessionExpireInHours(session, model.SessionUserAccessTokenExpiryHours)
 
 	session, nErr = a.Srv().Store().Session().Save(c, session)
@@ -455,6 +455,50 @@ func TestSessionsLimit(t *testing.T) {
 	}
 }
 
+func TestImpersonatedSessions(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	tokenSession := func(t *testing.T, token *model.UserAccessToken) *model.Session {
+		t.Helper()
+		token, appErr := th.App.CreateUserAccessToken(th.Context, token)
+		require.Nil(t, appErr)
+		session, appErr := th.App.createSessionForUserAccessToken(th.Context, token.Token)
+		require.Nil(t, appErr)
+		return session
+	}
+
+	t.Run("token created by the user", func(t *testing.T) {
+		session := tokenSession(t, &model.UserAccessToken{UserId: th.BasicUser.Id, CreatorId: th.BasicUser.Id, Description: "own"})
+		assert.Empty(t, session.ImpersonatedBy())
+	})
+
+	t.Run("token created by an administrator", func(t *testing.T) {
+		session := tokenSession(t, &model.UserAccessToken{UserId: th.BasicUser.Id, CreatorId: th.SystemAdminUser.Id, Description: "admin"})
+		assert.Equal(t, th.SystemAdminUser.Id, session.ImpersonatedBy())
+
+		// The session is loaded with the prop on later requests.
+		session, appErr := th.App.GetSession(session.Token)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.SystemAdminUser.Id, session.ImpersonatedBy())
+	})
+
+	t.Run("bot token created by its owner", func(t *testing.T) {
+		bot := th.CreateBot()
+		session := tokenSession(t, &model.UserAccessToken{UserId: bot.UserId, CreatorId: th.BasicUser.Id, Description: "bot"})
+		assert.Empty(t, session.ImpersonatedBy())
+	})
+
+	t.Run("session created by a plugin", func(t *testing.T) {
+		session, appErr := th.SetupPluginAPI().CreateSession(&model.Session{UserId: th.BasicUser.Id})
+		require.Nil(t, appErr)
+		assert.Equal(t, "pluginid", session.ImpersonatedBy())
+	})
+}
+
 func TestSetExtraSessionProps(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic()
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type CaughtUpProvider struct {
+}
+
+const (
+	CmdCaughtUp = "caughtup"
+
+	// caughtUpListLimit is the number of members listed by the command.
+	caughtUpListLimit = 50
+)
+
+func init() {
+	app.RegisterCommandProvider(&CaughtUpProvider{})
+}
+
+func (*CaughtUpProvider) GetTrigger() string {
+	return CmdCaughtUp
+}
+
+func (*CaughtUpProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return nil
+	}
+
+	return &model.Command{
+		Trigger:          CmdCaughtUp,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_caughtup.desc"),
+		DisplayName:      T("api.command_caughtup.name"),
+	}
+}
+
+func (*CaughtUpProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	channel, appErr := a.GetChannel(c, args.ChannelId)
+	if appErr != nil {
+		return &model.CommandResponse{Text: args.T("api.command_caughtup.channel.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	if !a.HasPermissionToChannel(c, args.UserId, channel.Id, model.PermissionReadChannelContent) ||
+		!a.HasPermissionToChannel(c, args.UserId, channel.Id, model.PermissionViewReadReceipts) {
+		return &model.CommandResponse{Text: args.T("api.command_caughtup.permission.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	enabled, appErr := a.ReadReceiptsEnabledForChannel(c, channel)
+	if appErr != nil || !enabled {
+		return &model.CommandResponse{Text: args.T("api.command_caughtup.disabled.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	if appErr := a.CheckReadReceiptsVisibleForChannel(c, args.UserId, channel.Id); appErr != nil {
+		return &model.CommandResponse{Text: args.T("api.command_caughtup.permission.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	// One extra member tells whether the list is complete.
+	users, appErr := a.GetCaughtUpUsersForChannel(c, args.UserId, channel.Id, caughtUpListLimit+1)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the caught up members of the channel", mlog.String("channel_id", channel.Id), mlog.Err(appErr))
+		return &model.CommandResponse{Text: args.T("api.command_caughtup.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	var text string
+	switch {
+	case len(users) == 0:
+		text = args.T("api.command_caughtup.none")
+	case len(users) > caughtUpListLimit:
+		text = args.T("api.command_caughtup.list_more", map[string]any{"Usernames": caughtUpUsernames(users[:caughtUpListLimit])})
+	default:
+		text = args.T("api.command_caughtup.list", map[string]any{"Usernames": caughtUpUsernames(users)})
+	}
+
+	return &model.CommandResponse{Text: text, ResponseType: model.CommandResponseTypeEphemeral}
+}
+
+func caughtUpUsernames(users []*model.User) string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, "@"+user.Username)
+	}
+	return strings.Join(usernames, ", ")
+}
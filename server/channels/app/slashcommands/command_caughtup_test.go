@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+)
+
+func TestCaughtUpCommand(t *testing.T) {
+	th := setupConfig(t, func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsDefaultSetting = model.ReadReceiptsAlwaysOn
+		*cfg.ServiceSettings.ReadReceiptsEnableTeamChannels = true
+	}).initBasic(t)
+	th.addUserToChannel(t, th.BasicUser2, th.BasicChannel)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "latest",
+	}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	cmd := &CaughtUpProvider{}
+	args := &model.CommandArgs{
+		T:         i18n.IdentityTfunc(),
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	t.Run("only the author read the latest post", func(t *testing.T) {
+		resp := cmd.DoCommand(th.App, th.Context, args, "")
+		assert.Equal(t, "api.command_caughtup.list", resp.Text)
+		assert.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
+
+		users, appErr := th.App.GetCaughtUpUsersForChannel(th.Context, th.BasicUser.Id, th.BasicChannel.Id, 10)
+		require.Nil(t, appErr)
+		require.Len(t, users, 1)
+		assert.Equal(t, th.BasicUser2.Id, users[0].Id)
+	})
+
+	t.Run("readers of the latest post are caught up", func(t *testing.T) {
+		_, err := th.App.Srv().Store().PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    th.BasicUser.Id,
+			ChannelId: post.ChannelId,
+			ReadAt:    model.GetMillis(),
+		})
+		require.NoError(t, err)
+
+		users, appErr := th.App.GetCaughtUpUsersForChannel(th.Context, th.BasicUser.Id, th.BasicChannel.Id, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, users, 2)
+	})
+
+	t.Run("requires access to the channel", func(t *testing.T) {
+		private := th.createPrivateChannel(t, th.BasicTeam)
+
+		resp := cmd.DoCommand(th.App, th.Context, &model.CommandArgs{
+			T:         i18n.IdentityTfunc(),
+			ChannelId: private.Id,
+			UserId:    th.BasicUser2.Id,
+		}, "")
+		assert.Equal(t, "api.command_caughtup.permission.app_error", resp.Text)
+	})
+
+	t.Run("hidden when read receipts are disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableReadReceipts = true })
+
+		assert.Nil(t, cmd.GetCommand(th.App, i18n.IdentityTfunc()))
+	})
+}
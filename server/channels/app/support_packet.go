@@ -21,12 +21,13 @@ import (
 
 func (a *App) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData {
 	functions := map[string]func(c request.CTX) (*model.FileData, error){
-		"metadata":    a.getSupportPacketMetadata,
-		"stats":       a.getSupportPacketStats,
-		"jobs":        a.getSupportPacketJobList,
-		"permissions": a.getSupportPacketPermissionsInfo,
-		"plugins":     a.getPluginsFile,
-		"schema":      a.getSupportPacketDatabaseSchema,
+		"metadata":      a.getSupportPacketMetadata,
+		"stats":         a.getSupportPacketStats,
+		"jobs":          a.getSupportPacketJobList,
+		"permissions":   a.getSupportPacketPermissionsInfo,
+		"plugins":       a.getPluginsFile,
+		"schema":        a.getSupportPacketDatabaseSchema,
+		"read_receipts": a.getSupportPacketReadReceipts,
 	}
 
 	var (
@@ -277,6 +278,89 @@ func (a *App) getSupportPacketJobList(rctx request.CTX) (*model.FileData, error)
 	return fileData, rErr.ErrorOrNil()
 }
 
+func (a *App) getSupportPacketReadReceipts(rctx request.CTX) (*model.FileData, error) {
+	const numberOfJobsRuns = 5
+
+	var (
+		rErr     *multierror.Error
+		err      error
+		receipts model.SupportPacketReadReceipts
+	)
+
+	ss := a.Config().ServiceSettings
+	receipts.Config = model.SupportPacketReadReceiptsConfig{
+		EnableReadReceipts:                  ss.EnableReadReceipts,
+		ReadReceiptsDefaultSetting:          ss.ReadReceiptsDefaultSetting,
+		ReadReceiptsMaxGroupSize:            ss.ReadReceiptsMaxGroupSize,
+		ReadReceiptsRetentionDays:           ss.ReadReceiptsRetentionDays,
+		ReadReceiptsEnableGhostMode:         ss.ReadReceiptsEnableGhostMode,
+		ReadReceiptsRequireAuditLog:         ss.ReadReceiptsRequireAuditLog,
+		ReadReceiptsBusinessHoursOnly:       ss.ReadReceiptsBusinessHoursOnly,
+		ReadReceiptsEnableDeviceTracking:    ss.ReadReceiptsEnableDeviceTracking,
+		ReadReceiptsThrottleIntervalMs:      ss.ReadReceiptsThrottleIntervalMs,
+		ReadReceiptsBatchWindowMs:           ss.ReadReceiptsBatchWindowMs,
+		ReadReceiptsEnableTeamChannels:      ss.ReadReceiptsEnableTeamChannels,
+		ReadReceiptsEnableBotReceipts:       ss.ReadReceiptsEnableBotReceipts,
+		ReadReceiptsClientDebounceMs:        ss.ReadReceiptsClientDebounceMs,
+		ReadReceiptsBatchMaxWaitMs:          ss.ReadReceiptsBatchMaxWaitMs,
+		ReadReceiptsStoreAllDevices:         ss.ReadReceiptsStoreAllDevices,
+		ReadReceiptsMaxClockSkewMs:          ss.ReadReceiptsMaxClockSkewMs,
+		ReadReceiptsEnableIntegrityChain:    ss.ReadReceiptsEnableIntegrityChain,
+		ReadReceiptsBufferMaxSize:           ss.ReadReceiptsBufferMaxSize,
+		ReadReceiptsBufferFlushIntervalMs:   ss.ReadReceiptsBufferFlushIntervalMs,
+		ReadReceiptsBufferMaxPendingPerUser: ss.ReadReceiptsBufferMaxPendingPerUser,
+		ReadReceiptsMaxPerChannel:           ss.ReadReceiptsMaxPerChannel,
+		ReadReceiptsEnableUnreadDMNudge:     ss.ReadReceiptsEnableUnreadDMNudge,
+		ReadReceiptsUnreadDMNudgeHours:      ss.ReadReceiptsUnreadDMNudgeHours,
+		ReadReceiptsExportSink:              ss.ReadReceiptsExportSink,
+		ReadReceiptsExportBufferSize:        ss.ReadReceiptsExportBufferSize,
+		ReadReceiptsSummarySLASeconds:       ss.ReadReceiptsSummarySLASeconds,
+		ReadReceiptsRestrictDMRecall:        ss.ReadReceiptsRestrictDMRecall,
+		ReadReceiptsDeactivationScrubDays:   ss.ReadReceiptsDeactivationScrubDays,
+		ReadReceiptsMinimumConfidence:       ss.ReadReceiptsMinimumConfidence,
+		ReadReceiptsMinWriteIntervalMs:      ss.ReadReceiptsMinWriteIntervalMs,
+		ReadReceiptsColdStorageDays:         ss.ReadReceiptsColdStorageDays,
+		ReadReceiptsLegacyBatchEvents:       ss.ReadReceiptsLegacyBatchEvents,
+		ReadReceiptsTransactionalSummary:    ss.ReadReceiptsTransactionalSummary,
+		ReadReceiptsServerTimestamps:        ss.ReadReceiptsServerTimestamps,
+		ReadReceiptsChangesRetentionHours:   ss.ReadReceiptsChangesRetentionHours,
+		ReadReceiptsPreciseUnread:           ss.ReadReceiptsPreciseUnread,
+		ReadReceiptsSamplingThreshold:       ss.ReadReceiptsSamplingThreshold,
+		ReadReceiptsBitmapStorageDays:       ss.ReadReceiptsBitmapStorageDays,
+		ReadReceiptsBitmapMinReceipts:       ss.ReadReceiptsBitmapMinReceipts,
+	}
+
+	receipts.Tables, err = a.Srv().Store().PostReadReceipt().GetTableStats()
+	if err != nil {
+		rErr = multierror.Append(rErr, errors.Wrap(err, "failed to get read receipt table stats"))
+	}
+
+	// The refresh job rebuilds the read receipt stats of the user reports, and
+	// receipts are cleaned up along with the posts deleted by data retention.
+	receipts.RefreshStatsJobs, err = a.Srv().Store().Job().GetAllByTypePage(rctx, model.JobTypeRefreshMaterializedViews, 0, numberOfJobsRuns)
+	if err != nil {
+		rErr = multierror.Append(rErr, errors.Wrap(err, "error while getting refresh materialized views jobs"))
+	}
+	receipts.DataRetentionJobs, err = a.Srv().Store().Job().GetAllByTypePage(rctx, model.JobTypeDataRetention, 0, numberOfJobsRuns)
+	if err != nil {
+		rErr = multierror.Append(rErr, errors.Wrap(err, "error while getting data retention jobs"))
+	}
+
+	receipts.Overview = a.GetReadReceiptsOverview()
+	receipts.PendingImplicitReceipts = a.ch.readReceiptBuffer.pendingCount()
+
+	b, err := yaml.Marshal(&receipts)
+	if err != nil {
+		rErr = multierror.Append(rErr, errors.Wrap(err, "failed to marshal read receipt diagnostics into yaml"))
+	}
+
+	fileData := &model.FileData{
+		Filename: "read_receipts.yaml",
+		Body:     b,
+	}
+	return fileData, rErr.ErrorOrNil()
+}
+
 func (a *App) getSupportPacketPermissionsInfo(_ request.CTX) (*model.FileData, error) {
 	var (
 		rErr        *multierror.Error
@@ -65,6 +65,7 @@ func TestGenerateSupportPacket(t *testing.T) {
 		"stats.yaml",
 		"jobs.yaml",
 		"permissions.yaml",
+		"read_receipts.yaml",
 		"plugins.json",
 		"sanitized_config.json",
 		"diagnostics.yaml",
@@ -133,6 +134,7 @@ func TestGenerateSupportPacket(t *testing.T) {
 		mockStore.On("Command").Return(th.App.Srv().Store().Command())
 		mockStore.On("Role").Return(th.App.Srv().Store().Role())
 		mockStore.On("Scheme").Return(th.App.Srv().Store().Scheme())
+		mockStore.On("PostReadReceipt").Return(th.App.Srv().Store().PostReadReceipt())
 		mockStore.On("Close").Return(nil)
 		mockStore.On("GetDBSchemaVersion").Return(1, nil)
 		mockStore.On("GetDbVersion", false).Return("1.0.0", nil)
@@ -572,6 +574,46 @@ func TestGetSupportPacketJobList(t *testing.T) {
 	})
 }
 
+func TestGetSupportPacketReadReceipts(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsRetentionDays = 7
+	})
+
+	job, err := th.App.Srv().Store().Job().Save(&model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeRefreshMaterializedViews,
+		CreateAt: model.GetMillis(),
+		Status:   model.JobStatusSuccess,
+	})
+	require.NoError(t, err)
+
+	fileData, err := th.App.getSupportPacketReadReceipts(th.Context)
+	require.NoError(t, err)
+	require.NotNil(t, fileData)
+	assert.Equal(t, "read_receipts.yaml", fileData.Filename)
+
+	var receipts model.SupportPacketReadReceipts
+	err = yaml.Unmarshal(fileData.Body, &receipts)
+	require.NoError(t, err)
+
+	assert.True(t, *receipts.Config.EnableReadReceipts)
+	assert.Equal(t, 7, *receipts.Config.ReadReceiptsRetentionDays)
+	require.Len(t, receipts.RefreshStatsJobs, 1)
+	assert.Equal(t, job.Id, receipts.RefreshStatsJobs[0].Id)
+	require.NotNil(t, receipts.Overview)
+
+	require.NotEmpty(t, receipts.Tables)
+	for _, table := range receipts.Tables {
+		assert.NotEmpty(t, table.Indexes, table.Table)
+		assert.Empty(t, table.MissingIndexes, table.Table)
+	}
+}
+
 func TestGetSupportPacketPermissionsInfo(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic()
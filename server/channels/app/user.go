@@ -1109,6 +1109,9 @@ func (a *App) UpdateActive(c request.CTX, user *model.User, active bool) (*model
 		if err := a.userDeactivated(c, ruser.Id); err != nil {
 			return nil, err
 		}
+		a.flagReadReceiptsForScrub(c, ruser)
+	} else {
+		a.unflagReadReceiptsForScrub(c, ruser.Id)
 	}
 
 	if appErr := a.invalidateUserChannelMembersCaches(c, user.Id); appErr != nil {
@@ -1890,6 +1893,14 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.reaction.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().PostReadReceipt().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.read_receipt.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.deleteColdStorageReadReceiptsForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.read_receipt.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().ScheduledPost().PermanentDeleteByUser(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.scheduled_post.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -2422,6 +2433,12 @@ func (a *App) UserCanSeeOtherUser(c request.CTX, userID string, otherUserId stri
 		return false, err
 	}
 
+	return a.userVisibleUnderRestrictions(otherUserId, restrictions)
+}
+
+// userVisibleUnderRestrictions reports whether a user is visible to someone
+// subject to the given restrictions, as returned by GetViewUsersRestrictions.
+func (a *App) userVisibleUnderRestrictions(otherUserId string, restrictions *model.ViewUsersRestrictions) (bool, *model.AppError) {
 	if restrictions == nil {
 		return true, nil
 	}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_postreadreceipts_channelid_readat;
+DROP INDEX IF EXISTS idx_postreadreceipts_userid_readat;
+DROP TABLE IF EXISTS postreadreceipts;
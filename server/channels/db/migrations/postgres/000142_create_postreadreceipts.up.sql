@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS postreadreceipts (
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    readat bigint NOT NULL,
+    devicetype VARCHAR(32) DEFAULT '',
+    deviceid VARCHAR(512) DEFAULT '',
+    sessionid VARCHAR(26) DEFAULT '',
+    PRIMARY KEY (postid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postreadreceipts_userid_readat ON postreadreceipts (userid, readat);
+CREATE INDEX IF NOT EXISTS idx_postreadreceipts_channelid_readat ON postreadreceipts (channelid, readat);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_postreadreceiptsummaries_channelid_lastupdated;
+DROP TABLE IF EXISTS postreadreceiptsummaries;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS postreadreceiptsummaries (
+    postid VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    readcount bigint NOT NULL DEFAULT 0,
+    botreadcount bigint NOT NULL DEFAULT 0,
+    lastreadat bigint NOT NULL DEFAULT 0,
+    lastupdated bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_postreadreceiptsummaries_channelid_lastupdated ON postreadreceiptsummaries (channelid, lastupdated);
@@ -0,0 +1 @@
+ALTER TABLE postreadreceiptsummaries DROP COLUMN IF EXISTS version;
@@ -0,0 +1 @@
+ALTER TABLE postreadreceipts DROP COLUMN IF EXISTS source;
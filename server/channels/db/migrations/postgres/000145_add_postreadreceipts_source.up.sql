@@ -0,0 +1 @@
+ALTER TABLE postreadreceipts ADD COLUMN IF NOT EXISTS source VARCHAR(32) DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS readreceiptpolicies (
+    id VARCHAR(26) PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    teamids text,
+    channelids text,
+    retentiondays integer NOT NULL DEFAULT 0,
+    visibilitymode VARCHAR(32) NOT NULL DEFAULT 'everyone',
+    includeinexport boolean NOT NULL DEFAULT false,
+    createat bigint,
+    updateat bigint
+);
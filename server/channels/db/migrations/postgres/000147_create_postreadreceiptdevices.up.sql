@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS postreadreceiptdevices (
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    deviceid VARCHAR(512) NOT NULL DEFAULT '',
+    channelid VARCHAR(26) NOT NULL,
+    readat bigint NOT NULL,
+    devicetype VARCHAR(32) DEFAULT '',
+    sessionid VARCHAR(26) DEFAULT '',
+    source VARCHAR(32) DEFAULT '',
+    PRIMARY KEY (postid, userid, deviceid)
+);
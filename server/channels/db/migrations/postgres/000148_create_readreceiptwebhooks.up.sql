@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS readreceiptwebhooks (
+    id VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    creatorid VARCHAR(26) NOT NULL,
+    url VARCHAR(1024) NOT NULL,
+    secret VARCHAR(32) NOT NULL,
+    createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptwebhooks_channelid ON readreceiptwebhooks(channelid);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS readreceiptchains (
+    channelid VARCHAR(26) NOT NULL,
+    sequence bigint NOT NULL,
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    readat bigint NOT NULL,
+    prevhash VARCHAR(64) NOT NULL,
+    hash VARCHAR(64) NOT NULL,
+    PRIMARY KEY (channelid, sequence)
+);
@@ -0,0 +1,9 @@
+DROP MATERIALIZED VIEW IF EXISTS readreceiptstats;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS readreceiptstats AS
+SELECT userid, to_timestamp(readat/1000)::date as day, channelid, COUNT(*) as numpostsread
+FROM postreadreceipts
+GROUP BY userid, day, channelid
+;
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptstats_userid ON readreceiptstats(userid);
@@ -0,0 +1,16 @@
+DROP MATERIALIZED VIEW IF EXISTS readreceiptstats;
+
+-- Receipts are bucketed by day in the timezone of the reader, falling back to UTC
+-- for users without a valid timezone.
+CREATE MATERIALIZED VIEW IF NOT EXISTS readreceiptstats AS
+SELECT r.userid, (to_timestamp(r.readat/1000) AT TIME ZONE COALESCE(tz.name, 'UTC'))::date as day, r.channelid, COUNT(*) as numpostsread
+FROM postreadreceipts r
+LEFT JOIN users u ON u.id = r.userid
+LEFT JOIN pg_timezone_names tz ON tz.name = CASE
+    WHEN u.timezone->>'useAutomaticTimezone' = 'true' THEN u.timezone->>'automaticTimezone'
+    ELSE u.timezone->>'manualTimezone'
+END
+GROUP BY r.userid, day, r.channelid
+;
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptstats_userid ON readreceiptstats(userid);
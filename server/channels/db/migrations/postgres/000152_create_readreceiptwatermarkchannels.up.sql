@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS readreceiptwatermarkchannels (
+    channelid VARCHAR(26) PRIMARY KEY,
+    receiptcount bigint NOT NULL,
+    createat bigint NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS readreceiptbroadcastposts;
+DROP TABLE IF EXISTS readreceiptbroadcasts;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS readreceiptbroadcasts (
+    id VARCHAR(26) PRIMARY KEY,
+    creatorid VARCHAR(26) NOT NULL,
+    createat bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS readreceiptbroadcastposts (
+    broadcastid VARCHAR(26) NOT NULL,
+    postid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    PRIMARY KEY (broadcastid, postid)
+);
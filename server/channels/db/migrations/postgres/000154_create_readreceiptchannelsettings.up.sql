@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS readreceiptchannelsettings (
+    channelid VARCHAR(26) PRIMARY KEY,
+    broadcastreceipts boolean NOT NULL DEFAULT true,
+    updateat bigint NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_readreceiptscrubs_deactivatedat;
+DROP TABLE IF EXISTS readreceiptscrubs;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS readreceiptscrubs (
+    userid VARCHAR(26) PRIMARY KEY,
+    deactivatedat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptscrubs_deactivatedat ON readreceiptscrubs (deactivatedat);
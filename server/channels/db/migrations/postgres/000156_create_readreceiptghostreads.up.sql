@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS readreceiptghostreads (
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    readat bigint NOT NULL,
+    promotedat bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (postid, userid)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE postreadreceiptdevices DROP COLUMN IF EXISTS confidence;
+ALTER TABLE postreadreceipts DROP COLUMN IF EXISTS confidence;
@@ -0,0 +1,2 @@
+ALTER TABLE postreadreceipts ADD COLUMN IF NOT EXISTS confidence VARCHAR(32) DEFAULT '';
+ALTER TABLE postreadreceiptdevices ADD COLUMN IF NOT EXISTS confidence VARCHAR(32) DEFAULT '';
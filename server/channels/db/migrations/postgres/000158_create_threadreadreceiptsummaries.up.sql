@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS threadreadreceiptsummaries (
+    rootid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    repliesread bigint NOT NULL DEFAULT 0,
+    lastupdated bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (rootid, userid)
+);
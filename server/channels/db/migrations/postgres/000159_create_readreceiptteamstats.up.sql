@@ -0,0 +1,15 @@
+-- Receipt volume and row storage of each team, direct and group messages being
+-- rolled up under the empty team id.
+CREATE MATERIALIZED VIEW IF NOT EXISTS readreceiptteamstats AS
+SELECT c.teamid,
+    COUNT(*) as receiptcount,
+    COUNT(*) FILTER (WHERE r.readat >= (extract(epoch from now() - interval '30 days') * 1000)::bigint) as recentreceiptcount,
+    COUNT(DISTINCT r.userid) as readercount,
+    COUNT(DISTINCT r.channelid) as channelcount,
+    SUM(pg_column_size(r.*))::bigint as rowbytes
+FROM postreadreceipts r
+INNER JOIN channels c ON c.id = r.channelid
+GROUP BY c.teamid
+;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_readreceiptteamstats_teamid ON readreceiptteamstats(teamid);
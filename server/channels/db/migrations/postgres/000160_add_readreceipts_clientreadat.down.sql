@@ -0,0 +1,2 @@
+ALTER TABLE postreadreceiptdevices DROP COLUMN IF EXISTS clientreadat;
+ALTER TABLE postreadreceipts DROP COLUMN IF EXISTS clientreadat;
@@ -0,0 +1,2 @@
+ALTER TABLE postreadreceipts ADD COLUMN IF NOT EXISTS clientreadat bigint DEFAULT 0;
+ALTER TABLE postreadreceiptdevices ADD COLUMN IF NOT EXISTS clientreadat bigint DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS readreceiptchanges (
+    sequence bigserial PRIMARY KEY,
+    op VARCHAR(16) NOT NULL,
+    postid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    channelid VARCHAR(26) NOT NULL,
+    at bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptchanges_channelid_sequence ON readreceiptchanges (channelid, sequence);
+CREATE INDEX IF NOT EXISTS idx_readreceiptchanges_at ON readreceiptchanges (at);
@@ -0,0 +1,4 @@
+-- morph:nontransactional
+-- The cutoff of a partial index must be a constant: the RefreshMaterializedViews job
+-- moves it daily to the start of the last 30 days.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_postreadreceipts_hot_channelid_readat ON postreadreceipts (channelid, readat) WHERE readat >= 1789516800000
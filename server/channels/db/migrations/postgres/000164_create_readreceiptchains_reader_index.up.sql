@@ -0,0 +1,2 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_readreceiptchains_channelid_postid_userid ON readreceiptchains (channelid, postid, userid)
@@ -0,0 +1 @@
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS creatorid varchar(26) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE readreceiptchannelsettings DROP COLUMN IF EXISTS pinnedunreadnotice;
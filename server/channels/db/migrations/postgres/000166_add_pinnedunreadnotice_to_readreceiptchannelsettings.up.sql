@@ -0,0 +1 @@
+ALTER TABLE readreceiptchannelsettings ADD COLUMN IF NOT EXISTS pinnedunreadnotice boolean NOT NULL DEFAULT false;
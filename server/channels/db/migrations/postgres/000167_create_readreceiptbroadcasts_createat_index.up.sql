@@ -0,0 +1,2 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_readreceiptbroadcasts_createat ON readreceiptbroadcasts (createat)
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS readreceiptbitmaps;
+DROP TABLE IF EXISTS readreceiptpostsequences;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS readreceiptpostsequences (
+    postid VARCHAR(26) PRIMARY KEY,
+    channelid VARCHAR(26) NOT NULL,
+    sequence bigserial NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS readreceiptbitmaps (
+    channelid VARCHAR(26) NOT NULL,
+    userid VARCHAR(26) NOT NULL,
+    bitmap bytea NOT NULL,
+    lastreadat bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (channelid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_readreceiptbitmaps_userid ON readreceiptbitmaps (userid);
@@ -0,0 +1 @@
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS scope varchar(255) NOT NULL DEFAULT '';
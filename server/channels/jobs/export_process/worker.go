@@ -53,6 +53,11 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		includeReadReceipts, ok := job.Data["include_read_receipts"]
+		if ok && includeReadReceipts == "true" {
+			opts.IncludeReadReceipts = true
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
@@ -43,6 +43,8 @@ func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ExportUsersToC
 			"LastPostDate",
 			"DaysActive",
 			"TotalPosts",
+			"PostsReadPerDay",
+			"ChannelsRead",
 			"DeletedAt",
 		},
 		getData(app),
@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package read_receipts_cleanup
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 24 * time.Hour
+
+// makeIsEnabled runs the cleanup when receipts expire, either after
+// ReadReceiptsRetentionDays or after the retention of a read receipt policy.
+func makeIsEnabled(app AppIface) func(cfg *model.Config) bool {
+	return func(cfg *model.Config) bool {
+		if !*cfg.ServiceSettings.EnableReadReceipts {
+			return false
+		}
+		return *cfg.ServiceSettings.ReadReceiptsRetentionDays > 0 || app.ReadReceiptPoliciesExpireReceipts()
+	}
+}
+
+func MakeScheduler(jobServer *jobs.JobServer, app AppIface) *jobs.PeriodicScheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeReadReceiptsCleanup, schedFreq, makeIsEnabled(app))
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package read_receipts_cleanup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type testApp struct {
+	policiesExpireReceipts bool
+}
+
+func (a *testApp) DeleteExpiredReadReceipts(model.ReadReceiptsPageCursor, func(model.ReadReceiptsPageCursor) error) (int64, error) {
+	return 0, nil
+}
+
+func (a *testApp) ReadReceiptPoliciesExpireReceipts() bool {
+	return a.policiesExpireReceipts
+}
+
+func TestIsEnabled(t *testing.T) {
+	newConfig := func(enabled bool, retentionDays int) *model.Config {
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+		cfg.ServiceSettings.EnableReadReceipts = model.NewPointer(enabled)
+		cfg.ServiceSettings.ReadReceiptsRetentionDays = model.NewPointer(retentionDays)
+		return cfg
+	}
+
+	assert.True(t, makeIsEnabled(&testApp{})(newConfig(true, 30)))
+	assert.False(t, makeIsEnabled(&testApp{})(newConfig(true, 0)))
+	assert.True(t, makeIsEnabled(&testApp{policiesExpireReceipts: true})(newConfig(true, 0)))
+	assert.False(t, makeIsEnabled(&testApp{policiesExpireReceipts: true})(newConfig(false, 0)))
+}
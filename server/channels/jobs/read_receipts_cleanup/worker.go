@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package read_receipts_cleanup
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	DeleteExpiredReadReceipts(cursor model.ReadReceiptsPageCursor, checkpoint func(cursor model.ReadReceiptsPageCursor) error) (int64, error)
+	ReadReceiptPoliciesExpireReceipts() bool
+}
+
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "ReadReceiptsCleanup"
+
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		if job.Data == nil {
+			job.Data = make(model.StringMap)
+		}
+
+		// A job interrupted halfway resumes after the last page it went through.
+		cursor := model.ReadReceiptsPageCursor{PostId: job.Data["post_id"], UserId: job.Data["user_id"]}
+		deleted, err := app.DeleteExpiredReadReceipts(cursor, func(cursor model.ReadReceiptsPageCursor) error {
+			job.Data["post_id"] = cursor.PostId
+			job.Data["user_id"] = cursor.UserId
+			if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+				return appErr
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		job.Data["deleted"] = strconv.FormatInt(deleted, 10)
+		if err := jobServer.UpdateInProgressJobData(job); err != nil {
+			logger.Error("Worker: Failed to update job data", mlog.Err(err))
+		}
+		return nil
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, makeIsEnabled(app))
+}
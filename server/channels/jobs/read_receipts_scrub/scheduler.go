@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package read_receipts_scrub
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 24 * time.Hour
+
+func isEnabled(cfg *model.Config) bool {
+	return *cfg.ServiceSettings.EnableReadReceipts && *cfg.ServiceSettings.ReadReceiptsDeactivationScrubDays > 0
+}
+
+func MakeScheduler(jobServer *jobs.JobServer) *jobs.PeriodicScheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeReadReceiptsScrub, schedFreq, isEnabled)
+}
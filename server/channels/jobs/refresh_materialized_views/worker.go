@@ -26,7 +26,15 @@ func MakeWorker(jobServer *jobs.JobServer, sqlDriverName string) *jobs.SimpleWor
 			return err
 		}
 
-		return jobServer.Store.User().RefreshPostStatsForUsers()
+		if err := jobServer.Store.User().RefreshPostStatsForUsers(); err != nil {
+			return err
+		}
+
+		if err := jobServer.Store.PostReadReceipt().RefreshReadReceiptStats(); err != nil {
+			return err
+		}
+
+		return jobServer.Store.PostReadReceipt().RotateHotReadReceiptIndexes()
 	}
 
 	worker := jobs.NewSimpleWorker(jobName, jobServer, execute, isEnabled)
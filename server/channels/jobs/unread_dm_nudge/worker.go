@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package unread_dm_nudge
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+func MakeWorker(jobServer *jobs.JobServer, sendNudges func() error) *jobs.SimpleWorker {
+	const workerName = "UnreadDMNudge"
+
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		return sendNudges()
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
@@ -68,6 +68,9 @@ const (
 	TeamCacheSize = 20000
 	TeamCacheSec  = 30 * 60
 
+	ReadReceiptPoliciesCacheSize = 1
+	ReadReceiptPoliciesCacheSec  = 30 * 60
+
 	ChannelCacheSec = 15 * 60 // 15 mins
 )
 
@@ -124,6 +127,9 @@ type LocalCacheStore struct {
 
 	termsOfService      LocalCacheTermsOfServiceStore
 	termsOfServiceCache cache.Cache
+
+	readReceiptPolicy        LocalCacheReadReceiptPolicyStore
+	readReceiptPoliciesCache cache.Cache
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface, cacheProvider cache.Provider, logger mlog.LoggerIFace) (localCacheStore LocalCacheStore, err error) {
@@ -366,6 +372,17 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 	}
 	localCacheStore.team = LocalCacheTeamStore{TeamStore: baseStore.Team(), rootStore: &localCacheStore}
 
+	// Read receipt policies
+	if localCacheStore.readReceiptPoliciesCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   ReadReceiptPoliciesCacheSize,
+		Name:                   "ReadReceiptPolicies",
+		DefaultExpiry:          ReadReceiptPoliciesCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForReadReceiptPolicies,
+	}); err != nil {
+		return
+	}
+	localCacheStore.readReceiptPolicy = LocalCacheReadReceiptPolicyStore{ReadReceiptPolicyStore: baseStore.ReadReceiptPolicy(), rootStore: &localCacheStore}
+
 	if cluster != nil {
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForReactions, localCacheStore.reaction.handleClusterInvalidateReaction)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForRoles, localCacheStore.role.handleClusterInvalidateRole)
@@ -390,6 +407,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForProfileInChannel, localCacheStore.user.handleClusterInvalidateProfilesInChannel)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForAllProfiles, localCacheStore.user.handleClusterInvalidateAllProfiles)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForTeams, localCacheStore.team.handleClusterInvalidateTeam)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForReadReceiptPolicies, localCacheStore.readReceiptPolicy.handleClusterInvalidateReadReceiptPolicies)
 	}
 	return
 }
@@ -438,6 +456,10 @@ func (s LocalCacheStore) Team() store.TeamStore {
 	return s.team
 }
 
+func (s LocalCacheStore) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	return s.readReceiptPolicy
+}
+
 func (s LocalCacheStore) DropAllTables() {
 	s.Invalidate()
 	s.Store.DropAllTables()
@@ -571,6 +593,7 @@ func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.profilesInChannelCache)
 	s.doClearCacheCluster(s.teamAllTeamIdsForUserCache)
 	s.doClearCacheCluster(s.rolePermissionsCache)
+	s.doClearCacheCluster(s.readReceiptPoliciesCache)
 }
 
 // allocateCacheTargets is used to fill target value types
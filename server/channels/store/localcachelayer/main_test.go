@@ -188,6 +188,12 @@ func getMockStore(t *testing.T) *mocks.Store {
 	mockTeamStore.On("GetUserTeamIds", "123", false).Return(fakeUserTeamIds, nil)
 	mockStore.On("Team").Return(&mockTeamStore)
 
+	fakeReadReceiptPolicies := []*model.ReadReceiptPolicy{{Id: "123", Name: "policy"}}
+	mockReadReceiptPolicyStore := mocks.ReadReceiptPolicyStore{}
+	mockReadReceiptPolicyStore.On("GetAll").Return(fakeReadReceiptPolicies, nil)
+	mockReadReceiptPolicyStore.On("ReplaceAll", fakeReadReceiptPolicies).Return(fakeReadReceiptPolicies, nil)
+	mockStore.On("ReadReceiptPolicy").Return(&mockReadReceiptPolicyStore)
+
 	return &mockStore
 }
 
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const readReceiptPoliciesCacheKey = "all"
+
+type LocalCacheReadReceiptPolicyStore struct {
+	store.ReadReceiptPolicyStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheReadReceiptPolicyStore) handleClusterInvalidateReadReceiptPolicies(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.readReceiptPoliciesCache.Purge()
+	} else {
+		s.rootStore.readReceiptPoliciesCache.Remove(string(msg.Data))
+	}
+}
+
+// GetAll is read for every receipt event and visibility check, while the
+// policies only change when an admin replaces them.
+func (s LocalCacheReadReceiptPolicyStore) GetAll() ([]*model.ReadReceiptPolicy, error) {
+	var policies []*model.ReadReceiptPolicy
+	if err := s.rootStore.doStandardReadCache(s.rootStore.readReceiptPoliciesCache, readReceiptPoliciesCacheKey, &policies); err == nil {
+		return policies, nil
+	}
+
+	policies, err := s.ReadReceiptPolicyStore.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.readReceiptPoliciesCache, readReceiptPoliciesCacheKey, policies)
+	return policies, nil
+}
+
+func (s LocalCacheReadReceiptPolicyStore) ReplaceAll(policies []*model.ReadReceiptPolicy) ([]*model.ReadReceiptPolicy, error) {
+	saved, err := s.ReadReceiptPolicyStore.ReplaceAll(policies)
+	if err == nil {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.readReceiptPoliciesCache, readReceiptPoliciesCacheKey, nil)
+	}
+	return saved, err
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestReadReceiptPolicyStoreCache(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		policies, err := cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		mockStore.ReadReceiptPolicy().(*mocks.ReadReceiptPolicyStore).AssertNumberOfCalls(t, "GetAll", 1)
+
+		cached, err := cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		assert.Equal(t, policies, cached)
+		mockStore.ReadReceiptPolicy().(*mocks.ReadReceiptPolicyStore).AssertNumberOfCalls(t, "GetAll", 1)
+	})
+
+	t.Run("first call not cached, replace, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		policies, err := cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		mockStore.ReadReceiptPolicy().(*mocks.ReadReceiptPolicyStore).AssertNumberOfCalls(t, "GetAll", 1)
+
+		_, err = cachedStore.ReadReceiptPolicy().ReplaceAll(policies)
+		require.NoError(t, err)
+
+		_, err = cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		mockStore.ReadReceiptPolicy().(*mocks.ReadReceiptPolicyStore).AssertNumberOfCalls(t, "GetAll", 2)
+	})
+
+	t.Run("first call not cached, invalidated by another node, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		_, err = cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		cachedStore.readReceiptPolicy.handleClusterInvalidateReadReceiptPolicies(&model.ClusterMessage{Data: clearCacheMessageData})
+
+		_, err = cachedStore.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		mockStore.ReadReceiptPolicy().(*mocks.ReadReceiptPolicyStore).AssertNumberOfCalls(t, "GetAll", 2)
+	})
+}
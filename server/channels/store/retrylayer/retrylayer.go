@@ -47,12 +47,16 @@ type RetryLayer struct {
 	PostAcknowledgementStore        store.PostAcknowledgementStore
 	PostPersistentNotificationStore store.PostPersistentNotificationStore
 	PostPriorityStore               store.PostPriorityStore
+	PostReadReceiptStore            store.PostReadReceiptStore
 	PreferenceStore                 store.PreferenceStore
 	ProductNoticesStore             store.ProductNoticesStore
 	PropertyFieldStore              store.PropertyFieldStore
 	PropertyGroupStore              store.PropertyGroupStore
 	PropertyValueStore              store.PropertyValueStore
 	ReactionStore                   store.ReactionStore
+	ReadReceiptBroadcastStore       store.ReadReceiptBroadcastStore
+	ReadReceiptPolicyStore          store.ReadReceiptPolicyStore
+	ReadReceiptWebhookStore         store.ReadReceiptWebhookStore
 	RemoteClusterStore              store.RemoteClusterStore
 	RetentionPolicyStore            store.RetentionPolicyStore
 	RoleStore                       store.RoleStore
@@ -181,6 +185,10 @@ func (s *RetryLayer) PostPriority() store.PostPriorityStore {
 	return s.PostPriorityStore
 }
 
+func (s *RetryLayer) PostReadReceipt() store.PostReadReceiptStore {
+	return s.PostReadReceiptStore
+}
+
 func (s *RetryLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -205,6 +213,18 @@ func (s *RetryLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *RetryLayer) ReadReceiptBroadcast() store.ReadReceiptBroadcastStore {
+	return s.ReadReceiptBroadcastStore
+}
+
+func (s *RetryLayer) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	return s.ReadReceiptPolicyStore
+}
+
+func (s *RetryLayer) ReadReceiptWebhook() store.ReadReceiptWebhookStore {
+	return s.ReadReceiptWebhookStore
+}
+
 func (s *RetryLayer) RemoteCluster() store.RemoteClusterStore {
 	return s.RemoteClusterStore
 }
@@ -412,6 +432,11 @@ type RetryLayerPostPriorityStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPostReadReceiptStore struct {
+	store.PostReadReceiptStore
+	Root *RetryLayer
+}
+
 type RetryLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *RetryLayer
@@ -442,6 +467,21 @@ type RetryLayerReactionStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerReadReceiptBroadcastStore struct {
+	store.ReadReceiptBroadcastStore
+	Root *RetryLayer
+}
+
+type RetryLayerReadReceiptPolicyStore struct {
+	store.ReadReceiptPolicyStore
+	Root *RetryLayer
+}
+
+type RetryLayerReadReceiptWebhookStore struct {
+	store.ReadReceiptWebhookStore
+	Root *RetryLayer
+}
+
 type RetryLayerRemoteClusterStore struct {
 	store.RemoteClusterStore
 	Root *RetryLayer
@@ -2136,6 +2176,27 @@ func (s *RetryLayerChannelStore) GetMembersByIds(channelID string, userIds []str
 
 }
 
+func (s *RetryLayerChannelStore) GetMembersByLastReadActivity(channelID string, offset int, limit int) (model.ChannelMembers, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelStore.GetMembersByLastReadActivity(channelID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
 
 	tries := 0
@@ -9003,95 +9064,95 @@ func (s *RetryLayerPostPriorityStore) Save(priority *model.PostPriority) (*model
 
 }
 
-func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) AppendReadReceiptChain(entries []*model.ReadReceiptChainEntry) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		err := s.PostReadReceiptStore.AppendReadReceiptChain(entries)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
+func (s *RetryLayerPostReadReceiptStore) CompactReadReceipts(channelID string, readBefore int64, limit int) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Delete(userID, category, name)
+		result, err := s.PostReadReceiptStore.CompactReadReceipts(channelID, readBefore, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+func (s *RetryLayerPostReadReceiptStore) ComputeReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategory(userID, category)
+		result, err := s.PostReadReceiptStore.ComputeReadReceiptSummary(postID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *RetryLayerPostReadReceiptStore) ComputeThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		result, err := s.PostReadReceiptStore.ComputeThreadReadReceiptSummary(rootID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteExpiredCompactedReadReceipts(channelID string, expiredBefore int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+		result, err := s.PostReadReceiptStore.DeleteExpiredCompactedReadReceipts(channelID, expiredBefore)
 		if err == nil {
 			return result, nil
 		}
@@ -9108,53 +9169,53 @@ func (s *RetryLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error)
 
 }
 
-func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteHealthCheckReceipt(postID string, userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+		err := s.PostReadReceiptStore.DeleteHealthCheckReceipt(postID, userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteReadReceipt(postID string, userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.Get(userID, category, name)
+		err := s.PostReadReceiptStore.DeleteReadReceipt(postID, userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteReadReceiptChangesBefore(before int64, limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetAll(userID)
+		result, err := s.PostReadReceiptStore.DeleteReadReceiptChangesBefore(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9171,11 +9232,11 @@ func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, er
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteReadReceipts(receipts []*model.PostReadReceipt) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategory(userID, category)
+		result, err := s.PostReadReceiptStore.DeleteReadReceipts(receipts)
 		if err == nil {
 			return result, nil
 		}
@@ -9192,11 +9253,32 @@ func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string)
 
 }
 
-func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+func (s *RetryLayerPostReadReceiptStore) DeleteReadReceiptsForPost(postID string) error {
 
 	tries := 0
 	for {
-		result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+		err := s.PostReadReceiptStore.DeleteReadReceiptsForPost(postID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) EstimateReadReceiptCount() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.EstimateReadReceiptCount()
 		if err == nil {
 			return result, nil
 		}
@@ -9213,11 +9295,11 @@ func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, name str
 
 }
 
-func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerPostReadReceiptStore) FlagUserReceiptsForScrub(userID string, deactivatedAt int64) error {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.PermanentDeleteByUser(userID)
+		err := s.PostReadReceiptStore.FlagUserReceiptsForScrub(userID, deactivatedAt)
 		if err == nil {
 			return nil
 		}
@@ -9234,74 +9316,74 @@ func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 
 }
 
-func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *RetryLayerPostReadReceiptStore) GetAnnouncementReadCounts(teamID string, postIDs []string) ([]*model.ReadReceiptBroadcastChannelSummary, error) {
 
 	tries := 0
 	for {
-		err := s.PreferenceStore.Save(preferences)
+		result, err := s.PostReadReceiptStore.GetAnnouncementReadCounts(teamID, postIDs)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+func (s *RetryLayerPostReadReceiptStore) GetCaughtUpUsersForChannel(channelID string, limit int) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.Clear(notices)
+		result, err := s.PostReadReceiptStore.GetCaughtUpUsersForChannel(channelID, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+func (s *RetryLayerPostReadReceiptStore) GetChannelArchiveRecommendations(opts *model.ChannelArchiveReportOptions) ([]*model.ChannelArchiveRecommendation, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		result, err := s.PostReadReceiptStore.GetChannelArchiveRecommendations(opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+func (s *RetryLayerPostReadReceiptStore) GetChannelSettings(channelID string) (*model.ReadReceiptChannelSettings, error) {
 
 	tries := 0
 	for {
-		result, err := s.ProductNoticesStore.GetViews(userID)
+		result, err := s.PostReadReceiptStore.GetChannelSettings(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -9318,32 +9400,32 @@ func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.Product
 
 }
 
-func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
+func (s *RetryLayerPostReadReceiptStore) GetChannelsForReadReceiptCompaction(minReceipts int64, afterChannelID string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.ProductNoticesStore.View(userID, notices)
+		result, err := s.PostReadReceiptStore.GetChannelsForReadReceiptCompaction(minReceipts, afterChannelID, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) GetCompactedReadReceiptChannels(afterChannelID string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+		result, err := s.PostReadReceiptStore.GetCompactedReadReceiptChannels(afterChannelID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9360,11 +9442,11 @@ func (s *RetryLayerPropertyFieldStore) CountForGroup(groupID string, includeDele
 
 }
 
-func (s *RetryLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetDistinctReaderCounts(channelID string, fromDay string, toDay string) ([]*model.DailyReaderCount, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.Create(field)
+		result, err := s.PostReadReceiptStore.GetDistinctReaderCounts(channelID, fromDay, toDay)
 		if err == nil {
 			return result, nil
 		}
@@ -9381,32 +9463,32 @@ func (s *RetryLayerPropertyFieldStore) Create(field *model.PropertyField) (*mode
 
 }
 
-func (s *RetryLayerPropertyFieldStore) Delete(groupID string, id string) error {
+func (s *RetryLayerPostReadReceiptStore) GetExpiredReadReceiptCounts(expiredBefore int64, teamIDs []string, channelIDs []string, limit int) (*model.ReadReceiptCleanupPreview, error) {
 
 	tries := 0
 	for {
-		err := s.PropertyFieldStore.Delete(groupID, id)
+		result, err := s.PostReadReceiptStore.GetExpiredReadReceiptCounts(expiredBefore, teamIDs, channelIDs, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetHumanMemberCount(channelID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.Get(groupID, id)
+		result, err := s.PostReadReceiptStore.GetHumanMemberCount(channelID)
 		if err == nil {
 			return result, nil
 		}
@@ -9423,11 +9505,11 @@ func (s *RetryLayerPropertyFieldStore) Get(groupID string, id string) (*model.Pr
 
 }
 
-func (s *RetryLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetMembersReadActivity(channelID string, since int64, offset int, limit int) ([]*model.ChannelMemberReadActivity, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+		result, err := s.PostReadReceiptStore.GetMembersReadActivity(channelID, since, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9444,11 +9526,11 @@ func (s *RetryLayerPropertyFieldStore) GetFieldByName(groupID string, targetID s
 
 }
 
-func (s *RetryLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadActivityBySession(userID string, since int64, offset int, limit int) ([]*model.ReadReceiptSessionActivity, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.GetMany(groupID, ids)
+		result, err := s.PostReadReceiptStore.GetReadActivityBySession(userID, since, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9465,11 +9547,11 @@ func (s *RetryLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]
 
 }
 
-func (s *RetryLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadCountsForLatestPosts(channelID string, limit int) ([]*model.PostReadCount, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.SearchPropertyFields(opts)
+		result, err := s.PostReadReceiptStore.GetReadCountsForLatestPosts(channelID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9486,11 +9568,11 @@ func (s *RetryLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyF
 
 }
 
-func (s *RetryLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadDevicesForPostUser(postID string, userID string) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyFieldStore.Update(groupID, fields)
+		result, err := s.PostReadReceiptStore.GetReadDevicesForPostUser(postID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9507,11 +9589,11 @@ func (s *RetryLayerPropertyFieldStore) Update(groupID string, fields []*model.Pr
 
 }
 
-func (s *RetryLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadPostIdsForUser(userID string, postIDs []string) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyGroupStore.Get(name)
+		result, err := s.PostReadReceiptStore.GetReadPostIdsForUser(userID, postIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -9528,11 +9610,11 @@ func (s *RetryLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, e
 
 }
 
-func (s *RetryLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceipt(postID string, userID string) (*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyGroupStore.Register(name)
+		result, err := s.PostReadReceiptStore.GetReadReceipt(postID, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -9549,11 +9631,11 @@ func (s *RetryLayerPropertyGroupStore) Register(name string) (*model.PropertyGro
 
 }
 
-func (s *RetryLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptChain(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChainEntry, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.Create(value)
+		result, err := s.PostReadReceiptStore.GetReadReceiptChain(channelID, afterSequence, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9570,74 +9652,74 @@ func (s *RetryLayerPropertyValueStore) Create(value *model.PropertyValue) (*mode
 
 }
 
-func (s *RetryLayerPropertyValueStore) Delete(groupID string, id string) error {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptChainHead(channelID string) (*model.ReadReceiptChainEntry, error) {
 
 	tries := 0
 	for {
-		err := s.PropertyValueStore.Delete(groupID, id)
+		result, err := s.PostReadReceiptStore.GetReadReceiptChainHead(channelID)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptChainMismatches(channelID string, limit int) ([]*model.ReadReceiptChainEntry, error) {
 
 	tries := 0
 	for {
-		err := s.PropertyValueStore.DeleteForField(groupID, fieldID)
+		result, err := s.PostReadReceiptStore.GetReadReceiptChainMismatches(channelID, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptChanges(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChange, error) {
 
 	tries := 0
 	for {
-		err := s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+		result, err := s.PostReadReceiptStore.GetReadReceiptChanges(channelID, afterSequence, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptExtremes(postID string) (*model.PostReadReceiptExtremes, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.Get(groupID, id)
+		result, err := s.PostReadReceiptStore.GetReadReceiptExtremes(postID)
 		if err == nil {
 			return result, nil
 		}
@@ -9654,11 +9736,11 @@ func (s *RetryLayerPropertyValueStore) Get(groupID string, id string) (*model.Pr
 
 }
 
-func (s *RetryLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptSummariesForChannel(channelID string, since int64) ([]*model.PostReadReceiptSummary, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.GetMany(groupID, ids)
+		result, err := s.PostReadReceiptStore.GetReadReceiptSummariesForChannel(channelID, since)
 		if err == nil {
 			return result, nil
 		}
@@ -9675,11 +9757,11 @@ func (s *RetryLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]
 
 }
 
-func (s *RetryLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptSummariesForPosts(postIDs []string, fromMaster bool) ([]*model.PostReadReceiptSummary, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.SearchPropertyValues(opts)
+		result, err := s.PostReadReceiptStore.GetReadReceiptSummariesForPosts(postIDs, fromMaster)
 		if err == nil {
 			return result, nil
 		}
@@ -9696,11 +9778,11 @@ func (s *RetryLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyV
 
 }
 
-func (s *RetryLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.Update(groupID, values)
+		result, err := s.PostReadReceiptStore.GetReadReceiptSummary(postID)
 		if err == nil {
 			return result, nil
 		}
@@ -9717,11 +9799,11 @@ func (s *RetryLayerPropertyValueStore) Update(groupID string, values []*model.Pr
 
 }
 
-func (s *RetryLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptTeamUsage() ([]*model.ReadReceiptTeamUsage, error) {
 
 	tries := 0
 	for {
-		result, err := s.PropertyValueStore.Upsert(values)
+		result, err := s.PostReadReceiptStore.GetReadReceiptTeamUsage()
 		if err == nil {
 			return result, nil
 		}
@@ -9738,11 +9820,11 @@ func (s *RetryLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]
 
 }
 
-func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsForPost(postID string, deviceType string) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.BulkGetForPosts(postIds)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsForPost(postID, deviceType)
 		if err == nil {
 			return result, nil
 		}
@@ -9759,11 +9841,11 @@ func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 
 }
 
-func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsForPosts(postIDs []string) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Delete(reaction)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsForPosts(postIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -9780,32 +9862,32 @@ func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 
 }
 
-func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsForSession(sessionID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsForSession(sessionID, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsForUser(userID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsForUser(userID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -9822,11 +9904,11 @@ func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsF
 
 }
 
-func (s *RetryLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsForUserPosts(userID string, postIDs []string) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsForUserPosts(userID, postIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -9843,11 +9925,11 @@ func (s *RetryLayerReactionStore) ExistsOnPost(postID string, emojiName string)
 
 }
 
-func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetReadReceiptsPage(cursor model.ReadReceiptsPageCursor, limit int) ([]*model.PostReadReceipt, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+		result, err := s.PostReadReceiptStore.GetReadReceiptsPage(cursor, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -9864,11 +9946,11 @@ func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool)
 
 }
 
-func (s *RetryLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetTableStats() ([]*model.ReadReceiptTableStats, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+		result, err := s.PostReadReceiptStore.GetTableStats()
 		if err == nil {
 			return result, nil
 		}
@@ -9885,11 +9967,11 @@ func (s *RetryLayerReactionStore) GetForPostSince(postID string, since int64, ex
 
 }
 
-func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+		result, err := s.PostReadReceiptStore.GetThreadReadReceiptSummary(rootID)
 		if err == nil {
 			return result, nil
 		}
@@ -9906,11 +9988,11 @@ func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remote
 
 }
 
-func (s *RetryLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+func (s *RetryLayerPostReadReceiptStore) GetUnreadDirectChannels(createdAfter int64, createdBefore int64) ([]*model.UnreadDirectChannel, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.GetUniqueCountForPost(postID)
+		result, err := s.PostReadReceiptStore.GetUnreadDirectChannels(createdAfter, createdBefore)
 		if err == nil {
 			return result, nil
 		}
@@ -9927,11 +10009,11 @@ func (s *RetryLayerReactionStore) GetUniqueCountForPost(postID string) (int, err
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerPostReadReceiptStore) GetUnreadPostsCount(channelID string, userID string, createdBefore int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		result, err := s.PostReadReceiptStore.GetUnreadPostsCount(channelID, userID, createdBefore)
 		if err == nil {
 			return result, nil
 		}
@@ -9948,32 +10030,1607 @@ func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 
 }
 
-func (s *RetryLayerReactionStore) PermanentDeleteByUser(userID string) error {
+func (s *RetryLayerPostReadReceiptStore) GetUnreadUsersForPost(postID string, limit int) ([]*model.User, error) {
 
 	tries := 0
 	for {
-		err := s.ReactionStore.PermanentDeleteByUser(userID)
+		result, err := s.PostReadReceiptStore.GetUnreadUsersForPost(postID, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *RetryLayerPostReadReceiptStore) GetUsersDueForReceiptScrub(deactivatedBefore int64, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		result, err := s.ReactionStore.Save(reaction)
+		result, err := s.PostReadReceiptStore.GetUsersDueForReceiptScrub(deactivatedBefore, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) IsPostReadByAnyone(postID string, fromMaster bool) (bool, int64, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostReadReceiptStore.IsPostReadByAnyone(postID, fromMaster)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) RefreshReadReceiptStats() error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.RefreshReadReceiptStats()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) RotateHotReadReceiptIndexes() error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.RotateHotReadReceiptIndexes()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveChannelSettings(settings *model.ReadReceiptChannelSettings) (*model.ReadReceiptChannelSettings, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveChannelSettings(settings)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveGhostRead(read *model.PostReadReceipt) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveGhostRead(read)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveHealthCheckReceipt(receipt *model.PostReadReceipt) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveHealthCheckReceipt(receipt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadDevices(receipts []*model.PostReadReceipt) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.SaveReadDevices(receipts)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadReceipt(receipt *model.PostReadReceipt) (*model.PostReadReceipt, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveReadReceipt(receipt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadReceiptWithSummary(receipt *model.PostReadReceipt) (*model.PostReadReceipt, *model.PostReadReceiptSummary, int64, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, resultVar2, err := s.PostReadReceiptStore.SaveReadReceiptWithSummary(receipt)
+		if err == nil {
+			return result, resultVar1, resultVar2, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, resultVar2, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, resultVar2, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadReceiptsBatch(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveReadReceiptsBatch(receipts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadReceiptsIfNotExist(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveReadReceiptsIfNotExist(receipts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveReadReceiptsUpToPost(receipt *model.PostReadReceipt, limit int, rootPostsOnly bool) ([]*model.PostReadReceipt, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.SaveReadReceiptsUpToPost(receipt, limit, rootPostsOnly)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) SaveThreadReadReceiptSummary(rootID string, channelID string, counts []*model.ThreadParticipantReadCount) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.SaveThreadReadReceiptSummary(rootID, channelID, counts)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) ScrubUserReceipts(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.ScrubUserReceipts(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) UnflagUserReceiptsForScrub(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.UnflagUserReceiptsForScrub(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) UpdateChannelWatermarkMode(channelID string, limit int64, recoverAt int64) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.UpdateChannelWatermarkMode(channelID, limit, recoverAt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) UpdateReadReceiptSummaries(summaries []*model.PostReadReceiptSummary) ([]*model.PostReadReceiptSummary, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostReadReceiptStore.UpdateReadReceiptSummaries(summaries)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostReadReceiptStore) UpdateReadReceiptSummary(summary *model.PostReadReceiptSummary) error {
+
+	tries := 0
+	for {
+		err := s.PostReadReceiptStore.UpdateReadReceiptSummary(summary)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Delete(userID string, category string, name string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.Delete(userID, category, name)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.DeleteCategory(userID, category)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.Get(userID, category, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetAll(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetCategory(userID, category)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+
+	tries := 0
+	for {
+		result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPreferenceStore) Save(preferences model.Preferences) error {
+
+	tries := 0
+	for {
+		err := s.PreferenceStore.Save(preferences)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) Clear(notices []string) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.Clear(notices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+
+	tries := 0
+	for {
+		result, err := s.ProductNoticesStore.GetViews(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerProductNoticesStore) View(userID string, notices []string) error {
+
+	tries := 0
+	for {
+		err := s.ProductNoticesStore.View(userID, notices)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.Create(field)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) Delete(groupID string, id string) error {
+
+	tries := 0
+	for {
+		err := s.PropertyFieldStore.Delete(groupID, id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.Get(groupID, id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.GetMany(groupID, ids)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.SearchPropertyFields(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyFieldStore.Update(groupID, fields)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyGroupStore.Get(name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyGroupStore.Register(name)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.Create(value)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) Delete(groupID string, id string) error {
+
+	tries := 0
+	for {
+		err := s.PropertyValueStore.Delete(groupID, id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+
+	tries := 0
+	for {
+		err := s.PropertyValueStore.DeleteForField(groupID, fieldID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+
+	tries := 0
+	for {
+		err := s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.Get(groupID, id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.GetMany(groupID, ids)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.SearchPropertyValues(opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.Update(groupID, values)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.Upsert(values)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.BulkGetForPosts(postIds)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.Delete(reaction)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+
+	tries := 0
+	for {
+		err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.GetUniqueCountForPost(postID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.ReactionStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReactionStore.Save(reaction)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptBroadcastStore) DeleteBefore(before int64, limit int) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptBroadcastStore.DeleteBefore(before, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptBroadcastStore) Get(id string) (*model.ReadReceiptBroadcast, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptBroadcastStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptBroadcastStore) Save(broadcast *model.ReadReceiptBroadcast) (*model.ReadReceiptBroadcast, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptBroadcastStore.Save(broadcast)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptPolicyStore) GetAll() ([]*model.ReadReceiptPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptPolicyStore.GetAll()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptPolicyStore) ReplaceAll(policies []*model.ReadReceiptPolicy) ([]*model.ReadReceiptPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptPolicyStore.ReplaceAll(policies)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptWebhookStore) Delete(id string) error {
+
+	tries := 0
+	for {
+		err := s.ReadReceiptWebhookStore.Delete(id)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptWebhookStore) Get(id string) (*model.ReadReceiptWebhook, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptWebhookStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptWebhookStore) GetForChannel(channelID string) ([]*model.ReadReceiptWebhook, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptWebhookStore.GetForChannel(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerReadReceiptWebhookStore) Save(hook *model.ReadReceiptWebhook) (*model.ReadReceiptWebhook, error) {
+
+	tries := 0
+	for {
+		result, err := s.ReadReceiptWebhookStore.Save(hook)
 		if err == nil {
 			return result, nil
 		}
@@ -16877,12 +18534,16 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.PostAcknowledgementStore = &RetryLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
 	newStore.PostPersistentNotificationStore = &RetryLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
 	newStore.PostPriorityStore = &RetryLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PostReadReceiptStore = &RetryLayerPostReadReceiptStore{PostReadReceiptStore: childStore.PostReadReceipt(), Root: &newStore}
 	newStore.PreferenceStore = &RetryLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &RetryLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.PropertyFieldStore = &RetryLayerPropertyFieldStore{PropertyFieldStore: childStore.PropertyField(), Root: &newStore}
 	newStore.PropertyGroupStore = &RetryLayerPropertyGroupStore{PropertyGroupStore: childStore.PropertyGroup(), Root: &newStore}
 	newStore.PropertyValueStore = &RetryLayerPropertyValueStore{PropertyValueStore: childStore.PropertyValue(), Root: &newStore}
 	newStore.ReactionStore = &RetryLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.ReadReceiptBroadcastStore = &RetryLayerReadReceiptBroadcastStore{ReadReceiptBroadcastStore: childStore.ReadReceiptBroadcast(), Root: &newStore}
+	newStore.ReadReceiptPolicyStore = &RetryLayerReadReceiptPolicyStore{ReadReceiptPolicyStore: childStore.ReadReceiptPolicy(), Root: &newStore}
+	newStore.ReadReceiptWebhookStore = &RetryLayerReadReceiptWebhookStore{ReadReceiptWebhookStore: childStore.ReadReceiptWebhook(), Root: &newStore}
 	newStore.RemoteClusterStore = &RetryLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &RetryLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &RetryLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
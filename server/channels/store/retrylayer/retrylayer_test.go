@@ -58,6 +58,9 @@ func genStore() *mocks.Store {
 	mock.On("Draft").Return(&mocks.DraftStore{})
 	mock.On("PostPriority").Return(&mocks.PostPriorityStore{})
 	mock.On("PostAcknowledgement").Return(&mocks.PostAcknowledgementStore{})
+	mock.On("PostReadReceipt").Return(&mocks.PostReadReceiptStore{})
+	mock.On("ReadReceiptPolicy").Return(&mocks.ReadReceiptPolicyStore{})
+	mock.On("ReadReceiptWebhook").Return(&mocks.ReadReceiptWebhookStore{})
 	mock.On("PostPersistentNotification").Return(&mocks.PostPersistentNotificationStore{})
 	mock.On("DesktopTokens").Return(&mocks.DesktopTokensStore{})
 	mock.On("ChannelBookmark").Return(&mocks.ChannelBookmarkStore{})
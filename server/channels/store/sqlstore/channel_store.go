@@ -2040,6 +2040,30 @@ func (s SqlChannelStore) GetMembers(opts model.ChannelMembersGetOptions) (model.
 	return dbMembers.ToModel(), nil
 }
 
+func (s SqlChannelStore) GetMembersByLastReadActivity(channelID string, offset, limit int) (model.ChannelMembers, error) {
+	lastReads := s.getSubQueryBuilder().
+		Select("UserId", "MAX(ReadAt) AS LastReadAt").
+		From("PostReadReceipts").
+		Where(sq.Eq{"ChannelId": channelID}).
+		GroupBy("UserId").
+		Prefix("LEFT JOIN (").
+		Suffix(") AS LastReads ON LastReads.UserId = ChannelMembers.UserId")
+
+	query := s.channelMembersForTeamWithSchemeSelectQuery.
+		JoinClause(lastReads).
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelID}).
+		OrderBy("LastReads.LastReadAt DESC NULLS LAST", "ChannelMembers.UserId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	dbMembers := channelMemberWithSchemeRolesList{}
+	if err := s.GetReplica().SelectBuilder(&dbMembers, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ChannelMembers by last read activity with channelId=%s", channelID)
+	}
+
+	return dbMembers.ToModel(), nil
+}
+
 func (s SqlChannelStore) GetChannelMembersTimezones(channelId string) ([]model.StringMap, error) {
 	dbMembersTimezone := []model.StringMap{}
 	err := s.GetReplica().Select(&dbMembersTimezone, `
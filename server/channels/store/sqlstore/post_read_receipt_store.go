@@ -0,0 +1,2284 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	readReceiptSummariesForChannelLimit = 1000
+	readReceiptSummariesUpsertChunkSize = 500
+
+	// readReceiptsHotWindowDays is how many days of receipts the hot partial
+	// indexes cover, the window read by the summaries and the sync of clients.
+	readReceiptsHotWindowDays = 30
+)
+
+// hotReadReceiptIndexes are the partial indexes of the receipts read since the
+// start of the hot window, next to the full ones on the same columns.
+var hotReadReceiptIndexes = []struct {
+	name    string
+	columns string
+}{
+	{"idx_postreadreceipts_hot_userid_readat", "UserId, ReadAt"},
+	{"idx_postreadreceipts_hot_channelid_readat", "ChannelId, ReadAt"},
+}
+
+// readReceiptsAllowedForPost filters out the posts whose author opted them out of
+// read receipts through the no_read_receipts prop.
+const readReceiptsAllowedForPost = "(Posts.Props->>'no_read_receipts') IS DISTINCT FROM 'true'"
+
+// readReceiptPostTypes keeps the posts that can be marked as read explicitly, as
+// model.IsReadReceiptPostType does. It is plain SQL so that the raw queries can
+// use it too.
+const readReceiptPostTypes = "(Posts.Type IN ('" + model.PostTypeDefault + "', '" + model.PostTypeSlackAttachment + "', '" +
+	model.PostTypeMe + "', '" + model.PostTypeReminder + "') OR Posts.Type LIKE '" + model.PostCustomTypePrefix + "%')"
+
+// readReceiptNotCompacted filters out the posts whose sequence falls in one of the
+// runs of compacted sequences given as its two arguments, the first and the last
+// sequences of the runs, see getCompactedSequenceRanges.
+const readReceiptNotCompacted = `NOT EXISTS (
+	SELECT 1 FROM ReadReceiptPostSequences, unnest(?::bigint[], ?::bigint[]) AS Compacted(First, Last)
+	WHERE ReadReceiptPostSequences.PostId = Posts.Id
+		AND ReadReceiptPostSequences.Sequence BETWEEN Compacted.First AND Compacted.Last
+)`
+
+// readReceiptTableIndexes lists the tables of the read receipt subsystem, in the
+// lower case Postgres names, along with the indexes their migrations create.
+var readReceiptTableIndexes = []struct {
+	table   string
+	indexes []string
+}{
+	{"postreadreceipts", []string{"postreadreceipts_pkey", "idx_postreadreceipts_userid_readat", "idx_postreadreceipts_channelid_readat", "idx_postreadreceipts_hot_userid_readat", "idx_postreadreceipts_hot_channelid_readat"}},
+	{"postreadreceiptsummaries", []string{"postreadreceiptsummaries_pkey", "idx_postreadreceiptsummaries_channelid_lastupdated"}},
+	{"postreadreceiptdevices", []string{"postreadreceiptdevices_pkey"}},
+	{"readreceiptpolicies", []string{"readreceiptpolicies_pkey", "readreceiptpolicies_name_key"}},
+	{"readreceiptwebhooks", []string{"readreceiptwebhooks_pkey", "idx_readreceiptwebhooks_channelid"}},
+	{"readreceiptchains", []string{"readreceiptchains_pkey", "idx_readreceiptchains_channelid_postid_userid"}},
+	{"readreceiptstats", []string{"idx_readreceiptstats_userid"}},
+	{"readreceiptteamstats", []string{"idx_readreceiptteamstats_teamid"}},
+	{"readreceiptwatermarkchannels", []string{"readreceiptwatermarkchannels_pkey"}},
+	{"readreceiptbroadcasts", []string{"readreceiptbroadcasts_pkey"}},
+	{"readreceiptbroadcastposts", []string{"readreceiptbroadcastposts_pkey"}},
+	{"readreceiptchannelsettings", []string{"readreceiptchannelsettings_pkey"}},
+	{"readreceiptscrubs", []string{"readreceiptscrubs_pkey", "idx_readreceiptscrubs_deactivatedat"}},
+	{"readreceiptghostreads", []string{"readreceiptghostreads_pkey"}},
+	{"threadreadreceiptsummaries", []string{"threadreadreceiptsummaries_pkey"}},
+	{"readreceiptchanges", []string{"readreceiptchanges_pkey", "idx_readreceiptchanges_channelid_sequence", "idx_readreceiptchanges_at"}},
+	{"readreceiptpostsequences", []string{"readreceiptpostsequences_pkey"}},
+	{"readreceiptbitmaps", []string{"readreceiptbitmaps_pkey", "idx_readreceiptbitmaps_userid"}},
+}
+
+type SqlPostReadReceiptStore struct {
+	*SqlStore
+}
+
+func newSqlPostReadReceiptStore(sqlStore *SqlStore) store.PostReadReceiptStore {
+	return &SqlPostReadReceiptStore{sqlStore}
+}
+
+func (s *SqlPostReadReceiptStore) receiptColumns() []string {
+	return []string{"PostId", "UserId", "ChannelId", "ReadAt", "DeviceType", "DeviceId", "SessionId", "Source", "Confidence", "ClientReadAt"}
+}
+
+func (s *SqlPostReadReceiptStore) chainColumns() []string {
+	return []string{"ChannelId", "Sequence", "PostId", "UserId", "ReadAt", "PrevHash", "Hash"}
+}
+
+func (s *SqlPostReadReceiptStore) summaryColumns() []string {
+	return []string{"PostId", "ChannelId", "ReadCount", "BotReadCount", "LastReadAt", "LastUpdated", "Version"}
+}
+
+func (s *SqlPostReadReceiptStore) SaveReadReceipt(receipt *model.PostReadReceipt) (*model.PostReadReceipt, error) {
+	saved, err := s.SaveReadReceiptsBatch([]*model.PostReadReceipt{receipt})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(saved) == 0 {
+		return nil, store.NewErrNotFound("Post", receipt.PostId)
+	}
+
+	return saved[0], nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveReadReceiptWithSummary(receipt *model.PostReadReceipt) (_ *model.PostReadReceipt, _ *model.PostReadReceiptSummary, _ int64, err error) {
+	receipt.PreSave()
+	if appErr := receipt.IsValid(); appErr != nil {
+		return nil, nil, 0, appErr
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	// The post is share-locked as in saveReadReceipts, so a concurrent deletion
+	// cannot leave the receipt or the summary behind.
+	var channelID string
+	if err = transaction.Get(&channelID, "SELECT ChannelId FROM Posts WHERE Id = $1 AND DeleteAt = 0 AND "+readReceiptPostTypes+" FOR SHARE", receipt.PostId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, 0, store.NewErrNotFound("Post", receipt.PostId)
+		}
+		return nil, nil, 0, errors.Wrapf(err, "failed to lock Post with id=%s", receipt.PostId)
+	}
+	receipt.ChannelId = channelID
+
+	// xmax is only 0 for rows the statement inserted, which tells a first read
+	// apart from the update of an existing receipt.
+	var inserted bool
+	if err = transaction.Get(&inserted, `
+		INSERT INTO PostReadReceipts (PostId, UserId, ChannelId, ReadAt, DeviceType, DeviceId, SessionId, Source, Confidence, ClientReadAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (PostId, UserId) DO UPDATE SET
+			ReadAt = EXCLUDED.ReadAt,
+			DeviceType = EXCLUDED.DeviceType,
+			DeviceId = EXCLUDED.DeviceId,
+			SessionId = EXCLUDED.SessionId,
+			Source = EXCLUDED.Source,
+			Confidence = EXCLUDED.Confidence,
+			ClientReadAt = EXCLUDED.ClientReadAt
+		RETURNING xmax = 0`,
+		receipt.PostId, receipt.UserId, receipt.ChannelId, receipt.ReadAt, receipt.DeviceType, receipt.DeviceId, receipt.SessionId, receipt.Source, receipt.Confidence, receipt.ClientReadAt); err != nil {
+		return nil, nil, 0, errors.Wrapf(err, "failed to save PostReadReceipt with postId=%s", receipt.PostId)
+	}
+	if inserted {
+		// The read was compacted, the summary already counts it.
+		compacted, compactedErr := s.getCompactedReadKeys(transaction, []*model.PostReadReceipt{receipt})
+		if compactedErr != nil {
+			return nil, nil, 0, compactedErr
+		}
+		inserted = !compacted[readReceiptKey(receipt.PostId, receipt.UserId)]
+	}
+	receipt.FirstRead = inserted
+
+	if err = s.promoteGhostReads(transaction, []*model.PostReadReceipt{receipt}); err != nil {
+		return nil, nil, 0, err
+	}
+
+	if err = s.recordReadReceiptChanges(transaction, model.ReadReceiptChangeOpSave, []*model.PostReadReceipt{receipt}); err != nil {
+		return nil, nil, 0, err
+	}
+
+	var readDelta, botReadDelta, lastReadAt int64
+	if receipt.DeviceType == model.ReadReceiptDeviceTypeBot {
+		if inserted {
+			botReadDelta = 1
+		}
+	} else {
+		lastReadAt = receipt.ReadAt
+		if inserted {
+			readDelta = 1
+		}
+	}
+
+	summary, err := s.applyReadReceiptSummaryDelta(transaction, receipt.PostId, readDelta, botReadDelta, lastReadAt)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var previousReadCount int64
+	if summary != nil {
+		previousReadCount = summary.ReadCount - readDelta
+	} else {
+		// The post was never summarized, the summary is computed from all of its
+		// receipts, this one included. A concurrent first summary makes the insert
+		// wait for it and do nothing, the delta then applies on top of it.
+		summary = &model.PostReadReceiptSummary{}
+		err = transaction.Get(summary, `
+			INSERT INTO PostReadReceiptSummaries (PostId, ChannelId, ReadCount, BotReadCount, LastReadAt, LastUpdated, Version)
+			SELECT $1, $2,
+				COALESCE(SUM(CASE WHEN DeviceType <> 'bot' THEN 1 ELSE 0 END), 0),
+				COALESCE(SUM(CASE WHEN DeviceType = 'bot' THEN 1 ELSE 0 END), 0),
+				COALESCE(MAX(CASE WHEN DeviceType <> 'bot' THEN ReadAt ELSE 0 END), 0),
+				$3, 1
+			FROM PostReadReceipts
+			WHERE PostId = $1
+			ON CONFLICT (PostId) DO NOTHING
+			RETURNING `+strings.Join(s.summaryColumns(), ", "),
+			receipt.PostId, receipt.ChannelId, model.GetMillis())
+		switch {
+		case err == sql.ErrNoRows:
+			if summary, err = s.applyReadReceiptSummaryDelta(transaction, receipt.PostId, readDelta, botReadDelta, lastReadAt); err != nil {
+				return nil, nil, 0, err
+			}
+			previousReadCount = summary.ReadCount - readDelta
+		case err != nil:
+			return nil, nil, 0, errors.Wrapf(err, "failed to save PostReadReceiptSummary with postId=%s", receipt.PostId)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, nil, 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return receipt, summary, previousReadCount, nil
+}
+
+// applyReadReceiptSummaryDelta adds the read counts to the stored summary of the
+// post and moves its LastReadAt forward. It bumps the version of the summary so
+// that summaries computed concurrently do not overwrite it, and returns nil when
+// the post has no summary yet.
+func (s *SqlPostReadReceiptStore) applyReadReceiptSummaryDelta(transaction *sqlxTxWrapper, postID string, readDelta, botReadDelta, lastReadAt int64) (*model.PostReadReceiptSummary, error) {
+	query := s.getQueryBuilder().
+		Update("PostReadReceiptSummaries").
+		Set("ReadCount", sq.Expr("ReadCount + ?", readDelta)).
+		Set("BotReadCount", sq.Expr("BotReadCount + ?", botReadDelta)).
+		Set("LastReadAt", sq.Expr("GREATEST(LastReadAt, ?)", lastReadAt)).
+		Set("LastUpdated", sq.Expr("GREATEST(LastUpdated + 1, ?)", model.GetMillis())).
+		Set("Version", sq.Expr("Version + 1")).
+		Where(sq.Eq{"PostId": postID}).
+		Suffix("RETURNING " + strings.Join(s.summaryColumns(), ", "))
+
+	var summary model.PostReadReceiptSummary
+	if err := transaction.GetBuilder(&summary, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to update PostReadReceiptSummary with postId=%s", postID)
+	}
+
+	return &summary, nil
+}
+
+// SaveReadReceiptsBatch saves the receipts of posts that are not deleted and skips
+// the others, overwriting existing receipts of the same users.
+func (s *SqlPostReadReceiptStore) SaveReadReceiptsBatch(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+	return s.saveReadReceipts(receipts, true)
+}
+
+// SaveReadReceiptsIfNotExist saves the receipts of posts that are not deleted and
+// that the user has not read yet, and returns only the receipts it inserted.
+func (s *SqlPostReadReceiptStore) SaveReadReceiptsIfNotExist(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+	return s.saveReadReceipts(receipts, false)
+}
+
+// saveReadReceipts writes the receipts of live posts that can be marked as read,
+// each with the channel of its post. The posts are share-locked until the receipts are written, so a concurrent
+// post deletion either waits for the receipts and removes them, or wins and no
+// receipt is written.
+func (s *SqlPostReadReceiptStore) saveReadReceipts(receipts []*model.PostReadReceipt, overwrite bool) (_ []*model.PostReadReceipt, err error) {
+	if len(receipts) == 0 {
+		return []*model.PostReadReceipt{}, nil
+	}
+
+	postIDs := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		receipt.PreSave()
+		if appErr := receipt.IsValid(); appErr != nil {
+			return nil, appErr
+		}
+		postIDs = append(postIDs, receipt.PostId)
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	livePosts := []struct {
+		Id        string
+		ChannelId string
+	}{}
+	lockQuery := s.getQueryBuilder().
+		Select("Id", "ChannelId").
+		From("Posts").
+		Where(sq.Eq{
+			"Id":       postIDs,
+			"DeleteAt": 0,
+		}).
+		Where(readReceiptPostTypes).
+		Suffix("FOR SHARE")
+	if err = transaction.SelectBuilder(&livePosts, lockQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to lock Posts")
+	}
+
+	channelIDs := make(map[string]string, len(livePosts))
+	for _, post := range livePosts {
+		channelIDs[post.Id] = post.ChannelId
+	}
+
+	// A compacted read is not a first read, and is not saved again when only new
+	// reads are.
+	compacted, err := s.getCompactedReadKeys(transaction, receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("PostReadReceipts").
+		Columns(s.receiptColumns()...)
+
+	saved := make([]*model.PostReadReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		channelID, ok := channelIDs[receipt.PostId]
+		if !ok || (!overwrite && compacted[readReceiptKey(receipt.PostId, receipt.UserId)]) {
+			continue
+		}
+
+		// The channel of a receipt is always the one of its post, whatever the
+		// caller passed, so batches spanning channels are stored correctly.
+		receipt.ChannelId = channelID
+		query = query.Values(receipt.PostId, receipt.UserId, receipt.ChannelId, receipt.ReadAt, receipt.DeviceType, receipt.DeviceId, receipt.SessionId, receipt.Source, receipt.Confidence, receipt.ClientReadAt)
+		saved = append(saved, receipt)
+	}
+
+	if len(saved) == 0 {
+		return saved, nil
+	}
+
+	if overwrite {
+		// xmax is only 0 for rows the statement inserted, which tells a first read
+		// apart from the update of an existing receipt.
+		query = query.Suffix(`ON CONFLICT (PostId, UserId) DO UPDATE SET
+			ReadAt = EXCLUDED.ReadAt,
+			DeviceType = EXCLUDED.DeviceType,
+			DeviceId = EXCLUDED.DeviceId,
+			SessionId = EXCLUDED.SessionId,
+			Source = EXCLUDED.Source,
+			Confidence = EXCLUDED.Confidence,
+			ClientReadAt = EXCLUDED.ClientReadAt
+			RETURNING PostId, UserId, xmax = 0 AS FirstRead`)
+
+		var written []struct {
+			PostId    string
+			UserId    string
+			FirstRead bool
+		}
+		if err = transaction.SelectBuilder(&written, query); err != nil {
+			return nil, errors.Wrap(err, "failed to save PostReadReceipts")
+		}
+
+		firstReads := make(map[string]bool, len(written))
+		for _, row := range written {
+			if row.FirstRead {
+				firstReads[readReceiptKey(row.PostId, row.UserId)] = true
+			}
+		}
+		for _, receipt := range saved {
+			key := readReceiptKey(receipt.PostId, receipt.UserId)
+			receipt.FirstRead = firstReads[key] && !compacted[key]
+		}
+	} else {
+		query = query.Suffix("ON CONFLICT (PostId, UserId) DO NOTHING RETURNING " + strings.Join(s.receiptColumns(), ", "))
+
+		saved = []*model.PostReadReceipt{}
+		if err = transaction.SelectBuilder(&saved, query); err != nil {
+			return nil, errors.Wrap(err, "failed to save PostReadReceipts")
+		}
+		for _, receipt := range saved {
+			receipt.FirstRead = true
+		}
+	}
+
+	if err = s.promoteGhostReads(transaction, saved); err != nil {
+		return nil, err
+	}
+
+	if err = s.recordReadReceiptChanges(transaction, model.ReadReceiptChangeOpSave, saved); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return saved, nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveReadReceiptsUpToPost(receipt *model.PostReadReceipt, limit int, rootPostsOnly bool) (_ []*model.PostReadReceipt, err error) {
+	receipt.PreSave()
+	if err := receipt.IsValid(); err != nil {
+		return nil, err
+	}
+
+	rootFilter := ""
+	if rootPostsOnly {
+		rootFilter = "AND Posts.RootId = ''"
+	}
+
+	// Resolve the post ids in the database so the client only has to send the watermark.
+	// Posts already read, compacted reads included, don't count towards the limit and
+	// keep their original ReadAt, none is recorded as read before its post was created,
+	// and posts opted out of receipts or of a type that can't be read explicitly are skipped.
+	query := `
+		INSERT INTO PostReadReceipts (PostId, UserId, ChannelId, ReadAt, DeviceType, DeviceId, SessionId, Source, Confidence, ClientReadAt)
+		SELECT Posts.Id, $1, Posts.ChannelId, GREATEST($2, Posts.CreateAt), $3, $4, $5, $9, $10, $11
+		FROM Posts
+		WHERE Posts.ChannelId = $6
+			AND Posts.DeleteAt = 0
+			AND Posts.CreateAt <= (SELECT Watermark.CreateAt FROM Posts Watermark WHERE Watermark.Id = $7 AND Watermark.ChannelId = $6)
+			AND ` + readReceiptsAllowedForPost + `
+			AND ` + readReceiptPostTypes + `
+			AND NOT EXISTS (SELECT 1 FROM PostReadReceipts Existing WHERE Existing.PostId = Posts.Id AND Existing.UserId = $1)
+			AND NOT EXISTS (
+				SELECT 1 FROM ReadReceiptPostSequences, unnest($12::bigint[], $13::bigint[]) AS Compacted(First, Last)
+				WHERE ReadReceiptPostSequences.PostId = Posts.Id
+					AND ReadReceiptPostSequences.Sequence BETWEEN Compacted.First AND Compacted.Last
+			)
+			` + rootFilter + `
+		ORDER BY Posts.CreateAt DESC
+		LIMIT $8
+		FOR SHARE OF Posts
+		ON CONFLICT (PostId, UserId) DO NOTHING
+		RETURNING PostId, UserId, ChannelId, ReadAt, DeviceType, DeviceId, SessionId, Source, Confidence, ClientReadAt`
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	compactedFirsts, compactedLasts, err := s.getCompactedSequenceRanges(transaction, receipt.ChannelId, receipt.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := []*model.PostReadReceipt{}
+	if err = transaction.Select(&receipts, query, receipt.UserId, receipt.ReadAt, receipt.DeviceType, receipt.DeviceId, receipt.SessionId, receipt.ChannelId, receipt.PostId, limit, receipt.Source, receipt.Confidence, receipt.ClientReadAt, pq.Array(compactedFirsts), pq.Array(compactedLasts)); err != nil {
+		return nil, errors.Wrapf(err, "failed to save PostReadReceipts up to postId=%s", receipt.PostId)
+	}
+	for _, saved := range receipts {
+		saved.FirstRead = true
+	}
+
+	if err = s.promoteGhostReads(transaction, receipts); err != nil {
+		return nil, err
+	}
+
+	if err = s.recordReadReceiptChanges(transaction, model.ReadReceiptChangeOpSave, receipts); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return receipts, nil
+}
+
+// promoteGhostReads links the ghost reads of the given receipts to them, so that a
+// read stays counted once after it was promoted to a normal receipt.
+func (s *SqlPostReadReceiptStore) promoteGhostReads(transaction *sqlxTxWrapper, receipts []*model.PostReadReceipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	keys := make(sq.Or, 0, len(receipts))
+	for _, receipt := range receipts {
+		keys = append(keys, sq.Eq{
+			"PostId": receipt.PostId,
+			"UserId": receipt.UserId,
+		})
+	}
+
+	query := s.getQueryBuilder().
+		Update("ReadReceiptGhostReads").
+		Set("PromotedAt", model.GetMillis()).
+		Where(sq.And{
+			sq.Eq{"PromotedAt": 0},
+			keys,
+		})
+	if _, err := transaction.ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to promote ReadReceiptGhostReads")
+	}
+
+	return nil
+}
+
+// recordReadReceiptChanges records a change with op for each of the receipts, in
+// the transaction that saved them, so that the changes are kept exactly when the
+// receipts are.
+func (s *SqlPostReadReceiptStore) recordReadReceiptChanges(transaction *sqlxTxWrapper, op string, receipts []*model.PostReadReceipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	at := model.GetMillis()
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptChanges").
+		Columns("Op", "PostId", "UserId", "ChannelId", "At")
+	for _, receipt := range receipts {
+		query = query.Values(op, receipt.PostId, receipt.UserId, receipt.ChannelId, at)
+	}
+	if _, err := transaction.ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to save ReadReceiptChanges")
+	}
+
+	return nil
+}
+
+// deleteReadReceiptsWithChanges deletes the receipts matching where and records a
+// delete change for each of them in the same statement, returning how many
+// receipts were deleted. The integrity chain entries of the receipts are pruned
+// by the statement too, so that retention and user deletion remove them as well.
+func (ss *SqlStore) deleteReadReceiptsWithChanges(transaction *sqlxTxWrapper, where sq.Sqlizer) (int64, error) {
+	deleteSQL, args, err := ss.getSubQueryBuilder().
+		Delete("PostReadReceipts").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build the PostReadReceipts delete query")
+	}
+
+	query := `
+		WITH Deleted AS (` + deleteSQL + ` RETURNING PostId, UserId, ChannelId),
+		Pruned AS (
+			UPDATE ReadReceiptChains SET PostId = '', UserId = '', ReadAt = 0
+			FROM Deleted
+			WHERE ReadReceiptChains.ChannelId = Deleted.ChannelId
+				AND ReadReceiptChains.PostId = Deleted.PostId
+				AND ReadReceiptChains.UserId = Deleted.UserId
+		)
+		INSERT INTO ReadReceiptChanges (Op, PostId, UserId, ChannelId, At)
+		SELECT ?, PostId, UserId, ChannelId, ? FROM Deleted`
+	result, err := transaction.Exec(query, append(args, model.ReadReceiptChangeOpDelete, model.GetMillis())...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete from PostReadReceipts")
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count the deleted PostReadReceipts")
+	}
+
+	return deleted, nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveHealthCheckReceipt(receipt *model.PostReadReceipt) (int64, error) {
+	var readAt int64
+	if err := s.GetMaster().Get(&readAt, `
+		INSERT INTO PostReadReceipts (PostId, UserId, ChannelId, ReadAt, DeviceType, DeviceId, SessionId, Source, Confidence, ClientReadAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (PostId, UserId) DO UPDATE SET ReadAt = EXCLUDED.ReadAt
+		RETURNING ReadAt`,
+		receipt.PostId, receipt.UserId, receipt.ChannelId, receipt.ReadAt, receipt.DeviceType, receipt.DeviceId, receipt.SessionId, receipt.Source, receipt.Confidence, receipt.ClientReadAt); err != nil {
+		return 0, errors.Wrapf(err, "failed to save the health check PostReadReceipt with postId=%s", receipt.PostId)
+	}
+
+	return readAt, nil
+}
+
+func (s *SqlPostReadReceiptStore) DeleteHealthCheckReceipt(postID, userID string) error {
+	query := s.getQueryBuilder().
+		Delete("PostReadReceipts").
+		Where(sq.Eq{
+			"PostId": postID,
+			"UserId": userID,
+		})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete the health check PostReadReceipt with postId=%s userId=%s", postID, userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceipt(postID, userID string) (*model.PostReadReceipt, error) {
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceipts").
+		Where(sq.Eq{
+			"PostId": postID,
+			"UserId": userID,
+		})
+
+	var receipt model.PostReadReceipt
+	if err := s.GetReplica().GetBuilder(&receipt, query); err != nil {
+		if err != sql.ErrNoRows {
+			return nil, errors.Wrapf(err, "failed to get PostReadReceipt with postId=%s userId=%s", postID, userID)
+		}
+
+		compacted, err := s.getCompactedReadReceipts(s.GetReplica(), []string{postID}, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(compacted) == 0 {
+			return nil, store.NewErrNotFound("PostReadReceipt", postID)
+		}
+		return compacted[0], nil
+	}
+
+	return &receipt, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptsForPost(postID, deviceType string) ([]*model.PostReadReceipt, error) {
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		Column(`EXISTS (
+			SELECT 1 FROM ReadReceiptGhostReads
+			WHERE ReadReceiptGhostReads.PostId = PostReadReceipts.PostId
+				AND ReadReceiptGhostReads.UserId = PostReadReceipts.UserId
+				AND ReadReceiptGhostReads.PromotedAt > 0
+		) AS ConvertedFromGhost`).
+		From("PostReadReceipts").
+		Where(sq.Eq{"PostId": postID}).
+		OrderBy("ReadAt ASC")
+	if deviceType != "" {
+		query = query.Where(sq.Eq{"DeviceType": deviceType})
+	}
+
+	receipts := []*model.PostReadReceipt{}
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts for postId=%s", postID)
+	}
+
+	// Compacted receipts have no device type, so they only match when none is asked for.
+	if deviceType == "" {
+		compacted, err := s.getCompactedReadReceipts(s.GetReplica(), []string{postID}, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(compacted) > 0 {
+			receipts = mergeCompactedReadReceipts(receipts, compacted)
+			slices.SortStableFunc(receipts, func(a, b *model.PostReadReceipt) int {
+				return cmp.Compare(a.ReadAt, b.ReadAt)
+			})
+		}
+	}
+
+	return receipts, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptsForPosts(postIDs []string) ([]*model.PostReadReceipt, error) {
+	receipts := []*model.PostReadReceipt{}
+	if len(postIDs) == 0 {
+		return receipts, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceipts").
+		Where(sq.Eq{"PostId": postIDs}).
+		OrderBy("PostId ASC", "ReadAt ASC")
+
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts for %d posts", len(postIDs))
+	}
+
+	compacted, err := s.getCompactedReadReceipts(s.GetReplica(), postIDs, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(compacted) > 0 {
+		receipts = mergeCompactedReadReceipts(receipts, compacted)
+		slices.SortStableFunc(receipts, func(a, b *model.PostReadReceipt) int {
+			return cmp.Or(strings.Compare(a.PostId, b.PostId), cmp.Compare(a.ReadAt, b.ReadAt))
+		})
+	}
+
+	return receipts, nil
+}
+
+// GetReadPostIdsForUser returns the ids among postIDs of the posts the user has
+// read, reading only the PostId column.
+func (s *SqlPostReadReceiptStore) GetReadPostIdsForUser(userID string, postIDs []string) ([]string, error) {
+	readPostIDs := []string{}
+	if len(postIDs) == 0 {
+		return readPostIDs, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("PostId").
+		From("PostReadReceipts").
+		Where(sq.Eq{
+			"UserId": userID,
+			"PostId": postIDs,
+		})
+
+	if err := s.GetReplica().SelectBuilder(&readPostIDs, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get read posts for userId=%s", userID)
+	}
+
+	compacted, err := s.getCompactedReadReceipts(s.GetReplica(), postIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, receipt := range compacted {
+		if !slices.Contains(readPostIDs, receipt.PostId) {
+			readPostIDs = append(readPostIDs, receipt.PostId)
+		}
+	}
+
+	return readPostIDs, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptsForUserPosts(userID string, postIDs []string) ([]*model.PostReadReceipt, error) {
+	receipts := []*model.PostReadReceipt{}
+	if len(postIDs) == 0 {
+		return receipts, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceipts").
+		Where(sq.Eq{
+			"UserId": userID,
+			"PostId": postIDs,
+		})
+
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts for userId=%s", userID)
+	}
+
+	compacted, err := s.getCompactedReadReceipts(s.GetReplica(), postIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeCompactedReadReceipts(receipts, compacted), nil
+}
+
+// GetReadReceiptsForUser returns a page of the user's receipts, newest first. Pages
+// are keyed on (ReadAt, PostId) so that results stay stable while new receipts arrive.
+func (s *SqlPostReadReceiptStore) GetReadReceiptsForUser(userID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
+	query := s.readReceiptsPageQuery(opts).Where(sq.Eq{"UserId": userID})
+
+	receipts := []*model.PostReadReceipt{}
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts for userId=%s", userID)
+	}
+
+	return receipts, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptsForSession(sessionID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
+	query := s.readReceiptsPageQuery(opts).Where(sq.Eq{"SessionId": sessionID})
+
+	receipts := []*model.PostReadReceipt{}
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts for sessionId=%s", sessionID)
+	}
+
+	return receipts, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadActivityBySession(userID string, since int64, offset, limit int) ([]*model.ReadReceiptSessionActivity, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"PostReadReceipts.SessionId",
+			"PostReadReceipts.DeviceType",
+			"PostReadReceipts.DeviceId",
+			"COALESCE(Sessions.Props->>'platform', '') AS Platform",
+			"COALESCE(Sessions.Props->>'os', '') AS Os",
+			"COALESCE(Sessions.Props->>'browser', '') AS Browser",
+			"Sessions.Id IS NOT NULL AS SessionActive",
+			"COUNT(*) AS ReadCount",
+			"MIN(PostReadReceipts.ReadAt) AS FirstReadAt",
+			"MAX(PostReadReceipts.ReadAt) AS LastReadAt",
+		).
+		From("PostReadReceipts").
+		LeftJoin("Sessions ON Sessions.Id = PostReadReceipts.SessionId").
+		Where(sq.Eq{"PostReadReceipts.UserId": userID}).
+		Where(sq.GtOrEq{"PostReadReceipts.ReadAt": since}).
+		GroupBy("PostReadReceipts.SessionId", "PostReadReceipts.DeviceType", "PostReadReceipts.DeviceId", "Sessions.Id").
+		OrderBy("LastReadAt DESC", "PostReadReceipts.SessionId", "PostReadReceipts.DeviceId").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+	query = whereHotReadReceipts(query, "PostReadReceipts.ReadAt", since)
+
+	activity := []*model.ReadReceiptSessionActivity{}
+	if err := s.GetReplica().SelectBuilder(&activity, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the read activity by session for userId=%s", userID)
+	}
+
+	return activity, nil
+}
+
+// readReceiptsPageQuery selects a page of receipts, most recent first, matching opts.
+func (s *SqlPostReadReceiptStore) readReceiptsPageQuery(opts model.GetReadReceiptsForUserOptions) sq.SelectBuilder {
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceipts").
+		OrderBy("ReadAt DESC", "PostId DESC").
+		Limit(uint64(opts.PerPage))
+
+	if opts.ChannelId != "" {
+		query = query.Where(sq.Eq{"ChannelId": opts.ChannelId})
+	}
+	if len(opts.Sources) > 0 {
+		query = query.Where(sq.Eq{"Source": opts.Sources})
+	}
+	if opts.Since > 0 {
+		query = query.Where(sq.GtOrEq{"ReadAt": opts.Since})
+		query = whereHotReadReceipts(query, "ReadAt", opts.Since)
+	}
+	if opts.Until > 0 {
+		query = query.Where(sq.Lt{"ReadAt": opts.Until})
+	}
+	if !opts.Cursor.IsEmpty() {
+		query = query.Where(sq.Expr("(ReadAt, PostId) < (?, ?)", opts.Cursor.ReadAt, opts.Cursor.PostId))
+	}
+
+	return query
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptsPage(cursor model.ReadReceiptsPageCursor, limit int) ([]*model.PostReadReceipt, error) {
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceipts").
+		OrderBy("PostId", "UserId").
+		Limit(uint64(limit))
+
+	if cursor.PostId != "" {
+		query = query.Where("(PostId, UserId) > (?, ?)", cursor.PostId, cursor.UserId)
+	}
+
+	receipts := []*model.PostReadReceipt{}
+	if err := s.GetReplica().SelectBuilder(&receipts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceipts after postId=%s userId=%s", cursor.PostId, cursor.UserId)
+	}
+
+	return receipts, nil
+}
+
+func (s *SqlPostReadReceiptStore) DeleteReadReceipt(postID, userID string) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	key := sq.Eq{
+		"PostId": postID,
+		"UserId": userID,
+	}
+
+	// The receipt is locked so that concurrent deletions only uncount it once.
+	var deviceType string
+	err = transaction.GetBuilder(&deviceType, s.getQueryBuilder().Select("DeviceType").From("PostReadReceipts").Where(key).Suffix("FOR UPDATE"))
+	switch {
+	case err == sql.ErrNoRows:
+		// The read may have been compacted, only bot reads are never compacted.
+		var removed bool
+		if removed, err = s.removeCompactedRead(transaction, postID, userID); err != nil {
+			return err
+		}
+		if removed {
+			if _, err = s.applyReadReceiptSummaryDelta(transaction, postID, -1, 0, 0); err != nil {
+				return err
+			}
+		}
+	case err != nil:
+		return errors.Wrapf(err, "failed to lock PostReadReceipt with postId=%s userId=%s", postID, userID)
+	default:
+		if _, err = s.deleteReadReceiptsWithChanges(transaction, key); err != nil {
+			return err
+		}
+
+		var readDelta, botReadDelta int64 = -1, 0
+		if deviceType == model.ReadReceiptDeviceTypeBot {
+			readDelta, botReadDelta = 0, -1
+		}
+		if _, err = s.applyReadReceiptSummaryDelta(transaction, postID, readDelta, botReadDelta, 0); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{"PostReadReceiptDevices", "ReadReceiptGhostReads"} {
+		if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete(table).Where(key)); err != nil {
+			return errors.Wrapf(err, "failed to delete from %s with postId=%s userId=%s", table, postID, userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetExpiredReadReceiptCounts(expiredBefore int64, teamIDs, channelIDs []string, limit int) (*model.ReadReceiptCleanupPreview, error) {
+	scope := sq.Or{}
+	if len(channelIDs) > 0 {
+		scope = append(scope, sq.Eq{"ChannelId": channelIDs})
+	}
+	if len(teamIDs) > 0 {
+		scope = append(scope, sq.Expr("ChannelId IN (?)", sq.Select("Id").From("Channels").Where(sq.Eq{"TeamId": teamIDs})))
+	}
+
+	countQuery := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("PostReadReceipts").
+		Where(sq.Lt{"ReadAt": expiredBefore})
+	channelsQuery := s.getQueryBuilder().
+		Select("ChannelId", "COUNT(*) AS Count").
+		From("PostReadReceipts").
+		Where(sq.Lt{"ReadAt": expiredBefore}).
+		GroupBy("ChannelId").
+		OrderBy("Count DESC", "ChannelId").
+		Limit(uint64(limit))
+	if len(scope) > 0 {
+		countQuery = countQuery.Where(scope)
+		channelsQuery = channelsQuery.Where(scope)
+	}
+
+	preview := &model.ReadReceiptCleanupPreview{
+		ExpiredBefore: expiredBefore,
+		Channels:      []*model.ReadReceiptCleanupChannelCount{},
+	}
+	if err := s.GetReplica().GetBuilder(&preview.TotalCount, countQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to count the expired PostReadReceipts")
+	}
+	if err := s.GetReplica().SelectBuilder(&preview.Channels, channelsQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to count the expired PostReadReceipts by channel")
+	}
+
+	return preview, nil
+}
+
+func (s *SqlPostReadReceiptStore) DeleteReadReceipts(receipts []*model.PostReadReceipt) (_ int64, err error) {
+	if len(receipts) == 0 {
+		return 0, nil
+	}
+
+	keys := make(sq.Or, 0, len(receipts))
+	for _, receipt := range receipts {
+		keys = append(keys, sq.Eq{
+			"PostId": receipt.PostId,
+			"UserId": receipt.UserId,
+		})
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	for _, table := range []string{"PostReadReceiptDevices", "ReadReceiptGhostReads"} {
+		if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete(table).Where(keys)); err != nil {
+			return 0, errors.Wrapf(err, "failed to delete from %s", table)
+		}
+	}
+
+	deleted, err := s.deleteReadReceiptsWithChanges(transaction, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return deleted, nil
+}
+
+// SaveReadDevices records, for each receipt, the read on its device. Unlike the
+// receipts themselves, reads from different devices of the same user are kept
+// side by side; a read from a device that is already known updates its row.
+func (s *SqlPostReadReceiptStore) SaveReadDevices(receipts []*model.PostReadReceipt) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder().
+		Insert("PostReadReceiptDevices").
+		Columns(s.receiptColumns()...)
+	for _, receipt := range receipts {
+		query = query.Values(receipt.PostId, receipt.UserId, receipt.ChannelId, receipt.ReadAt, receipt.DeviceType, receipt.DeviceId, receipt.SessionId, receipt.Source, receipt.Confidence, receipt.ClientReadAt)
+	}
+	query = query.Suffix(`ON CONFLICT (PostId, UserId, DeviceId) DO UPDATE SET
+		ReadAt = EXCLUDED.ReadAt,
+		DeviceType = EXCLUDED.DeviceType,
+		SessionId = EXCLUDED.SessionId,
+		Source = EXCLUDED.Source,
+		Confidence = EXCLUDED.Confidence,
+		ClientReadAt = EXCLUDED.ClientReadAt`)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to save PostReadReceiptDevices")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadDevicesForPostUser(postID, userID string) ([]*model.PostReadReceipt, error) {
+	query := s.getQueryBuilder().
+		Select(s.receiptColumns()...).
+		From("PostReadReceiptDevices").
+		Where(sq.Eq{
+			"PostId": postID,
+			"UserId": userID,
+		}).
+		OrderBy("ReadAt ASC")
+
+	devices := []*model.PostReadReceipt{}
+	if err := s.GetReplica().SelectBuilder(&devices, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceiptDevices for postId=%s userId=%s", postID, userID)
+	}
+
+	return devices, nil
+}
+
+func (s *SqlPostReadReceiptStore) PermanentDeleteByUser(userID string) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = s.deleteReadReceiptsWithChanges(transaction, sq.Eq{"UserId": userID}); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"PostReadReceiptDevices", "ReadReceiptGhostReads", "ReadReceiptScrubs", "ReadReceiptBitmaps"} {
+		if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete(table).Where(sq.Eq{"UserId": userID})); err != nil {
+			return errors.Wrapf(err, "failed to delete %s for userId=%s", table, userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) DeleteReadReceiptsForPost(postID string) error {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = s.deleteReadReceiptsWithChanges(transaction, sq.Eq{"PostId": postID}); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"PostReadReceiptDevices", "PostReadReceiptSummaries", "ReadReceiptGhostReads", "ReadReceiptPostSequences"} {
+		if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete(table).Where(sq.Eq{"PostId": postID})); err != nil {
+			return errors.Wrapf(err, "failed to delete %s for postId=%s", table, postID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+// GetHumanMemberCount returns the number of active, non-bot members of a channel.
+func (s *SqlPostReadReceiptStore) GetHumanMemberCount(channelID string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("ChannelMembers").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = ChannelMembers.UserId").
+		Where(sq.Eq{
+			"ChannelMembers.ChannelId": channelID,
+			"Users.DeleteAt":           0,
+			"Bots.UserId":              nil,
+		})
+
+	var count int64
+	if err := s.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count human members for channelId=%s", channelID)
+	}
+
+	return count, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetAnnouncementReadCounts(teamID string, postIDs []string) ([]*model.ReadReceiptBroadcastChannelSummary, error) {
+	reads := s.getSubQueryBuilder().
+		Select("PostId", "COUNT(*) AS ReadCount").
+		From("PostReadReceipts").
+		Where(sq.Eq{"PostId": postIDs}).
+		Where(sq.NotEq{"DeviceType": model.ReadReceiptDeviceTypeBot}).
+		GroupBy("PostId").
+		Prefix("LEFT JOIN (").
+		Suffix(") AS Reads ON Reads.PostId = Posts.Id")
+
+	postChannels := s.getSubQueryBuilder().
+		Select("ChannelId").
+		From("Posts").
+		Where(sq.Eq{"Id": postIDs})
+
+	members := s.getSubQueryBuilder().
+		Select("ChannelMembers.ChannelId", "COUNT(*) AS TotalMembers").
+		From("ChannelMembers").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = ChannelMembers.UserId").
+		Where(sq.Expr("ChannelMembers.ChannelId IN (?)", postChannels)).
+		Where(sq.Eq{
+			"Users.DeleteAt": 0,
+			"Bots.UserId":    nil,
+		}).
+		GroupBy("ChannelMembers.ChannelId").
+		Prefix("LEFT JOIN (").
+		Suffix(") AS Members ON Members.ChannelId = Posts.ChannelId")
+
+	query := s.getQueryBuilder().
+		Select(
+			"Posts.ChannelId",
+			"Posts.Id AS PostId",
+			"COALESCE(Reads.ReadCount, 0) AS ReadCount",
+			"COALESCE(Members.TotalMembers, 0) AS TotalMembers",
+		).
+		From("Posts").
+		Join("Channels ON Channels.Id = Posts.ChannelId").
+		JoinClause(reads).
+		JoinClause(members).
+		Where(sq.Eq{
+			"Posts.Id":        postIDs,
+			"Posts.DeleteAt":  0,
+			"Channels.TeamId": teamID,
+		}).
+		OrderBy("Posts.ChannelId", "Posts.Id")
+
+	counts := []*model.ReadReceiptBroadcastChannelSummary{}
+	if err := s.GetReplica().SelectBuilder(&counts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get announcement read counts for teamId=%s", teamID)
+	}
+
+	return counts, nil
+}
+
+func (s *SqlPostReadReceiptStore) IsPostReadByAnyone(postID string, fromMaster bool) (bool, int64, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"COUNT(*) AS Count",
+			"COALESCE(SUM(CASE WHEN PostReadReceipts.UserId <> Posts.UserId THEN 1 ELSE 0 END), 0) AS OthersCount",
+		).
+		From("PostReadReceipts").
+		Join("Posts ON Posts.Id = PostReadReceipts.PostId").
+		Where(sq.Eq{"PostReadReceipts.PostId": postID})
+
+	db := s.GetReplica()
+	if fromMaster {
+		db = s.GetMaster()
+	}
+
+	var counts struct {
+		Count       int64
+		OthersCount int64
+	}
+	if err := db.GetBuilder(&counts, query); err != nil {
+		return false, 0, errors.Wrapf(err, "failed to check whether postId=%s was read", postID)
+	}
+
+	compacted, err := s.getCompactedReadReceiptsWithoutReceipt(db, postID)
+	if err != nil {
+		return false, 0, err
+	}
+	if len(compacted) > 0 {
+		var authorID string
+		if err := db.Get(&authorID, "SELECT UserId FROM Posts WHERE Id = $1", postID); err != nil && err != sql.ErrNoRows {
+			return false, 0, errors.Wrapf(err, "failed to get the author of postId=%s", postID)
+		}
+		for _, receipt := range compacted {
+			counts.Count++
+			if receipt.UserId != authorID {
+				counts.OthersCount++
+			}
+		}
+	}
+
+	return counts.OthersCount > 0, counts.Count, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetUnreadUsersForPost(postID string, limit int) ([]*model.User, error) {
+	compacted, err := s.getCompactedReadReceipts(s.GetReplica(), []string{postID}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Select(getUsersColumns()...).
+		From("Posts").
+		Join("ChannelMembers ON ChannelMembers.ChannelId = Posts.ChannelId").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = Users.Id").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = Posts.Id AND PostReadReceipts.UserId = Users.Id").
+		Where(sq.Eq{
+			"Posts.Id":                postID,
+			"Users.DeleteAt":          0,
+			"Bots.UserId":             nil,
+			"PostReadReceipts.UserId": nil,
+		}).
+		Where("Users.Id <> Posts.UserId").
+		Where(readReceiptsAllowedForPost).
+		OrderBy("Users.Username").
+		Limit(uint64(limit))
+	if len(compacted) > 0 {
+		readerIDs := make([]string, 0, len(compacted))
+		for _, receipt := range compacted {
+			readerIDs = append(readerIDs, receipt.UserId)
+		}
+		query = query.Where(sq.NotEq{"Users.Id": readerIDs})
+	}
+
+	users := []*model.User{}
+	if err := s.GetReplica().SelectBuilder(&users, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get unread users for postId=%s", postID)
+	}
+
+	return users, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetCaughtUpUsersForChannel(channelID string, limit int) ([]*model.User, error) {
+	latestPost := s.getSubQueryBuilder().
+		Select("Posts.Id", "Posts.UserId").
+		From("Posts").
+		Where(sq.Eq{
+			"Posts.ChannelId": channelID,
+			"Posts.RootId":    "",
+			"Posts.DeleteAt":  0,
+		}).
+		Where(readReceiptsAllowedForPost).
+		OrderBy("Posts.CreateAt DESC").
+		Limit(1).
+		Prefix("JOIN (").
+		Suffix(") AS LatestPost ON true")
+
+	query := s.getQueryBuilder().
+		Select(getUsersColumns()...).
+		From("ChannelMembers").
+		JoinClause(latestPost).
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = Users.Id").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = LatestPost.Id AND PostReadReceipts.UserId = Users.Id").
+		Where(sq.Eq{
+			"ChannelMembers.ChannelId": channelID,
+			"Users.DeleteAt":           0,
+			"Bots.UserId":              nil,
+		}).
+		Where("(PostReadReceipts.UserId IS NOT NULL OR Users.Id = LatestPost.UserId)").
+		OrderBy("Users.Username").
+		Limit(uint64(limit))
+
+	users := []*model.User{}
+	if err := s.GetReplica().SelectBuilder(&users, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get caught up users for channelId=%s", channelID)
+	}
+
+	return users, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetUnreadPostsCount(channelID, userID string, createdBefore int64) (int64, error) {
+	compactedFirsts, compactedLasts, err := s.getCompactedSequenceRanges(s.GetReplica(), channelID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("Posts").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = Posts.Id AND PostReadReceipts.UserId = ?", userID).
+		Where(sq.Eq{
+			"Posts.ChannelId":         channelID,
+			"Posts.DeleteAt":          0,
+			"PostReadReceipts.UserId": nil,
+		}).
+		Where(sq.NotEq{"Posts.UserId": userID}).
+		Where(sq.Lt{"Posts.CreateAt": createdBefore}).
+		Where(readReceiptPostTypes).
+		Where(readReceiptsAllowedForPost)
+	if len(compactedFirsts) > 0 {
+		query = query.Where(readReceiptNotCompacted, pq.Array(compactedFirsts), pq.Array(compactedLasts))
+	}
+
+	var count int64
+	if err := s.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count unread posts for channelId=%s userId=%s", channelID, userID)
+	}
+
+	return count, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetUnreadDirectChannels(createdAfter, createdBefore int64) ([]*model.UnreadDirectChannel, error) {
+	query := s.getQueryBuilder().
+		Select("ChannelMembers.ChannelId", "ChannelMembers.UserId").
+		Distinct().
+		From("Posts").
+		Join("Channels ON Channels.Id = Posts.ChannelId").
+		Join("ChannelMembers ON ChannelMembers.ChannelId = Posts.ChannelId AND ChannelMembers.UserId <> Posts.UserId").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = ChannelMembers.UserId").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = Posts.Id AND PostReadReceipts.UserId = ChannelMembers.UserId").
+		Where(sq.Eq{
+			"Channels.Type":           model.ChannelTypeDirect,
+			"Posts.DeleteAt":          0,
+			"Users.DeleteAt":          0,
+			"Bots.UserId":             nil,
+			"PostReadReceipts.UserId": nil,
+		}).
+		Where(sq.GtOrEq{"Posts.CreateAt": createdAfter}).
+		Where(sq.Lt{"Posts.CreateAt": createdBefore}).
+		Where(readReceiptPostTypes).
+		Where(readReceiptsAllowedForPost).
+		OrderBy("ChannelMembers.UserId", "ChannelMembers.ChannelId")
+
+	channels := []*model.UnreadDirectChannel{}
+	if err := s.GetReplica().SelectBuilder(&channels, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get unread direct channels")
+	}
+
+	return channels, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetMembersReadActivity(channelID string, since int64, offset, limit int) ([]*model.ChannelMemberReadActivity, error) {
+	reads := s.getSubQueryBuilder().
+		Select("UserId", "MAX(ReadAt) AS LastPostReadAt").
+		Column(sq.Alias(sq.Expr("COUNT(*) FILTER (WHERE ReadAt >= ?)", since), "PostsRead")).
+		From("PostReadReceipts").
+		Where(sq.Eq{"ChannelId": channelID}).
+		GroupBy("UserId").
+		Prefix("LEFT JOIN (").
+		Suffix(") AS Reads ON Reads.UserId = ChannelMembers.UserId")
+
+	query := s.getQueryBuilder().
+		Select(
+			"ChannelMembers.UserId",
+			"Reads.LastPostReadAt",
+			"COALESCE(Reads.PostsRead, 0) AS PostsRead",
+		).
+		From("ChannelMembers").
+		JoinClause(reads).
+		Where(sq.Eq{"ChannelMembers.ChannelId": channelID}).
+		OrderBy("Reads.LastPostReadAt ASC NULLS FIRST", "ChannelMembers.UserId").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	activity := []*model.ChannelMemberReadActivity{}
+	if err := s.GetReplica().SelectBuilder(&activity, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the read activity of the members of channelId=%s", channelID)
+	}
+
+	return activity, nil
+}
+
+// utcDay returns the expression of the UTC day, formatted as 2006-01-02, of the
+// millisecond timestamp column.
+func utcDay(column string) string {
+	return "to_char(date_trunc('day', to_timestamp(" + column + " / 1000.0) AT TIME ZONE 'UTC'), 'YYYY-MM-DD')"
+}
+
+func (s *SqlPostReadReceiptStore) GetDistinctReaderCounts(channelID, fromDay, toDay string) ([]*model.DailyReaderCount, error) {
+	from, err := time.Parse("2006-01-02", fromDay)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid fromDay=%s", fromDay)
+	}
+	to, err := time.Parse("2006-01-02", toDay)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid toDay=%s", toDay)
+	}
+
+	day := utcDay("ReadAt")
+	query := s.getQueryBuilder().
+		Select(day+" AS Day", "COUNT(DISTINCT UserId) AS ReaderCount").
+		From("PostReadReceipts").
+		Where(sq.Eq{"ChannelId": channelID}).
+		Where(sq.GtOrEq{"ReadAt": from.UnixMilli()}).
+		Where(sq.Lt{"ReadAt": to.AddDate(0, 0, 1).UnixMilli()}).
+		Where(sq.NotEq{"DeviceType": model.ReadReceiptDeviceTypeBot}).
+		GroupBy(day).
+		OrderBy(day)
+	query = whereHotReadReceipts(query, "ReadAt", from.UnixMilli())
+
+	counts := []*model.DailyReaderCount{}
+	if err := s.GetReplica().SelectBuilder(&counts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the daily reader counts of channelId=%s", channelID)
+	}
+
+	return counts, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptExtremes(postID string) (*model.PostReadReceiptExtremes, error) {
+	// Only the receipts read at the MIN and MAX ReadAt of the post are fetched.
+	query := `
+		WITH Bounds AS (
+			SELECT MIN(ReadAt) AS FirstReadAt, MAX(ReadAt) AS LastReadAt
+			FROM PostReadReceipts
+			WHERE PostId = $1 AND DeviceType <> 'bot'
+		)
+		SELECT PostReadReceipts.UserId, PostReadReceipts.ReadAt
+		FROM PostReadReceipts, Bounds
+		WHERE PostReadReceipts.PostId = $1
+			AND PostReadReceipts.DeviceType <> 'bot'
+			AND PostReadReceipts.ReadAt IN (Bounds.FirstReadAt, Bounds.LastReadAt)
+		ORDER BY PostReadReceipts.ReadAt, PostReadReceipts.UserId`
+
+	readers := []*model.ReadReceiptReader{}
+	if err := s.GetReplica().Select(&readers, query, postID); err != nil {
+		return nil, errors.Wrapf(err, "failed to get read receipt extremes for postId=%s", postID)
+	}
+
+	extremes := &model.PostReadReceiptExtremes{PostId: postID}
+	if len(readers) > 0 {
+		extremes.FirstReader = readers[0]
+		// The last reader is the first one, by user id, of those read at the MAX.
+		last := readers[len(readers)-1]
+		for _, reader := range readers {
+			if reader.ReadAt == last.ReadAt {
+				extremes.LastReader = reader
+				break
+			}
+		}
+	}
+
+	return extremes, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetChannelSettings(channelID string) (*model.ReadReceiptChannelSettings, error) {
+	query := s.getQueryBuilder().
+		Select("ChannelId", "BroadcastReceipts", "PinnedUnreadNotice", "UpdateAt").
+		From("ReadReceiptChannelSettings").
+		Where(sq.Eq{"ChannelId": channelID})
+
+	var settings model.ReadReceiptChannelSettings
+	if err := s.GetReplica().GetBuilder(&settings, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ReadReceiptChannelSettings", channelID)
+		}
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptChannelSettings with channelId=%s", channelID)
+	}
+
+	return &settings, nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveChannelSettings(settings *model.ReadReceiptChannelSettings) (*model.ReadReceiptChannelSettings, error) {
+	settings.UpdateAt = model.GetMillis()
+
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptChannelSettings").
+		Columns("ChannelId", "BroadcastReceipts", "PinnedUnreadNotice", "UpdateAt").
+		Values(settings.ChannelId, settings.BroadcastReceipts, settings.PinnedUnreadNotice, settings.UpdateAt).
+		Suffix("ON CONFLICT (ChannelId) DO UPDATE SET BroadcastReceipts = EXCLUDED.BroadcastReceipts, PinnedUnreadNotice = EXCLUDED.PinnedUnreadNotice, UpdateAt = EXCLUDED.UpdateAt")
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ReadReceiptChannelSettings with channelId=%s", settings.ChannelId)
+	}
+
+	return settings, nil
+}
+
+// UpdateChannelWatermarkMode reports whether the channel only accepts watermark
+// receipts. It switches the channel once it holds more than limit receipts, and
+// back once it holds no more than recoverAt receipts, so that a channel shrunk by
+// the retention or a raised limit gets its per-post receipts back. The receipts
+// are counted no further than the limit.
+func (s *SqlPostReadReceiptStore) UpdateChannelWatermarkMode(channelID string, limit, recoverAt int64) (bool, error) {
+	var switched bool
+	if err := s.GetReplica().Get(&switched, "SELECT EXISTS (SELECT 1 FROM ReadReceiptWatermarkChannels WHERE ChannelId = $1)", channelID); err != nil {
+		return false, errors.Wrapf(err, "failed to get the read receipt mode of channelId=%s", channelID)
+	}
+
+	var count int64
+	if err := s.GetReplica().Get(&count, "SELECT COUNT(*) FROM (SELECT 1 FROM PostReadReceipts WHERE ChannelId = $1 LIMIT $2) AS Receipts", channelID, limit+1); err != nil {
+		return false, errors.Wrapf(err, "failed to count the read receipts of channelId=%s", channelID)
+	}
+
+	if switched {
+		if count > recoverAt {
+			return true, nil
+		}
+
+		query := s.getQueryBuilder().
+			Delete("ReadReceiptWatermarkChannels").
+			Where(sq.Eq{"ChannelId": channelID})
+		if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+			return false, errors.Wrapf(err, "failed to switch channelId=%s back to per-post read receipts", channelID)
+		}
+		return false, nil
+	}
+
+	if count <= limit {
+		return false, nil
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptWatermarkChannels").
+		Columns("ChannelId", "ReceiptCount", "CreateAt").
+		Values(channelID, count, model.GetMillis()).
+		Suffix("ON CONFLICT (ChannelId) DO NOTHING")
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return false, errors.Wrapf(err, "failed to switch channelId=%s to watermark read receipts", channelID)
+	}
+
+	return true, nil
+}
+
+// ComputeReadReceiptSummary aggregates the stored receipts of a post into a
+// summary. Bot receipts are counted separately from human ones.
+func (s *SqlPostReadReceiptStore) ComputeReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
+	query := s.getQueryBuilder().
+		Select(
+			"COALESCE(SUM(CASE WHEN DeviceType <> 'bot' THEN 1 ELSE 0 END), 0) AS ReadCount",
+			"COALESCE(SUM(CASE WHEN DeviceType = 'bot' THEN 1 ELSE 0 END), 0) AS BotReadCount",
+			"COALESCE(MAX(CASE WHEN DeviceType <> 'bot' THEN ReadAt ELSE 0 END), 0) AS LastReadAt",
+		).
+		From("PostReadReceipts").
+		Where(sq.Eq{"PostId": postID})
+
+	summary := model.PostReadReceiptSummary{PostId: postID}
+	if err := s.GetReplica().GetBuilder(&summary, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to compute PostReadReceiptSummary for postId=%s", postID)
+	}
+
+	compacted, err := s.getCompactedReadReceiptsWithoutReceipt(s.GetReplica(), postID)
+	if err != nil {
+		return nil, err
+	}
+	for _, receipt := range compacted {
+		summary.ReadCount++
+		summary.LastReadAt = max(summary.LastReadAt, receipt.ReadAt)
+	}
+
+	return &summary, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
+	query := s.getQueryBuilder().
+		Select(s.summaryColumns()...).
+		From("PostReadReceiptSummaries").
+		Where(sq.Eq{"PostId": postID})
+
+	var summary model.PostReadReceiptSummary
+	if err := s.GetReplica().GetBuilder(&summary, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("PostReadReceiptSummary", postID)
+		}
+		return nil, errors.Wrapf(err, "failed to get PostReadReceiptSummary with postId=%s", postID)
+	}
+
+	return &summary, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptSummariesForChannel(channelID string, since int64) ([]*model.PostReadReceiptSummary, error) {
+	query := s.getQueryBuilder().
+		Select(s.summaryColumns()...).
+		From("PostReadReceiptSummaries").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Gt{"LastUpdated": since},
+		}).
+		OrderBy("LastUpdated ASC").
+		Limit(readReceiptSummariesForChannelLimit)
+
+	summaries := []*model.PostReadReceiptSummary{}
+	if err := s.GetReplica().SelectBuilder(&summaries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceiptSummaries for channelId=%s", channelID)
+	}
+
+	return summaries, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptSummariesForPosts(postIDs []string, fromMaster bool) ([]*model.PostReadReceiptSummary, error) {
+	summaries := []*model.PostReadReceiptSummary{}
+	if len(postIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select(s.summaryColumns()...).
+		From("PostReadReceiptSummaries").
+		Where(sq.Eq{"PostId": postIDs})
+
+	db := s.GetReplica()
+	if fromMaster {
+		db = s.GetMaster()
+	}
+	if err := db.SelectBuilder(&summaries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PostReadReceiptSummaries for %d posts", len(postIDs))
+	}
+
+	return summaries, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadCountsForLatestPosts(channelID string, limit int) ([]*model.PostReadCount, error) {
+	latestPosts := s.getSubQueryBuilder().
+		Select("Id", "CreateAt").
+		From("Posts").
+		Where(sq.Eq{
+			"ChannelId": channelID,
+			"DeleteAt":  0,
+		}).
+		Where(readReceiptsAllowedForPost).
+		OrderBy("CreateAt DESC").
+		Limit(uint64(limit))
+
+	query := s.getQueryBuilder().
+		Select(
+			"LatestPosts.Id AS PostId",
+			"COALESCE(SUM(CASE WHEN PostReadReceipts.DeviceType <> 'bot' THEN 1 ELSE 0 END), 0) AS ReadCount",
+			"COALESCE(SUM(CASE WHEN PostReadReceipts.DeviceType = 'bot' THEN 1 ELSE 0 END), 0) AS BotReadCount",
+		).
+		FromSelect(latestPosts, "LatestPosts").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = LatestPosts.Id").
+		GroupBy("LatestPosts.Id", "LatestPosts.CreateAt").
+		OrderBy("LatestPosts.CreateAt DESC")
+
+	counts := []*model.PostReadCount{}
+	if err := s.GetReplica().SelectBuilder(&counts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get read counts for latest posts of channelId=%s", channelID)
+	}
+
+	return counts, nil
+}
+
+// readReceiptSummaryUpsertSuffix only applies an update when nobody else wrote the
+// summary since it was read (the versions match) and it does not move LastUpdated
+// backwards.
+const readReceiptSummaryUpsertSuffix = `ON CONFLICT (PostId) DO UPDATE SET
+	ReadCount = EXCLUDED.ReadCount,
+	BotReadCount = EXCLUDED.BotReadCount,
+	LastReadAt = EXCLUDED.LastReadAt,
+	LastUpdated = EXCLUDED.LastUpdated,
+	Version = EXCLUDED.Version
+	WHERE PostReadReceiptSummaries.Version = EXCLUDED.Version - 1
+		AND PostReadReceiptSummaries.LastUpdated < EXCLUDED.LastUpdated`
+
+func (s *SqlPostReadReceiptStore) UpdateReadReceiptSummary(summary *model.PostReadReceiptSummary) error {
+	query := s.getQueryBuilder().
+		Insert("PostReadReceiptSummaries").
+		Columns(s.summaryColumns()...).
+		Values(summary.PostId, summary.ChannelId, summary.ReadCount, summary.BotReadCount, summary.LastReadAt, summary.LastUpdated, summary.Version+1).
+		Suffix(readReceiptSummaryUpsertSuffix + " RETURNING Version")
+
+	var version int64
+	if err := s.GetMaster().GetBuilder(&version, query); err != nil {
+		if err == sql.ErrNoRows {
+			return store.NewErrConflict("PostReadReceiptSummary", err, "postId="+summary.PostId)
+		}
+		return errors.Wrapf(err, "failed to update PostReadReceiptSummary with postId=%s", summary.PostId)
+	}
+
+	summary.Version = version
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) UpdateReadReceiptSummaries(summaries []*model.PostReadReceiptSummary) (_ []*model.PostReadReceiptSummary, err error) {
+	stored := []*model.PostReadReceiptSummary{}
+	if len(summaries) == 0 {
+		return stored, nil
+	}
+
+	// A single upsert cannot update the same row twice, only the last summary of
+	// each post is kept. Sorting the posts makes concurrent batches lock the
+	// summaries in the same order.
+	byPostID := make(map[string]*model.PostReadReceiptSummary, len(summaries))
+	unique := make([]*model.PostReadReceiptSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		if _, ok := byPostID[summary.PostId]; !ok {
+			unique = append(unique, summary)
+		}
+		byPostID[summary.PostId] = summary
+	}
+	slices.SortFunc(unique, func(a, b *model.PostReadReceiptSummary) int {
+		return strings.Compare(a.PostId, b.PostId)
+	})
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	for i := 0; i < len(unique); i += readReceiptSummariesUpsertChunkSize {
+		chunk := unique[i:min(i+readReceiptSummariesUpsertChunkSize, len(unique))]
+
+		query := s.getQueryBuilder().
+			Insert("PostReadReceiptSummaries").
+			Columns(s.summaryColumns()...)
+		for _, postSummary := range chunk {
+			summary := byPostID[postSummary.PostId]
+			query = query.Values(summary.PostId, summary.ChannelId, summary.ReadCount, summary.BotReadCount, summary.LastReadAt, summary.LastUpdated, summary.Version+1)
+		}
+		query = query.Suffix(readReceiptSummaryUpsertSuffix + " RETURNING PostId, Version")
+
+		var versions []struct {
+			PostId  string
+			Version int64
+		}
+		if err = transaction.SelectBuilder(&versions, query); err != nil {
+			return nil, errors.Wrap(err, "failed to update PostReadReceiptSummaries")
+		}
+
+		for _, version := range versions {
+			summary := byPostID[version.PostId]
+			summary.Version = version.Version
+			stored = append(stored, summary)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return stored, nil
+}
+
+func (s *SqlPostReadReceiptStore) ComputeThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
+	// Authors have no receipt for their own replies, the union counts them as read
+	// without counting twice a reply someone read and wrote.
+	query := `
+		SELECT UserId, COUNT(*) AS RepliesRead
+		FROM (
+			SELECT PostReadReceipts.UserId, PostReadReceipts.PostId
+			FROM PostReadReceipts
+			INNER JOIN Posts ON Posts.Id = PostReadReceipts.PostId
+			WHERE Posts.RootId = $1 AND Posts.DeleteAt = 0 AND PostReadReceipts.DeviceType <> $2
+			UNION
+			SELECT UserId, Id
+			FROM Posts
+			WHERE RootId = $1 AND DeleteAt = 0
+		) ThreadReplies
+		GROUP BY UserId
+		ORDER BY UserId`
+
+	counts := []*model.ThreadParticipantReadCount{}
+	if err := s.GetReplica().Select(&counts, query, rootID, model.ReadReceiptDeviceTypeBot); err != nil {
+		return nil, errors.Wrapf(err, "failed to compute ThreadReadReceiptSummary for rootId=%s", rootID)
+	}
+
+	return counts, nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveThreadReadReceiptSummary(rootID, channelID string, counts []*model.ThreadParticipantReadCount) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("ThreadReadReceiptSummaries").
+		Where(sq.Eq{"RootId": rootID})
+	if _, err = transaction.ExecBuilder(deleteQuery); err != nil {
+		return errors.Wrapf(err, "failed to delete ThreadReadReceiptSummaries with rootId=%s", rootID)
+	}
+
+	if len(counts) > 0 {
+		now := model.GetMillis()
+		insertQuery := s.getQueryBuilder().
+			Insert("ThreadReadReceiptSummaries").
+			Columns("RootId", "UserId", "ChannelId", "RepliesRead", "LastUpdated")
+		for _, count := range counts {
+			insertQuery = insertQuery.Values(rootID, count.UserId, channelID, count.RepliesRead, now)
+		}
+		if _, err = transaction.ExecBuilder(insertQuery); err != nil {
+			return errors.Wrapf(err, "failed to save ThreadReadReceiptSummaries with rootId=%s", rootID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
+	query := s.getQueryBuilder().
+		Select("UserId", "RepliesRead").
+		From("ThreadReadReceiptSummaries").
+		Where(sq.Eq{"RootId": rootID}).
+		OrderBy("UserId")
+
+	counts := []*model.ThreadParticipantReadCount{}
+	if err := s.GetReplica().SelectBuilder(&counts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ThreadReadReceiptSummaries with rootId=%s", rootID)
+	}
+
+	return counts, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetChannelArchiveRecommendations(opts *model.ChannelArchiveReportOptions) ([]*model.ChannelArchiveRecommendation, error) {
+	since := time.Now().AddDate(0, 0, -opts.InactiveDays).UTC().Format("2006-01-02")
+	readersSQL, readersArgs, err := s.getSubQueryBuilder().
+		Select("UserId", "ChannelId").
+		Distinct().
+		From("ReadReceiptStats").
+		Where(sq.GtOrEq{"Day": since}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build the readers query")
+	}
+
+	// Above the sampling threshold only a sample of the members have receipts,
+	// so the readers are scaled up to all the members like the app does.
+	readerCount := "COUNT(Readers.UserId)"
+	if opts.SamplingThreshold > 0 {
+		readerCount = fmt.Sprintf("LEAST(ROUND(COUNT(Readers.UserId) * GREATEST(COUNT(ChannelMembers.UserId)::float / %d, 1))::bigint, COUNT(ChannelMembers.UserId))", opts.SamplingThreshold)
+	}
+
+	query := s.getQueryBuilder().
+		Select(
+			"Channels.Id AS ChannelId",
+			"Channels.TeamId",
+			"Channels.Name",
+			"Channels.DisplayName",
+			"Channels.Type",
+			"COUNT(ChannelMembers.UserId) AS MemberCount",
+			readerCount+" AS ReaderCount",
+		).
+		From("Channels").
+		Join("ChannelMembers ON ChannelMembers.ChannelId = Channels.Id").
+		Join("Users ON Users.Id = ChannelMembers.UserId").
+		LeftJoin("Bots ON Bots.UserId = ChannelMembers.UserId").
+		LeftJoin("("+readersSQL+") Readers ON Readers.ChannelId = Channels.Id AND Readers.UserId = ChannelMembers.UserId", readersArgs...).
+		Where(sq.Eq{
+			"Channels.DeleteAt": 0,
+			"Channels.Type":     []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate},
+			"Users.DeleteAt":    0,
+			"Bots.UserId":       nil,
+		}).
+		GroupBy("Channels.Id").
+		Having(readerCount+" * 100 < ? * COUNT(ChannelMembers.UserId)", opts.MaxReaderPercent).
+		OrderBy(readerCount+"::float / COUNT(ChannelMembers.UserId)", "Channels.Id").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+	if opts.TeamId != "" {
+		query = query.Where(sq.Eq{"Channels.TeamId": opts.TeamId})
+	}
+
+	recommendations := []*model.ChannelArchiveRecommendation{}
+	if err := s.GetReplica().SelectBuilder(&recommendations, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get channel archive recommendations")
+	}
+
+	for _, recommendation := range recommendations {
+		recommendation.ReaderPercent = float64(recommendation.ReaderCount) * 100 / float64(recommendation.MemberCount)
+	}
+
+	return recommendations, nil
+}
+
+func (s *SqlPostReadReceiptStore) RefreshReadReceiptStats() error {
+	if _, err := s.GetMaster().Exec("REFRESH MATERIALIZED VIEW readreceiptstats"); err != nil {
+		return errors.Wrap(err, "failed to refresh readreceiptstats")
+	}
+
+	if _, err := s.GetMaster().Exec("REFRESH MATERIALIZED VIEW readreceiptteamstats"); err != nil {
+		return errors.Wrap(err, "failed to refresh readreceiptteamstats")
+	}
+
+	return nil
+}
+
+// hotReadReceiptsCutoff returns the start of the hot window at the given time. It
+// moves once a day, at midnight UTC, so that every node agrees with the cutoff the
+// indexes were last rotated to.
+func hotReadReceiptsCutoff(now time.Time) int64 {
+	day := now.UTC().Truncate(24 * time.Hour)
+	return day.AddDate(0, 0, -readReceiptsHotWindowDays).UnixMilli()
+}
+
+// whereHotReadReceipts restricts a query reading the receipts from since on to the
+// hot window, when since falls within it. The cutoff is inlined rather than bound so
+// that the planner can tell it implies the predicate of the hot indexes, even with
+// the generic plan of a prepared statement. Until the indexes are rotated for the
+// day, their cutoff is older, which the predicate still implies.
+func whereHotReadReceipts(query sq.SelectBuilder, column string, since int64) sq.SelectBuilder {
+	cutoff := hotReadReceiptsCutoff(time.Now())
+	if since < cutoff {
+		return query
+	}
+
+	return query.Where(fmt.Sprintf("%s >= %d", column, cutoff))
+}
+
+func (s *SqlPostReadReceiptStore) RotateHotReadReceiptIndexes() error {
+	cutoff := hotReadReceiptsCutoff(time.Now())
+
+	for _, index := range hotReadReceiptIndexes {
+		next := index.name + "_next"
+		previous := index.name + "_previous"
+
+		// Leftovers of a rotation that failed half way.
+		for _, name := range []string{next, previous} {
+			if _, err := s.GetMaster().Exec("DROP INDEX CONCURRENTLY IF EXISTS " + name); err != nil {
+				return errors.Wrapf(err, "failed to drop %s", name)
+			}
+		}
+
+		if _, err := s.GetMaster().Exec(fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON PostReadReceipts (%s) WHERE ReadAt >= %d", next, index.columns, cutoff)); err != nil {
+			return errors.Wrapf(err, "failed to create %s", next)
+		}
+
+		if err := s.swapHotReadReceiptIndex(index.name, next, previous); err != nil {
+			return err
+		}
+
+		if _, err := s.GetMaster().Exec("DROP INDEX CONCURRENTLY IF EXISTS " + previous); err != nil {
+			return errors.Wrapf(err, "failed to drop %s", previous)
+		}
+	}
+
+	return nil
+}
+
+// swapHotReadReceiptIndex renames the index to previous and next to the index. The
+// renames only take a short lock, so that the previous index can then be dropped
+// without blocking the receipt queries.
+func (s *SqlPostReadReceiptStore) swapHotReadReceiptIndex(name, next, previous string) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.Exec(fmt.Sprintf("ALTER INDEX IF EXISTS %s RENAME TO %s", name, previous)); err != nil {
+		return errors.Wrapf(err, "failed to rename %s", name)
+	}
+	if _, err = transaction.Exec(fmt.Sprintf("ALTER INDEX %s RENAME TO %s", next, name)); err != nil {
+		return errors.Wrapf(err, "failed to rename %s", next)
+	}
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) EstimateReadReceiptCount() (int64, error) {
+	// reltuples is -1 until the table is first analyzed.
+	query := s.getQueryBuilder().
+		Select("GREATEST(c.reltuples, 0)::bigint").
+		From("pg_class c").
+		InnerJoin("pg_namespace n ON n.oid = c.relnamespace").
+		Where(sq.Expr("n.nspname = current_schema()")).
+		Where(sq.Eq{"c.relname": "postreadreceipts"})
+
+	var count int64
+	if err := s.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrap(err, "failed to estimate the number of PostReadReceipts")
+	}
+
+	return count, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptTeamUsage() ([]*model.ReadReceiptTeamUsage, error) {
+	// The row bytes of each team are scaled up by the ratio of the whole size of the
+	// receipt table, indexes and TOAST included, to the size of its rows.
+	query := s.getQueryBuilder().
+		Select(
+			"s.TeamId",
+			"COALESCE(t.DisplayName, '') AS TeamDisplayName",
+			"s.ReceiptCount",
+			"s.RecentReceiptCount",
+			"s.ReaderCount",
+			"s.ChannelCount",
+			"(s.RowBytes * pg_total_relation_size('postreadreceipts')::float8 / GREATEST(pg_relation_size('postreadreceipts'), 1))::bigint AS EstimatedBytes",
+		).
+		From("ReadReceiptTeamStats s").
+		LeftJoin("Teams t ON t.Id = s.TeamId").
+		OrderBy("s.ReceiptCount DESC", "s.TeamId")
+
+	usage := []*model.ReadReceiptTeamUsage{}
+	if err := s.GetReplica().SelectBuilder(&usage, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get read receipt team usage")
+	}
+
+	return usage, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetTableStats() ([]*model.ReadReceiptTableStats, error) {
+	tables := make([]string, 0, len(readReceiptTableIndexes))
+	for _, t := range readReceiptTableIndexes {
+		tables = append(tables, t.table)
+	}
+
+	// reltuples is -1 until the table is first analyzed.
+	rowsQuery := s.getQueryBuilder().
+		Select("c.relname AS Name", "GREATEST(c.reltuples, 0)::bigint AS EstimatedRows").
+		From("pg_class c").
+		InnerJoin("pg_namespace n ON n.oid = c.relnamespace").
+		Where(sq.Expr("n.nspname = current_schema()")).
+		Where(sq.Eq{"c.relname": tables})
+
+	var estimates []struct {
+		Name          string
+		EstimatedRows int64
+	}
+	if err := s.GetReplica().SelectBuilder(&estimates, rowsQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to get read receipt table row estimates")
+	}
+
+	indexesQuery := s.getQueryBuilder().
+		Select("tablename AS TableName", "indexname AS IndexName").
+		From("pg_indexes").
+		Where(sq.Expr("schemaname = current_schema()")).
+		Where(sq.Eq{"tablename": tables}).
+		OrderBy("tablename", "indexname")
+
+	var indexes []struct {
+		TableName string
+		IndexName string
+	}
+	if err := s.GetReplica().SelectBuilder(&indexes, indexesQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to get read receipt table indexes")
+	}
+
+	statsByTable := make(map[string]*model.ReadReceiptTableStats, len(readReceiptTableIndexes))
+	stats := make([]*model.ReadReceiptTableStats, 0, len(readReceiptTableIndexes))
+	for _, t := range readReceiptTableIndexes {
+		tableStats := &model.ReadReceiptTableStats{Table: t.table, Indexes: []string{}}
+		statsByTable[t.table] = tableStats
+		stats = append(stats, tableStats)
+	}
+	for _, estimate := range estimates {
+		statsByTable[estimate.Name].EstimatedRows = estimate.EstimatedRows
+	}
+	for _, index := range indexes {
+		statsByTable[index.TableName].Indexes = append(statsByTable[index.TableName].Indexes, index.IndexName)
+	}
+	for _, t := range readReceiptTableIndexes {
+		tableStats := statsByTable[t.table]
+		for _, index := range t.indexes {
+			if !slices.Contains(tableStats.Indexes, index) {
+				tableStats.MissingIndexes = append(tableStats.MissingIndexes, index)
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptChainHead(channelID string) (*model.ReadReceiptChainEntry, error) {
+	query := s.getQueryBuilder().
+		Select(s.chainColumns()...).
+		From("ReadReceiptChains").
+		Where(sq.Eq{"ChannelId": channelID}).
+		OrderBy("Sequence DESC").
+		Limit(1)
+
+	// The head is read from the master since the next entries are linked to it.
+	var head model.ReadReceiptChainEntry
+	if err := s.GetMaster().GetBuilder(&head, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ReadReceiptChain", channelID)
+		}
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptChain head for channelId=%s", channelID)
+	}
+
+	return &head, nil
+}
+
+func (s *SqlPostReadReceiptStore) AppendReadReceiptChain(entries []*model.ReadReceiptChainEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptChains").
+		Columns(s.chainColumns()...)
+	for _, entry := range entries {
+		query = query.Values(entry.ChannelId, entry.Sequence, entry.PostId, entry.UserId, entry.ReadAt, entry.PrevHash, entry.Hash)
+	}
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"readreceiptchains_pkey"}) {
+			return store.NewErrConflict("ReadReceiptChain", err, "channelId="+entries[0].ChannelId)
+		}
+		return errors.Wrap(err, "failed to save ReadReceiptChains")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptChain(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChainEntry, error) {
+	query := s.getQueryBuilder().
+		Select(s.chainColumns()...).
+		From("ReadReceiptChains").
+		Where(sq.And{
+			sq.Eq{"ChannelId": channelID},
+			sq.Gt{"Sequence": afterSequence},
+		}).
+		OrderBy("Sequence ASC").
+		Limit(uint64(limit))
+
+	entries := []*model.ReadReceiptChainEntry{}
+	if err := s.GetReplica().SelectBuilder(&entries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptChain for channelId=%s", channelID)
+	}
+
+	return entries, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptChainMismatches(channelID string, limit int) ([]*model.ReadReceiptChainEntry, error) {
+	columns := make([]string, 0, len(s.chainColumns()))
+	for _, column := range s.chainColumns() {
+		columns = append(columns, "ReadReceiptChains."+column)
+	}
+
+	// Only the latest entry of a reader has to match the receipt, earlier ones
+	// chained the reads it replaced.
+	query := s.getQueryBuilder().
+		Select(columns...).
+		From("ReadReceiptChains").
+		LeftJoin("PostReadReceipts ON PostReadReceipts.PostId = ReadReceiptChains.PostId AND PostReadReceipts.UserId = ReadReceiptChains.UserId").
+		Where(sq.Eq{"ReadReceiptChains.ChannelId": channelID}).
+		Where(sq.NotEq{"ReadReceiptChains.UserId": ""}).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM ReadReceiptChains Later
+			WHERE Later.ChannelId = ReadReceiptChains.ChannelId
+				AND Later.PostId = ReadReceiptChains.PostId
+				AND Later.UserId = ReadReceiptChains.UserId
+				AND Later.Sequence > ReadReceiptChains.Sequence
+		)`).
+		Where("(PostReadReceipts.PostId IS NULL OR PostReadReceipts.ReadAt <> ReadReceiptChains.ReadAt)").
+		OrderBy("ReadReceiptChains.Sequence ASC").
+		Limit(uint64(limit))
+
+	// Read from the master, receipts saved moments ago would be reported otherwise.
+	entries := []*model.ReadReceiptChainEntry{}
+	if err := s.GetMaster().SelectBuilder(&entries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the ReadReceiptChain mismatches for channelId=%s", channelID)
+	}
+
+	return entries, nil
+}
+
+func (s *SqlPostReadReceiptStore) GetReadReceiptChanges(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChange, error) {
+	query := s.getQueryBuilder().
+		Select("Sequence", "Op", "PostId", "UserId", "ChannelId", "At").
+		From("ReadReceiptChanges").
+		Where(sq.Gt{"Sequence": afterSequence}).
+		OrderBy("Sequence ASC").
+		Limit(uint64(limit))
+	if channelID != "" {
+		query = query.Where(sq.Eq{"ChannelId": channelID})
+	}
+
+	changes := []*model.ReadReceiptChange{}
+	if err := s.GetReplica().SelectBuilder(&changes, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptChanges after sequence=%d", afterSequence)
+	}
+
+	return changes, nil
+}
+
+func (s *SqlPostReadReceiptStore) DeleteReadReceiptChangesBefore(before int64, limit int) (int64, error) {
+	query := `
+		DELETE FROM ReadReceiptChanges
+		WHERE Sequence IN (
+			SELECT Sequence FROM ReadReceiptChanges
+			WHERE At < $1
+			ORDER BY Sequence
+			LIMIT $2
+		)`
+	result, err := s.GetMaster().Exec(query, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete ReadReceiptChanges")
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count the deleted ReadReceiptChanges")
+	}
+
+	return deleted, nil
+}
+
+func (s *SqlPostReadReceiptStore) FlagUserReceiptsForScrub(userID string, deactivatedAt int64) error {
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptScrubs").
+		Columns("UserId", "DeactivatedAt").
+		Values(userID, deactivatedAt).
+		Suffix("ON CONFLICT (UserId) DO UPDATE SET DeactivatedAt = EXCLUDED.DeactivatedAt")
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to save ReadReceiptScrub with userId=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) UnflagUserReceiptsForScrub(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("ReadReceiptScrubs").
+		Where(sq.Eq{"UserId": userID})
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete ReadReceiptScrub with userId=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) GetUsersDueForReceiptScrub(deactivatedBefore int64, limit int) ([]string, error) {
+	query := s.getQueryBuilder().
+		Select("UserId").
+		From("ReadReceiptScrubs").
+		Where(sq.Lt{"DeactivatedAt": deactivatedBefore}).
+		OrderBy("DeactivatedAt ASC", "UserId ASC").
+		Limit(uint64(limit))
+
+	userIDs := []string{}
+	if err := s.GetMaster().SelectBuilder(&userIDs, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get the users due for a ReadReceiptScrub")
+	}
+
+	return userIDs, nil
+}
+
+func (s *SqlPostReadReceiptStore) ScrubUserReceipts(userID string) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	update := s.getQueryBuilder().
+		Update("PostReadReceipts").
+		Set("DeviceId", "").
+		Set("SessionId", "").
+		Where(sq.And{
+			sq.Eq{"UserId": userID},
+			sq.Or{sq.NotEq{"DeviceId": ""}, sq.NotEq{"SessionId": ""}},
+		})
+	if _, err = transaction.ExecBuilder(update); err != nil {
+		return errors.Wrapf(err, "failed to scrub PostReadReceipts with userId=%s", userID)
+	}
+
+	for _, table := range []string{"PostReadReceiptDevices", "ReadReceiptScrubs"} {
+		query := s.getQueryBuilder().
+			Delete(table).
+			Where(sq.Eq{"UserId": userID})
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to delete from %s with userId=%s", table, userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPostReadReceiptStore) SaveGhostRead(read *model.PostReadReceipt) (bool, error) {
+	read.PreSave()
+	if appErr := read.IsValid(); appErr != nil {
+		return false, appErr
+	}
+
+	query := `
+		INSERT INTO ReadReceiptGhostReads (PostId, UserId, ChannelId, ReadAt)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM PostReadReceipts WHERE PostId = $1 AND UserId = $2)
+		ON CONFLICT (PostId, UserId) DO NOTHING`
+	result, err := s.GetMaster().Exec(query, read.PostId, read.UserId, read.ChannelId, read.ReadAt)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to save ReadReceiptGhostRead with postId=%s userId=%s", read.PostId, read.UserId)
+	}
+	saved, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to count the saved ReadReceiptGhostReads")
+	}
+
+	return saved > 0, nil
+}
@@ -0,0 +1,521 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/RoaringBitmap/roaring/v2/roaring64"
+	"github.com/lib/pq"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// readReceiptBitmap holds the compacted receipts of a user in a channel, as a
+// roaring bitmap of the sequences ReadReceiptPostSequences gives to the posts they
+// read. LastReadAt is the latest read time of the receipts compacted into it.
+type readReceiptBitmap struct {
+	ChannelId  string
+	UserId     string
+	Bitmap     []byte
+	LastReadAt int64
+}
+
+func (b *readReceiptBitmap) decode() (*roaring64.Bitmap, error) {
+	bitmap := roaring64.New()
+	if len(b.Bitmap) == 0 {
+		return bitmap, nil
+	}
+	if err := bitmap.UnmarshalBinary(b.Bitmap); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the ReadReceiptBitmap of channelId=%s userId=%s", b.ChannelId, b.UserId)
+	}
+	return bitmap, nil
+}
+
+type readReceiptPostSequence struct {
+	PostId    string
+	ChannelId string
+	Sequence  int64
+}
+
+func readReceiptKey(postID, userID string) string {
+	return postID + userID
+}
+
+// getCompactedReadReceipts returns the compacted receipts of the posts, only those
+// of userID unless it is empty. Only posts that had receipts compacted have a
+// sequence, so for any other post this costs a single primary key lookup.
+func (s *SqlPostReadReceiptStore) getCompactedReadReceipts(db sqlxExecutor, postIDs []string, userID string) ([]*model.PostReadReceipt, error) {
+	receipts := []*model.PostReadReceipt{}
+	if len(postIDs) == 0 {
+		return receipts, nil
+	}
+
+	sequences := []readReceiptPostSequence{}
+	query := s.getQueryBuilder().
+		Select("PostId", "ChannelId", "Sequence").
+		From("ReadReceiptPostSequences").
+		Where(sq.Eq{"PostId": postIDs})
+	if err := db.SelectBuilder(&sequences, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get ReadReceiptPostSequences")
+	}
+	if len(sequences) == 0 {
+		return receipts, nil
+	}
+
+	sequencesByChannel := make(map[string][]readReceiptPostSequence)
+	for _, sequence := range sequences {
+		sequencesByChannel[sequence.ChannelId] = append(sequencesByChannel[sequence.ChannelId], sequence)
+	}
+	channelIDs := make([]string, 0, len(sequencesByChannel))
+	for channelID := range sequencesByChannel {
+		channelIDs = append(channelIDs, channelID)
+	}
+
+	bitmapsQuery := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "Bitmap", "LastReadAt").
+		From("ReadReceiptBitmaps").
+		Where(sq.Eq{"ChannelId": channelIDs})
+	if userID != "" {
+		bitmapsQuery = bitmapsQuery.Where(sq.Eq{"UserId": userID})
+	}
+	bitmaps := []readReceiptBitmap{}
+	if err := db.SelectBuilder(&bitmaps, bitmapsQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to get ReadReceiptBitmaps")
+	}
+
+	for _, row := range bitmaps {
+		bitmap, err := row.decode()
+		if err != nil {
+			return nil, err
+		}
+		for _, sequence := range sequencesByChannel[row.ChannelId] {
+			if bitmap.Contains(uint64(sequence.Sequence)) {
+				receipts = append(receipts, &model.PostReadReceipt{
+					PostId:    sequence.PostId,
+					UserId:    row.UserId,
+					ChannelId: row.ChannelId,
+					ReadAt:    row.LastReadAt,
+					Compacted: true,
+				})
+			}
+		}
+	}
+
+	return receipts, nil
+}
+
+// getCompactedReadKeys returns the keys, as built by readReceiptKey, of the given
+// receipts that are already compacted.
+func (s *SqlPostReadReceiptStore) getCompactedReadKeys(db sqlxExecutor, receipts []*model.PostReadReceipt) (map[string]bool, error) {
+	postIDs := make([]string, 0, len(receipts))
+	userID := ""
+	for i, receipt := range receipts {
+		postIDs = append(postIDs, receipt.PostId)
+		if i == 0 {
+			userID = receipt.UserId
+		} else if receipt.UserId != userID {
+			userID = ""
+		}
+	}
+
+	compacted, err := s.getCompactedReadReceipts(db, postIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(compacted))
+	for _, receipt := range compacted {
+		keys[readReceiptKey(receipt.PostId, receipt.UserId)] = true
+	}
+	return keys, nil
+}
+
+// getCompactedReadReceiptsWithoutReceipt returns the compacted receipts of the
+// post whose user has no stored receipt for it, the ones a read after compaction
+// did not replace.
+func (s *SqlPostReadReceiptStore) getCompactedReadReceiptsWithoutReceipt(db sqlxExecutor, postID string) ([]*model.PostReadReceipt, error) {
+	compacted, err := s.getCompactedReadReceipts(db, []string{postID}, "")
+	if err != nil || len(compacted) == 0 {
+		return compacted, err
+	}
+
+	userIDs := make([]string, 0, len(compacted))
+	for _, receipt := range compacted {
+		userIDs = append(userIDs, receipt.UserId)
+	}
+	stored := []string{}
+	query := s.getQueryBuilder().
+		Select("UserId").
+		From("PostReadReceipts").
+		Where(sq.Eq{
+			"PostId": postID,
+			"UserId": userIDs,
+		})
+	if err := db.SelectBuilder(&stored, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the PostReadReceipts of postId=%s", postID)
+	}
+
+	hasReceipt := make(map[string]bool, len(stored))
+	for _, userID := range stored {
+		hasReceipt[userID] = true
+	}
+	withoutReceipt := []*model.PostReadReceipt{}
+	for _, receipt := range compacted {
+		if !hasReceipt[receipt.UserId] {
+			withoutReceipt = append(withoutReceipt, receipt)
+		}
+	}
+	return withoutReceipt, nil
+}
+
+// mergeCompactedReadReceipts appends to receipts the compacted receipts of the
+// posts and users that have no stored receipt among them. A stored receipt is kept
+// over a compacted one, since it comes from a read made after the compaction.
+func mergeCompactedReadReceipts(receipts, compacted []*model.PostReadReceipt) []*model.PostReadReceipt {
+	if len(compacted) == 0 {
+		return receipts
+	}
+
+	stored := make(map[string]bool, len(receipts))
+	for _, receipt := range receipts {
+		stored[readReceiptKey(receipt.PostId, receipt.UserId)] = true
+	}
+	for _, receipt := range compacted {
+		if !stored[readReceiptKey(receipt.PostId, receipt.UserId)] {
+			receipts = append(receipts, receipt)
+		}
+	}
+	return receipts
+}
+
+// getCompactedSequenceRanges returns the sequences of the posts of the channel the
+// user has compacted receipts for, as the runs of consecutive sequences they form:
+// the i-th run goes from firsts[i] to lasts[i] included. The reads of a user are
+// mostly consecutive posts, so there are far fewer runs than sequences.
+func (s *SqlPostReadReceiptStore) getCompactedSequenceRanges(db sqlxExecutor, channelID, userID string) (firsts, lasts []int64, err error) {
+	rows := []readReceiptBitmap{}
+	query := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "Bitmap", "LastReadAt").
+		From("ReadReceiptBitmaps").
+		Where(sq.Eq{
+			"ChannelId": channelID,
+			"UserId":    userID,
+		})
+	if err = db.SelectBuilder(&rows, query); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get the ReadReceiptBitmap of channelId=%s userId=%s", channelID, userID)
+	}
+
+	firsts, lasts = []int64{}, []int64{}
+	for _, row := range rows {
+		bitmap, err := row.decode()
+		if err != nil {
+			return nil, nil, err
+		}
+		for it := bitmap.Iterator(); it.HasNext(); {
+			sequence := int64(it.Next())
+			if n := len(lasts); n > 0 && lasts[n-1] == sequence-1 {
+				lasts[n-1] = sequence
+				continue
+			}
+			firsts = append(firsts, sequence)
+			lasts = append(lasts, sequence)
+		}
+	}
+	return firsts, lasts, nil
+}
+
+// removeCompactedRead clears the post from the bitmap of the user, and reports
+// whether it was set. A bitmap left empty is removed.
+func (s *SqlPostReadReceiptStore) removeCompactedRead(transaction *sqlxTxWrapper, postID, userID string) (bool, error) {
+	var sequence readReceiptPostSequence
+	query := s.getQueryBuilder().
+		Select("PostId", "ChannelId", "Sequence").
+		From("ReadReceiptPostSequences").
+		Where(sq.Eq{"PostId": postID})
+	if err := transaction.GetBuilder(&sequence, query); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the ReadReceiptPostSequence of postId=%s", postID)
+	}
+
+	key := sq.Eq{
+		"ChannelId": sequence.ChannelId,
+		"UserId":    userID,
+	}
+	var row readReceiptBitmap
+	lockQuery := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "Bitmap", "LastReadAt").
+		From("ReadReceiptBitmaps").
+		Where(key).
+		Suffix("FOR UPDATE")
+	if err := transaction.GetBuilder(&row, lockQuery); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to lock the ReadReceiptBitmap of channelId=%s userId=%s", sequence.ChannelId, userID)
+	}
+
+	bitmap, err := row.decode()
+	if err != nil {
+		return false, err
+	}
+	if !bitmap.CheckedRemove(uint64(sequence.Sequence)) {
+		return false, nil
+	}
+
+	if bitmap.IsEmpty() {
+		if _, err := transaction.ExecBuilder(s.getQueryBuilder().Delete("ReadReceiptBitmaps").Where(key)); err != nil {
+			return false, errors.Wrapf(err, "failed to delete the ReadReceiptBitmap of channelId=%s userId=%s", sequence.ChannelId, userID)
+		}
+		return true, nil
+	}
+
+	data, err := bitmap.MarshalBinary()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to encode the ReadReceiptBitmap")
+	}
+	if _, err := transaction.ExecBuilder(s.getQueryBuilder().Update("ReadReceiptBitmaps").Set("Bitmap", data).Where(key)); err != nil {
+		return false, errors.Wrapf(err, "failed to update the ReadReceiptBitmap of channelId=%s userId=%s", sequence.ChannelId, userID)
+	}
+	return true, nil
+}
+
+// GetChannelsForReadReceiptCompaction returns at most limit of the channels
+// following afterChannelID, in order, that hold at least minReceipts receipts.
+// Channels with a receipt chain are left out, their chain is checked against the
+// stored receipts.
+func (s *SqlPostReadReceiptStore) GetChannelsForReadReceiptCompaction(minReceipts int64, afterChannelID string, limit int) ([]string, error) {
+	query := s.getQueryBuilder().
+		Select("ChannelId").
+		From("PostReadReceipts").
+		Where(sq.Gt{"ChannelId": afterChannelID}).
+		Where("NOT EXISTS (SELECT 1 FROM ReadReceiptChains WHERE ReadReceiptChains.ChannelId = PostReadReceipts.ChannelId)").
+		GroupBy("ChannelId").
+		Having(sq.GtOrEq{"COUNT(*)": minReceipts}).
+		OrderBy("ChannelId ASC").
+		Limit(uint64(limit))
+
+	channelIDs := []string{}
+	if err := s.GetReplica().SelectBuilder(&channelIDs, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get the channels to compact the PostReadReceipts of")
+	}
+
+	return channelIDs, nil
+}
+
+// CompactReadReceipts moves at most limit of the human receipts of the channel
+// read before readBefore to the bitmaps of their users, and returns how many it
+// moved. The read counters of the posts are summarized first so that they survive
+// the receipts. The moved receipts are not recorded as changes since the reads
+// still stand. Bot receipts are kept as rows, their device type is what tells them
+// apart from human reads.
+func (s *SqlPostReadReceiptStore) CompactReadReceipts(channelID string, readBefore int64, limit int) (_ int64, err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	receipts := []*model.PostReadReceipt{}
+	query := s.getQueryBuilder().
+		Select("PostId", "UserId", "ReadAt").
+		From("PostReadReceipts").
+		Where(sq.Eq{"ChannelId": channelID}).
+		Where(sq.Lt{"ReadAt": readBefore}).
+		Where(sq.NotEq{"DeviceType": model.ReadReceiptDeviceTypeBot}).
+		OrderBy("PostId", "UserId").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE")
+	if err = transaction.SelectBuilder(&receipts, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to lock the PostReadReceipts to compact for channelId=%s", channelID)
+	}
+	if len(receipts) == 0 {
+		return 0, nil
+	}
+
+	postIDs := make([]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		if len(postIDs) == 0 || postIDs[len(postIDs)-1] != receipt.PostId {
+			postIDs = append(postIDs, receipt.PostId)
+		}
+	}
+
+	if _, err = transaction.Exec(`
+		INSERT INTO PostReadReceiptSummaries (PostId, ChannelId, ReadCount, BotReadCount, LastReadAt, LastUpdated, Version)
+		SELECT PostId, ChannelId,
+			SUM(CASE WHEN DeviceType <> 'bot' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN DeviceType = 'bot' THEN 1 ELSE 0 END),
+			MAX(CASE WHEN DeviceType <> 'bot' THEN ReadAt ELSE 0 END),
+			$2, 1
+		FROM PostReadReceipts
+		WHERE PostId = ANY($1)
+		GROUP BY PostId, ChannelId
+		ON CONFLICT (PostId) DO NOTHING`, pq.Array(postIDs), model.GetMillis()); err != nil {
+		return 0, errors.Wrap(err, "failed to summarize the PostReadReceipts to compact")
+	}
+
+	// Posts take their sequence in creation order, so that the reads of a user
+	// set runs of bits that compress well.
+	if _, err = transaction.Exec(`
+		INSERT INTO ReadReceiptPostSequences (PostId, ChannelId)
+		SELECT Id, ChannelId FROM Posts
+		WHERE Id = ANY($1)
+		ORDER BY CreateAt, Id
+		ON CONFLICT (PostId) DO NOTHING`, pq.Array(postIDs)); err != nil {
+		return 0, errors.Wrap(err, "failed to save ReadReceiptPostSequences")
+	}
+
+	sequences := []readReceiptPostSequence{}
+	if err = transaction.SelectBuilder(&sequences, s.getQueryBuilder().
+		Select("PostId", "ChannelId", "Sequence").
+		From("ReadReceiptPostSequences").
+		Where(sq.Eq{"PostId": postIDs})); err != nil {
+		return 0, errors.Wrap(err, "failed to get ReadReceiptPostSequences")
+	}
+	sequenceByPost := make(map[string]uint64, len(sequences))
+	for _, sequence := range sequences {
+		sequenceByPost[sequence.PostId] = uint64(sequence.Sequence)
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+	for _, receipt := range receipts {
+		if !seen[receipt.UserId] {
+			seen[receipt.UserId] = true
+			userIDs = append(userIDs, receipt.UserId)
+		}
+	}
+
+	// The missing bitmaps are created empty first, so that concurrent writers
+	// always find a row to lock.
+	insertQuery := s.getQueryBuilder().
+		Insert("ReadReceiptBitmaps").
+		Columns("ChannelId", "UserId", "Bitmap", "LastReadAt")
+	for _, userID := range userIDs {
+		insertQuery = insertQuery.Values(channelID, userID, []byte{}, 0)
+	}
+	if _, err = transaction.ExecBuilder(insertQuery.Suffix("ON CONFLICT (ChannelId, UserId) DO NOTHING")); err != nil {
+		return 0, errors.Wrap(err, "failed to create ReadReceiptBitmaps")
+	}
+
+	rows := []readReceiptBitmap{}
+	if err = transaction.SelectBuilder(&rows, s.getQueryBuilder().
+		Select("ChannelId", "UserId", "Bitmap", "LastReadAt").
+		From("ReadReceiptBitmaps").
+		Where(sq.Eq{
+			"ChannelId": channelID,
+			"UserId":    userIDs,
+		}).
+		Suffix("FOR UPDATE")); err != nil {
+		return 0, errors.Wrap(err, "failed to lock ReadReceiptBitmaps")
+	}
+	bitmaps := make(map[string]*roaring64.Bitmap, len(rows))
+	lastReadAt := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		if bitmaps[row.UserId], err = row.decode(); err != nil {
+			return 0, err
+		}
+		lastReadAt[row.UserId] = row.LastReadAt
+	}
+
+	// A receipt whose post is gone has no sequence and is left to the deletion of
+	// its post.
+	compacted := make(sq.Or, 0, len(receipts))
+	for _, receipt := range receipts {
+		sequence, ok := sequenceByPost[receipt.PostId]
+		if !ok {
+			continue
+		}
+		bitmaps[receipt.UserId].Add(sequence)
+		lastReadAt[receipt.UserId] = max(lastReadAt[receipt.UserId], receipt.ReadAt)
+		compacted = append(compacted, sq.Eq{
+			"PostId": receipt.PostId,
+			"UserId": receipt.UserId,
+		})
+	}
+	if len(compacted) == 0 {
+		return 0, nil
+	}
+
+	for _, userID := range userIDs {
+		bitmap := bitmaps[userID]
+		bitmap.RunOptimize()
+		data, marshalErr := bitmap.MarshalBinary()
+		if marshalErr != nil {
+			return 0, errors.Wrap(marshalErr, "failed to encode the ReadReceiptBitmap")
+		}
+		updateQuery := s.getQueryBuilder().
+			Update("ReadReceiptBitmaps").
+			Set("Bitmap", data).
+			Set("LastReadAt", lastReadAt[userID]).
+			Where(sq.Eq{
+				"ChannelId": channelID,
+				"UserId":    userID,
+			})
+		if _, err = transaction.ExecBuilder(updateQuery); err != nil {
+			return 0, errors.Wrapf(err, "failed to update the ReadReceiptBitmap of channelId=%s userId=%s", channelID, userID)
+		}
+	}
+
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete("PostReadReceiptDevices").Where(compacted)); err != nil {
+		return 0, errors.Wrap(err, "failed to delete the PostReadReceiptDevices of the compacted receipts")
+	}
+	result, err := transaction.ExecBuilder(s.getQueryBuilder().Delete("PostReadReceipts").Where(compacted))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete the compacted PostReadReceipts")
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count the compacted PostReadReceipts")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+
+	return count, nil
+}
+
+// GetCompactedReadReceiptChannels returns at most limit of the channels following
+// afterChannelID, in order, that have compacted receipts.
+func (s *SqlPostReadReceiptStore) GetCompactedReadReceiptChannels(afterChannelID string, limit int) ([]string, error) {
+	query := s.getQueryBuilder().
+		Select("DISTINCT ChannelId").
+		From("ReadReceiptBitmaps").
+		Where(sq.Gt{"ChannelId": afterChannelID}).
+		OrderBy("ChannelId ASC").
+		Limit(uint64(limit))
+
+	channelIDs := []string{}
+	if err := s.GetReplica().SelectBuilder(&channelIDs, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get the channels with ReadReceiptBitmaps")
+	}
+
+	return channelIDs, nil
+}
+
+// DeleteExpiredCompactedReadReceipts deletes the bitmaps of the channel whose
+// latest read is before expiredBefore, and returns how many it deleted. A bitmap
+// does not keep the read time of each post, so it expires as a whole.
+func (s *SqlPostReadReceiptStore) DeleteExpiredCompactedReadReceipts(channelID string, expiredBefore int64) (int64, error) {
+	query := s.getQueryBuilder().
+		Delete("ReadReceiptBitmaps").
+		Where(sq.Eq{"ChannelId": channelID}).
+		Where(sq.Lt{"LastReadAt": expiredBefore})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete the expired ReadReceiptBitmaps of channelId=%s", channelID)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count the deleted ReadReceiptBitmaps")
+	}
+
+	return count, nil
+}
@@ -974,6 +974,10 @@ func (s *SqlPostStore) Delete(rctx request.CTX, postID string, time int64, delet
 		return errors.Wrap(err, "failed to update Posts")
 	}
 
+	if err = s.deleteReadReceipts(transaction, []string{postID}); err != nil {
+		return err
+	}
+
 	if id.RootId == "" {
 		err = s.deleteThread(transaction, postID, time)
 	} else {
@@ -1017,6 +1021,10 @@ func (s *SqlPostStore) permanentDelete(postIds []string) (err error) {
 		return err
 	}
 
+	if err = s.deleteReadReceipts(transaction, postIds); err != nil {
+		return err
+	}
+
 	query := s.getQueryBuilder().
 		Delete("Posts").
 		Where(
@@ -1073,6 +1081,10 @@ func (s *SqlPostStore) permanentDeleteAllCommentByUser(userId string) (err error
 		return err
 	}
 
+	if err = s.deleteReadReceipts(transaction, postIds); err != nil {
+		return err
+	}
+
 	if err = transaction.Commit(); err != nil {
 		return errors.Wrap(err, "commit_transaction")
 	}
@@ -1154,6 +1166,10 @@ func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId stri
 		}
 		time.Sleep(10 * time.Millisecond)
 
+		if err = s.deleteReadReceipts(transaction, ids); err != nil {
+			return err
+		}
+
 		query := s.getQueryBuilder().
 			Delete("Posts").
 			Where(
@@ -1165,6 +1181,11 @@ func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId stri
 		time.Sleep(10 * time.Millisecond)
 	}
 
+	// The compacted receipts are kept per channel rather than per post.
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete("ReadReceiptBitmaps").Where(sq.Eq{"ChannelId": channelId})); err != nil {
+		return errors.Wrap(err, "failed to delete ReadReceiptBitmaps")
+	}
+
 	if err = transaction.Commit(); err != nil {
 		return errors.Wrap(err, "commit_transaction")
 	}
@@ -2899,6 +2920,37 @@ func (s *SqlPostStore) permanentDeleteReactions(transaction *sqlxTxWrapper, post
 	return nil
 }
 
+// deleteReadReceipts removes the read receipts and summaries of the given posts and their
+// replies, and the thread summaries of the given posts. It runs in the deleting transaction so that no receipt outlives its post.
+func (s *SqlPostStore) deleteReadReceipts(transaction *sqlxTxWrapper, postIds []string) error {
+	threadPostIds := sq.Select("Id").From("Posts").Where(sq.Or{
+		sq.Eq{"Id": postIds},
+		sq.Eq{"RootId": postIds},
+	})
+
+	if _, err := s.deleteReadReceiptsWithChanges(transaction, sq.Expr("PostId IN (?)", threadPostIds)); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"PostReadReceiptDevices", "PostReadReceiptSummaries", "ReadReceiptGhostReads", "ReadReceiptPostSequences"} {
+		query := s.getQueryBuilder().
+			Delete(table).
+			Where(sq.Expr("PostId IN (?)", threadPostIds))
+		if _, err := transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to delete %s", table)
+		}
+	}
+
+	query := s.getQueryBuilder().
+		Delete("ThreadReadReceiptSummaries").
+		Where(sq.Eq{"RootId": postIds})
+	if _, err := transaction.ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to delete ThreadReadReceiptSummaries")
+	}
+
+	return nil
+}
+
 // deleteThread marks a thread as deleted at the given time.
 func (s *SqlPostStore) deleteThread(transaction *sqlxTxWrapper, postId string, deleteAtTime int64) error {
 	queryString, args, err := s.getQueryBuilder().
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlReadReceiptBroadcastStore struct {
+	*SqlStore
+}
+
+func newSqlReadReceiptBroadcastStore(sqlStore *SqlStore) store.ReadReceiptBroadcastStore {
+	return &SqlReadReceiptBroadcastStore{sqlStore}
+}
+
+func (s *SqlReadReceiptBroadcastStore) Save(broadcast *model.ReadReceiptBroadcast) (_ *model.ReadReceiptBroadcast, err error) {
+	broadcast.PreSave()
+	if appErr := broadcast.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptBroadcasts").
+		Columns("Id", "CreatorId", "CreateAt").
+		Values(broadcast.Id, broadcast.CreatorId, broadcast.CreateAt)
+	if _, err = transaction.ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ReadReceiptBroadcast with id=%s", broadcast.Id)
+	}
+
+	postsQuery := s.getQueryBuilder().
+		Insert("ReadReceiptBroadcastPosts").
+		Columns("BroadcastId", "PostId", "ChannelId")
+	for _, post := range broadcast.Posts {
+		postsQuery = postsQuery.Values(broadcast.Id, post.PostId, post.ChannelId)
+	}
+	if _, err = transaction.ExecBuilder(postsQuery); err != nil {
+		return nil, errors.Wrapf(err, "failed to save the posts of ReadReceiptBroadcast with id=%s", broadcast.Id)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return broadcast, nil
+}
+
+func (s *SqlReadReceiptBroadcastStore) Get(id string) (*model.ReadReceiptBroadcast, error) {
+	query := s.getQueryBuilder().
+		Select("Id", "CreatorId", "CreateAt").
+		From("ReadReceiptBroadcasts").
+		Where(sq.Eq{"Id": id})
+
+	var broadcast model.ReadReceiptBroadcast
+	if err := s.GetReplica().GetBuilder(&broadcast, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ReadReceiptBroadcast", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptBroadcast with id=%s", id)
+	}
+
+	postsQuery := s.getQueryBuilder().
+		Select("PostId", "ChannelId").
+		From("ReadReceiptBroadcastPosts").
+		Where(sq.Eq{"BroadcastId": id}).
+		OrderBy("ChannelId")
+
+	broadcast.Posts = []*model.ReadReceiptBroadcastPost{}
+	if err := s.GetReplica().SelectBuilder(&broadcast.Posts, postsQuery); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the posts of ReadReceiptBroadcast with id=%s", id)
+	}
+
+	return &broadcast, nil
+}
+
+func (s *SqlReadReceiptBroadcastStore) DeleteBefore(before int64, limit int) (int64, error) {
+	query := `
+		WITH Deleted AS (
+			DELETE FROM ReadReceiptBroadcasts
+			WHERE Id IN (
+				SELECT Id FROM ReadReceiptBroadcasts
+				WHERE CreateAt < $1
+				ORDER BY CreateAt
+				LIMIT $2
+			)
+			RETURNING Id
+		), DeletedPosts AS (
+			DELETE FROM ReadReceiptBroadcastPosts
+			WHERE BroadcastId IN (SELECT Id FROM Deleted)
+		)
+		SELECT COUNT(*) FROM Deleted`
+	var deleted int64
+	if err := s.GetMaster().Get(&deleted, query, before, limit); err != nil {
+		return 0, errors.Wrap(err, "failed to delete ReadReceiptBroadcasts")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlReadReceiptPolicyStore struct {
+	*SqlStore
+}
+
+func newSqlReadReceiptPolicyStore(sqlStore *SqlStore) store.ReadReceiptPolicyStore {
+	return &SqlReadReceiptPolicyStore{sqlStore}
+}
+
+func (s *SqlReadReceiptPolicyStore) columns() []string {
+	return []string{"Id", "Name", "TeamIds", "ChannelIds", "RetentionDays", "VisibilityMode", "IncludeInExport", "CreateAt", "UpdateAt"}
+}
+
+func (s *SqlReadReceiptPolicyStore) GetAll() ([]*model.ReadReceiptPolicy, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("ReadReceiptPolicies").
+		OrderBy("Name ASC")
+
+	policies := []*model.ReadReceiptPolicy{}
+	if err := s.GetReplica().SelectBuilder(&policies, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get ReadReceiptPolicies")
+	}
+
+	return policies, nil
+}
+
+func (s *SqlReadReceiptPolicyStore) ReplaceAll(policies []*model.ReadReceiptPolicy) (_ []*model.ReadReceiptPolicy, err error) {
+	for _, policy := range policies {
+		policy.PreSave()
+		if appErr := policy.IsValid(); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete("ReadReceiptPolicies")); err != nil {
+		return nil, errors.Wrap(err, "failed to delete ReadReceiptPolicies")
+	}
+
+	if len(policies) > 0 {
+		query := s.getQueryBuilder().
+			Insert("ReadReceiptPolicies").
+			Columns(s.columns()...)
+		for _, policy := range policies {
+			query = query.Values(policy.Id, policy.Name, policy.TeamIds, policy.ChannelIds, policy.RetentionDays, policy.VisibilityMode, policy.IncludeInExport, policy.CreateAt, policy.UpdateAt)
+		}
+
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			if IsUniqueConstraintError(err, []string{"Name", "readreceiptpolicies_name_key"}) {
+				return nil, store.NewErrConflict("ReadReceiptPolicy", err, "name")
+			}
+			return nil, errors.Wrap(err, "failed to save ReadReceiptPolicies")
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return policies, nil
+}
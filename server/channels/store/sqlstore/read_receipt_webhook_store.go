@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlReadReceiptWebhookStore struct {
+	*SqlStore
+}
+
+func newSqlReadReceiptWebhookStore(sqlStore *SqlStore) store.ReadReceiptWebhookStore {
+	return &SqlReadReceiptWebhookStore{sqlStore}
+}
+
+func (s *SqlReadReceiptWebhookStore) columns() []string {
+	return []string{"Id", "ChannelId", "CreatorId", "URL", "Secret", "CreateAt"}
+}
+
+func (s *SqlReadReceiptWebhookStore) Save(hook *model.ReadReceiptWebhook) (*model.ReadReceiptWebhook, error) {
+	hook.PreSave()
+	if appErr := hook.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ReadReceiptWebhooks").
+		Columns(s.columns()...).
+		Values(hook.Id, hook.ChannelId, hook.CreatorId, hook.URL, hook.Secret, hook.CreateAt)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ReadReceiptWebhook with id=%s", hook.Id)
+	}
+
+	return hook, nil
+}
+
+func (s *SqlReadReceiptWebhookStore) Get(id string) (*model.ReadReceiptWebhook, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("ReadReceiptWebhooks").
+		Where(sq.Eq{"Id": id})
+
+	var hook model.ReadReceiptWebhook
+	if err := s.GetReplica().GetBuilder(&hook, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ReadReceiptWebhook", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptWebhook with id=%s", id)
+	}
+
+	return &hook, nil
+}
+
+func (s *SqlReadReceiptWebhookStore) GetForChannel(channelID string) ([]*model.ReadReceiptWebhook, error) {
+	query := s.getQueryBuilder().
+		Select(s.columns()...).
+		From("ReadReceiptWebhooks").
+		Where(sq.Eq{"ChannelId": channelID}).
+		OrderBy("CreateAt ASC")
+
+	hooks := []*model.ReadReceiptWebhook{}
+	if err := s.GetReplica().SelectBuilder(&hooks, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ReadReceiptWebhooks for channelId=%s", channelID)
+	}
+
+	return hooks, nil
+}
+
+func (s *SqlReadReceiptWebhookStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("ReadReceiptWebhooks").
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ReadReceiptWebhook with id=%s", id)
+	}
+
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return store.NewErrNotFound("ReadReceiptWebhook", id)
+	}
+
+	return nil
+}
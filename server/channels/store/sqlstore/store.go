@@ -102,6 +102,10 @@ type SqlStoreStores struct {
 	notifyAdmin                store.NotifyAdminStore
 	postPriority               store.PostPriorityStore
 	postAcknowledgement        store.PostAcknowledgementStore
+	postReadReceipt            store.PostReadReceiptStore
+	readReceiptPolicy          store.ReadReceiptPolicyStore
+	readReceiptWebhook         store.ReadReceiptWebhookStore
+	readReceiptBroadcast       store.ReadReceiptBroadcastStore
 	postPersistentNotification store.PostPersistentNotificationStore
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
@@ -252,6 +256,10 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.notifyAdmin = newSqlNotifyAdminStore(store)
 	store.stores.postPriority = newSqlPostPriorityStore(store)
 	store.stores.postAcknowledgement = newSqlPostAcknowledgementStore(store)
+	store.stores.postReadReceipt = newSqlPostReadReceiptStore(store)
+	store.stores.readReceiptPolicy = newSqlReadReceiptPolicyStore(store)
+	store.stores.readReceiptWebhook = newSqlReadReceiptWebhookStore(store)
+	store.stores.readReceiptBroadcast = newSqlReadReceiptBroadcastStore(store)
 	store.stores.postPersistentNotification = newSqlPostPersistentNotificationStore(store)
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
@@ -852,6 +860,21 @@ func (ss *SqlStore) PostAcknowledgement() store.PostAcknowledgementStore {
 	return ss.stores.postAcknowledgement
 }
 
+func (ss *SqlStore) PostReadReceipt() store.PostReadReceiptStore {
+	return ss.stores.postReadReceipt
+}
+
+func (ss *SqlStore) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	return ss.stores.readReceiptPolicy
+}
+
+func (ss *SqlStore) ReadReceiptWebhook() store.ReadReceiptWebhookStore {
+	return ss.stores.readReceiptWebhook
+}
+
+func (ss *SqlStore) ReadReceiptBroadcast() store.ReadReceiptBroadcastStore {
+	return ss.stores.readReceiptBroadcast
+}
 
 func (ss *SqlStore) PostPersistentNotification() store.PostPersistentNotificationStore {
 	return ss.stores.postPersistentNotification
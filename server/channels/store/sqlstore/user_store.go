@@ -2377,6 +2377,8 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 			"MAX(ps.LastPostDate) AS LastPostDate",
 			"COUNT(ps.Day) AS DaysActive",
 			"SUM(ps.NumPosts) AS TotalPosts",
+			"MAX(rs.PostsReadPerDay) AS PostsReadPerDay",
+			"MAX(rs.ChannelsRead) AS ChannelsRead",
 		)
 	}
 
@@ -2426,19 +2428,37 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 
 	if isPostgres {
 		joinSql := sq.And{}
+		readStatsQuery := us.getSubQueryBuilder().
+			Select(
+				"UserId",
+				"SUM(NumPostsRead)::float / COUNT(DISTINCT Day) AS PostsReadPerDay",
+				"COUNT(DISTINCT ChannelId) AS ChannelsRead",
+			).
+			From("ReadReceiptStats").
+			GroupBy("UserId")
 		if filter.StartAt > 0 {
 			startDate := time.UnixMilli(filter.StartAt)
 			joinSql = append(joinSql, sq.GtOrEq{"ps.Day": startDate.Format("2006-01-02")})
+			readStatsQuery = readStatsQuery.Where(sq.GtOrEq{"Day": startDate.Format("2006-01-02")})
 		}
 		if filter.EndAt > 0 {
 			endDate := time.UnixMilli(filter.EndAt)
 			joinSql = append(joinSql, sq.Lt{"ps.Day": endDate.Format("2006-01-02")})
+			readStatsQuery = readStatsQuery.Where(sq.Lt{"Day": endDate.Format("2006-01-02")})
 		}
 		sql, args, err := joinSql.ToSql()
 		if err != nil {
 			return nil, err
 		}
 		query = query.LeftJoin("PostStats ps ON ps.UserId = Users.Id AND "+sql, args...)
+
+		// The read stats are aggregated per user beforehand so that joining them
+		// does not multiply the post stats rows.
+		readStatsSql, readStatsArgs, err := readStatsQuery.ToSql()
+		if err != nil {
+			return nil, err
+		}
+		query = query.LeftJoin("("+readStatsSql+") rs ON rs.UserId = Users.Id", readStatsArgs...)
 	}
 
 	query = applyUserReportFilter(query, filter, isPostgres)
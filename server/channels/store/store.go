@@ -88,6 +88,10 @@ type Store interface {
 	NotifyAdmin() NotifyAdminStore
 	PostPriority() PostPriorityStore
 	PostAcknowledgement() PostAcknowledgementStore
+	PostReadReceipt() PostReadReceiptStore
+	ReadReceiptPolicy() ReadReceiptPolicyStore
+	ReadReceiptWebhook() ReadReceiptWebhookStore
+	ReadReceiptBroadcast() ReadReceiptBroadcastStore
 	PostPersistentNotification() PostPersistentNotificationStore
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
@@ -234,6 +238,10 @@ type ChannelStore interface {
 	UpdateMemberNotifyProps(channelID, userID string, props map[string]string) (*model.ChannelMember, error)
 	PatchMultipleMembersNotifyProps(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) ([]*model.ChannelMember, error)
 	GetMembers(opts model.ChannelMembersGetOptions) (model.ChannelMembers, error)
+	// GetMembersByLastReadActivity returns a page of the channel members, the ones
+	// with the most recent read receipt in the channel first. Members who never
+	// read a post come last.
+	GetMembersByLastReadActivity(channelID string, offset, limit int) (model.ChannelMembers, error)
 	GetMember(ctx context.Context, channelID string, userID string) (*model.ChannelMember, error)
 	GetMemberLastViewedAt(ctx context.Context, channelID string, userID string) (int64, error)
 	GetChannelMembersTimezones(channelID string) ([]model.StringMap, error)
@@ -1066,6 +1074,231 @@ type PostAcknowledgementStore interface {
 	BatchDelete(acknowledgements []*model.PostAcknowledgement) error
 }
 
+type PostReadReceiptStore interface {
+	SaveReadReceipt(receipt *model.PostReadReceipt) (*model.PostReadReceipt, error)
+	SaveReadReceiptsBatch(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error)
+	// SaveReadReceiptWithSummary saves the receipt and, in the same transaction,
+	// applies it to the summary of its post: the read count only grows on the
+	// first read of the user. It returns the saved receipt, the updated summary and
+	// the read count the summary had before.
+	SaveReadReceiptWithSummary(receipt *model.PostReadReceipt) (*model.PostReadReceipt, *model.PostReadReceiptSummary, int64, error)
+	// SaveReadReceiptsIfNotExist saves only the receipts of posts the user has not read
+	// yet, leaving existing receipts untouched, and returns the receipts it inserted.
+	// Receipts of deleted posts and of posts model.IsReadReceiptPostType rejects are
+	// dropped.
+	SaveReadReceiptsIfNotExist(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error)
+	// SaveReadReceiptsUpToPost marks every post of receipt.ChannelId created up to and
+	// including receipt.PostId as read, using the remaining receipt fields for each row.
+	// At most limit of the most recent posts the user has not read are marked, so
+	// longer backlogs are marked by calling it again until fewer than limit receipts
+	// are returned. Only the newly created receipts are returned. With rootPostsOnly,
+	// thread replies are left unread. Posts opted out of read receipts are skipped.
+	SaveReadReceiptsUpToPost(receipt *model.PostReadReceipt, limit int, rootPostsOnly bool) ([]*model.PostReadReceipt, error)
+	GetReadReceipt(postID, userID string) (*model.PostReadReceipt, error)
+	// SaveHealthCheckReceipt upserts the receipt on the master and returns the read
+	// time it stored. Neither the changes, the summaries nor the ghost reads are
+	// touched, so that the health check leaves nothing behind once
+	// DeleteHealthCheckReceipt removes it.
+	SaveHealthCheckReceipt(receipt *model.PostReadReceipt) (int64, error)
+	DeleteHealthCheckReceipt(postID, userID string) error
+	// GetReadReceiptsForPost returns the receipts of the post, only those recorded
+	// from deviceType unless it is empty.
+	GetReadReceiptsForPost(postID, deviceType string) ([]*model.PostReadReceipt, error)
+	// GetReadReceiptsForPosts returns the receipts of several posts at once, ordered
+	// by post and then by ReadAt.
+	GetReadReceiptsForPosts(postIDs []string) ([]*model.PostReadReceipt, error)
+	// GetReadPostIdsForUser returns the posts among postIDs the user has a receipt for.
+	GetReadPostIdsForUser(userID string, postIDs []string) ([]string, error)
+	// GetReadReceiptsForUserPosts returns the receipts of the user for the posts
+	// among postIDs they read.
+	GetReadReceiptsForUserPosts(userID string, postIDs []string) ([]*model.PostReadReceipt, error)
+	GetReadReceiptsForUser(userID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error)
+	// GetReadActivityBySession returns a page of the read activity of the user since
+	// since, grouped by session and device, the most recently used first.
+	GetReadActivityBySession(userID string, since int64, offset, limit int) ([]*model.ReadReceiptSessionActivity, error)
+	// GetReadReceiptsForSession returns the receipts recorded through the session,
+	// paginated like GetReadReceiptsForUser.
+	GetReadReceiptsForSession(sessionID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error)
+	// GetChannelSettings returns the read receipt settings of the channel, or a
+	// *ErrNotFound when they were never saved.
+	GetChannelSettings(channelID string) (*model.ReadReceiptChannelSettings, error)
+	SaveChannelSettings(settings *model.ReadReceiptChannelSettings) (*model.ReadReceiptChannelSettings, error)
+	// GetReadReceiptsPage returns at most limit receipts following cursor in primary
+	// key order, so that every receipt of the server can be walked in bounded pages.
+	GetReadReceiptsPage(cursor model.ReadReceiptsPageCursor, limit int) ([]*model.PostReadReceipt, error)
+	DeleteReadReceipt(postID, userID string) error
+	// GetExpiredReadReceiptCounts counts the receipts read before expiredBefore in
+	// the given channels and in the channels of the given teams, or in every channel
+	// when both are empty, with a breakdown of at most limit channels by count.
+	GetExpiredReadReceiptCounts(expiredBefore int64, teamIDs, channelIDs []string, limit int) (*model.ReadReceiptCleanupPreview, error)
+	// DeleteReadReceipts deletes the given receipts along with their read devices and
+	// returns how many receipts were removed.
+	DeleteReadReceipts(receipts []*model.PostReadReceipt) (int64, error)
+	// SaveReadDevices keeps one row per device the user read the post on, next to the
+	// single receipt per user stored by the other save methods.
+	SaveReadDevices(receipts []*model.PostReadReceipt) error
+	GetReadDevicesForPostUser(postID, userID string) ([]*model.PostReadReceipt, error)
+	DeleteReadReceiptsForPost(postID string) error
+	// PermanentDeleteByUser deletes every receipt of the user, along with their read
+	// devices and ghost reads. The read counters of the posts are left untouched.
+	PermanentDeleteByUser(userID string) error
+	GetHumanMemberCount(channelID string) (int64, error)
+	// GetAnnouncementReadCounts returns the human read count of each of the posts that
+	// belongs to a channel of the team and is not deleted, along with the number of
+	// active human members of its channel, ordered by channel.
+	GetAnnouncementReadCounts(teamID string, postIDs []string) ([]*model.ReadReceiptBroadcastChannelSummary, error)
+	// IsPostReadByAnyone reports whether anyone other than its author has a receipt
+	// for the post, along with the number of receipts of the post, in a single query.
+	// Unless fromMaster is set, it may lag behind on a replica.
+	IsPostReadByAnyone(postID string, fromMaster bool) (bool, int64, error)
+	// GetUnreadUsersForPost returns at most limit of the active human members of the
+	// channel of the post, other than its author, who have no receipt for it, by username.
+	// Posts opted out of read receipts have no unread users.
+	GetUnreadUsersForPost(postID string, limit int) ([]*model.User, error)
+	// GetCaughtUpUsersForChannel returns at most limit of the active human members of
+	// the channel who read its latest root post accepting read receipts, or wrote it,
+	// by username. Reading a post through the watermark form marks every earlier one.
+	GetCaughtUpUsersForChannel(channelID string, limit int) ([]*model.User, error)
+	// GetUnreadPostsCount returns how many posts of the channel created before
+	// createdBefore and written by someone else the user has no receipt for. System
+	// messages and posts opted out of read receipts are not counted.
+	GetUnreadPostsCount(channelID, userID string, createdBefore int64) (int64, error)
+	// GetUnreadDirectChannels returns the direct channels, along with the active human
+	// member, holding a post created in [createdAfter, createdBefore) that the member
+	// received and has no receipt for, ordered by user.
+	GetUnreadDirectChannels(createdAfter, createdBefore int64) ([]*model.UnreadDirectChannel, error)
+	// GetMembersReadActivity returns a page of the members of the channel with the
+	// time they last read one of its posts and how many they read since since, the
+	// members who read the least recently, or never, first.
+	GetMembersReadActivity(channelID string, since int64, offset, limit int) ([]*model.ChannelMemberReadActivity, error)
+	// GetDistinctReaderCounts returns how many distinct users read posts of the
+	// channel on each UTC day from fromDay to toDay included, both formatted as
+	// 2006-01-02, in order. Days without reads are left out and bots are not counted.
+	GetDistinctReaderCounts(channelID, fromDay, toDay string) ([]*model.DailyReaderCount, error)
+	ComputeReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error)
+	GetReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error)
+	// GetReadReceiptExtremes returns the earliest and the latest human readers of the
+	// post, breaking ties on ReadAt by user id.
+	GetReadReceiptExtremes(postID string) (*model.PostReadReceiptExtremes, error)
+	GetReadReceiptSummariesForChannel(channelID string, since int64) ([]*model.PostReadReceiptSummary, error)
+	// GetReadReceiptSummariesForPosts returns the stored summaries of the posts. Posts
+	// that were never summarized are left out. Unless fromMaster is set, they may lag
+	// behind on a replica.
+	GetReadReceiptSummariesForPosts(postIDs []string, fromMaster bool) ([]*model.PostReadReceiptSummary, error)
+	// GetReadCountsForLatestPosts returns the read counters of the limit most recent
+	// posts of the channel, newest first, including posts nobody has read yet but not
+	// posts opted out of read receipts.
+	GetReadCountsForLatestPosts(channelID string, limit int) ([]*model.PostReadCount, error)
+	// UpdateReadReceiptSummary stores the summary if it is newer than the stored one and
+	// summary.Version matches the stored version, then bumps summary.Version. A stale or
+	// concurrently modified summary is rejected with a *ErrConflict.
+	UpdateReadReceiptSummary(summary *model.PostReadReceiptSummary) error
+	// UpdateReadReceiptSummaries applies UpdateReadReceiptSummary to several summaries
+	// in a single transaction and returns the ones that were stored. Stale or
+	// concurrently modified summaries are skipped rather than failing the batch.
+	UpdateReadReceiptSummaries(summaries []*model.PostReadReceiptSummary) ([]*model.PostReadReceiptSummary, error)
+	// ComputeThreadReadReceiptSummary counts, for each user who read or wrote a reply
+	// of the thread, how many of its replies they read or wrote. Bot reads are left out.
+	ComputeThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error)
+	// SaveThreadReadReceiptSummary replaces the stored read counts of the thread.
+	SaveThreadReadReceiptSummary(rootID, channelID string, counts []*model.ThreadParticipantReadCount) error
+	GetThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error)
+	// GetChannelArchiveRecommendations returns a page of the public and private
+	// channels matching the options, according to the daily rollup of the receipts,
+	// the ones read by the smallest share of their members first.
+	GetChannelArchiveRecommendations(opts *model.ChannelArchiveReportOptions) ([]*model.ChannelArchiveRecommendation, error)
+	// RefreshReadReceiptStats recomputes the daily per user rollup of the receipts
+	// used by the user reports, and the per team rollup of their volume.
+	RefreshReadReceiptStats() error
+	// RotateHotReadReceiptIndexes rebuilds the partial indexes of the recent receipts
+	// for the current hot window, leaving out the receipts that got older.
+	RotateHotReadReceiptIndexes() error
+	// EstimateReadReceiptCount returns the number of stored receipts estimated by the
+	// database statistics, which is cheap enough to be checked often.
+	EstimateReadReceiptCount() (int64, error)
+	// GetReadReceiptTeamUsage returns the receipt volume of every team from the last
+	// rollup, the teams with the most receipts first.
+	GetReadReceiptTeamUsage() ([]*model.ReadReceiptTeamUsage, error)
+	// GetTableStats returns the estimated row counts and the indexes of the read
+	// receipt tables, flagging the indexes created by the migrations that are missing.
+	GetTableStats() ([]*model.ReadReceiptTableStats, error)
+	// GetReadReceiptChainHead returns the last entry of the receipt chain of the
+	// channel, or a *ErrNotFound if the channel has none yet.
+	GetReadReceiptChainHead(channelID string) (*model.ReadReceiptChainEntry, error)
+	// AppendReadReceiptChain saves entries linked to the current head of their chain.
+	// When another writer extended the chain first it fails with a *ErrConflict.
+	AppendReadReceiptChain(entries []*model.ReadReceiptChainEntry) error
+	// GetReadReceiptChain returns at most limit entries of the receipt chain of the
+	// channel that follow afterSequence, in order.
+	GetReadReceiptChain(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChainEntry, error)
+	// GetReadReceiptChainMismatches returns, in chain order, at most limit entries
+	// of the receipt chain of the channel that are the latest entry of their reader
+	// and whose stored receipt is missing or has another read time.
+	GetReadReceiptChainMismatches(channelID string, limit int) ([]*model.ReadReceiptChainEntry, error)
+	// GetReadReceiptChanges returns at most limit receipt changes of the channel, or
+	// of every channel when channelID is empty, that follow afterSequence, in order.
+	// Sequences are taken when the changes are written, so a change committed late
+	// can show up behind a sequence a consumer already went past.
+	GetReadReceiptChanges(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChange, error)
+	// DeleteReadReceiptChangesBefore deletes at most limit of the receipt changes
+	// written before the given time, oldest first, and returns how many it deleted.
+	DeleteReadReceiptChangesBefore(before int64, limit int) (int64, error)
+	// UpdateChannelWatermarkMode reports whether the channel only accepts watermark
+	// receipts. It switches the channel once it holds more than limit receipts, and
+	// back once it holds no more than recoverAt receipts.
+	UpdateChannelWatermarkMode(channelID string, limit, recoverAt int64) (bool, error)
+	// FlagUserReceiptsForScrub records that the device and session data of the receipts
+	// of the user are to be scrubbed once the user has been deactivated long enough.
+	FlagUserReceiptsForScrub(userID string, deactivatedAt int64) error
+	// UnflagUserReceiptsForScrub cancels a pending scrub of the receipts of the user.
+	UnflagUserReceiptsForScrub(userID string) error
+	// GetUsersDueForReceiptScrub returns at most limit users flagged for a scrub who
+	// were deactivated before deactivatedBefore, the longest deactivated first.
+	GetUsersDueForReceiptScrub(deactivatedBefore int64, limit int) ([]string, error)
+	// ScrubUserReceipts clears the device and session data of the receipts of the user
+	// and its flag, in a single transaction.
+	ScrubUserReceipts(userID string) error
+	// SaveGhostRead records a read of the post that is not visible to anyone, unless
+	// the user already has a receipt or a ghost read for it. It reports whether the
+	// ghost read was recorded. Saving a receipt later promotes the ghost read.
+	SaveGhostRead(read *model.PostReadReceipt) (bool, error)
+	// GetChannelsForReadReceiptCompaction returns at most limit of the channels
+	// following afterChannelID, in order, that hold at least minReceipts receipts
+	// and have no receipt chain.
+	GetChannelsForReadReceiptCompaction(minReceipts int64, afterChannelID string, limit int) ([]string, error)
+	// CompactReadReceipts moves at most limit of the human receipts of the channel
+	// read before readBefore to per user bitmaps, and returns how many it moved.
+	// Compacted receipts keep answering reads, without their device and read time.
+	CompactReadReceipts(channelID string, readBefore int64, limit int) (int64, error)
+	// GetCompactedReadReceiptChannels returns at most limit of the channels following
+	// afterChannelID, in order, that have compacted receipts.
+	GetCompactedReadReceiptChannels(afterChannelID string, limit int) ([]string, error)
+	// DeleteExpiredCompactedReadReceipts deletes the bitmaps of the channel whose
+	// latest read is before expiredBefore, and returns how many it deleted.
+	DeleteExpiredCompactedReadReceipts(channelID string, expiredBefore int64) (int64, error)
+}
+
+type ReadReceiptPolicyStore interface {
+	GetAll() ([]*model.ReadReceiptPolicy, error)
+	// ReplaceAll atomically replaces every stored policy with the given ones.
+	ReplaceAll(policies []*model.ReadReceiptPolicy) ([]*model.ReadReceiptPolicy, error)
+}
+
+type ReadReceiptWebhookStore interface {
+	Save(hook *model.ReadReceiptWebhook) (*model.ReadReceiptWebhook, error)
+	Get(id string) (*model.ReadReceiptWebhook, error)
+	GetForChannel(channelID string) ([]*model.ReadReceiptWebhook, error)
+	Delete(id string) error
+}
+
+type ReadReceiptBroadcastStore interface {
+	// Save stores the broadcast along with its posts.
+	Save(broadcast *model.ReadReceiptBroadcast) (*model.ReadReceiptBroadcast, error)
+	Get(id string) (*model.ReadReceiptBroadcast, error)
+	// DeleteBefore deletes at most limit of the broadcasts created before the given
+	// time, oldest first, along with their posts, and returns how many it deleted.
+	DeleteBefore(before int64, limit int) (int64, error)
+}
 
 type PostPersistentNotificationStore interface {
 	Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error)
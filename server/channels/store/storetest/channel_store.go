@@ -74,6 +74,7 @@ func TestChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore
 	t.Run("SaveDirectChannel", func(t *testing.T) { testChannelStoreSaveDirectChannel(t, rctx, ss, s) })
 	t.Run("CreateDirectChannel", func(t *testing.T) { testChannelStoreCreateDirectChannel(t, rctx, ss) })
 	t.Run("GetMembersWithCursorPagination", func(t *testing.T) { testChannelStoreGetMembersWithCursorPagination(t, rctx, ss) })
+	t.Run("GetMembersByLastReadActivity", func(t *testing.T) { testChannelStoreGetMembersByLastReadActivity(t, rctx, ss) })
 	t.Run("Update", func(t *testing.T) { testChannelStoreUpdate(t, rctx, ss) })
 	t.Run("GetChannelUnread", func(t *testing.T) { testGetChannelUnread(t, rctx, ss) })
 	t.Run("Get", func(t *testing.T) { testChannelStoreGet(t, rctx, ss, s) })
@@ -401,6 +402,50 @@ func testChannelStoreGetMembersWithCursorPagination(t *testing.T, rctx request.C
 	require.Len(t, membersAfter, 1, "should have found only 1 member created after the timestamp")
 }
 
+func testChannelStoreGetMembersByLastReadActivity(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel := &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}
+	_, nErr := ss.Channel().Save(rctx, channel, -1)
+	require.NoError(t, nErr)
+
+	neverRead, earlyReader, lateReader := model.NewId(), model.NewId(), model.NewId()
+	for _, userID := range []string{neverRead, earlyReader, lateReader} {
+		_, err := ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:   channel.Id,
+			UserId:      userID,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+	}
+
+	post1 := savePostForReadReceipts(t, rctx, ss, channel.Id)
+	post2 := savePostForReadReceipts(t, rctx, ss, channel.Id)
+	MarkPostsAsRead(t, ss, earlyReader, 1000, post1)
+	MarkPostsAsRead(t, ss, lateReader, 1000, post1)
+	MarkPostsAsRead(t, ss, lateReader, 2000, post2)
+	MarkPostsAsRead(t, ss, earlyReader, 1500, post2)
+
+	// Receipts in other channels don't count.
+	otherPost := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	MarkPostsAsRead(t, ss, neverRead, 3000, otherPost)
+
+	members, err := ss.Channel().GetMembersByLastReadActivity(channel.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, members, 3)
+	assert.Equal(t, lateReader, members[0].UserId)
+	assert.Equal(t, earlyReader, members[1].UserId)
+	assert.Equal(t, neverRead, members[2].UserId)
+
+	members, err = ss.Channel().GetMembersByLastReadActivity(channel.Id, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, earlyReader, members[0].UserId)
+}
+
 func testChannelStoreUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
 	o1 := model.Channel{}
 	o1.TeamId = model.NewId()
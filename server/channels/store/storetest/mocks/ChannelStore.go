@@ -1670,6 +1670,36 @@ func (_m *ChannelStore) GetMembersByIds(channelID string, userIds []string) (mod
 	return r0, r1
 }
 
+// GetMembersByLastReadActivity provides a mock function with given fields: channelID, offset, limit
+func (_m *ChannelStore) GetMembersByLastReadActivity(channelID string, offset int, limit int) (model.ChannelMembers, error) {
+	ret := _m.Called(channelID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembersByLastReadActivity")
+	}
+
+	var r0 model.ChannelMembers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) (model.ChannelMembers, error)); ok {
+		return rf(channelID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) model.ChannelMembers); ok {
+		r0 = rf(channelID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.ChannelMembers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(channelID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMembersForUser provides a mock function with given fields: teamID, userID
 func (_m *ChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
 	ret := _m.Called(teamID, userID)
@@ -828,6 +828,26 @@ func (_m *Store) PostPriority() store.PostPriorityStore {
 	return r0
 }
 
+// PostReadReceipt provides a mock function with no fields
+func (_m *Store) PostReadReceipt() store.PostReadReceiptStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostReadReceipt")
+	}
+
+	var r0 store.PostReadReceiptStore
+	if rf, ok := ret.Get(0).(func() store.PostReadReceiptStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PostReadReceiptStore)
+		}
+	}
+
+	return r0
+}
+
 // Preference provides a mock function with no fields
 func (_m *Store) Preference() store.PreferenceStore {
 	ret := _m.Called()
@@ -948,6 +968,66 @@ func (_m *Store) Reaction() store.ReactionStore {
 	return r0
 }
 
+// ReadReceiptBroadcast provides a mock function with no fields
+func (_m *Store) ReadReceiptBroadcast() store.ReadReceiptBroadcastStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadReceiptBroadcast")
+	}
+
+	var r0 store.ReadReceiptBroadcastStore
+	if rf, ok := ret.Get(0).(func() store.ReadReceiptBroadcastStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReadReceiptBroadcastStore)
+		}
+	}
+
+	return r0
+}
+
+// ReadReceiptPolicy provides a mock function with no fields
+func (_m *Store) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadReceiptPolicy")
+	}
+
+	var r0 store.ReadReceiptPolicyStore
+	if rf, ok := ret.Get(0).(func() store.ReadReceiptPolicyStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReadReceiptPolicyStore)
+		}
+	}
+
+	return r0
+}
+
+// ReadReceiptWebhook provides a mock function with no fields
+func (_m *Store) ReadReceiptWebhook() store.ReadReceiptWebhookStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadReceiptWebhook")
+	}
+
+	var r0 store.ReadReceiptWebhookStore
+	if rf, ok := ret.Get(0).(func() store.ReadReceiptWebhookStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ReadReceiptWebhookStore)
+		}
+	}
+
+	return r0
+}
+
 // RecycleDBConnections provides a mock function with given fields: d
 func (_m *Store) RecycleDBConnections(d time.Duration) {
 	_m.Called(d)
@@ -0,0 +1,2046 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPostReadReceiptStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveReadReceipt", func(t *testing.T) { testPostReadReceiptStoreSave(t, rctx, ss) })
+	t.Run("SaveReadReceiptsBatch", func(t *testing.T) { testPostReadReceiptStoreSaveBatch(t, rctx, ss) })
+	t.Run("SaveReadReceiptWithSummary", func(t *testing.T) { testPostReadReceiptStoreSaveWithSummary(t, rctx, ss) })
+	t.Run("SaveReadReceiptsBatchAcrossChannels", func(t *testing.T) { testPostReadReceiptStoreSaveBatchAcrossChannels(t, rctx, ss) })
+	t.Run("SaveReadReceiptsIfNotExist", func(t *testing.T) { testPostReadReceiptStoreSaveIfNotExist(t, rctx, ss) })
+	t.Run("SaveReadReceiptsUpToPost", func(t *testing.T) { testPostReadReceiptStoreSaveUpToPost(t, rctx, ss) })
+	t.Run("ReadDevices", func(t *testing.T) { testPostReadReceiptStoreReadDevices(t, rctx, ss) })
+	t.Run("GetReadReceiptsForPosts", func(t *testing.T) { testPostReadReceiptStoreGetForPosts(t, rctx, ss) })
+	t.Run("GetReadReceiptsForUser", func(t *testing.T) { testPostReadReceiptStoreGetForUser(t, rctx, ss) })
+	t.Run("GetReadReceiptsForSession", func(t *testing.T) { testPostReadReceiptStoreGetForSession(t, rctx, ss) })
+	t.Run("GetReadActivityBySession", func(t *testing.T) { testPostReadReceiptStoreGetReadActivityBySession(t, rctx, ss) })
+	t.Run("GetReadReceiptsPage", func(t *testing.T) { testPostReadReceiptStoreGetPage(t, rctx, ss) })
+	t.Run("GetExpiredReadReceiptCounts", func(t *testing.T) { testPostReadReceiptStoreGetExpiredCounts(t, rctx, ss) })
+	t.Run("GetAnnouncementReadCounts", func(t *testing.T) { testPostReadReceiptStoreGetAnnouncementReadCounts(t, rctx, ss) })
+	t.Run("IsPostReadByAnyone", func(t *testing.T) { testPostReadReceiptStoreIsPostReadByAnyone(t, rctx, ss) })
+	t.Run("GetUnreadUsersForPost", func(t *testing.T) { testPostReadReceiptStoreGetUnreadUsersForPost(t, rctx, ss) })
+	t.Run("GetCaughtUpUsersForChannel", func(t *testing.T) { testPostReadReceiptStoreGetCaughtUpUsersForChannel(t, rctx, ss) })
+	t.Run("GetMembersReadActivity", func(t *testing.T) { testPostReadReceiptStoreGetMembersReadActivity(t, rctx, ss) })
+	t.Run("GetDistinctReaderCounts", func(t *testing.T) { testPostReadReceiptStoreGetDistinctReaderCounts(t, rctx, ss) })
+	t.Run("GetUnreadDirectMessages", func(t *testing.T) { testPostReadReceiptStoreGetUnreadDirectMessages(t, rctx, ss) })
+	t.Run("DeleteReadReceiptsForPost", func(t *testing.T) { testPostReadReceiptStoreDeleteForPost(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPostReadReceiptStorePermanentDeleteByUser(t, rctx, ss) })
+	t.Run("HealthCheckReceipt", func(t *testing.T) { testPostReadReceiptStoreHealthCheckReceipt(t, rctx, ss) })
+	t.Run("PostDeletion", func(t *testing.T) { testPostReadReceiptStorePostDeletion(t, rctx, ss) })
+	t.Run("PostDeletionRace", func(t *testing.T) { testPostReadReceiptStorePostDeletionRace(t, rctx, ss) })
+	t.Run("ReadReceiptSummary", func(t *testing.T) { testPostReadReceiptStoreSummary(t, rctx, ss) })
+	t.Run("GetReadReceiptExtremes", func(t *testing.T) { testPostReadReceiptStoreExtremes(t, rctx, ss) })
+	t.Run("GetReadCountsForLatestPosts", func(t *testing.T) { testPostReadReceiptStoreReadCountsForLatestPosts(t, rctx, ss) })
+	t.Run("RefreshReadReceiptStats", func(t *testing.T) { testPostReadReceiptStoreRefreshReadReceiptStats(t, rctx, ss) })
+	t.Run("RotateHotReadReceiptIndexes", func(t *testing.T) { testPostReadReceiptStoreRotateHotIndexes(t, rctx, ss) })
+	t.Run("GetReadReceiptTeamUsage", func(t *testing.T) { testPostReadReceiptStoreGetReadReceiptTeamUsage(t, rctx, ss) })
+	t.Run("GetChannelArchiveRecommendations", func(t *testing.T) { testPostReadReceiptStoreChannelArchiveRecommendations(t, rctx, ss) })
+	t.Run("ReadReceiptChain", func(t *testing.T) { testPostReadReceiptStoreChain(t, rctx, ss) })
+	t.Run("UpdateChannelWatermarkMode", func(t *testing.T) { testPostReadReceiptStoreUpdateChannelWatermarkMode(t, rctx, ss) })
+	t.Run("ChannelSettings", func(t *testing.T) { testPostReadReceiptStoreChannelSettings(t, rctx, ss) })
+	t.Run("ScrubUserReceipts", func(t *testing.T) { testPostReadReceiptStoreScrubUserReceipts(t, rctx, ss) })
+	t.Run("GhostReads", func(t *testing.T) { testPostReadReceiptStoreGhostReads(t, rctx, ss) })
+	t.Run("ThreadReadReceiptSummary", func(t *testing.T) { testPostReadReceiptStoreThreadSummary(t, rctx, ss) })
+	t.Run("ReadReceiptChanges", func(t *testing.T) { testPostReadReceiptStoreChanges(t, rctx, ss) })
+	t.Run("CompactReadReceipts", func(t *testing.T) { testPostReadReceiptStoreCompaction(t, rctx, ss) })
+}
+
+func savePostForReadReceipts(t *testing.T, rctx request.CTX, ss store.Store, channelID string) *model.Post {
+	t.Helper()
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    model.NewId(),
+		Message:   NewTestID(),
+	})
+	require.NoError(t, err)
+
+	return post
+}
+
+func testPostReadReceiptStoreGetForPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	other := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+
+	_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post1.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000},
+		{PostId: post2.Id, UserId: userID, ChannelId: channelID, ReadAt: 2000},
+		{PostId: other.Id, UserId: userID, ChannelId: channelID, ReadAt: 3000},
+	})
+	require.NoError(t, err)
+
+	t.Run("no posts", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPosts(nil)
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+	})
+
+	t.Run("only the requested posts", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPosts([]string{post1.Id, post2.Id})
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+		for _, receipt := range receipts {
+			assert.NotEqual(t, other.Id, receipt.PostId)
+		}
+	})
+
+	t.Run("only the receipts of the user", func(t *testing.T) {
+		MarkPostsAsRead(t, ss, model.NewId(), 4000, post1)
+
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForUserPosts(userID, []string{post1.Id, model.NewId()})
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, userID, receipts[0].UserId)
+		assert.Equal(t, int64(1000), receipts[0].ReadAt)
+	})
+}
+
+func testPostReadReceiptStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	userID := model.NewId()
+
+	t.Run("invalid receipt is rejected", func(t *testing.T) {
+		_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: "junk", ChannelId: post.ChannelId})
+		require.Error(t, err)
+	})
+
+	t.Run("saving twice keeps a single row with the latest values", func(t *testing.T) {
+		_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: post.ChannelId, ReadAt: 1000})
+		require.NoError(t, err)
+
+		_, err = ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: post.ChannelId, ReadAt: 2000, DeviceType: model.ReadReceiptDeviceTypeMobile})
+		require.NoError(t, err)
+
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, int64(2000), receipts[0].ReadAt)
+		assert.Equal(t, model.ReadReceiptDeviceTypeMobile, receipts[0].DeviceType)
+
+		mobileReceipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, model.ReadReceiptDeviceTypeMobile)
+		require.NoError(t, err)
+		assert.Equal(t, receipts, mobileReceipts)
+
+		webReceipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, model.ReadReceiptDeviceTypeWeb)
+		require.NoError(t, err)
+		assert.Empty(t, webReceipts)
+
+		receipt, err := ss.PostReadReceipt().GetReadReceipt(post.Id, userID)
+		require.NoError(t, err)
+		assert.Equal(t, receipts[0], receipt)
+	})
+
+	t.Run("missing receipt returns not found", func(t *testing.T) {
+		_, err := ss.PostReadReceipt().GetReadReceipt(post.Id, model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("delete removes the receipt", func(t *testing.T) {
+		err := ss.PostReadReceipt().DeleteReadReceipt(post.Id, userID)
+		require.NoError(t, err)
+
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+	})
+}
+
+func testPostReadReceiptStoreSaveBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+
+	saved, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post1.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000},
+		{PostId: post2.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+
+	for _, postID := range []string{post1.Id, post2.Id} {
+		receipt, err := ss.PostReadReceipt().GetReadReceipt(postID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), receipt.ReadAt)
+	}
+
+	saved, err = ss.PostReadReceipt().SaveReadReceiptsBatch(nil)
+	require.NoError(t, err)
+	require.Empty(t, saved)
+}
+
+func testPostReadReceiptStoreSaveIfNotExist(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post1.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000})
+	require.NoError(t, err)
+
+	saved, err := ss.PostReadReceipt().SaveReadReceiptsIfNotExist([]*model.PostReadReceipt{
+		{PostId: post1.Id, UserId: userID, ChannelId: channelID, ReadAt: 2000, Source: model.ReadReceiptSourceReaction},
+		{PostId: post2.Id, UserId: userID, ChannelId: channelID, ReadAt: 2000, Source: model.ReadReceiptSourceReaction},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, post2.Id, saved[0].PostId)
+	assert.Equal(t, model.ReadReceiptSourceReaction, saved[0].Source)
+
+	receipt, err := ss.PostReadReceipt().GetReadReceipt(post1.Id, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), receipt.ReadAt)
+	assert.Empty(t, receipt.Source)
+
+	t.Run("system posts are skipped", func(t *testing.T) {
+		systemPost, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Type:      model.PostTypeHeaderChange,
+			Message:   NewTestID(),
+		})
+		require.NoError(t, err)
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsIfNotExist([]*model.PostReadReceipt{
+			{PostId: systemPost.Id, UserId: userID, ChannelId: channelID, ReadAt: 3000, Source: model.ReadReceiptSourceReply},
+		})
+		require.NoError(t, err)
+		require.Empty(t, saved)
+
+		_, err = ss.PostReadReceipt().GetReadReceipt(systemPost.Id, userID)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testPostReadReceiptStoreReadDevices(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	userID := model.NewId()
+
+	for i, deviceID := range []string{"laptop", "phone", "laptop"} {
+		err := ss.PostReadReceipt().SaveReadDevices([]*model.PostReadReceipt{
+			{PostId: post.Id, UserId: userID, ChannelId: post.ChannelId, ReadAt: int64(1000 + i), DeviceId: deviceID},
+		})
+		require.NoError(t, err)
+	}
+
+	devices, err := ss.PostReadReceipt().GetReadDevicesForPostUser(post.Id, userID)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, "phone", devices[0].DeviceId)
+	assert.Equal(t, "laptop", devices[1].DeviceId)
+	assert.Equal(t, int64(1002), devices[1].ReadAt)
+
+	err = ss.PostReadReceipt().DeleteReadReceipt(post.Id, userID)
+	require.NoError(t, err)
+
+	devices, err = ss.PostReadReceipt().GetReadDevicesForPostUser(post.Id, userID)
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
+
+func testPostReadReceiptStoreSaveUpToPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+
+	var posts []*model.Post
+	for i := range 4 {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestID(),
+			CreateAt:  int64(1000 + i),
+		})
+		require.NoError(t, err)
+		posts = append(posts, post)
+	}
+	otherPost := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: posts[0].Id, UserId: userID, ChannelId: channelID, ReadAt: 500})
+	require.NoError(t, err)
+
+	t.Run("marks posts up to the watermark and keeps existing receipts", func(t *testing.T) {
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: posts[2].Id, UserId: userID, ChannelId: channelID, ReadAt: 5000}, 100, false)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+
+		receipt, err := ss.PostReadReceipt().GetReadReceipt(posts[0].Id, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), receipt.ReadAt)
+
+		_, err = ss.PostReadReceipt().GetReadReceipt(posts[3].Id, userID)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("respects the limit", func(t *testing.T) {
+		otherUserID := model.NewId()
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: posts[3].Id, UserId: otherUserID, ChannelId: channelID, ReadAt: 5000}, 2, false)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+	})
+
+	t.Run("pages through the unread posts", func(t *testing.T) {
+		otherUserID := model.NewId()
+		watermark := &model.PostReadReceipt{PostId: posts[3].Id, UserId: otherUserID, ChannelId: channelID, ReadAt: 5000}
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(watermark, 2, false)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.ElementsMatch(t, []string{posts[3].Id, posts[2].Id}, []string{saved[0].PostId, saved[1].PostId})
+
+		saved, err = ss.PostReadReceipt().SaveReadReceiptsUpToPost(watermark, 2, false)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.ElementsMatch(t, []string{posts[1].Id, posts[0].Id}, []string{saved[0].PostId, saved[1].PostId})
+
+		saved, err = ss.PostReadReceipt().SaveReadReceiptsUpToPost(watermark, 2, false)
+		require.NoError(t, err)
+		require.Empty(t, saved)
+	})
+
+	t.Run("watermark from another channel marks nothing", func(t *testing.T) {
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: otherPost.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 5000}, 100, false)
+		require.NoError(t, err)
+		require.Empty(t, saved)
+	})
+
+	t.Run("root posts only leaves replies unread", func(t *testing.T) {
+		reply, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			RootId:    posts[3].Id,
+			Message:   NewTestID(),
+			CreateAt:  2000,
+		})
+		require.NoError(t, err)
+
+		replyReader := model.NewId()
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: reply.Id, UserId: replyReader, ChannelId: channelID, ReadAt: 5000}, 100, true)
+		require.NoError(t, err)
+		require.Len(t, saved, 4)
+		for _, receipt := range saved {
+			assert.NotEqual(t, reply.Id, receipt.PostId)
+		}
+	})
+
+	t.Run("skips posts opted out of read receipts", func(t *testing.T) {
+		optedOut := &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestID(),
+			CreateAt:  3000,
+		}
+		optedOut.AddProp(model.PostPropsNoReadReceipts, true)
+		optedOut, err := ss.Post().Save(rctx, optedOut)
+		require.NoError(t, err)
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: optedOut.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 5000}, 100, false)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved)
+		for _, receipt := range saved {
+			assert.NotEqual(t, optedOut.Id, receipt.PostId)
+		}
+	})
+
+	t.Run("skips system posts", func(t *testing.T) {
+		systemPost, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Type:      model.PostTypeJoinChannel,
+			Message:   NewTestID(),
+			CreateAt:  4000,
+		})
+		require.NoError(t, err)
+		pluginPost, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Type:      model.PostCustomTypePrefix + "plugin",
+			Message:   NewTestID(),
+			CreateAt:  4001,
+		})
+		require.NoError(t, err)
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: pluginPost.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 5000}, 100, false)
+		require.NoError(t, err)
+		savedPostIDs := make([]string, 0, len(saved))
+		for _, receipt := range saved {
+			savedPostIDs = append(savedPostIDs, receipt.PostId)
+		}
+		assert.Contains(t, savedPostIDs, pluginPost.Id)
+		assert.Contains(t, savedPostIDs, posts[0].Id)
+		assert.NotContains(t, savedPostIDs, systemPost.Id)
+	})
+}
+
+func testPostReadReceiptStoreGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	userID := model.NewId()
+
+	for i := range 3 {
+		post := savePostForReadReceipts(t, rctx, ss, channelID)
+		_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: channelID, ReadAt: int64(1000 + i)})
+		require.NoError(t, err)
+	}
+	otherPost := savePostForReadReceipts(t, rctx, ss, otherChannelID)
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: otherPost.Id, UserId: userID, ChannelId: otherChannelID, ReadAt: 1001, Source: model.ReadReceiptSourceExplicitClick})
+	require.NoError(t, err)
+
+	t.Run("limit", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{PerPage: 2})
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+		assert.Equal(t, int64(1002), receipts[0].ReadAt)
+		assert.Equal(t, int64(1001), receipts[1].ReadAt)
+	})
+
+	t.Run("time range and channel", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{Since: 1001, Until: 1002, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+
+		receipts, err = ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{ChannelId: channelID, Since: 1001, Until: 1002, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, channelID, receipts[0].ChannelId)
+	})
+
+	t.Run("source", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{Sources: []string{model.ReadReceiptSourceExplicitClick, model.ReadReceiptSourceChannelOpen}, PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		assert.Equal(t, otherPost.Id, receipts[0].PostId)
+		assert.Equal(t, model.ReadReceiptSourceExplicitClick, receipts[0].Source)
+
+		receipts, err = ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{Sources: []string{model.ReadReceiptSourceAutoScroll}, PerPage: 10})
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+	})
+
+	t.Run("keyset pagination walks every receipt once", func(t *testing.T) {
+		var (
+			cursor model.ReadReceiptCursor
+			seen   = map[string]bool{}
+		)
+		for {
+			receipts, err := ss.PostReadReceipt().GetReadReceiptsForUser(userID, model.GetReadReceiptsForUserOptions{Cursor: cursor, PerPage: 1})
+			require.NoError(t, err)
+			if len(receipts) == 0 {
+				break
+			}
+			require.False(t, seen[receipts[0].PostId])
+			seen[receipts[0].PostId] = true
+			cursor = model.ReadReceiptCursor{ReadAt: receipts[0].ReadAt, PostId: receipts[0].PostId}
+		}
+		require.Len(t, seen, 4)
+	})
+}
+
+func testPostReadReceiptStoreGetForSession(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	userID := model.NewId()
+	sessionID := model.NewId()
+	otherSessionID := model.NewId()
+
+	var posts []*model.Post
+	for i := range 3 {
+		post := savePostForReadReceipts(t, rctx, ss, channelID)
+		sid := sessionID
+		if i == 1 {
+			sid = otherSessionID
+		}
+		_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: channelID, ReadAt: int64(1000 + i), SessionId: sid})
+		require.NoError(t, err)
+		posts = append(posts, post)
+	}
+
+	receipts, err := ss.PostReadReceipt().GetReadReceiptsForSession(sessionID, model.GetReadReceiptsForUserOptions{PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	assert.Equal(t, posts[2].Id, receipts[0].PostId)
+	assert.Equal(t, posts[0].Id, receipts[1].PostId)
+	assert.Equal(t, sessionID, receipts[0].SessionId)
+
+	receipts, err = ss.PostReadReceipt().GetReadReceiptsForSession(sessionID, model.GetReadReceiptsForUserOptions{
+		Cursor:  model.ReadReceiptCursor{ReadAt: receipts[0].ReadAt, PostId: receipts[0].PostId},
+		PerPage: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, posts[0].Id, receipts[0].PostId)
+
+	receipts, err = ss.PostReadReceipt().GetReadReceiptsForSession(model.NewId(), model.GetReadReceiptsForUserOptions{PerPage: 10})
+	require.NoError(t, err)
+	assert.Empty(t, receipts)
+}
+
+func testPostReadReceiptStoreGetPage(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	saved := append(MarkPostsAsRead(t, ss, userID, 1000, post1, post2), MarkPostsAsRead(t, ss, otherUserID, 2000, post1)...)
+
+	walk := func() []*model.PostReadReceipt {
+		var cursor model.ReadReceiptsPageCursor
+		var walked []*model.PostReadReceipt
+		for {
+			receipts, err := ss.PostReadReceipt().GetReadReceiptsPage(cursor, 100)
+			require.NoError(t, err)
+			for _, receipt := range receipts {
+				if receipt.ChannelId == channelID {
+					walked = append(walked, receipt)
+				}
+			}
+			if len(receipts) < 100 {
+				return walked
+			}
+			last := receipts[len(receipts)-1]
+			cursor = model.ReadReceiptsPageCursor{PostId: last.PostId, UserId: last.UserId}
+		}
+	}
+
+	t.Run("pages walk every receipt once", func(t *testing.T) {
+		assert.ElementsMatch(t, saved, walk())
+	})
+
+	t.Run("delete removes the given receipts only", func(t *testing.T) {
+		deleted, err := ss.PostReadReceipt().DeleteReadReceipts([]*model.PostReadReceipt{
+			{PostId: post1.Id, UserId: userID},
+			{PostId: post2.Id, UserId: userID},
+			{PostId: post2.Id, UserId: otherUserID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		remaining := walk()
+		require.Len(t, remaining, 1)
+		assert.Equal(t, otherUserID, remaining[0].UserId)
+
+		deleted, err = ss.PostReadReceipt().DeleteReadReceipts(nil)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+}
+
+func testPostReadReceiptStoreGetExpiredCounts(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	post := savePostForReadReceipts(t, rctx, ss, channelID)
+	otherPost := savePostForReadReceipts(t, rctx, ss, otherChannelID)
+	MarkPostsAsRead(t, ss, model.NewId(), 1000, post, otherPost)
+	MarkPostsAsRead(t, ss, model.NewId(), 1000, post)
+	MarkPostsAsRead(t, ss, model.NewId(), 5000, post)
+
+	preview, err := ss.PostReadReceipt().GetExpiredReadReceiptCounts(2000, nil, []string{channelID, otherChannelID}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), preview.ExpiredBefore)
+	assert.Equal(t, int64(3), preview.TotalCount)
+	assert.Equal(t, []*model.ReadReceiptCleanupChannelCount{
+		{ChannelId: channelID, Count: 2},
+		{ChannelId: otherChannelID, Count: 1},
+	}, preview.Channels)
+
+	preview, err = ss.PostReadReceipt().GetExpiredReadReceiptCounts(2000, nil, []string{channelID, otherChannelID}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), preview.TotalCount)
+	assert.Len(t, preview.Channels, 1)
+
+	preview, err = ss.PostReadReceipt().GetExpiredReadReceiptCounts(2000, []string{model.NewId()}, nil, 10)
+	require.NoError(t, err)
+	assert.Zero(t, preview.TotalCount)
+	assert.Empty(t, preview.Channels)
+}
+
+func testPostReadReceiptStoreGetMembersReadActivity(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	saveMember := func() string {
+		user, err := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: "member" + model.NewId(),
+		})
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:   channelID,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+
+		return user.Id
+	}
+
+	active := saveMember()
+	idle := saveMember()
+	neverRead := saveMember()
+
+	oldPost := savePostForReadReceipts(t, rctx, ss, channelID)
+	newPost := savePostForReadReceipts(t, rctx, ss, channelID)
+	otherPost := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	MarkPostsAsRead(t, ss, active, 1000, oldPost)
+	MarkPostsAsRead(t, ss, active, 5000, newPost, otherPost)
+	MarkPostsAsRead(t, ss, idle, 2000, oldPost)
+
+	activity, err := ss.PostReadReceipt().GetMembersReadActivity(channelID, 3000, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ChannelMemberReadActivity{
+		{UserId: neverRead},
+		{UserId: idle, LastPostReadAt: model.NewPointer(int64(2000))},
+		{UserId: active, LastPostReadAt: model.NewPointer(int64(5000)), PostsRead: 1},
+	}, activity)
+
+	activity, err = ss.PostReadReceipt().GetMembersReadActivity(channelID, 0, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	assert.Equal(t, idle, activity[0].UserId)
+	assert.Equal(t, int64(1), activity[0].PostsRead)
+}
+
+func testPostReadReceiptStoreGetDistinctReaderCounts(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	reader1 := model.NewId()
+	reader2 := model.NewId()
+
+	day1 := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	day2 := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC).UnixMilli()
+	day3 := time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	otherPost := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	MarkPostsAsRead(t, ss, reader1, day1, post1)
+	MarkPostsAsRead(t, ss, reader1, day1+1000, post2, otherPost)
+	MarkPostsAsRead(t, ss, reader2, day2-1, post1)
+	MarkPostsAsRead(t, ss, reader2, day2, post2)
+	MarkPostsAsRead(t, ss, model.NewId(), day3, post1)
+
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post1.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: day2, DeviceType: model.ReadReceiptDeviceTypeBot})
+	require.NoError(t, err)
+
+	counts, err := ss.PostReadReceipt().GetDistinctReaderCounts(channelID, "2024-03-01", "2024-03-02")
+	require.NoError(t, err)
+	assert.Equal(t, []*model.DailyReaderCount{
+		{Day: "2024-03-01", ReaderCount: 2},
+		{Day: "2024-03-02", ReaderCount: 1},
+	}, counts)
+
+	counts, err = ss.PostReadReceipt().GetDistinctReaderCounts(channelID, "2024-03-04", "2024-03-05")
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	_, err = ss.PostReadReceipt().GetDistinctReaderCounts(channelID, "March 1st", "2024-03-05")
+	require.Error(t, err)
+}
+
+func testPostReadReceiptStoreGetAnnouncementReadCounts(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	saveChannel := func(teamID string, members int) *model.Channel {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Announcements",
+			Name:        NewTestID(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+
+		for range members {
+			user, err := ss.User().Save(rctx, &model.User{
+				Email:    MakeEmail(),
+				Username: "member" + model.NewId(),
+			})
+			require.NoError(t, err)
+
+			_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+				ChannelId:   channel.Id,
+				UserId:      user.Id,
+				NotifyProps: model.GetDefaultChannelNotifyProps(),
+			})
+			require.NoError(t, err)
+		}
+
+		return channel
+	}
+
+	channel1 := saveChannel(teamID, 2)
+	channel2 := saveChannel(teamID, 3)
+	otherTeamChannel := saveChannel(model.NewId(), 1)
+
+	post1 := savePostForReadReceipts(t, rctx, ss, channel1.Id)
+	post2 := savePostForReadReceipts(t, rctx, ss, channel2.Id)
+	otherTeamPost := savePostForReadReceipts(t, rctx, ss, otherTeamChannel.Id)
+	MarkPostsAsRead(t, ss, model.NewId(), 1000, post1, otherTeamPost)
+	MarkPostsAsRead(t, ss, model.NewId(), 2000, post1)
+
+	counts, err := ss.PostReadReceipt().GetAnnouncementReadCounts(teamID, []string{post1.Id, post2.Id, otherTeamPost.Id})
+	require.NoError(t, err)
+
+	expected := []*model.ReadReceiptBroadcastChannelSummary{
+		{ChannelId: channel1.Id, PostId: post1.Id, ReadCount: 2, TotalMembers: 2},
+		{ChannelId: channel2.Id, PostId: post2.Id, ReadCount: 0, TotalMembers: 3},
+	}
+	assert.ElementsMatch(t, expected, counts)
+}
+
+func testPostReadReceiptStoreIsPostReadByAnyone(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	read, count, err := ss.PostReadReceipt().IsPostReadByAnyone(post.Id, false)
+	require.NoError(t, err)
+	assert.False(t, read)
+	assert.Equal(t, int64(0), count)
+
+	// The author reading their own post does not count as read, but is counted.
+	MarkPostsAsRead(t, ss, post.UserId, 1000, post)
+	read, count, err = ss.PostReadReceipt().IsPostReadByAnyone(post.Id, false)
+	require.NoError(t, err)
+	assert.False(t, read)
+	assert.Equal(t, int64(1), count)
+
+	MarkPostsAsRead(t, ss, model.NewId(), 2000, post)
+	read, count, err = ss.PostReadReceipt().IsPostReadByAnyone(post.Id, true)
+	require.NoError(t, err)
+	assert.True(t, read)
+	assert.Equal(t, int64(2), count)
+}
+
+func testPostReadReceiptStoreGetUnreadUsersForPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	saveMember := func(username string) *model.User {
+		user, err := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: username + model.NewId(),
+		})
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:   channelID,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+
+		return user
+	}
+
+	author := saveMember("author")
+	reader := saveMember("reader")
+	unreadB := saveMember("unreadb")
+	unreadA := saveMember("unreada")
+
+	bot := saveMember("bot")
+	_, err := ss.Bot().Save(&model.Bot{UserId: bot.Id, Username: bot.Username, OwnerId: author.Id})
+	require.NoError(t, err)
+
+	deactivated := saveMember("deactivated")
+	deactivated.DeleteAt = model.GetMillis()
+	_, err = ss.User().Update(rctx, deactivated, true)
+	require.NoError(t, err)
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    author.Id,
+		Message:   NewTestID(),
+	})
+	require.NoError(t, err)
+	MarkPostsAsRead(t, ss, reader.Id, 1000, post)
+
+	users, err := ss.PostReadReceipt().GetUnreadUsersForPost(post.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, unreadA.Id, users[0].Id)
+	assert.Equal(t, unreadB.Id, users[1].Id)
+
+	users, err = ss.PostReadReceipt().GetUnreadUsersForPost(post.Id, 1)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, unreadA.Id, users[0].Id)
+}
+
+func testPostReadReceiptStoreGetCaughtUpUsersForChannel(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	saveMember := func(username string) *model.User {
+		user, err := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: username + model.NewId(),
+		})
+		require.NoError(t, err)
+
+		_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+			ChannelId:   channelID,
+			UserId:      user.Id,
+			NotifyProps: model.GetDefaultChannelNotifyProps(),
+		})
+		require.NoError(t, err)
+
+		return user
+	}
+
+	author := saveMember("author")
+	reader := saveMember("reader")
+	behind := saveMember("behind")
+	saveMember("unread")
+
+	older, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: author.Id, Message: NewTestID()})
+	require.NoError(t, err)
+	latest, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: author.Id, Message: NewTestID(), CreateAt: older.CreateAt + 1})
+	require.NoError(t, err)
+	_, err = ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: author.Id, RootId: latest.Id, Message: NewTestID(), CreateAt: latest.CreateAt + 1})
+	require.NoError(t, err)
+
+	MarkPostsAsRead(t, ss, reader.Id, 1000, older, latest)
+	MarkPostsAsRead(t, ss, behind.Id, 1000, older)
+
+	users, err := ss.PostReadReceipt().GetCaughtUpUsersForChannel(channelID, 10)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, author.Id, users[0].Id)
+	assert.Equal(t, reader.Id, users[1].Id)
+
+	users, err = ss.PostReadReceipt().GetCaughtUpUsersForChannel(model.NewId(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func testPostReadReceiptStoreGetUnreadDirectMessages(t *testing.T, rctx request.CTX, ss store.Store) {
+	saveUser := func() *model.User {
+		user, err := ss.User().Save(rctx, &model.User{
+			Email:    MakeEmail(),
+			Username: "u" + model.NewId(),
+		})
+		require.NoError(t, err)
+		return user
+	}
+
+	sender := saveUser()
+	recipient := saveUser()
+	channel, err := ss.Channel().CreateDirectChannel(rctx, sender, recipient)
+	require.NoError(t, err)
+
+	savePost := func(createAt int64, postType string) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channel.Id,
+			UserId:    sender.Id,
+			Message:   NewTestID(),
+			CreateAt:  createAt,
+			Type:      postType,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	base := model.GetMillis() - 10000
+	read := savePost(base, model.PostTypeDefault)
+	savePost(base+1, model.PostTypeDefault)
+	savePost(base+2, model.PostTypeJoinChannel)
+	savePost(base+3, model.PostTypeDefault)
+	MarkPostsAsRead(t, ss, recipient.Id, base+10, read)
+
+	t.Run("counts the unread posts written by others before the cutoff", func(t *testing.T) {
+		count, err := ss.PostReadReceipt().GetUnreadPostsCount(channel.Id, recipient.Id, base+3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = ss.PostReadReceipt().GetUnreadPostsCount(channel.Id, recipient.Id, base+4)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		count, err = ss.PostReadReceipt().GetUnreadPostsCount(channel.Id, sender.Id, base+4)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("lists the recipients with unread posts in the window", func(t *testing.T) {
+		channels, err := ss.PostReadReceipt().GetUnreadDirectChannels(base+1, base+2)
+		require.NoError(t, err)
+		assert.Contains(t, channels, &model.UnreadDirectChannel{ChannelId: channel.Id, UserId: recipient.Id})
+		for _, unread := range channels {
+			assert.NotEqual(t, sender.Id, unread.UserId)
+		}
+
+		channels, err = ss.PostReadReceipt().GetUnreadDirectChannels(base, base+1)
+		require.NoError(t, err)
+		assert.NotContains(t, channels, &model.UnreadDirectChannel{ChannelId: channel.Id, UserId: recipient.Id})
+
+		channels, err = ss.PostReadReceipt().GetUnreadDirectChannels(base+2, base+3)
+		require.NoError(t, err)
+		assert.NotContains(t, channels, &model.UnreadDirectChannel{ChannelId: channel.Id, UserId: recipient.Id})
+	})
+}
+
+func testPostReadReceiptStoreDeleteForPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId})
+	require.NoError(t, err)
+	err = ss.PostReadReceipt().UpdateReadReceiptSummary(&model.PostReadReceiptSummary{PostId: post.Id, ChannelId: post.ChannelId, ReadCount: 1, LastUpdated: model.GetMillis()})
+	require.NoError(t, err)
+
+	err = ss.PostReadReceipt().DeleteReadReceiptsForPost(post.Id)
+	require.NoError(t, err)
+
+	receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+
+	_, err = ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPostReadReceiptStorePermanentDeleteByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	for _, id := range []string{userID, otherUserID} {
+		receipt := &model.PostReadReceipt{PostId: post.Id, UserId: id, ChannelId: post.ChannelId, DeviceId: "laptop", ReadAt: model.GetMillis()}
+		_, err := ss.PostReadReceipt().SaveReadReceipt(receipt)
+		require.NoError(t, err)
+		require.NoError(t, ss.PostReadReceipt().SaveReadDevices([]*model.PostReadReceipt{receipt}))
+	}
+
+	err := ss.PostReadReceipt().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+
+	_, err = ss.PostReadReceipt().GetReadReceipt(post.Id, userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+	devices, err := ss.PostReadReceipt().GetReadDevicesForPostUser(post.Id, userID)
+	require.NoError(t, err)
+	require.Empty(t, devices)
+
+	_, err = ss.PostReadReceipt().GetReadReceipt(post.Id, otherUserID)
+	require.NoError(t, err)
+	devices, err = ss.PostReadReceipt().GetReadDevicesForPostUser(post.Id, otherUserID)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+}
+
+func testPostReadReceiptStoreHealthCheckReceipt(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	userID := model.NewId()
+
+	for _, readAt := range []int64{1000, 2000} {
+		saved, err := ss.PostReadReceipt().SaveHealthCheckReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: post.ChannelId, ReadAt: readAt})
+		require.NoError(t, err)
+		require.Equal(t, readAt, saved)
+	}
+
+	changes, err := ss.PostReadReceipt().GetReadReceiptChanges(post.ChannelId, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	err = ss.PostReadReceipt().DeleteHealthCheckReceipt(post.Id, userID)
+	require.NoError(t, err)
+	_, err = ss.PostReadReceipt().GetReadReceipt(post.Id, userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testPostReadReceiptStorePostDeletion(t *testing.T, rctx request.CTX, ss store.Store) {
+	root := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	reply, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: root.ChannelId,
+		UserId:    model.NewId(),
+		RootId:    root.Id,
+		Message:   NewTestID(),
+	})
+	require.NoError(t, err)
+	userID := model.NewId()
+
+	_, err = ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: root.Id, UserId: userID, ChannelId: root.ChannelId},
+		{PostId: reply.Id, UserId: userID, ChannelId: root.ChannelId},
+	})
+	require.NoError(t, err)
+
+	t.Run("deleting a post removes the receipts of the thread", func(t *testing.T) {
+		err = ss.Post().Delete(rctx, root.Id, model.GetMillis(), userID)
+		require.NoError(t, err)
+
+		for _, postID := range []string{root.Id, reply.Id} {
+			receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(postID, "")
+			require.NoError(t, err)
+			require.Empty(t, receipts)
+		}
+	})
+
+	t.Run("receipts for a deleted post are not saved", func(t *testing.T) {
+		_, err = ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: root.Id, UserId: model.NewId(), ChannelId: root.ChannelId})
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+			{PostId: reply.Id, UserId: model.NewId(), ChannelId: root.ChannelId},
+		})
+		require.NoError(t, err)
+		require.Empty(t, saved)
+	})
+}
+
+func testPostReadReceiptStorePostDeletionRace(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Saving may legitimately fail with not found once the post is deleted.
+			_, _ = ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId})
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, ss.Post().Delete(rctx, post.Id, model.GetMillis(), model.NewId()))
+	}()
+	wg.Wait()
+
+	receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+	require.NoError(t, err)
+	require.Empty(t, receipts, "no receipt may outlive its post")
+}
+
+func testPostReadReceiptStoreExtremes(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	t.Run("nobody read the post", func(t *testing.T) {
+		extremes, err := ss.PostReadReceipt().GetReadReceiptExtremes(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, post.Id, extremes.PostId)
+		assert.Nil(t, extremes.FirstReader)
+		assert.Nil(t, extremes.LastReader)
+	})
+
+	first, middle, last := model.NewId(), model.NewId(), model.NewId()
+	_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post.Id, UserId: middle, ChannelId: post.ChannelId, ReadAt: 2000},
+		{PostId: post.Id, UserId: last, ChannelId: post.ChannelId, ReadAt: 3000},
+		{PostId: post.Id, UserId: first, ChannelId: post.ChannelId, ReadAt: 1000},
+		{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId, ReadAt: 4000, DeviceType: model.ReadReceiptDeviceTypeBot},
+	})
+	require.NoError(t, err)
+
+	t.Run("bots are ignored", func(t *testing.T) {
+		extremes, err := ss.PostReadReceipt().GetReadReceiptExtremes(post.Id)
+		require.NoError(t, err)
+		require.NotNil(t, extremes.FirstReader)
+		require.NotNil(t, extremes.LastReader)
+		assert.Equal(t, &model.ReadReceiptReader{UserId: first, ReadAt: 1000}, extremes.FirstReader)
+		assert.Equal(t, &model.ReadReceiptReader{UserId: last, ReadAt: 3000}, extremes.LastReader)
+	})
+
+	t.Run("single reader", func(t *testing.T) {
+		other := savePostForReadReceipts(t, rctx, ss, model.NewId())
+		MarkPostsAsRead(t, ss, first, 1500, other)
+
+		extremes, err := ss.PostReadReceipt().GetReadReceiptExtremes(other.Id)
+		require.NoError(t, err)
+		assert.Equal(t, extremes.FirstReader, extremes.LastReader)
+	})
+}
+
+func testPostReadReceiptStoreSummary(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+
+	_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId, ReadAt: 1000},
+		{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId, ReadAt: 3000},
+		{PostId: post.Id, UserId: model.NewId(), ChannelId: post.ChannelId, ReadAt: 5000, DeviceType: model.ReadReceiptDeviceTypeBot},
+	})
+	require.NoError(t, err)
+
+	t.Run("compute excludes bots from the human counters", func(t *testing.T) {
+		summary, err := ss.PostReadReceipt().ComputeReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, post.Id, summary.PostId)
+		assert.Equal(t, int64(2), summary.ReadCount)
+		assert.Equal(t, int64(1), summary.BotReadCount)
+		assert.Equal(t, int64(3000), summary.LastReadAt)
+	})
+
+	t.Run("compute for a post without receipts", func(t *testing.T) {
+		summary, err := ss.PostReadReceipt().ComputeReadReceiptSummary(model.NewId())
+		require.NoError(t, err)
+		assert.Zero(t, summary.ReadCount)
+		assert.Zero(t, summary.BotReadCount)
+	})
+
+	t.Run("update and get", func(t *testing.T) {
+		summary := &model.PostReadReceiptSummary{PostId: post.Id, ChannelId: post.ChannelId, ReadCount: 2, BotReadCount: 1, LastReadAt: 3000, LastUpdated: 10}
+		require.NoError(t, ss.PostReadReceipt().UpdateReadReceiptSummary(summary))
+		assert.Equal(t, int64(1), summary.Version)
+
+		summary.ReadCount = 3
+		summary.LastUpdated = 20
+		require.NoError(t, ss.PostReadReceipt().UpdateReadReceiptSummary(summary))
+		assert.Equal(t, int64(2), summary.Version)
+
+		stored, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, summary, stored)
+
+		summaries, err := ss.PostReadReceipt().GetReadReceiptSummariesForChannel(post.ChannelId, 10)
+		require.NoError(t, err)
+		require.Len(t, summaries, 1)
+
+		summaries, err = ss.PostReadReceipt().GetReadReceiptSummariesForChannel(post.ChannelId, 20)
+		require.NoError(t, err)
+		require.Empty(t, summaries)
+	})
+
+	t.Run("stale and concurrent updates are rejected", func(t *testing.T) {
+		stored, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+
+		older := *stored
+		older.LastUpdated = stored.LastUpdated - 1
+		older.ReadCount = 100
+		err = ss.PostReadReceipt().UpdateReadReceiptSummary(&older)
+		var conflictErr *store.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+
+		first := *stored
+		second := *stored
+		first.LastUpdated = stored.LastUpdated + 1
+		second.LastUpdated = stored.LastUpdated + 2
+		require.NoError(t, ss.PostReadReceipt().UpdateReadReceiptSummary(&first))
+		err = ss.PostReadReceipt().UpdateReadReceiptSummary(&second)
+		require.ErrorAs(t, err, &conflictErr)
+
+		current, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, first.LastUpdated, current.LastUpdated)
+		assert.Equal(t, stored.Version+1, current.Version)
+	})
+
+	t.Run("batched updates skip stale summaries", func(t *testing.T) {
+		stored, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+
+		stale := *stored
+		stale.LastUpdated = stored.LastUpdated - 1
+		newPost := savePostForReadReceipts(t, rctx, ss, post.ChannelId)
+		fresh := &model.PostReadReceiptSummary{PostId: newPost.Id, ChannelId: newPost.ChannelId, ReadCount: 1, LastUpdated: 10}
+
+		updated, err := ss.PostReadReceipt().UpdateReadReceiptSummaries([]*model.PostReadReceiptSummary{&stale, fresh})
+		require.NoError(t, err)
+		require.Equal(t, []*model.PostReadReceiptSummary{fresh}, updated)
+		assert.Equal(t, int64(1), fresh.Version)
+
+		current, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, stored, current)
+
+		next := *stored
+		next.LastUpdated = stored.LastUpdated + 1
+		fresh.LastUpdated = 20
+		updated, err = ss.PostReadReceipt().UpdateReadReceiptSummaries([]*model.PostReadReceiptSummary{&next, fresh})
+		require.NoError(t, err)
+		require.Len(t, updated, 2)
+		assert.Equal(t, stored.Version+1, next.Version)
+		assert.Equal(t, int64(2), fresh.Version)
+
+		fromMaster, err := ss.PostReadReceipt().GetReadReceiptSummariesForPosts([]string{post.Id, newPost.Id}, true)
+		require.NoError(t, err)
+		assert.Len(t, fromMaster, 2)
+	})
+
+	t.Run("saves report first reads and deletes uncount them", func(t *testing.T) {
+		other := savePostForReadReceipts(t, rctx, ss, post.ChannelId)
+		userID := model.NewId()
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{{PostId: other.Id, UserId: userID, ChannelId: other.ChannelId, ReadAt: 1000}})
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.True(t, saved[0].FirstRead)
+
+		saved, err = ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{{PostId: other.Id, UserId: userID, ChannelId: other.ChannelId, ReadAt: 2000}})
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.False(t, saved[0].FirstRead)
+
+		err = ss.PostReadReceipt().UpdateReadReceiptSummary(&model.PostReadReceiptSummary{PostId: other.Id, ChannelId: other.ChannelId, ReadCount: 1, LastUpdated: model.GetMillis()})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(other.Id, userID))
+		require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(other.Id, userID))
+
+		summary, err := ss.PostReadReceipt().GetReadReceiptSummary(other.Id)
+		require.NoError(t, err)
+		assert.Zero(t, summary.ReadCount)
+	})
+}
+
+func testPostReadReceiptStoreReadCountsForLatestPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	posts := make([]*model.Post, 3)
+	for i := range posts {
+		var err error
+		posts[i], err = ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   NewTestID(),
+			CreateAt:  int64(1000 + i),
+		})
+		require.NoError(t, err)
+	}
+	oldest, read, unread := posts[0], posts[1], posts[2]
+
+	_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: oldest.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1000},
+		{PostId: read.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1000},
+		{PostId: read.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1000},
+		{PostId: read.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1000, DeviceType: model.ReadReceiptDeviceTypeBot},
+	})
+	require.NoError(t, err)
+
+	counts, err := ss.PostReadReceipt().GetReadCountsForLatestPosts(channelID, 2)
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+
+	assert.Equal(t, &model.PostReadCount{PostId: unread.Id}, counts[0])
+	assert.Equal(t, &model.PostReadCount{PostId: read.Id, ReadCount: 2, BotReadCount: 1}, counts[1])
+}
+
+func testPostReadReceiptStoreRefreshReadReceiptStats(t *testing.T, rctx request.CTX, ss store.Store) {
+	user, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "readstats" + model.NewId(),
+	})
+	require.NoError(t, err)
+
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post3 := savePostForReadReceipts(t, rctx, ss, otherChannelID)
+
+	now := time.Now()
+	MarkPostsAsRead(t, ss, user.Id, now.UnixMilli(), post1, post2)
+	MarkPostsAsRead(t, ss, user.Id, now.AddDate(0, 0, -2).UnixMilli(), post3)
+
+	require.NoError(t, ss.PostReadReceipt().RefreshReadReceiptStats())
+
+	report := func(username string, startAt int64) *model.UserReportQuery {
+		t.Helper()
+		userReport, err := ss.User().GetUserReport(&model.UserReportOptions{
+			ReportingBaseOptions: model.ReportingBaseOptions{
+				SortColumn: "Username",
+				PageSize:   10,
+				StartAt:    startAt,
+			},
+			SearchTerm: username,
+		})
+		require.NoError(t, err)
+		require.Len(t, userReport, 1)
+		return userReport[0]
+	}
+
+	userReport := report(user.Username, 0)
+	require.NotNil(t, userReport.PostsReadPerDay)
+	assert.Equal(t, 1.5, *userReport.PostsReadPerDay)
+	require.NotNil(t, userReport.ChannelsRead)
+	assert.Equal(t, 2, *userReport.ChannelsRead)
+
+	userReport = report(user.Username, now.AddDate(0, 0, -1).UnixMilli())
+	assert.Equal(t, 2.0, *userReport.PostsReadPerDay)
+	assert.Equal(t, 1, *userReport.ChannelsRead)
+
+	t.Run("days follow the timezone of the user", func(t *testing.T) {
+		// 22:00 and 01:00 UTC on consecutive days are the same morning in Tokyo.
+		firstReadAt := time.Date(2024, time.January, 1, 22, 0, 0, 0, time.UTC).UnixMilli()
+		secondReadAt := time.Date(2024, time.January, 2, 1, 0, 0, 0, time.UTC).UnixMilli()
+
+		for timezone, expectedPerDay := range map[string]float64{
+			"":           1,
+			"Asia/Tokyo": 2,
+			"Not/A_Zone": 1,
+		} {
+			reader, err := ss.User().Save(rctx, &model.User{
+				Email:    MakeEmail(),
+				Username: "readstats" + model.NewId(),
+				Timezone: model.StringMap{"useAutomaticTimezone": "false", "manualTimezone": timezone},
+			})
+			require.NoError(t, err)
+
+			MarkPostsAsRead(t, ss, reader.Id, firstReadAt, post1)
+			MarkPostsAsRead(t, ss, reader.Id, secondReadAt, post2)
+			require.NoError(t, ss.PostReadReceipt().RefreshReadReceiptStats())
+
+			userReport := report(reader.Username, 0)
+			require.NotNil(t, userReport.PostsReadPerDay, timezone)
+			assert.Equal(t, expectedPerDay, *userReport.PostsReadPerDay, timezone)
+		}
+	})
+}
+
+func testPostReadReceiptStoreChain(t *testing.T, rctx request.CTX, ss store.Store) {
+	key := []byte("integrity key")
+	channelID := model.NewId()
+
+	_, err := ss.PostReadReceipt().GetReadReceiptChainHead(channelID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	var head *model.ReadReceiptChainEntry
+	entries := make([]*model.ReadReceiptChainEntry, 0, 3)
+	for i := range 3 {
+		entry := &model.ReadReceiptChainEntry{
+			ChannelId: channelID,
+			PostId:    model.NewId(),
+			UserId:    model.NewId(),
+			ReadAt:    int64(1000 + i),
+		}
+		entry.Link(key, head)
+		entries = append(entries, entry)
+		head = entry
+	}
+	require.NoError(t, ss.PostReadReceipt().AppendReadReceiptChain(entries[:2]))
+	require.NoError(t, ss.PostReadReceipt().AppendReadReceiptChain(entries[2:]))
+
+	t.Run("head is the last entry", func(t *testing.T) {
+		got, err := ss.PostReadReceipt().GetReadReceiptChainHead(channelID)
+		require.NoError(t, err)
+		assert.Equal(t, entries[2], got)
+	})
+
+	t.Run("appending after a stale head conflicts", func(t *testing.T) {
+		stale := &model.ReadReceiptChainEntry{ChannelId: channelID, PostId: model.NewId(), UserId: model.NewId(), ReadAt: 2000}
+		stale.Link(key, entries[1])
+
+		err := ss.PostReadReceipt().AppendReadReceiptChain([]*model.ReadReceiptChainEntry{stale})
+		var cErr *store.ErrConflict
+		require.ErrorAs(t, err, &cErr)
+	})
+
+	t.Run("pages in order", func(t *testing.T) {
+		page, err := ss.PostReadReceipt().GetReadReceiptChain(channelID, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, entries[:2], page)
+
+		page, err = ss.PostReadReceipt().GetReadReceiptChain(channelID, page[1].Sequence, 2)
+		require.NoError(t, err)
+		assert.Equal(t, entries[2:], page)
+
+		page, err = ss.PostReadReceipt().GetReadReceiptChain(model.NewId(), 0, 2)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+	})
+
+	t.Run("mismatches and pruning", func(t *testing.T) {
+		post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+		reader, other := model.NewId(), model.NewId()
+		_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+			{PostId: post.Id, UserId: reader, ChannelId: post.ChannelId, ReadAt: 1000},
+			{PostId: post.Id, UserId: other, ChannelId: post.ChannelId, ReadAt: 1000},
+		})
+		require.NoError(t, err)
+
+		var head *model.ReadReceiptChainEntry
+		chain := make([]*model.ReadReceiptChainEntry, 0, 3)
+		for _, read := range []struct {
+			userID string
+			readAt int64
+		}{{reader, 500}, {reader, 1000}, {other, 2000}} {
+			entry := &model.ReadReceiptChainEntry{ChannelId: post.ChannelId, PostId: post.Id, UserId: read.userID, ReadAt: read.readAt}
+			entry.Link(key, head)
+			chain = append(chain, entry)
+			head = entry
+		}
+		require.NoError(t, ss.PostReadReceipt().AppendReadReceiptChain(chain))
+
+		// Only the latest entry of a reader has to match its receipt.
+		mismatches, err := ss.PostReadReceipt().GetReadReceiptChainMismatches(post.ChannelId, 10)
+		require.NoError(t, err)
+		require.Equal(t, []*model.ReadReceiptChainEntry{chain[2]}, mismatches)
+
+		require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(post.Id, reader))
+
+		entries, err := ss.PostReadReceipt().GetReadReceiptChain(post.ChannelId, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.True(t, entries[0].IsPruned())
+		assert.True(t, entries[1].IsPruned())
+		assert.Equal(t, chain[1].Hash, entries[1].Hash)
+		assert.Equal(t, chain[2], entries[2])
+
+		require.NoError(t, ss.PostReadReceipt().PermanentDeleteByUser(other))
+
+		mismatches, err = ss.PostReadReceipt().GetReadReceiptChainMismatches(post.ChannelId, 10)
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+}
+
+func testPostReadReceiptStoreUpdateChannelWatermarkMode(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+	MarkPostsAsRead(t, ss, userID, 1000, post1, post2)
+
+	t.Run("at the limit", func(t *testing.T) {
+		switched, err := ss.PostReadReceipt().UpdateChannelWatermarkMode(channelID, 2, 1)
+		require.NoError(t, err)
+		assert.False(t, switched)
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		switched, err := ss.PostReadReceipt().UpdateChannelWatermarkMode(channelID, 1, 0)
+		require.NoError(t, err)
+		assert.True(t, switched)
+	})
+
+	t.Run("stays switched above the recovery count", func(t *testing.T) {
+		switched, err := ss.PostReadReceipt().UpdateChannelWatermarkMode(channelID, 10, 1)
+		require.NoError(t, err)
+		assert.True(t, switched)
+	})
+
+	t.Run("switches back at the recovery count", func(t *testing.T) {
+		require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(post2.Id, userID))
+
+		switched, err := ss.PostReadReceipt().UpdateChannelWatermarkMode(channelID, 1, 1)
+		require.NoError(t, err)
+		assert.False(t, switched)
+
+		// Back in the per-post mode, the channel is only switched again over the limit.
+		switched, err = ss.PostReadReceipt().UpdateChannelWatermarkMode(channelID, 1, 0)
+		require.NoError(t, err)
+		assert.False(t, switched)
+	})
+
+	t.Run("other channels are unaffected", func(t *testing.T) {
+		switched, err := ss.PostReadReceipt().UpdateChannelWatermarkMode(model.NewId(), 1, 0)
+		require.NoError(t, err)
+		assert.False(t, switched)
+	})
+}
+
+func testPostReadReceiptStoreChannelSettings(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	_, err := ss.PostReadReceipt().GetChannelSettings(channelID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	saved, err := ss.PostReadReceipt().SaveChannelSettings(&model.ReadReceiptChannelSettings{ChannelId: channelID})
+	require.NoError(t, err)
+	assert.NotZero(t, saved.UpdateAt)
+
+	settings, err := ss.PostReadReceipt().GetChannelSettings(channelID)
+	require.NoError(t, err)
+	assert.False(t, settings.BroadcastReceipts)
+
+	_, err = ss.PostReadReceipt().SaveChannelSettings(&model.ReadReceiptChannelSettings{ChannelId: channelID, BroadcastReceipts: true})
+	require.NoError(t, err)
+
+	settings, err = ss.PostReadReceipt().GetChannelSettings(channelID)
+	require.NoError(t, err)
+	assert.True(t, settings.BroadcastReceipts)
+	assert.False(t, settings.PinnedUnreadNotice)
+
+	_, err = ss.PostReadReceipt().SaveChannelSettings(&model.ReadReceiptChannelSettings{ChannelId: channelID, BroadcastReceipts: true, PinnedUnreadNotice: true})
+	require.NoError(t, err)
+
+	settings, err = ss.PostReadReceipt().GetChannelSettings(channelID)
+	require.NoError(t, err)
+	assert.True(t, settings.PinnedUnreadNotice)
+}
+
+func testPostReadReceiptStoreScrubUserReceipts(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	userID := model.NewId()
+	reactivatedUserID := model.NewId()
+	receipt := &model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: post.ChannelId, ReadAt: 1000, DeviceType: "desktop", DeviceId: "laptop", SessionId: model.NewId()}
+	_, err := ss.PostReadReceipt().SaveReadReceipt(receipt)
+	require.NoError(t, err)
+	require.NoError(t, ss.PostReadReceipt().SaveReadDevices([]*model.PostReadReceipt{receipt}))
+
+	require.NoError(t, ss.PostReadReceipt().FlagUserReceiptsForScrub(userID, 1000))
+	require.NoError(t, ss.PostReadReceipt().FlagUserReceiptsForScrub(reactivatedUserID, 1000))
+
+	t.Run("only users deactivated long enough are due", func(t *testing.T) {
+		userIDs, err := ss.PostReadReceipt().GetUsersDueForReceiptScrub(1000, 100)
+		require.NoError(t, err)
+		assert.NotContains(t, userIDs, userID)
+
+		userIDs, err = ss.PostReadReceipt().GetUsersDueForReceiptScrub(1001, 100)
+		require.NoError(t, err)
+		assert.Contains(t, userIDs, userID)
+		assert.Contains(t, userIDs, reactivatedUserID)
+	})
+
+	t.Run("unflagging cancels the scrub", func(t *testing.T) {
+		require.NoError(t, ss.PostReadReceipt().UnflagUserReceiptsForScrub(reactivatedUserID))
+
+		userIDs, err := ss.PostReadReceipt().GetUsersDueForReceiptScrub(1001, 100)
+		require.NoError(t, err)
+		assert.NotContains(t, userIDs, reactivatedUserID)
+	})
+
+	t.Run("scrubbing keeps the receipts without their device and session", func(t *testing.T) {
+		require.NoError(t, ss.PostReadReceipt().ScrubUserReceipts(userID))
+
+		got, err := ss.PostReadReceipt().GetReadReceipt(post.Id, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), got.ReadAt)
+		assert.Equal(t, "desktop", got.DeviceType)
+		assert.Empty(t, got.DeviceId)
+		assert.Empty(t, got.SessionId)
+
+		devices, err := ss.PostReadReceipt().GetReadDevicesForPostUser(post.Id, userID)
+		require.NoError(t, err)
+		assert.Empty(t, devices)
+
+		userIDs, err := ss.PostReadReceipt().GetUsersDueForReceiptScrub(1001, 100)
+		require.NoError(t, err)
+		assert.NotContains(t, userIDs, userID)
+	})
+}
+
+func testPostReadReceiptStoreGhostReads(t *testing.T, rctx request.CTX, ss store.Store) {
+	post := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	ghostUserID := model.NewId()
+	readerID := model.NewId()
+
+	saved, err := ss.PostReadReceipt().SaveGhostRead(&model.PostReadReceipt{PostId: post.Id, UserId: ghostUserID, ChannelId: post.ChannelId, ReadAt: 1000})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	t.Run("a ghost read is recorded once", func(t *testing.T) {
+		saved, err := ss.PostReadReceipt().SaveGhostRead(&model.PostReadReceipt{PostId: post.Id, UserId: ghostUserID, ChannelId: post.ChannelId, ReadAt: 2000})
+		require.NoError(t, err)
+		assert.False(t, saved)
+	})
+
+	t.Run("a receipt promotes the ghost read", func(t *testing.T) {
+		MarkPostsAsRead(t, ss, ghostUserID, 3000, post)
+		MarkPostsAsRead(t, ss, readerID, 3000, post)
+
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(post.Id, "")
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+		for _, receipt := range receipts {
+			assert.Equal(t, receipt.UserId == ghostUserID, receipt.ConvertedFromGhost, receipt.UserId)
+		}
+	})
+
+	t.Run("no ghost read once the post was read", func(t *testing.T) {
+		saved, err := ss.PostReadReceipt().SaveGhostRead(&model.PostReadReceipt{PostId: post.Id, UserId: readerID, ChannelId: post.ChannelId, ReadAt: 4000})
+		require.NoError(t, err)
+		assert.False(t, saved)
+	})
+}
+
+func testPostReadReceiptStoreThreadSummary(t *testing.T, rctx request.CTX, ss store.Store) {
+	root := savePostForReadReceipts(t, rctx, ss, model.NewId())
+	authorID := model.NewId()
+	readerID := model.NewId()
+	botID := model.NewId()
+
+	var replies []*model.Post
+	for range 3 {
+		reply, err := ss.Post().Save(rctx, &model.Post{ChannelId: root.ChannelId, RootId: root.Id, UserId: authorID, Message: NewTestID()})
+		require.NoError(t, err)
+		replies = append(replies, reply)
+	}
+	MarkPostsAsRead(t, ss, readerID, 1000, replies[0], replies[1])
+	_, err := ss.PostReadReceipt().SaveReadReceipt(&model.PostReadReceipt{PostId: replies[0].Id, UserId: botID, ChannelId: root.ChannelId, ReadAt: 1000, DeviceType: model.ReadReceiptDeviceTypeBot})
+	require.NoError(t, err)
+
+	t.Run("authors count their replies as read and bots are left out", func(t *testing.T) {
+		counts, err := ss.PostReadReceipt().ComputeThreadReadReceiptSummary(root.Id)
+		require.NoError(t, err)
+
+		repliesRead := make(map[string]int64)
+		for _, count := range counts {
+			repliesRead[count.UserId] = count.RepliesRead
+		}
+		assert.Equal(t, map[string]int64{authorID: 3, readerID: 2}, repliesRead)
+
+		require.NoError(t, ss.PostReadReceipt().SaveThreadReadReceiptSummary(root.Id, root.ChannelId, counts))
+		stored, err := ss.PostReadReceipt().GetThreadReadReceiptSummary(root.Id)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, counts, stored)
+	})
+
+	t.Run("saving replaces the stored counts", func(t *testing.T) {
+		require.NoError(t, ss.PostReadReceipt().SaveThreadReadReceiptSummary(root.Id, root.ChannelId, []*model.ThreadParticipantReadCount{{UserId: readerID, RepliesRead: 3}}))
+
+		stored, err := ss.PostReadReceipt().GetThreadReadReceiptSummary(root.Id)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.ThreadParticipantReadCount{{UserId: readerID, RepliesRead: 3}}, stored)
+	})
+
+	t.Run("deleting the root deletes the summary", func(t *testing.T) {
+		require.NoError(t, ss.Post().Delete(rctx, root.Id, model.GetMillis(), model.NewId()))
+
+		stored, err := ss.PostReadReceipt().GetThreadReadReceiptSummary(root.Id)
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
+
+func testPostReadReceiptStoreChannelArchiveRecommendations(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	saveChannel := func(readers, idlers int) *model.Channel {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      teamID,
+			DisplayName: "Channel",
+			Name:        NewTestID(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+		post := savePostForReadReceipts(t, rctx, ss, channel.Id)
+
+		for i := range readers + idlers {
+			user, err := ss.User().Save(rctx, &model.User{
+				Email:    MakeEmail(),
+				Username: "member" + model.NewId(),
+			})
+			require.NoError(t, err)
+
+			_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+				ChannelId:   channel.Id,
+				UserId:      user.Id,
+				NotifyProps: model.GetDefaultChannelNotifyProps(),
+			})
+			require.NoError(t, err)
+
+			if i < readers {
+				MarkPostsAsRead(t, ss, user.Id, model.GetMillis(), post)
+			}
+		}
+
+		return channel
+	}
+
+	quiet := saveChannel(1, 3)
+	saveChannel(2, 0)
+	require.NoError(t, ss.PostReadReceipt().RefreshReadReceiptStats())
+
+	recommendations, err := ss.PostReadReceipt().GetChannelArchiveRecommendations(&model.ChannelArchiveReportOptions{
+		TeamId:           teamID,
+		MaxReaderPercent: 30,
+		InactiveDays:     7,
+		PerPage:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, quiet.Id, recommendations[0].ChannelId)
+	assert.Equal(t, int64(4), recommendations[0].MemberCount)
+	assert.Equal(t, int64(1), recommendations[0].ReaderCount)
+	assert.Equal(t, 25.0, recommendations[0].ReaderPercent)
+
+	recommendations, err = ss.PostReadReceipt().GetChannelArchiveRecommendations(&model.ChannelArchiveReportOptions{
+		TeamId:           teamID,
+		MaxReaderPercent: 25,
+		InactiveDays:     7,
+		PerPage:          10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, recommendations)
+}
+
+func testPostReadReceiptStoreGetReadActivityBySession(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	session, err := ss.Session().Save(rctx, &model.Session{
+		UserId: userID,
+		Props: model.StringMap{
+			model.SessionPropPlatform: "Linux",
+			model.SessionPropOs:       "Linux",
+			model.SessionPropBrowser:  "Firefox/120.0",
+		},
+	})
+	require.NoError(t, err)
+	revokedSessionID := model.NewId()
+
+	channelID := model.NewId()
+	post1 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post2 := savePostForReadReceipts(t, rctx, ss, channelID)
+	post3 := savePostForReadReceipts(t, rctx, ss, channelID)
+	_, err = ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post1.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000, DeviceType: model.ReadReceiptDeviceTypeWeb, SessionId: session.Id},
+		{PostId: post2.Id, UserId: userID, ChannelId: channelID, ReadAt: 2000, DeviceType: model.ReadReceiptDeviceTypeWeb, SessionId: session.Id},
+		{PostId: post3.Id, UserId: userID, ChannelId: channelID, ReadAt: 3000, DeviceType: model.ReadReceiptDeviceTypeMobile, DeviceId: "device", SessionId: revokedSessionID},
+		{PostId: post3.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 3000, DeviceType: model.ReadReceiptDeviceTypeWeb, SessionId: session.Id},
+	})
+	require.NoError(t, err)
+
+	activity, err := ss.PostReadReceipt().GetReadActivityBySession(userID, 0, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.ReadReceiptSessionActivity{
+		{SessionId: revokedSessionID, DeviceType: model.ReadReceiptDeviceTypeMobile, DeviceId: "device", ReadCount: 1, FirstReadAt: 3000, LastReadAt: 3000},
+		{SessionId: session.Id, DeviceType: model.ReadReceiptDeviceTypeWeb, Platform: "Linux", Os: "Linux", Browser: "Firefox/120.0", SessionActive: true, ReadCount: 2, FirstReadAt: 1000, LastReadAt: 2000},
+	}, activity)
+
+	activity, err = ss.PostReadReceipt().GetReadActivityBySession(userID, 0, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	assert.Equal(t, session.Id, activity[0].SessionId)
+
+	activity, err = ss.PostReadReceipt().GetReadActivityBySession(userID, 2500, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+	assert.Equal(t, revokedSessionID, activity[0].SessionId)
+}
+
+func testPostReadReceiptStoreSaveBatchAcrossChannels(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	post := savePostForReadReceipts(t, rctx, ss, channelID)
+	otherPost := savePostForReadReceipts(t, rctx, ss, otherChannelID)
+	userID := model.NewId()
+
+	saved, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: post.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000},
+		{PostId: otherPost.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+
+	receipt, err := ss.PostReadReceipt().GetReadReceipt(post.Id, userID)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, receipt.ChannelId)
+
+	receipt, err = ss.PostReadReceipt().GetReadReceipt(otherPost.Id, userID)
+	require.NoError(t, err)
+	assert.Equal(t, otherChannelID, receipt.ChannelId, "the receipt takes the channel of its post")
+
+	t.Run("without overwrite", func(t *testing.T) {
+		otherUserID := model.NewId()
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsIfNotExist([]*model.PostReadReceipt{
+			{PostId: otherPost.Id, UserId: otherUserID, ChannelId: channelID, ReadAt: 1000},
+		})
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+		assert.Equal(t, otherChannelID, saved[0].ChannelId)
+	})
+}
+
+func testPostReadReceiptStoreSaveWithSummary(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	// Receipts saved before the post was summarized are counted too.
+	MarkPostsAsRead(t, ss, otherUserID, 1000, post)
+
+	saved, summary, previousReadCount, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: channelID, ReadAt: 2000})
+	require.NoError(t, err)
+	assert.Equal(t, post.Id, saved.PostId)
+	assert.Equal(t, int64(0), previousReadCount)
+	assert.Equal(t, int64(2), summary.ReadCount)
+	assert.Equal(t, int64(2000), summary.LastReadAt)
+
+	t.Run("first read increments the read count", func(t *testing.T) {
+		_, summary, previousReadCount, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: post.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1500})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), previousReadCount)
+		assert.Equal(t, int64(3), summary.ReadCount)
+		assert.Equal(t, int64(2000), summary.LastReadAt)
+	})
+
+	t.Run("read again keeps the read count", func(t *testing.T) {
+		_, summary, previousReadCount, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: post.Id, UserId: userID, ChannelId: channelID, ReadAt: 3000})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), previousReadCount)
+		assert.Equal(t, int64(3), summary.ReadCount)
+		assert.Equal(t, int64(3000), summary.LastReadAt)
+
+		stored, err := ss.PostReadReceipt().GetReadReceiptSummary(post.Id)
+		require.NoError(t, err)
+		assert.Equal(t, summary.Version, stored.Version)
+	})
+
+	t.Run("bot reads are counted apart", func(t *testing.T) {
+		_, summary, _, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: post.Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 4000, DeviceType: model.ReadReceiptDeviceTypeBot})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), summary.ReadCount)
+		assert.Equal(t, int64(1), summary.BotReadCount)
+		assert.Equal(t, int64(3000), summary.LastReadAt)
+	})
+
+	t.Run("deleted post", func(t *testing.T) {
+		deleted := savePostForReadReceipts(t, rctx, ss, channelID)
+		require.NoError(t, ss.Post().Delete(rctx, deleted.Id, model.GetMillis(), model.NewId()))
+
+		_, _, _, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: deleted.Id, UserId: userID, ChannelId: channelID, ReadAt: 1000})
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testPostReadReceiptStoreRotateHotIndexes(t *testing.T, rctx request.CTX, ss store.Store) {
+	user, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "hotreads" + model.NewId(),
+	})
+	require.NoError(t, err)
+
+	channelID := model.NewId()
+	recent := savePostForReadReceipts(t, rctx, ss, channelID)
+	old := savePostForReadReceipts(t, rctx, ss, channelID)
+	now := time.Now()
+	MarkPostsAsRead(t, ss, user.Id, now.UnixMilli(), recent)
+	MarkPostsAsRead(t, ss, user.Id, now.AddDate(0, 0, -60).UnixMilli(), old)
+
+	// Rotating twice also covers the swap with indexes of a previous rotation.
+	for range 2 {
+		require.NoError(t, ss.PostReadReceipt().RotateHotReadReceiptIndexes())
+
+		stats, err := ss.PostReadReceipt().GetTableStats()
+		require.NoError(t, err)
+		for _, table := range stats {
+			if table.Table == "postreadreceipts" {
+				require.Empty(t, table.MissingIndexes)
+				require.Contains(t, table.Indexes, "idx_postreadreceipts_hot_userid_readat")
+				require.Contains(t, table.Indexes, "idx_postreadreceipts_hot_channelid_readat")
+				require.NotContains(t, table.Indexes, "idx_postreadreceipts_hot_userid_readat_next")
+				require.NotContains(t, table.Indexes, "idx_postreadreceipts_hot_userid_readat_previous")
+			}
+		}
+	}
+
+	t.Run("queries within and beyond the hot window", func(t *testing.T) {
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForUser(user.Id, model.GetReadReceiptsForUserOptions{Since: now.AddDate(0, 0, -1).UnixMilli(), PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, recent.Id, receipts[0].PostId)
+
+		receipts, err = ss.PostReadReceipt().GetReadReceiptsForUser(user.Id, model.GetReadReceiptsForUserOptions{Since: now.AddDate(0, 0, -90).UnixMilli(), PerPage: 10})
+		require.NoError(t, err)
+		require.Len(t, receipts, 2)
+	})
+}
+
+func testPostReadReceiptStoreGetReadReceiptTeamUsage(t *testing.T, rctx request.CTX, ss store.Store) {
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Usage",
+		Name:        NewTestID(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	var posts []*model.Post
+	for range 2 {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      team.Id,
+			DisplayName: "Channel",
+			Name:        NewTestID(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err)
+		posts = append(posts, savePostForReadReceipts(t, rctx, ss, channel.Id))
+	}
+
+	userID := model.NewId()
+	MarkPostsAsRead(t, ss, userID, model.GetMillis(), posts...)
+	MarkPostsAsRead(t, ss, model.NewId(), model.GetMillis()-40*24*time.Hour.Milliseconds(), posts[0])
+	require.NoError(t, ss.PostReadReceipt().RefreshReadReceiptStats())
+
+	usage, err := ss.PostReadReceipt().GetReadReceiptTeamUsage()
+	require.NoError(t, err)
+
+	var teamUsage *model.ReadReceiptTeamUsage
+	for _, u := range usage {
+		if u.TeamId == team.Id {
+			teamUsage = u
+		}
+	}
+	require.NotNil(t, teamUsage)
+	assert.Equal(t, "Usage", teamUsage.TeamDisplayName)
+	assert.Equal(t, int64(3), teamUsage.ReceiptCount)
+	assert.Equal(t, int64(2), teamUsage.RecentReceiptCount)
+	assert.Equal(t, int64(2), teamUsage.ReaderCount)
+	assert.Equal(t, int64(2), teamUsage.ChannelCount)
+	assert.Positive(t, teamUsage.EstimatedBytes)
+}
+
+func testPostReadReceiptStoreChanges(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	post := savePostForReadReceipts(t, rctx, ss, channelID)
+	otherPost := savePostForReadReceipts(t, rctx, ss, channelID)
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	MarkPostsAsRead(t, ss, userID, 1000, post, otherPost)
+	_, _, _, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: post.Id, UserId: otherUserID, ChannelId: channelID, ReadAt: 2000})
+	require.NoError(t, err)
+	require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(post.Id, userID))
+	require.NoError(t, ss.PostReadReceipt().DeleteReadReceiptsForPost(otherPost.Id))
+
+	changes, err := ss.PostReadReceipt().GetReadReceiptChanges(channelID, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+
+	ops := make([]string, 0, len(changes))
+	for i, change := range changes {
+		assert.Equal(t, channelID, change.ChannelId)
+		if i > 0 {
+			assert.Greater(t, change.Sequence, changes[i-1].Sequence)
+		}
+		ops = append(ops, change.Op)
+	}
+	assert.Equal(t, []string{
+		model.ReadReceiptChangeOpSave,
+		model.ReadReceiptChangeOpSave,
+		model.ReadReceiptChangeOpSave,
+		model.ReadReceiptChangeOpDelete,
+		model.ReadReceiptChangeOpDelete,
+	}, ops)
+	assert.Equal(t, otherUserID, changes[2].UserId)
+	assert.Equal(t, post.Id, changes[3].PostId)
+	assert.Equal(t, userID, changes[3].UserId)
+	assert.Equal(t, otherPost.Id, changes[4].PostId)
+
+	t.Run("after a sequence", func(t *testing.T) {
+		tail, err := ss.PostReadReceipt().GetReadReceiptChanges(channelID, changes[2].Sequence, 100)
+		require.NoError(t, err)
+		require.Len(t, tail, 2)
+		assert.Equal(t, changes[3].Sequence, tail[0].Sequence)
+
+		page, err := ss.PostReadReceipt().GetReadReceiptChanges(channelID, 0, 2)
+		require.NoError(t, err)
+		assert.Len(t, page, 2)
+	})
+
+	t.Run("other channels are left out", func(t *testing.T) {
+		otherChannelPost := savePostForReadReceipts(t, rctx, ss, model.NewId())
+		MarkPostsAsRead(t, ss, userID, 3000, otherChannelPost)
+
+		tail, err := ss.PostReadReceipt().GetReadReceiptChanges(channelID, changes[4].Sequence, 100)
+		require.NoError(t, err)
+		assert.Empty(t, tail)
+
+		all, err := ss.PostReadReceipt().GetReadReceiptChanges("", changes[4].Sequence, 100)
+		require.NoError(t, err)
+		require.NotEmpty(t, all)
+		assert.Equal(t, otherChannelPost.Id, all[len(all)-1].PostId)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		deleted, err := ss.PostReadReceipt().DeleteReadReceiptChangesBefore(model.GetMillis()+1, 100000)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(len(changes)))
+
+		remaining, err := ss.PostReadReceipt().GetReadReceiptChanges(channelID, 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
+
+func testPostReadReceiptStoreCompaction(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	posts := []*model.Post{
+		savePostForReadReceipts(t, rctx, ss, channelID),
+		savePostForReadReceipts(t, rctx, ss, channelID),
+		savePostForReadReceipts(t, rctx, ss, channelID),
+	}
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	MarkPostsAsRead(t, ss, userID, 1000, posts[0], posts[1])
+	MarkPostsAsRead(t, ss, userID, 9000, posts[2])
+	MarkPostsAsRead(t, ss, otherUserID, 1500, posts[0])
+	_, err := ss.PostReadReceipt().SaveReadReceiptsBatch([]*model.PostReadReceipt{
+		{PostId: posts[0].Id, UserId: model.NewId(), ChannelId: channelID, ReadAt: 1000, DeviceType: model.ReadReceiptDeviceTypeBot},
+	})
+	require.NoError(t, err)
+
+	channelIDs, err := ss.PostReadReceipt().GetChannelsForReadReceiptCompaction(5, "", 10000)
+	require.NoError(t, err)
+	assert.Contains(t, channelIDs, channelID)
+	channelIDs, err = ss.PostReadReceipt().GetChannelsForReadReceiptCompaction(6, "", 10000)
+	require.NoError(t, err)
+	assert.NotContains(t, channelIDs, channelID)
+
+	compacted, err := ss.PostReadReceipt().CompactReadReceipts(channelID, 5000, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), compacted)
+
+	t.Run("compacted receipts are still read", func(t *testing.T) {
+		receipt, err := ss.PostReadReceipt().GetReadReceipt(posts[1].Id, userID)
+		require.NoError(t, err)
+		assert.True(t, receipt.Compacted)
+		assert.Equal(t, int64(1000), receipt.ReadAt)
+
+		receipts, err := ss.PostReadReceipt().GetReadReceiptsForPost(posts[0].Id, "")
+		require.NoError(t, err)
+		assert.Len(t, receipts, 3)
+
+		readPostIDs, err := ss.PostReadReceipt().GetReadPostIdsForUser(userID, []string{posts[0].Id, posts[1].Id, posts[2].Id})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{posts[0].Id, posts[1].Id, posts[2].Id}, readPostIDs)
+
+		summary, err := ss.PostReadReceipt().ComputeReadReceiptSummary(posts[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), summary.ReadCount)
+		assert.Equal(t, int64(1), summary.BotReadCount)
+	})
+
+	t.Run("reading a compacted post again is not a first read", func(t *testing.T) {
+		receipt, _, _, err := ss.PostReadReceipt().SaveReadReceiptWithSummary(&model.PostReadReceipt{PostId: posts[0].Id, UserId: userID, ChannelId: channelID, ReadAt: 10000})
+		require.NoError(t, err)
+		assert.False(t, receipt.FirstRead)
+
+		saved, err := ss.PostReadReceipt().SaveReadReceiptsUpToPost(&model.PostReadReceipt{PostId: posts[2].Id, UserId: otherUserID, ChannelId: channelID, ReadAt: 10000}, 100, false)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.ElementsMatch(t, []string{posts[1].Id, posts[2].Id}, []string{saved[0].PostId, saved[1].PostId})
+	})
+
+	t.Run("delete removes a compacted receipt", func(t *testing.T) {
+		require.NoError(t, ss.PostReadReceipt().DeleteReadReceipt(posts[1].Id, userID))
+
+		_, err := ss.PostReadReceipt().GetReadReceipt(posts[1].Id, userID)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("expired bitmaps are deleted", func(t *testing.T) {
+		channelIDs, err := ss.PostReadReceipt().GetCompactedReadReceiptChannels("", 10000)
+		require.NoError(t, err)
+		assert.Contains(t, channelIDs, channelID)
+
+		deleted, err := ss.PostReadReceipt().DeleteExpiredCompactedReadReceipts(channelID, 2000)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		_, err = ss.PostReadReceipt().GetReadReceipt(posts[0].Id, otherUserID)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
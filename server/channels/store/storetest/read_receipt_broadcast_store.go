@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReadReceiptBroadcastStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGet", func(t *testing.T) { testReadReceiptBroadcastStoreSaveGet(t, rctx, ss) })
+	t.Run("DeleteBefore", func(t *testing.T) { testReadReceiptBroadcastStoreDeleteBefore(t, rctx, ss) })
+}
+
+func testReadReceiptBroadcastStoreSaveGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	broadcast, err := ss.ReadReceiptBroadcast().Save(&model.ReadReceiptBroadcast{
+		CreatorId: model.NewId(),
+		Posts: []*model.ReadReceiptBroadcastPost{
+			{PostId: model.NewId(), ChannelId: model.NewId()},
+			{PostId: model.NewId(), ChannelId: model.NewId()},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, broadcast.Id)
+
+	fetched, err := ss.ReadReceiptBroadcast().Get(broadcast.Id)
+	require.NoError(t, err)
+	assert.Equal(t, broadcast.CreatorId, fetched.CreatorId)
+	assert.Equal(t, broadcast.CreateAt, fetched.CreateAt)
+	assert.ElementsMatch(t, broadcast.Posts, fetched.Posts)
+
+	t.Run("unknown broadcast", func(t *testing.T) {
+		_, err := ss.ReadReceiptBroadcast().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("broadcast without posts", func(t *testing.T) {
+		_, err := ss.ReadReceiptBroadcast().Save(&model.ReadReceiptBroadcast{CreatorId: model.NewId()})
+		require.Error(t, err)
+	})
+}
+
+func testReadReceiptBroadcastStoreDeleteBefore(t *testing.T, rctx request.CTX, ss store.Store) {
+	broadcast, err := ss.ReadReceiptBroadcast().Save(&model.ReadReceiptBroadcast{
+		CreatorId: model.NewId(),
+		Posts:     []*model.ReadReceiptBroadcastPost{{PostId: model.NewId(), ChannelId: model.NewId()}},
+	})
+	require.NoError(t, err)
+
+	_, err = ss.ReadReceiptBroadcast().DeleteBefore(broadcast.CreateAt, 100000)
+	require.NoError(t, err)
+	_, err = ss.ReadReceiptBroadcast().Get(broadcast.Id)
+	require.NoError(t, err, "a broadcast created at the cutoff is kept")
+
+	deleted, err := ss.ReadReceiptBroadcast().DeleteBefore(broadcast.CreateAt+1, 100000)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = ss.ReadReceiptBroadcast().Get(broadcast.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReadReceiptPolicyStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("ReplaceAll", func(t *testing.T) { testReadReceiptPolicyStoreReplaceAll(t, rctx, ss) })
+}
+
+func testReadReceiptPolicyStoreReplaceAll(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	saved, err := ss.ReadReceiptPolicy().ReplaceAll([]*model.ReadReceiptPolicy{
+		{Name: "default"},
+		{Name: "legal", TeamIds: model.StringArray{teamID}, RetentionDays: 30, VisibilityMode: model.ReadReceiptVisibilityAuthorOnly, IncludeInExport: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+
+	policies, err := ss.ReadReceiptPolicy().GetAll()
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "default", policies[0].Name)
+	assert.Equal(t, model.StringArray{teamID}, policies[1].TeamIds)
+	assert.Equal(t, 30, policies[1].RetentionDays)
+	assert.True(t, policies[1].IncludeInExport)
+
+	t.Run("invalid policy leaves the stored ones untouched", func(t *testing.T) {
+		_, err := ss.ReadReceiptPolicy().ReplaceAll([]*model.ReadReceiptPolicy{{Name: ""}})
+		require.Error(t, err)
+
+		policies, err := ss.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		require.Len(t, policies, 2)
+	})
+
+	t.Run("duplicate names conflict", func(t *testing.T) {
+		_, err := ss.ReadReceiptPolicy().ReplaceAll([]*model.ReadReceiptPolicy{{Name: "same"}, {Name: "same"}})
+		var conflictErr *store.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+	})
+
+	t.Run("empty list removes every policy", func(t *testing.T) {
+		_, err := ss.ReadReceiptPolicy().ReplaceAll(nil)
+		require.NoError(t, err)
+
+		policies, err := ss.ReadReceiptPolicy().GetAll()
+		require.NoError(t, err)
+		require.Empty(t, policies)
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReadReceiptWebhookStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testReadReceiptWebhookStoreSaveGetDelete(t, rctx, ss) })
+}
+
+func testReadReceiptWebhookStoreSaveGetDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+
+	first, err := ss.ReadReceiptWebhook().Save(&model.ReadReceiptWebhook{ChannelId: channelID, CreatorId: model.NewId(), URL: "https://example.com/first"})
+	require.NoError(t, err)
+	second, err := ss.ReadReceiptWebhook().Save(&model.ReadReceiptWebhook{ChannelId: channelID, CreatorId: model.NewId(), URL: "https://example.com/second"})
+	require.NoError(t, err)
+	_, err = ss.ReadReceiptWebhook().Save(&model.ReadReceiptWebhook{ChannelId: model.NewId(), CreatorId: model.NewId(), URL: "https://example.com/other"})
+	require.NoError(t, err)
+
+	fetched, err := ss.ReadReceiptWebhook().Get(first.Id)
+	require.NoError(t, err)
+	assert.Equal(t, first.URL, fetched.URL)
+	assert.Equal(t, first.Secret, fetched.Secret)
+
+	hooks, err := ss.ReadReceiptWebhook().GetForChannel(channelID)
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		_, err := ss.ReadReceiptWebhook().Save(&model.ReadReceiptWebhook{ChannelId: channelID, CreatorId: model.NewId(), URL: "junk"})
+		require.Error(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, ss.ReadReceiptWebhook().Delete(second.Id))
+
+		_, err := ss.ReadReceiptWebhook().Get(second.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		err = ss.ReadReceiptWebhook().Delete(second.Id)
+		require.ErrorAs(t, err, &nfErr)
+
+		hooks, err := ss.ReadReceiptWebhook().GetForChannel(channelID)
+		require.NoError(t, err)
+		require.Len(t, hooks, 1)
+	})
+}
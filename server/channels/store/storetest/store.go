@@ -62,6 +62,10 @@ type Store struct {
 	NotifyAdminStore                mocks.NotifyAdminStore
 	PostPriorityStore               mocks.PostPriorityStore
 	PostAcknowledgementStore        mocks.PostAcknowledgementStore
+	PostReadReceiptStore            mocks.PostReadReceiptStore
+	ReadReceiptPolicyStore          mocks.ReadReceiptPolicyStore
+	ReadReceiptWebhookStore         mocks.ReadReceiptWebhookStore
+	ReadReceiptBroadcastStore       mocks.ReadReceiptBroadcastStore
 	PostPersistentNotificationStore mocks.PostPersistentNotificationStore
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
@@ -130,6 +134,18 @@ func (s *Store) PropertyValue() store.PropertyValueStore     { return &s.Propert
 func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 	return &s.PostAcknowledgementStore
 }
+func (s *Store) PostReadReceipt() store.PostReadReceiptStore {
+	return &s.PostReadReceiptStore
+}
+func (s *Store) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	return &s.ReadReceiptPolicyStore
+}
+func (s *Store) ReadReceiptWebhook() store.ReadReceiptWebhookStore {
+	return &s.ReadReceiptWebhookStore
+}
+func (s *Store) ReadReceiptBroadcast() store.ReadReceiptBroadcastStore {
+	return &s.ReadReceiptBroadcastStore
+}
 func (s *Store) PostPersistentNotification() store.PostPersistentNotificationStore {
 	return &s.PostPersistentNotificationStore
 }
@@ -207,6 +223,10 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.NotifyAdminStore,
 		&s.PostPriorityStore,
 		&s.PostAcknowledgementStore,
+		&s.PostReadReceiptStore,
+		&s.ReadReceiptPolicyStore,
+		&s.ReadReceiptWebhookStore,
+		&s.ReadReceiptBroadcastStore,
 		&s.PostPersistentNotificationStore,
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
@@ -4,9 +4,33 @@
 package storetest
 
 import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
 func MakeEmail() string {
 	return "success_" + model.NewId() + "@simulator.amazonses.com"
 }
+
+// MarkPostsAsRead saves read receipts for the posts, as if the user read them at readAt.
+func MarkPostsAsRead(t *testing.T, ss store.Store, userID string, readAt int64, posts ...*model.Post) []*model.PostReadReceipt {
+	t.Helper()
+
+	receipts := make([]*model.PostReadReceipt, 0, len(posts))
+	for _, post := range posts {
+		receipts = append(receipts, &model.PostReadReceipt{
+			PostId:    post.Id,
+			UserId:    userID,
+			ChannelId: post.ChannelId,
+			ReadAt:    readAt,
+		})
+	}
+
+	saved, err := ss.PostReadReceipt().SaveReadReceiptsBatch(receipts)
+	require.NoError(t, err)
+	return saved
+}
@@ -25,6 +25,8 @@ func testUserAccessTokenSaveGetDelete(t *testing.T, rctx request.CTX, ss store.S
 		Token:       model.NewId(),
 		UserId:      model.NewId(),
 		Description: "testtoken",
+		CreatorId:   model.NewId(),
+		Scope:       model.UserAccessTokenScopeBotReadReceipts,
 	}
 
 	s1 := &model.Session{}
@@ -44,6 +46,8 @@ func testUserAccessTokenSaveGetDelete(t *testing.T, rctx request.CTX, ss store.S
 	received, err2 := ss.UserAccessToken().GetByToken(uat.Token)
 	require.NoError(t, err2)
 	require.Equal(t, received.Token, uat.Token, "received incorrect token after save")
+	require.Equal(t, uat.CreatorId, received.CreatorId, "received incorrect creator after save")
+	require.Equal(t, uat.Scope, received.Scope, "received incorrect scope after save")
 
 	_, nErr = ss.UserAccessToken().GetByToken("notarealtoken")
 	require.Error(t, nErr, "should have failed on bad token")
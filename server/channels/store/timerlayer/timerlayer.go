@@ -46,12 +46,16 @@ type TimerLayer struct {
 	PostAcknowledgementStore        store.PostAcknowledgementStore
 	PostPersistentNotificationStore store.PostPersistentNotificationStore
 	PostPriorityStore               store.PostPriorityStore
+	PostReadReceiptStore            store.PostReadReceiptStore
 	PreferenceStore                 store.PreferenceStore
 	ProductNoticesStore             store.ProductNoticesStore
 	PropertyFieldStore              store.PropertyFieldStore
 	PropertyGroupStore              store.PropertyGroupStore
 	PropertyValueStore              store.PropertyValueStore
 	ReactionStore                   store.ReactionStore
+	ReadReceiptBroadcastStore       store.ReadReceiptBroadcastStore
+	ReadReceiptPolicyStore          store.ReadReceiptPolicyStore
+	ReadReceiptWebhookStore         store.ReadReceiptWebhookStore
 	RemoteClusterStore              store.RemoteClusterStore
 	RetentionPolicyStore            store.RetentionPolicyStore
 	RoleStore                       store.RoleStore
@@ -180,6 +184,10 @@ func (s *TimerLayer) PostPriority() store.PostPriorityStore {
 	return s.PostPriorityStore
 }
 
+func (s *TimerLayer) PostReadReceipt() store.PostReadReceiptStore {
+	return s.PostReadReceiptStore
+}
+
 func (s *TimerLayer) Preference() store.PreferenceStore {
 	return s.PreferenceStore
 }
@@ -204,6 +212,18 @@ func (s *TimerLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *TimerLayer) ReadReceiptBroadcast() store.ReadReceiptBroadcastStore {
+	return s.ReadReceiptBroadcastStore
+}
+
+func (s *TimerLayer) ReadReceiptPolicy() store.ReadReceiptPolicyStore {
+	return s.ReadReceiptPolicyStore
+}
+
+func (s *TimerLayer) ReadReceiptWebhook() store.ReadReceiptWebhookStore {
+	return s.ReadReceiptWebhookStore
+}
+
 func (s *TimerLayer) RemoteCluster() store.RemoteClusterStore {
 	return s.RemoteClusterStore
 }
@@ -411,6 +431,11 @@ type TimerLayerPostPriorityStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPostReadReceiptStore struct {
+	store.PostReadReceiptStore
+	Root *TimerLayer
+}
+
 type TimerLayerPreferenceStore struct {
 	store.PreferenceStore
 	Root *TimerLayer
@@ -441,6 +466,21 @@ type TimerLayerReactionStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerReadReceiptBroadcastStore struct {
+	store.ReadReceiptBroadcastStore
+	Root *TimerLayer
+}
+
+type TimerLayerReadReceiptPolicyStore struct {
+	store.ReadReceiptPolicyStore
+	Root *TimerLayer
+}
+
+type TimerLayerReadReceiptWebhookStore struct {
+	store.ReadReceiptWebhookStore
+	Root *TimerLayer
+}
+
 type TimerLayerRemoteClusterStore struct {
 	store.RemoteClusterStore
 	Root *TimerLayer
@@ -1777,6 +1817,22 @@ func (s *TimerLayerChannelStore) GetMembersByIds(channelID string, userIds []str
 	return result, err
 }
 
+func (s *TimerLayerChannelStore) GetMembersByLastReadActivity(channelID string, offset int, limit int) (model.ChannelMembers, error) {
+	start := time.Now()
+
+	result, err := s.ChannelStore.GetMembersByLastReadActivity(channelID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelStore.GetMembersByLastReadActivity", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
 	start := time.Now()
 
@@ -7157,10 +7213,10 @@ func (s *TimerLayerPostPriorityStore) Save(priority *model.PostPriority) (*model
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) AppendReadReceiptChain(entries []*model.ReadReceiptChainEntry) error {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	err := s.PostReadReceiptStore.AppendReadReceiptChain(entries)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7168,15 +7224,31 @@ func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.AppendReadReceiptChain", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) CompactReadReceipts(channelID string, readBefore int64, limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.CompactReadReceipts(channelID, readBefore, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.CompactReadReceipts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name string) error {
+func (s *TimerLayerPostReadReceiptStore) ComputeReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
 	start := time.Now()
 
-	err := s.PreferenceStore.Delete(userID, category, name)
+	result, err := s.PostReadReceiptStore.ComputeReadReceiptSummary(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7184,15 +7256,15 @@ func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.ComputeReadReceiptSummary", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+func (s *TimerLayerPostReadReceiptStore) ComputeThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
 	start := time.Now()
 
-	err := s.PreferenceStore.DeleteCategory(userID, category)
+	result, err := s.PostReadReceiptStore.ComputeThreadReadReceiptSummary(rootID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7200,15 +7272,47 @@ func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category strin
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.ComputeThreadReadReceiptSummary", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) DeleteExpiredCompactedReadReceipts(channelID string, expiredBefore int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.DeleteExpiredCompactedReadReceipts(channelID, expiredBefore)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteExpiredCompactedReadReceipts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) DeleteHealthCheckReceipt(postID string, userID string) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.DeleteHealthCheckReceipt(postID, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteHealthCheckReceipt", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+func (s *TimerLayerPostReadReceiptStore) DeleteReadReceipt(postID string, userID string) error {
 	start := time.Now()
 
-	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	err := s.PostReadReceiptStore.DeleteReadReceipt(postID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7216,15 +7320,15 @@ func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteReadReceipt", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) DeleteReadReceiptChangesBefore(before int64, limit int) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+	result, err := s.PostReadReceiptStore.DeleteReadReceiptChangesBefore(before, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7232,15 +7336,15 @@ func (s *TimerLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteInvalidVisibleDmsGms", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteReadReceiptChangesBefore", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) DeleteReadReceipts(receipts []*model.PostReadReceipt) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+	result, err := s.PostReadReceiptStore.DeleteReadReceipts(receipts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7248,15 +7352,15 @@ func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteOrphanedRows", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteReadReceipts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+func (s *TimerLayerPostReadReceiptStore) DeleteReadReceiptsForPost(postID string) error {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.Get(userID, category, name)
+	err := s.PostReadReceiptStore.DeleteReadReceiptsForPost(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7264,15 +7368,15 @@ func (s *TimerLayerPreferenceStore) Get(userID string, category string, name str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.DeleteReadReceiptsForPost", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+func (s *TimerLayerPostReadReceiptStore) EstimateReadReceiptCount() (int64, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.GetAll(userID)
+	result, err := s.PostReadReceiptStore.EstimateReadReceiptCount()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7280,15 +7384,15 @@ func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.EstimateReadReceiptCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+func (s *TimerLayerPostReadReceiptStore) FlagUserReceiptsForScrub(userID string, deactivatedAt int64) error {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.GetCategory(userID, category)
+	err := s.PostReadReceiptStore.FlagUserReceiptsForScrub(userID, deactivatedAt)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7296,15 +7400,15 @@ func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.FlagUserReceiptsForScrub", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+func (s *TimerLayerPostReadReceiptStore) GetAnnouncementReadCounts(teamID string, postIDs []string) ([]*model.ReadReceiptBroadcastChannelSummary, error) {
 	start := time.Now()
 
-	result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+	result, err := s.PostReadReceiptStore.GetAnnouncementReadCounts(teamID, postIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7312,15 +7416,15 @@ func (s *TimerLayerPreferenceStore) GetCategoryAndName(category string, name str
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategoryAndName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetAnnouncementReadCounts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *TimerLayerPostReadReceiptStore) GetCaughtUpUsersForChannel(channelID string, limit int) ([]*model.User, error) {
 	start := time.Now()
 
-	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+	result, err := s.PostReadReceiptStore.GetCaughtUpUsersForChannel(channelID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7328,15 +7432,15 @@ func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetCaughtUpUsersForChannel", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *TimerLayerPostReadReceiptStore) GetChannelArchiveRecommendations(opts *model.ChannelArchiveReportOptions) ([]*model.ChannelArchiveRecommendation, error) {
 	start := time.Now()
 
-	err := s.PreferenceStore.Save(preferences)
+	result, err := s.PostReadReceiptStore.GetChannelArchiveRecommendations(opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7344,15 +7448,15 @@ func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetChannelArchiveRecommendations", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
+func (s *TimerLayerPostReadReceiptStore) GetChannelSettings(channelID string) (*model.ReadReceiptChannelSettings, error) {
 	start := time.Now()
 
-	err := s.ProductNoticesStore.Clear(notices)
+	result, err := s.PostReadReceiptStore.GetChannelSettings(channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7360,15 +7464,15 @@ func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetChannelSettings", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+func (s *TimerLayerPostReadReceiptStore) GetChannelsForReadReceiptCompaction(minReceipts int64, afterChannelID string, limit int) ([]string, error) {
 	start := time.Now()
 
-	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	result, err := s.PostReadReceiptStore.GetChannelsForReadReceiptCompaction(minReceipts, afterChannelID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7376,15 +7480,15 @@ func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.Pro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetChannelsForReadReceiptCompaction", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+func (s *TimerLayerPostReadReceiptStore) GetCompactedReadReceiptChannels(afterChannelID string, limit int) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.ProductNoticesStore.GetViews(userID)
+	result, err := s.PostReadReceiptStore.GetCompactedReadReceiptChannels(afterChannelID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7392,15 +7496,15 @@ func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.Product
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetCompactedReadReceiptChannels", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) error {
+func (s *TimerLayerPostReadReceiptStore) GetDistinctReaderCounts(channelID string, fromDay string, toDay string) ([]*model.DailyReaderCount, error) {
 	start := time.Now()
 
-	err := s.ProductNoticesStore.View(userID, notices)
+	result, err := s.PostReadReceiptStore.GetDistinctReaderCounts(channelID, fromDay, toDay)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7408,15 +7512,15 @@ func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) er
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetDistinctReaderCounts", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) GetExpiredReadReceiptCounts(expiredBefore int64, teamIDs []string, channelIDs []string, limit int) (*model.ReadReceiptCleanupPreview, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+	result, err := s.PostReadReceiptStore.GetExpiredReadReceiptCounts(expiredBefore, teamIDs, channelIDs, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7424,15 +7528,15 @@ func (s *TimerLayerPropertyFieldStore) CountForGroup(groupID string, includeDele
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.CountForGroup", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetExpiredReadReceiptCounts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetHumanMemberCount(channelID string) (int64, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.Create(field)
+	result, err := s.PostReadReceiptStore.GetHumanMemberCount(channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7440,15 +7544,15 @@ func (s *TimerLayerPropertyFieldStore) Create(field *model.PropertyField) (*mode
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Create", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetHumanMemberCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) Delete(groupID string, id string) error {
+func (s *TimerLayerPostReadReceiptStore) GetMembersReadActivity(channelID string, since int64, offset int, limit int) ([]*model.ChannelMemberReadActivity, error) {
 	start := time.Now()
 
-	err := s.PropertyFieldStore.Delete(groupID, id)
+	result, err := s.PostReadReceiptStore.GetMembersReadActivity(channelID, since, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7456,15 +7560,15 @@ func (s *TimerLayerPropertyFieldStore) Delete(groupID string, id string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetMembersReadActivity", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadActivityBySession(userID string, since int64, offset int, limit int) ([]*model.ReadReceiptSessionActivity, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.Get(groupID, id)
+	result, err := s.PostReadReceiptStore.GetReadActivityBySession(userID, since, offset, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7472,15 +7576,15 @@ func (s *TimerLayerPropertyFieldStore) Get(groupID string, id string) (*model.Pr
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadActivityBySession", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadCountsForLatestPosts(channelID string, limit int) ([]*model.PostReadCount, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+	result, err := s.PostReadReceiptStore.GetReadCountsForLatestPosts(channelID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7488,15 +7592,15 @@ func (s *TimerLayerPropertyFieldStore) GetFieldByName(groupID string, targetID s
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.GetFieldByName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadCountsForLatestPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadDevicesForPostUser(postID string, userID string) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.GetMany(groupID, ids)
+	result, err := s.PostReadReceiptStore.GetReadDevicesForPostUser(postID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7504,15 +7608,15 @@ func (s *TimerLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.GetMany", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadDevicesForPostUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadPostIdsForUser(userID string, postIDs []string) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.SearchPropertyFields(opts)
+	result, err := s.PostReadReceiptStore.GetReadPostIdsForUser(userID, postIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7520,15 +7624,15 @@ func (s *TimerLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyF
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.SearchPropertyFields", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadPostIdsForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceipt(postID string, userID string) (*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.PropertyFieldStore.Update(groupID, fields)
+	result, err := s.PostReadReceiptStore.GetReadReceipt(postID, userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7536,15 +7640,15 @@ func (s *TimerLayerPropertyFieldStore) Update(groupID string, fields []*model.Pr
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceipt", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptChain(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChainEntry, error) {
 	start := time.Now()
 
-	result, err := s.PropertyGroupStore.Get(name)
+	result, err := s.PostReadReceiptStore.GetReadReceiptChain(channelID, afterSequence, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7552,15 +7656,15 @@ func (s *TimerLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, e
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyGroupStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptChain", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptChainHead(channelID string) (*model.ReadReceiptChainEntry, error) {
 	start := time.Now()
 
-	result, err := s.PropertyGroupStore.Register(name)
+	result, err := s.PostReadReceiptStore.GetReadReceiptChainHead(channelID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7568,15 +7672,15 @@ func (s *TimerLayerPropertyGroupStore) Register(name string) (*model.PropertyGro
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyGroupStore.Register", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptChainHead", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptChainMismatches(channelID string, limit int) ([]*model.ReadReceiptChainEntry, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.Create(value)
+	result, err := s.PostReadReceiptStore.GetReadReceiptChainMismatches(channelID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7584,15 +7688,15 @@ func (s *TimerLayerPropertyValueStore) Create(value *model.PropertyValue) (*mode
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Create", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptChainMismatches", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) Delete(groupID string, id string) error {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptChanges(channelID string, afterSequence int64, limit int) ([]*model.ReadReceiptChange, error) {
 	start := time.Now()
 
-	err := s.PropertyValueStore.Delete(groupID, id)
+	result, err := s.PostReadReceiptStore.GetReadReceiptChanges(channelID, afterSequence, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7600,15 +7704,15 @@ func (s *TimerLayerPropertyValueStore) Delete(groupID string, id string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptChanges", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptExtremes(postID string) (*model.PostReadReceiptExtremes, error) {
 	start := time.Now()
 
-	err := s.PropertyValueStore.DeleteForField(groupID, fieldID)
+	result, err := s.PostReadReceiptStore.GetReadReceiptExtremes(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7616,15 +7720,15 @@ func (s *TimerLayerPropertyValueStore) DeleteForField(groupID string, fieldID st
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.DeleteForField", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptExtremes", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptSummariesForChannel(channelID string, since int64) ([]*model.PostReadReceiptSummary, error) {
 	start := time.Now()
 
-	err := s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+	result, err := s.PostReadReceiptStore.GetReadReceiptSummariesForChannel(channelID, since)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7632,15 +7736,15 @@ func (s *TimerLayerPropertyValueStore) DeleteForTarget(groupID string, targetTyp
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.DeleteForTarget", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptSummariesForChannel", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptSummariesForPosts(postIDs []string, fromMaster bool) ([]*model.PostReadReceiptSummary, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.Get(groupID, id)
+	result, err := s.PostReadReceiptStore.GetReadReceiptSummariesForPosts(postIDs, fromMaster)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7648,15 +7752,15 @@ func (s *TimerLayerPropertyValueStore) Get(groupID string, id string) (*model.Pr
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptSummariesForPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptSummary(postID string) (*model.PostReadReceiptSummary, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.GetMany(groupID, ids)
+	result, err := s.PostReadReceiptStore.GetReadReceiptSummary(postID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7664,15 +7768,15 @@ func (s *TimerLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.GetMany", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptSummary", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptTeamUsage() ([]*model.ReadReceiptTeamUsage, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.SearchPropertyValues(opts)
+	result, err := s.PostReadReceiptStore.GetReadReceiptTeamUsage()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7680,15 +7784,15 @@ func (s *TimerLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyV
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.SearchPropertyValues", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptTeamUsage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsForPost(postID string, deviceType string) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.Update(groupID, values)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsForPost(postID, deviceType)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7696,15 +7800,15 @@ func (s *TimerLayerPropertyValueStore) Update(groupID string, values []*model.Pr
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Update", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsForPosts(postIDs []string) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.PropertyValueStore.Upsert(values)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsForPosts(postIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7712,15 +7816,15 @@ func (s *TimerLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Upsert", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsForPosts", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsForSession(sessionID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsForSession(sessionID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7728,15 +7832,15 @@ func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Re
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsForSession", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsForUser(userID string, opts model.GetReadReceiptsForUserOptions) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.Delete(reaction)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsForUser(userID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7744,15 +7848,15 @@ func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.React
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsForUser", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsForUserPosts(userID string, postIDs []string) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsForUserPosts(userID, postIDs)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7760,15 +7864,15 @@ func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsForUserPosts", success, elapsed)
 	}
-	return err
+	return result, err
 }
 
-func (s *TimerLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) GetReadReceiptsPage(cursor model.ReadReceiptsPageCursor, limit int) ([]*model.PostReadReceipt, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+	result, err := s.PostReadReceiptStore.GetReadReceiptsPage(cursor, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7776,15 +7880,15 @@ func (s *TimerLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsF
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteOrphanedRowsByIds", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetReadReceiptsPage", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+func (s *TimerLayerPostReadReceiptStore) GetTableStats() ([]*model.ReadReceiptTableStats, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
+	result, err := s.PostReadReceiptStore.GetTableStats()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7792,15 +7896,15 @@ func (s *TimerLayerReactionStore) ExistsOnPost(postID string, emojiName string)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.ExistsOnPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetTableStats", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) GetThreadReadReceiptSummary(rootID string) ([]*model.ThreadParticipantReadCount, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+	result, err := s.PostReadReceiptStore.GetThreadReadReceiptSummary(rootID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7808,15 +7912,15 @@ func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool)
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetThreadReadReceiptSummary", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) GetUnreadDirectChannels(createdAfter int64, createdBefore int64) ([]*model.UnreadDirectChannel, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+	result, err := s.PostReadReceiptStore.GetUnreadDirectChannels(createdAfter, createdBefore)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7824,15 +7928,15 @@ func (s *TimerLayerReactionStore) GetForPostSince(postID string, since int64, ex
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPostSince", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetUnreadDirectChannels", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) GetUnreadPostsCount(channelID string, userID string, createdBefore int64) (int64, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+	result, err := s.PostReadReceiptStore.GetUnreadPostsCount(channelID, userID, createdBefore)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7840,15 +7944,15 @@ func (s *TimerLayerReactionStore) GetSingle(userID string, postID string, remote
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetSingle", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetUnreadPostsCount", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+func (s *TimerLayerPostReadReceiptStore) GetUnreadUsersForPost(postID string, limit int) ([]*model.User, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.GetUniqueCountForPost(postID)
+	result, err := s.PostReadReceiptStore.GetUnreadUsersForPost(postID, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7856,15 +7960,15 @@ func (s *TimerLayerReactionStore) GetUniqueCountForPost(postID string) (int, err
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetUniqueCountForPost", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetUnreadUsersForPost", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+func (s *TimerLayerPostReadReceiptStore) GetUsersDueForReceiptScrub(deactivatedBefore int64, limit int) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	result, err := s.PostReadReceiptStore.GetUsersDueForReceiptScrub(deactivatedBefore, limit)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7872,15 +7976,15 @@ func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int6
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.GetUsersDueForReceiptScrub", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerReactionStore) PermanentDeleteByUser(userID string) error {
+func (s *TimerLayerPostReadReceiptStore) IsPostReadByAnyone(postID string, fromMaster bool) (bool, int64, error) {
 	start := time.Now()
 
-	err := s.ReactionStore.PermanentDeleteByUser(userID)
+	result, resultVar1, err := s.PostReadReceiptStore.IsPostReadByAnyone(postID, fromMaster)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7888,15 +7992,31 @@ func (s *TimerLayerReactionStore) PermanentDeleteByUser(userID string) error {
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteByUser", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.IsPostReadByAnyone", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.PermanentDeleteByUser", success, elapsed)
 	}
 	return err
 }
 
-func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+func (s *TimerLayerPostReadReceiptStore) RefreshReadReceiptStats() error {
 	start := time.Now()
 
-	result, err := s.ReactionStore.Save(reaction)
+	err := s.PostReadReceiptStore.RefreshReadReceiptStats()
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7904,7 +8024,1159 @@ func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reactio
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.RefreshReadReceiptStats", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) RotateHotReadReceiptIndexes() error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.RotateHotReadReceiptIndexes()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.RotateHotReadReceiptIndexes", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveChannelSettings(settings *model.ReadReceiptChannelSettings) (*model.ReadReceiptChannelSettings, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveChannelSettings(settings)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveChannelSettings", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveGhostRead(read *model.PostReadReceipt) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveGhostRead(read)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveGhostRead", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveHealthCheckReceipt(receipt *model.PostReadReceipt) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveHealthCheckReceipt(receipt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveHealthCheckReceipt", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadDevices(receipts []*model.PostReadReceipt) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.SaveReadDevices(receipts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadDevices", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadReceipt(receipt *model.PostReadReceipt) (*model.PostReadReceipt, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveReadReceipt(receipt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadReceipt", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadReceiptWithSummary(receipt *model.PostReadReceipt) (*model.PostReadReceipt, *model.PostReadReceiptSummary, int64, error) {
+	start := time.Now()
+
+	result, resultVar1, resultVar2, err := s.PostReadReceiptStore.SaveReadReceiptWithSummary(receipt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadReceiptWithSummary", success, elapsed)
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadReceiptsBatch(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveReadReceiptsBatch(receipts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadReceiptsBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadReceiptsIfNotExist(receipts []*model.PostReadReceipt) ([]*model.PostReadReceipt, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveReadReceiptsIfNotExist(receipts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadReceiptsIfNotExist", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveReadReceiptsUpToPost(receipt *model.PostReadReceipt, limit int, rootPostsOnly bool) ([]*model.PostReadReceipt, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.SaveReadReceiptsUpToPost(receipt, limit, rootPostsOnly)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveReadReceiptsUpToPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) SaveThreadReadReceiptSummary(rootID string, channelID string, counts []*model.ThreadParticipantReadCount) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.SaveThreadReadReceiptSummary(rootID, channelID, counts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.SaveThreadReadReceiptSummary", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) ScrubUserReceipts(userID string) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.ScrubUserReceipts(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.ScrubUserReceipts", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) UnflagUserReceiptsForScrub(userID string) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.UnflagUserReceiptsForScrub(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.UnflagUserReceiptsForScrub", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPostReadReceiptStore) UpdateChannelWatermarkMode(channelID string, limit int64, recoverAt int64) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.UpdateChannelWatermarkMode(channelID, limit, recoverAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.UpdateChannelWatermarkMode", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) UpdateReadReceiptSummaries(summaries []*model.PostReadReceiptSummary) ([]*model.PostReadReceiptSummary, error) {
+	start := time.Now()
+
+	result, err := s.PostReadReceiptStore.UpdateReadReceiptSummaries(summaries)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.UpdateReadReceiptSummaries", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostReadReceiptStore) UpdateReadReceiptSummary(summary *model.PostReadReceiptSummary) error {
+	start := time.Now()
+
+	err := s.PostReadReceiptStore.UpdateReadReceiptSummary(summary)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostReadReceiptStore.UpdateReadReceiptSummary", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.CleanupFlagsBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) Delete(userID string, category string, name string) error {
+	start := time.Now()
+
+	err := s.PreferenceStore.Delete(userID, category, name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+	start := time.Now()
+
+	err := s.PreferenceStore.DeleteCategory(userID, category)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategory", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	start := time.Now()
+
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteCategoryAndName", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteInvalidVisibleDmsGms", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.DeleteOrphanedRows", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.Get(userID, category, name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.GetAll(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.GetCategory(userID, category)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategory", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+	start := time.Now()
+
+	result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.GetCategoryAndName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPreferenceStore) Save(preferences model.Preferences) error {
+	start := time.Now()
+
+	err := s.PreferenceStore.Save(preferences)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PreferenceStore.Save", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerProductNoticesStore) Clear(notices []string) error {
+	start := time.Now()
+
+	err := s.ProductNoticesStore.Clear(notices)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.Clear", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+	start := time.Now()
+
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.ClearOldNotices", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+	start := time.Now()
+
+	result, err := s.ProductNoticesStore.GetViews(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.GetViews", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerProductNoticesStore) View(userID string, notices []string) error {
+	start := time.Now()
+
+	err := s.ProductNoticesStore.View(userID, notices)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ProductNoticesStore.View", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.CountForGroup", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.Create(field)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Create", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) Delete(groupID string, id string) error {
+	start := time.Now()
+
+	err := s.PropertyFieldStore.Delete(groupID, id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.Get(groupID, id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.GetFieldByName", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.GetMany(groupID, ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.GetMany", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.SearchPropertyFields(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.SearchPropertyFields", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+	start := time.Now()
+
+	result, err := s.PropertyFieldStore.Update(groupID, fields)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyFieldStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+	start := time.Now()
+
+	result, err := s.PropertyGroupStore.Get(name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyGroupStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+	start := time.Now()
+
+	result, err := s.PropertyGroupStore.Register(name)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyGroupStore.Register", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.Create(value)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Create", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) Delete(groupID string, id string) error {
+	start := time.Now()
+
+	err := s.PropertyValueStore.Delete(groupID, id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+	start := time.Now()
+
+	err := s.PropertyValueStore.DeleteForField(groupID, fieldID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.DeleteForField", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+	start := time.Now()
+
+	err := s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.DeleteForTarget", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.Get(groupID, id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.GetMany(groupID, ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.GetMany", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.SearchPropertyValues(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.SearchPropertyValues", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.Update(groupID, values)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.Upsert(values)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.Upsert", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.BulkGetForPosts", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.Delete(reaction)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	start := time.Now()
+
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteAllWithEmojiName", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.DeleteOrphanedRowsByIds", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.ExistsOnPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetForPostSince", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetSingle", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.GetUniqueCountForPost(postID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.GetUniqueCountForPost", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteBatch", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReactionStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.ReactionStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	start := time.Now()
+
+	result, err := s.ReactionStore.Save(reaction)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReactionStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptBroadcastStore) DeleteBefore(before int64, limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptBroadcastStore.DeleteBefore(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptBroadcastStore.DeleteBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptBroadcastStore) Get(id string) (*model.ReadReceiptBroadcast, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptBroadcastStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptBroadcastStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptBroadcastStore) Save(broadcast *model.ReadReceiptBroadcast) (*model.ReadReceiptBroadcast, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptBroadcastStore.Save(broadcast)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptBroadcastStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptPolicyStore) GetAll() ([]*model.ReadReceiptPolicy, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptPolicyStore.GetAll()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptPolicyStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptPolicyStore) ReplaceAll(policies []*model.ReadReceiptPolicy) ([]*model.ReadReceiptPolicy, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptPolicyStore.ReplaceAll(policies)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptPolicyStore.ReplaceAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptWebhookStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.ReadReceiptWebhookStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptWebhookStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerReadReceiptWebhookStore) Get(id string) (*model.ReadReceiptWebhook, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptWebhookStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptWebhookStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptWebhookStore) GetForChannel(channelID string) ([]*model.ReadReceiptWebhook, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptWebhookStore.GetForChannel(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptWebhookStore.GetForChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerReadReceiptWebhookStore) Save(hook *model.ReadReceiptWebhook) (*model.ReadReceiptWebhook, error) {
+	start := time.Now()
+
+	result, err := s.ReadReceiptWebhookStore.Save(hook)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ReadReceiptWebhookStore.Save", success, elapsed)
 	}
 	return result, err
 }
@@ -13313,12 +14585,16 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
 	newStore.PostPersistentNotificationStore = &TimerLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
 	newStore.PostPriorityStore = &TimerLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PostReadReceiptStore = &TimerLayerPostReadReceiptStore{PostReadReceiptStore: childStore.PostReadReceipt(), Root: &newStore}
 	newStore.PreferenceStore = &TimerLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &TimerLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.PropertyFieldStore = &TimerLayerPropertyFieldStore{PropertyFieldStore: childStore.PropertyField(), Root: &newStore}
 	newStore.PropertyGroupStore = &TimerLayerPropertyGroupStore{PropertyGroupStore: childStore.PropertyGroup(), Root: &newStore}
 	newStore.PropertyValueStore = &TimerLayerPropertyValueStore{PropertyValueStore: childStore.PropertyValue(), Root: &newStore}
 	newStore.ReactionStore = &TimerLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.ReadReceiptBroadcastStore = &TimerLayerReadReceiptBroadcastStore{ReadReceiptBroadcastStore: childStore.ReadReceiptBroadcast(), Root: &newStore}
+	newStore.ReadReceiptPolicyStore = &TimerLayerReadReceiptPolicyStore{ReadReceiptPolicyStore: childStore.ReadReceiptPolicy(), Root: &newStore}
+	newStore.ReadReceiptWebhookStore = &TimerLayerReadReceiptWebhookStore{ReadReceiptWebhookStore: childStore.ReadReceiptWebhook(), Root: &newStore}
 	newStore.RemoteClusterStore = &TimerLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &TimerLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &TimerLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
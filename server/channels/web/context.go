@@ -141,6 +141,24 @@ func (c *Context) SessionRequired() {
 	}
 }
 
+// OAuthScopeRequired rejects the OAuth sessions limited to read receipt scopes
+// when they were granted none of the given scopes. Sessions granted any other
+// scope keep access to every endpoint.
+func (c *Context) OAuthScopeRequired(scopes []string) {
+	session := c.AppContext.Session()
+	if !session.HasOnlyReadReceiptsOAuthScopes() {
+		return
+	}
+
+	for _, scope := range scopes {
+		if session.HasOAuthScope(scope) {
+			return
+		}
+	}
+
+	c.Err = model.NewAppError("", "api.context.oauth_scope.app_error", nil, "", http.StatusForbidden)
+}
+
 func (c *Context) CloudKeyRequired() {
 	if license := c.App.Channels().License(); license == nil || !license.IsCloud() || c.AppContext.Session().Props[model.SessionPropType] != model.SessionTypeCloudKey {
 		c.Err = model.NewAppError("", "api.context.session_expired.app_error", nil, "TokenRequired", http.StatusUnauthorized)
@@ -612,6 +630,18 @@ func (c *Context) RequireHookId() *Context {
 	return c
 }
 
+func (c *Context) RequireSessionId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.SessionId) {
+		c.SetInvalidURLParam("session_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireCommandId() *Context {
 	if c.Err != nil {
 		return c
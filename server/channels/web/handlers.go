@@ -78,6 +78,9 @@ type Handler struct {
 	IsLocal                   bool
 	DisableWhenBusy           bool
 	FileAPI                   bool
+	// OAuthScopes lists the read receipt scopes that grant access to the endpoint
+	// to the OAuth apps limited to them.
+	OAuthScopes []string
 
 	cspShaDirective string
 }
@@ -320,10 +323,18 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	)
 	c.AppContext = c.AppContext.WithLogger(c.Logger)
 
+	if impersonator := c.AppContext.Session().ImpersonatedBy(); impersonator != "" {
+		c.AppContext = request.WithImpersonation(c.AppContext, impersonator)
+	}
+
 	if c.Err == nil && h.RequireSession {
 		c.SessionRequired()
 	}
 
+	if c.Err == nil && h.RequireSession {
+		c.OAuthScopeRequired(h.OAuthScopes)
+	}
+
 	if c.Err == nil && h.RequireMfa {
 		c.MfaRequired()
 	}
@@ -42,6 +42,7 @@ type Params struct {
 	PluginId                           string
 	CommandId                          string
 	HookId                             string
+	SessionId                          string
 	ReportId                           string
 	EmojiId                            string
 	AppId                              string
@@ -155,6 +156,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	}
 	params.CommandId = props["command_id"]
 	params.HookId = props["hook_id"]
+	params.SessionId = props["session_id"]
 	params.ReportId = props["report_id"]
 	params.EmojiId = props["emoji_id"]
 	params.AppId = props["app_id"]
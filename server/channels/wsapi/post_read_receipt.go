@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package wsapi
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func (api *API) InitPostReadReceipt() {
+	api.Router.Handle("post_read", api.APIWebSocketHandler(api.postRead))
+}
+
+// postRead saves read receipts sent over the websocket. It takes the same fields
+// as the batch endpoint of the REST API and goes through the same validation and
+// deduplication.
+func (api *API) postRead(req *model.WebSocketRequest) (map[string]any, *model.AppError) {
+	if !*api.App.Config().ServiceSettings.EnableReadReceipts {
+		return nil, model.NewAppError("websocket: "+req.Action, "api.read_receipt.disabled.app_error", nil, "", http.StatusNotImplemented).WithCode(model.ReadReceiptErrorCodeDisabled)
+	}
+
+	if req.Session.IsBotUser() {
+		return nil, model.NewAppError("websocket: "+req.Action, "api.read_receipt.bot_session.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeBotSessionNotAllowed)
+	}
+	if !req.Session.CanRecordReadReceipts() {
+		return nil, model.NewAppError("websocket: "+req.Action, "api.read_receipt.integration_session.app_error", nil, "", http.StatusForbidden).WithCode(model.ReadReceiptErrorCodeIntegrationNotAllowed)
+	}
+
+	rctx := request.EmptyContext(api.App.Log()).WithSession(&req.Session)
+	api.App.ExtendSessionExpiryIfNeeded(rctx, &req.Session)
+
+	batch := &model.ReadReceiptBatchRequest{
+		PostIds: model.ArrayFromInterface(req.Data["post_ids"]),
+	}
+
+	var ok bool
+	if batch.ChannelId, ok = req.Data["channel_id"].(string); !ok || !model.IsValidId(batch.ChannelId) {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_id")
+	}
+	if upToPostId, ok := req.Data["up_to_post_id"].(string); ok {
+		batch.UpToPostId = upToPostId
+	}
+	if deviceId, ok := req.Data["device_id"].(string); ok {
+		batch.DeviceId = deviceId
+	}
+	if batch.ReadAt, ok = int64FromWebSocketData(req.Data["read_at"]); !ok {
+		return nil, NewInvalidWebSocketParamError(req.Action, "read_at")
+	}
+
+	if appErr := batch.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if !api.App.SessionHasPermissionToChannel(rctx, req.Session, batch.ChannelId, model.PermissionReadChannelContent) {
+		return nil, model.MakePermissionError(&req.Session, []*model.Permission{model.PermissionReadChannelContent})
+	}
+
+	resp, appErr := api.App.SaveReadReceiptsBatch(rctx, req.Session.UserId, batch)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return map[string]any{"processed_count": resp.ProcessedCount}, nil
+}
+
+// int64FromWebSocketData reads an optional number from the data of a websocket
+// request, which JSON decodes as float64 and msgpack as any integer type.
+func int64FromWebSocketData(value any) (int64, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true
+	case float64:
+		return int64(v), true
+	case float32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
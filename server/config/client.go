@@ -55,6 +55,8 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	
 	// Read receipts settings
 	props["PostReadReceipts"] = strconv.FormatBool(*c.ServiceSettings.EnableReadReceipts)
+	props["ReadReceiptsClientDebounceMs"] = strconv.FormatInt(int64(*c.ServiceSettings.ReadReceiptsClientDebounceMs), 10)
+	props["ReadReceiptsBatchMaxWaitMs"] = strconv.FormatInt(int64(*c.ServiceSettings.ReadReceiptsBatchMaxWaitMs), 10)
 
 	// This setting is only temporary, so keep using the old setting name for the mobile and web apps
 	props["ExperimentalEnablePostMetadata"] = "true"
@@ -383,6 +383,21 @@ func TestGetClientConfig(t *testing.T) {
 				"EnableUserManagedAttributes":       "false",
 			},
 		},
+		{
+			"read receipt debounce hints",
+			&model.Config{
+				ServiceSettings: model.ServiceSettings{
+					ReadReceiptsClientDebounceMs: model.NewPointer(250),
+					ReadReceiptsBatchMaxWaitMs:   model.NewPointer(2000),
+				},
+			},
+			"",
+			nil,
+			map[string]string{
+				"ReadReceiptsClientDebounceMs": "250",
+				"ReadReceiptsBatchMaxWaitMs":   "2000",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
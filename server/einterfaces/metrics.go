@@ -139,4 +139,11 @@ type MetricsInterface interface {
 	ObserveAccessControlExpressionCompileDuration(value float64)
 	ObserveAccessControlEvaluateDuration(value float64)
 	IncrementAccessControlCacheInvalidation()
+
+	ObserveReadReceiptBufferSize(size int64)
+	ObserveReadReceiptBufferFlushDuration(elapsed float64)
+	ObserveReadReceiptSummaryStaleness(staleness float64)
+	IncrementReadReceiptEventsPublished(count float64)
+	IncrementReadReceiptEventsDropped(count float64)
+	IncrementReadReceiptEventPublishRetries()
 }
@@ -243,6 +243,21 @@ func (_m *MetricsInterface) IncrementPostsSearchCounter() {
 	_m.Called()
 }
 
+// IncrementReadReceiptEventPublishRetries provides a mock function with no fields
+func (_m *MetricsInterface) IncrementReadReceiptEventPublishRetries() {
+	_m.Called()
+}
+
+// IncrementReadReceiptEventsDropped provides a mock function with given fields: count
+func (_m *MetricsInterface) IncrementReadReceiptEventsDropped(count float64) {
+	_m.Called(count)
+}
+
+// IncrementReadReceiptEventsPublished provides a mock function with given fields: count
+func (_m *MetricsInterface) IncrementReadReceiptEventsPublished(count float64) {
+	_m.Called(count)
+}
+
 // IncrementRemoteClusterConnStateChangeCounter provides a mock function with given fields: remoteID, online
 func (_m *MetricsInterface) IncrementRemoteClusterConnStateChangeCounter(remoteID string, online bool) {
 	_m.Called(remoteID, online)
@@ -503,6 +518,21 @@ func (_m *MetricsInterface) ObservePostsSearchDuration(elapsed float64) {
 	_m.Called(elapsed)
 }
 
+// ObserveReadReceiptBufferFlushDuration provides a mock function with given fields: elapsed
+func (_m *MetricsInterface) ObserveReadReceiptBufferFlushDuration(elapsed float64) {
+	_m.Called(elapsed)
+}
+
+// ObserveReadReceiptBufferSize provides a mock function with given fields: size
+func (_m *MetricsInterface) ObserveReadReceiptBufferSize(size int64) {
+	_m.Called(size)
+}
+
+// ObserveReadReceiptSummaryStaleness provides a mock function with given fields: staleness
+func (_m *MetricsInterface) ObserveReadReceiptSummaryStaleness(staleness float64) {
+	_m.Called(staleness)
+}
+
 // ObserveRedisEndpointDuration provides a mock function with given fields: cacheName, operation, elapsed
 func (_m *MetricsInterface) ObserveRedisEndpointDuration(cacheName string, operation string, elapsed float64) {
 	_m.Called(cacheName, operation, elapsed)
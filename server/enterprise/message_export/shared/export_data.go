@@ -4,6 +4,7 @@
 package shared
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -42,6 +43,7 @@ type PostExport struct {
 	AttachmentCreates []*FileUploadStartExport // the post's attachments that were uploaded this export period
 	AttachmentDeletes []PostExport             // the post's attachments that were deleted
 	FileInfo          *model.FileInfo          // if this was a file PostExport, FileInfo will contain that info. Otherwise, nil.
+	ReadReceipts      []*model.PostReadReceipt // who read the post, only set when MessageExportSettings.IncludeReadReceipts is on
 }
 
 type FileUploadStartExport struct {
@@ -130,6 +132,16 @@ func GetGenericExportData(p ExportParams) (GenericExportData, error) {
 	uploadStopsByChannel := make(map[string][]*FileUploadStopExport)
 	deletedFilesByChannel := make(map[string][]PostExport)
 
+	// Receipts are fetched for the whole batch at once so they travel with the posts they belong to,
+	// instead of needing a separate pass over the export period.
+	var receiptsByPost map[string][]*model.PostReadReceipt
+	if p.Config != nil && model.SafeDereference(p.Config.MessageExportSettings.IncludeReadReceipts) {
+		var err error
+		if receiptsByPost, err = getReadReceiptsForPosts(p.Posts, p.Db); err != nil {
+			return GenericExportData{}, err
+		}
+	}
+
 	processPostAttachments := func(post *model.MessageExport, postExport PostExport, originalPostThatWillBeDeletedLater bool) error {
 		// originalPostThatWillBeDeletedLater means we are recording this message's original file starts and stops,
 		// before it was deleted (we'll record that next call to this function)
@@ -179,6 +191,10 @@ func GetGenericExportData(p ExportParams) (GenericExportData, error) {
 		}
 		var postExport PostExport
 		postExport, results = getPostExport(post, results)
+		if postExport.UpdatedType != Deleted && postExport.UpdatedType != EditedOriginalMsg {
+			postExport.ReadReceipts = receiptsByPost[*post.PostId]
+			results.ReadReceipts += len(postExport.ReadReceipts)
+		}
 
 		if err := processPostAttachments(post, postExport, false); err != nil {
 			return GenericExportData{}, err
@@ -249,6 +265,38 @@ func GetGenericExportData(p ExportParams) (GenericExportData, error) {
 	return GenericExportData{channelExports, metadata, results}, nil
 }
 
+// getReadReceiptsForPosts returns the read receipts of the batch's posts, keyed by post id. Posts of
+// channels whose read receipt policy leaves receipts out of exports get none.
+func getReadReceiptsForPosts(posts []*model.MessageExport, db MessageExportStore) (map[string][]*model.PostReadReceipt, error) {
+	policies, err := db.ReadReceiptPolicy().GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read receipt policies: %w", err)
+	}
+
+	postIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		policy := model.ResolveReadReceiptPolicy(policies, model.SafeDereference(post.TeamId), model.SafeDereference(post.ChannelId))
+		if policy != nil && !policy.IncludeInExport {
+			continue
+		}
+		postIds = append(postIds, *post.PostId)
+	}
+	if len(postIds) == 0 {
+		return nil, nil
+	}
+
+	receipts, err := db.PostReadReceipt().GetReadReceiptsForPosts(postIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read receipts for posts: %w", err)
+	}
+
+	receiptsByPost := make(map[string][]*model.PostReadReceipt, len(posts))
+	for _, receipt := range receipts {
+		receiptsByPost[receipt.PostId] = append(receiptsByPost[receipt.PostId], receipt)
+	}
+	return receiptsByPost, nil
+}
+
 // postToAttachmentsEntries returns every fileInfo as uploadedFiles. It also adds each file into the lists:
 //
 //		startUploads, stopUploads, and deleteFileMessages (for ActianceExport).
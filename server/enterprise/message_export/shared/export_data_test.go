@@ -521,3 +521,78 @@ func TestGetJoinLeavePosts(t *testing.T) {
 		ClosedOut: true,
 	}, leaves[5])
 }
+
+func TestGetGenericExportDataReadReceipts(t *testing.T) {
+	chanTypeOpen := model.ChannelTypeOpen
+	post := &model.MessageExport{
+		PostId:       model.NewPointer("post1"),
+		ChannelId:    model.NewPointer("channel1"),
+		TeamId:       model.NewPointer("team1"),
+		ChannelType:  &chanTypeOpen,
+		PostCreateAt: model.NewPointer(int64(1)),
+		PostUpdateAt: model.NewPointer(int64(1)),
+		PostMessage:  model.NewPointer("message"),
+		UserEmail:    model.NewPointer("test@test.com"),
+		UserId:       model.NewPointer("user1"),
+		Username:     model.NewPointer("test"),
+	}
+	receipts := []*model.PostReadReceipt{
+		{PostId: "post1", UserId: "user2", ChannelId: "channel1", ReadAt: 2},
+	}
+
+	getParams := func(mockStore *storetest.Store, includeReadReceipts bool) ExportParams {
+		config := &model.Config{}
+		config.SetDefaults()
+		config.MessageExportSettings.IncludeReadReceipts = model.NewPointer(includeReadReceipts)
+		return ExportParams{
+			ChannelMetadata: map[string]*MetadataChannel{
+				"channel1": {ChannelId: "channel1", ChannelType: chanTypeOpen},
+			},
+			Posts:          []*model.MessageExport{post},
+			BatchStartTime: 1,
+			BatchEndTime:   3,
+			Config:         config,
+			Db:             NewMessageExportStore(mockStore),
+		}
+	}
+
+	t.Run("receipts are attached to their posts", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		defer mockStore.AssertExpectations(t)
+		mockStore.ReadReceiptPolicyStore.On("GetAll").Return([]*model.ReadReceiptPolicy{}, nil)
+		mockStore.PostReadReceiptStore.On("GetReadReceiptsForPosts", []string{"post1"}).Return(receipts, nil)
+
+		data, err := GetGenericExportData(getParams(mockStore, true))
+		require.NoError(t, err)
+		require.Len(t, data.Exports, 1)
+		require.Len(t, data.Exports[0].Posts, 1)
+		assert.Equal(t, receipts, data.Exports[0].Posts[0].ReadReceipts)
+		assert.Equal(t, 1, data.Results.ReadReceipts)
+	})
+
+	t.Run("receipts excluded from exports by their policy are left out", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		defer mockStore.AssertExpectations(t)
+		mockStore.ReadReceiptPolicyStore.On("GetAll").Return([]*model.ReadReceiptPolicy{
+			{Id: "policy1", TeamIds: []string{"team1"}, IncludeInExport: false},
+			{Id: "policy2", IncludeInExport: true},
+		}, nil)
+
+		data, err := GetGenericExportData(getParams(mockStore, true))
+		require.NoError(t, err)
+		require.Len(t, data.Exports, 1)
+		require.Len(t, data.Exports[0].Posts, 1)
+		assert.Nil(t, data.Exports[0].Posts[0].ReadReceipts)
+		assert.Equal(t, 0, data.Results.ReadReceipts)
+	})
+
+	t.Run("receipts are not fetched when disabled", func(t *testing.T) {
+		mockStore := &storetest.Store{}
+		defer mockStore.AssertExpectations(t)
+
+		data, err := GetGenericExportData(getParams(mockStore, false))
+		require.NoError(t, err)
+		require.Len(t, data.Exports, 1)
+		assert.Nil(t, data.Exports[0].Posts[0].ReadReceipts)
+	})
+}
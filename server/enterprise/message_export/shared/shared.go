@@ -269,6 +269,7 @@ type RunExportResults struct {
 	NumChannels        int
 	Joins              int
 	Leaves             int
+	ReadReceipts       int
 	ProcessingPostsMs  int64
 	WriteExportResult
 }
@@ -14,6 +14,8 @@ type MessageExportStore interface {
 	Channel() store.ChannelStore
 	Compliance() store.ComplianceStore
 	FileInfo() MEFileInfoStore
+	PostReadReceipt() store.PostReadReceiptStore
+	ReadReceiptPolicy() store.ReadReceiptPolicyStore
 }
 
 type MEFileInfoStore interface {
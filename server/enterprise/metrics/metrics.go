@@ -44,6 +44,7 @@ const (
 	MetricsSubsystemClientsWeb         = "webapp"
 	MetricsSubsystemClientsDesktopApp  = "desktopapp"
 	MetricsSubsystemAccessControl      = "access_control"
+	MetricsSubsystemReadReceipts       = "read_receipts"
 	MetricsCloudInstallationLabel      = "installationId"
 	MetricsCloudDatabaseClusterLabel   = "databaseClusterName"
 	MetricsCloudInstallationGroupLabel = "installationGroupId"
@@ -238,6 +239,13 @@ type MetricsInterfaceImpl struct {
 	AccessControlEvaluateDuration          prometheus.Histogram
 	AccessControlSearchQueryDuration       prometheus.Histogram
 	AccessControlCacheInvalidation         prometheus.Counter
+
+	ReadReceiptBufferSize          prometheus.Gauge
+	ReadReceiptBufferFlushDuration prometheus.Histogram
+	ReadReceiptSummaryStaleness    prometheus.Gauge
+	ReadReceiptEventsPublished     prometheus.Counter
+	ReadReceiptEventsDropped       prometheus.Counter
+	ReadReceiptEventPublishRetries prometheus.Counter
 }
 
 func init() {
@@ -527,6 +535,8 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventPluginEvent,
 		model.ClusterEventInvalidateCacheForTermsOfService,
 		model.ClusterEventBusyStateChanged,
+		model.ClusterEventReadReceiptAggregate,
+		model.ClusterEventInvalidateCacheForReadReceiptPolicies,
 	} {
 		m.ClusterEventMap[event] = m.ClusterEventTypeCounters.With(prometheus.Labels{"name": string(event)})
 	}
@@ -1576,6 +1586,64 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		})
 	m.Registry.MustRegister(m.AccessControlCacheInvalidation)
 
+	m.ReadReceiptBufferSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemReadReceipts,
+			Name:        "buffer_size",
+			Help:        "Current number of implicit read receipts waiting to be written",
+			ConstLabels: additionalLabels,
+		},
+	)
+	m.Registry.MustRegister(m.ReadReceiptBufferSize)
+
+	m.ReadReceiptBufferFlushDuration = prometheus.NewHistogram(
+		withLabels(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystemReadReceipts,
+			Name:      "buffer_flush_duration_seconds",
+			Help:      "Duration of the time taken to write the buffered implicit read receipts (seconds)",
+		}))
+	m.Registry.MustRegister(m.ReadReceiptBufferFlushDuration)
+
+	m.ReadReceiptSummaryStaleness = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemReadReceipts,
+			Name:        "summary_staleness_seconds",
+			Help:        "Time the oldest read receipt summary update in progress has been waiting (seconds)",
+			ConstLabels: additionalLabels,
+		},
+	)
+	m.Registry.MustRegister(m.ReadReceiptSummaryStaleness)
+
+	m.ReadReceiptEventsPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemReadReceipts,
+		Name:        "events_published_total",
+		Help:        "The total number of read receipt websocket events published",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ReadReceiptEventsPublished)
+
+	m.ReadReceiptEventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemReadReceipts,
+		Name:        "events_dropped_total",
+		Help:        "The total number of read receipt websocket events that could not be published",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ReadReceiptEventsDropped)
+
+	m.ReadReceiptEventPublishRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemReadReceipts,
+		Name:        "event_publish_retries_total",
+		Help:        "The total number of retried deliveries of read receipt websocket events",
+		ConstLabels: additionalLabels,
+	})
+	m.Registry.MustRegister(m.ReadReceiptEventPublishRetries)
+
 	return m
 }
 
@@ -2194,6 +2262,30 @@ func (mi *MetricsInterfaceImpl) IncrementAccessControlCacheInvalidation() {
 	mi.AccessControlCacheInvalidation.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) ObserveReadReceiptBufferSize(size int64) {
+	mi.ReadReceiptBufferSize.Set(float64(size))
+}
+
+func (mi *MetricsInterfaceImpl) ObserveReadReceiptBufferFlushDuration(elapsed float64) {
+	mi.ReadReceiptBufferFlushDuration.Observe(elapsed)
+}
+
+func (mi *MetricsInterfaceImpl) ObserveReadReceiptSummaryStaleness(staleness float64) {
+	mi.ReadReceiptSummaryStaleness.Set(staleness)
+}
+
+func (mi *MetricsInterfaceImpl) IncrementReadReceiptEventsPublished(count float64) {
+	mi.ReadReceiptEventsPublished.Add(count)
+}
+
+func (mi *MetricsInterfaceImpl) IncrementReadReceiptEventsDropped(count float64) {
+	mi.ReadReceiptEventsDropped.Add(count)
+}
+
+func (mi *MetricsInterfaceImpl) IncrementReadReceiptEventPublishRetries() {
+	mi.ReadReceiptEventPublishRetries.Inc()
+}
+
 func (mi *MetricsInterfaceImpl) ClearMobileClientSessionMetadata() {
 	mi.MobileClientSessionMetadataGauge.Reset()
 }
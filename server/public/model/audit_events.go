@@ -273,6 +273,19 @@ const (
 	AuditEventUploadRemoteData               = "uploadRemoteData"               // upload data to remote cluster
 )
 
+// Read Receipts
+const (
+	AuditEventClampReadReceiptReadAt           = "clampReadReceiptReadAt"           // adjust a client supplied read time that was out of range
+	AuditEventCreateReadReceiptWebhook         = "createReadReceiptWebhook"         // add a read receipt webhook to a channel
+	AuditEventDeleteReadReceiptWebhook         = "deleteReadReceiptWebhook"         // remove a read receipt webhook from a channel
+	AuditEventExportReadReceipts               = "exportReadReceipts"               // download the read receipts of the user
+	AuditEventSaveGhostReadReceipt             = "saveGhostReadReceipt"             // record a read that is not visible to anyone
+	AuditEventSkipImpersonatedReadReceipt      = "skipImpersonatedReadReceipt"      // ignore a read made on behalf of a user
+	AuditEventUpdateReadReceiptChannelSettings = "updateReadReceiptChannelSettings" // change the read receipt settings of a channel
+	AuditEventUpdateReadReceiptPolicies        = "updateReadReceiptPolicies"        // replace read receipt policies
+	AuditEventUpdateSessionReadReceiptOptOut   = "updateSessionReadReceiptOptOut"   // stop or resume recording the reads made through a session
+)
+
 // Roles
 const (
 	AuditEventPatchRole = "patchRole" // update role permissions
@@ -12,6 +12,12 @@ const (
 	AuthCodeResponseType = "code"
 	ImplicitResponseType = "token"
 	DefaultScope         = "user"
+	// ReadReceiptsWriteScope lets an OAuth app mark posts as read on behalf of
+	// the user, which tokens issued to integrations cannot do otherwise.
+	ReadReceiptsWriteScope = "read_receipts:write"
+	// ReadReceiptsScope lets an OAuth app read the receipts and summaries of
+	// posts without being granted the default scope.
+	ReadReceiptsScope = "read_receipts"
 )
 
 type AuthData struct {
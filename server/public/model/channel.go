@@ -43,6 +43,10 @@ const (
 
 	ChannelSortByUsername = "username"
 	ChannelSortByStatus   = "status"
+
+	// ChannelMembersSortByLastRead orders channel members by their most recent
+	// read receipt in the channel.
+	ChannelMembersSortByLastRead = "last_read"
 )
 
 type ChannelBannerInfo struct {
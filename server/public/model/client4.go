@@ -1868,6 +1868,18 @@ func (c *Client4) RevokeSession(ctx context.Context, userId, sessionId string) (
 	return BuildResponse(r), nil
 }
 
+// UpdateSessionReadReceiptOptOut stops or resumes recording the reads made
+// through a session of the user.
+func (c *Client4) UpdateSessionReadReceiptOptOut(ctx context.Context, userId, sessionId string, optOut bool) (*Response, error) {
+	requestBody := map[string]any{"opt_out": optOut}
+	r, err := c.DoAPIPut(ctx, c.userRoute(userId)+"/sessions/"+sessionId+"/read_receipt_optout", StringInterfaceToJSON(requestBody))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // RevokeAllSessions revokes all sessions for the provided user id string.
 func (c *Client4) RevokeAllSessions(ctx context.Context, userId string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/sessions/revoke/all", "")
@@ -2209,6 +2221,36 @@ func (c *Client4) GetUsersForReporting(ctx context.Context, options *UserReportO
 	return list, BuildResponse(r), nil
 }
 
+// GetChannelArchiveRecommendations returns a page of the channels few of whose
+// members read anything lately. Zero options fall back to the server defaults.
+func (c *Client4) GetChannelArchiveRecommendations(ctx context.Context, options *ChannelArchiveReportOptions) ([]*ChannelArchiveRecommendation, *Response, error) {
+	values := url.Values{}
+	if options.TeamId != "" {
+		values.Set("team_id", options.TeamId)
+	}
+	if options.MaxReaderPercent > 0 {
+		values.Set("max_reader_percent", strconv.Itoa(options.MaxReaderPercent))
+	}
+	if options.InactiveDays > 0 {
+		values.Set("inactive_days", strconv.Itoa(options.InactiveDays))
+	}
+	values.Set("page", strconv.Itoa(options.Page))
+	if options.PerPage > 0 {
+		values.Set("per_page", strconv.Itoa(options.PerPage))
+	}
+
+	r, err := c.DoAPIGet(ctx, c.reportsRoute()+"/channels/archive_recommendations?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*ChannelArchiveRecommendation
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetChannelArchiveRecommendations", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // Bots section
 
 // CreateBot creates a bot in the system based on the provided bot struct.
@@ -3744,6 +3786,60 @@ func (c *Client4) GetChannelMembers(ctx context.Context, channelId string, page,
 	return ch, BuildResponse(r), nil
 }
 
+// GetChannelMembersByLastReadActivity gets a page of channel members, the ones
+// who most recently read a post in the channel first.
+func (c *Client4) GetChannelMembersByLastReadActivity(ctx context.Context, channelId string, page, perPage int) (ChannelMembers, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v&sort=%v", page, perPage, ChannelMembersSortByLastRead)
+	r, err := c.DoAPIGet(ctx, c.channelMembersRoute(channelId)+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var ch ChannelMembers
+	err = json.NewDecoder(r.Body).Decode(&ch)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetChannelMembersByLastReadActivity", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return ch, BuildResponse(r), nil
+}
+
+// GetChannelMembersReadActivity gets a page of channel members with their read
+// activity, the ones who read the channel the least recently first.
+func (c *Client4) GetChannelMembersReadActivity(ctx context.Context, channelId string, page, perPage int) ([]*ChannelMemberReadActivity, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.channelMembersRoute(channelId)+"/read_activity"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var activity []*ChannelMemberReadActivity
+	if err := json.NewDecoder(r.Body).Decode(&activity); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetChannelMembersReadActivity", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return activity, BuildResponse(r), nil
+}
+
+// GetChannelDailyReaderCounts returns how many distinct users read the channel on
+// each UTC day from fromDay to toDay, both formatted as 2006-01-02.
+func (c *Client4) GetChannelDailyReaderCounts(ctx context.Context, channelId, fromDay, toDay string) ([]*DailyReaderCount, *Response, error) {
+	values := url.Values{}
+	values.Set("from", fromDay)
+	values.Set("to", toDay)
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/read_receipts/daily_readers?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var counts []*DailyReaderCount
+	if err := json.NewDecoder(r.Body).Decode(&counts); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetChannelDailyReaderCounts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return counts, BuildResponse(r), nil
+}
+
 // GetChannelMembersWithTeamData gets a page of all channel members for a user.
 func (c *Client4) GetChannelMembersWithTeamData(ctx context.Context, userID string, page, perPage int) (ChannelMembersWithTeamData, *Response, error) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -9417,6 +9513,691 @@ func (c *Client4) UnacknowledgePost(ctx context.Context, postId, userId string)
 	return BuildResponse(r), nil
 }
 
+// SavePostReadReceipt marks a post as read by the current user. When the receipt
+// matches the stored one the server answers {"changed": false} and the returned
+// receipt is empty.
+func (c *Client4) SavePostReadReceipt(ctx context.Context, postId string, readReceiptRequest *ReadReceiptRequest) (*PostReadReceipt, *Response, error) {
+	buf, err := json.Marshal(readReceiptRequest)
+	if err != nil {
+		return nil, nil, NewAppError("SavePostReadReceipt", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postRoute(postId)+"/read", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var receipt *PostReadReceipt
+	if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil {
+		return nil, nil, NewAppError("SavePostReadReceipt", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return receipt, BuildResponse(r), nil
+}
+
+// SaveEmailLinkPostReadReceipt records that the user opened the post from the
+// permalink of a notification email, given the token the permalink carried. The
+// receipt is nil when the user had already read the post.
+func (c *Client4) SaveEmailLinkPostReadReceipt(ctx context.Context, postId, token string) (*PostReadReceipt, *Response, error) {
+	buf, err := json.Marshal(&ReadReceiptEmailLinkRequest{Token: token})
+	if err != nil {
+		return nil, nil, NewAppError("SaveEmailLinkPostReadReceipt", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postRoute(postId)+"/read/email_link", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var receipt *PostReadReceipt
+	if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil {
+		return nil, nil, NewAppError("SaveEmailLinkPostReadReceipt", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if receipt != nil && receipt.PostId == "" {
+		receipt = nil
+	}
+	return receipt, BuildResponse(r), nil
+}
+
+// SaveThreadReadReceipts marks a thread, its root post and every reply, as read.
+func (c *Client4) SaveThreadReadReceipts(ctx context.Context, rootId string, readReceiptRequest *ReadReceiptRequest) (*ReadReceiptBatchResponse, *Response, error) {
+	buf, err := json.Marshal(readReceiptRequest)
+	if err != nil {
+		return nil, nil, NewAppError("SaveThreadReadReceipts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postRoute(rootId)+"/read/thread", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var resp *ReadReceiptBatchResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, nil, NewAppError("SaveThreadReadReceipts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return resp, BuildResponse(r), nil
+}
+
+// SaveBotPostReadReceipt acknowledges a post on behalf of a bot. The client must
+// be authenticated with a bot's personal access token granted
+// UserAccessTokenScopeBotReadReceipts.
+func (c *Client4) SaveBotPostReadReceipt(ctx context.Context, postId string) (*PostReadReceipt, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postRoute(postId)+"/read/bot", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var receipt *PostReadReceipt
+	if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil {
+		return nil, nil, NewAppError("SaveBotPostReadReceipt", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return receipt, BuildResponse(r), nil
+}
+
+func (c *Client4) DeletePostReadReceipt(ctx context.Context, postId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.postRoute(postId)+"/read")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// IsPostRead reports whether the current user has a receipt for the post,
+// without fetching the receipts of the post.
+func (c *Client4) IsPostRead(ctx context.Context, postId string) (bool, *Response, error) {
+	r, err := c.DoAPIRequest(ctx, http.MethodHead, c.APIURL+c.postRoute(postId)+"/read_receipts/me", "", "")
+	if r != nil && r.StatusCode == http.StatusNotFound {
+		return false, BuildResponse(r), nil
+	}
+	if err != nil {
+		return false, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return true, BuildResponse(r), nil
+}
+
+func (c *Client4) GetPostReadReceipts(ctx context.Context, postId string) (*PostReadReceiptInfo, *Response, error) {
+	return c.GetPostReadReceiptsForDeviceType(ctx, postId, "")
+}
+
+// GetPostReadReceiptsForDeviceType returns the receipts of the post recorded from
+// deviceType, one of the ReadReceiptDeviceType constants, or all of them if empty.
+func (c *Client4) GetPostReadReceiptsForDeviceType(ctx context.Context, postId, deviceType string) (*PostReadReceiptInfo, *Response, error) {
+	values := url.Values{}
+	if deviceType != "" {
+		values.Set("device_type", deviceType)
+	}
+
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/receipts?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var info *PostReadReceiptInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return nil, nil, NewAppError("GetPostReadReceiptsForDeviceType", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return info, BuildResponse(r), nil
+}
+
+// GetPostReadReceiptsCompact returns the same receipts as GetPostReadReceipts,
+// fetched in the compact encoding to save bandwidth.
+func (c *Client4) GetPostReadReceiptsCompact(ctx context.Context, postId string) (*PostReadReceiptInfo, *Response, error) {
+	r, err := c.DoAPIRequestWithHeaders(ctx, http.MethodGet, c.APIURL+c.postRoute(postId)+"/receipts", "", map[string]string{"Accept": ReadReceiptCompactContentType})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var info PostReadReceiptInfo
+	data, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = info.UnmarshalCompactJSON(data)
+	}
+	if err != nil {
+		return nil, nil, NewAppError("GetPostReadReceiptsCompact", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &info, BuildResponse(r), nil
+}
+
+// GetPostReadReceiptSummary returns the read counters of a post.
+func (c *Client4) GetPostReadReceiptSummary(ctx context.Context, postId string) (*PostReadReceiptSummary, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/receipts/summary", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var summary *PostReadReceiptSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		return nil, nil, NewAppError("GetPostReadReceiptSummary", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return summary, BuildResponse(r), nil
+}
+
+// GetChannelReadReceiptSummaries returns the read counters of the posts of the
+// channel updated after since, as seen by the user.
+func (c *Client4) GetChannelReadReceiptSummaries(ctx context.Context, userId, channelId string, since int64) ([]*PostReadReceiptSummary, *Response, error) {
+	values := url.Values{}
+	values.Set("since", strconv.FormatInt(since, 10))
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/channels/"+channelId+"/read_receipts?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var summaries []*PostReadReceiptSummary
+	if err := json.NewDecoder(r.Body).Decode(&summaries); err != nil {
+		return nil, nil, NewAppError("GetChannelReadReceiptSummaries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return summaries, BuildResponse(r), nil
+}
+
+// GetPostReadReceiptExtremes returns the earliest and the latest readers of the post.
+func (c *Client4) GetPostReadReceiptExtremes(ctx context.Context, postId string) (*PostReadReceiptExtremes, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/read_receipts/extremes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var extremes *PostReadReceiptExtremes
+	if err := json.NewDecoder(r.Body).Decode(&extremes); err != nil {
+		return nil, nil, NewAppError("GetPostReadReceiptExtremes", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return extremes, BuildResponse(r), nil
+}
+
+// GetPostSeenState returns whether a direct message was read by someone other
+// than its author.
+func (c *Client4) GetPostSeenState(ctx context.Context, postId string) (*PostSeenState, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/read_receipts/seen", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var state *PostSeenState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		return nil, nil, NewAppError("GetPostSeenState", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return state, BuildResponse(r), nil
+}
+
+// GetPostMentionReadState returns which of the users mentioned by the post read it.
+func (c *Client4) GetPostMentionReadState(ctx context.Context, postId string) (*PostMentionReadState, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/read_receipts/mentions", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var state *PostMentionReadState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		return nil, nil, NewAppError("GetPostMentionReadState", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return state, BuildResponse(r), nil
+}
+
+// GetReadReceiptBroadcastSummary returns the read progress of the copies of a broadcast.
+func (c *Client4) GetReadReceiptBroadcastSummary(ctx context.Context, broadcastId string) (*ReadReceiptBroadcastSummary, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/broadcasts/"+broadcastId+"/read_summary", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var summary *ReadReceiptBroadcastSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptBroadcastSummary", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return summary, BuildResponse(r), nil
+}
+
+// GetReadReceiptAnnouncementReport returns the read progress of the copies of an
+// announcement cross-posted to channels of the team.
+func (c *Client4) GetReadReceiptAnnouncementReport(ctx context.Context, teamId string, postIds []string) (*ReadReceiptAnnouncementReport, *Response, error) {
+	values := url.Values{}
+	values.Set("post_ids", strings.Join(postIds, ","))
+	r, err := c.DoAPIGet(ctx, c.teamRoute(teamId)+"/read_receipts/announcement_report?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report *ReadReceiptAnnouncementReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptAnnouncementReport", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return report, BuildResponse(r), nil
+}
+
+// GetReadDevicesForPostUser returns every device the user read the post on.
+func (c *Client4) GetReadDevicesForPostUser(ctx context.Context, postId, userId string) ([]*PostReadReceipt, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/receipts/"+userId+"/devices", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var devices []*PostReadReceipt
+	if err := json.NewDecoder(r.Body).Decode(&devices); err != nil {
+		return nil, nil, NewAppError("GetReadDevicesForPostUser", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return devices, BuildResponse(r), nil
+}
+
+func (c *Client4) SavePostReadReceiptsBatch(ctx context.Context, batch *ReadReceiptBatchRequest) (*ReadReceiptBatchResponse, *Response, error) {
+	buf, err := json.Marshal(batch)
+	if err != nil {
+		return nil, nil, NewAppError("SavePostReadReceiptsBatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postsRoute()+"/read/batch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var resp *ReadReceiptBatchResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, nil, NewAppError("SavePostReadReceiptsBatch", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return resp, BuildResponse(r), nil
+}
+
+// GetReadCountsForLatestPosts returns the read counters of the perPage most recent
+// posts of a channel, newest first.
+func (c *Client4) GetReadCountsForLatestPosts(ctx context.Context, channelId string, perPage int) ([]*PostReadCount, *Response, error) {
+	query := fmt.Sprintf("?per_page=%v", perPage)
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/posts/latest_read_counts"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var counts []*PostReadCount
+	if err := json.NewDecoder(r.Body).Decode(&counts); err != nil {
+		return nil, nil, NewAppError("GetReadCountsForLatestPosts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return counts, BuildResponse(r), nil
+}
+
+// GetReadReceiptsForUser returns a page of the user's read receipts. Pass the
+// NextPage token of the previous response, decoded into opts.Cursor, to continue.
+func (c *Client4) GetReadReceiptsForUser(ctx context.Context, userId string, opts GetReadReceiptsForUserOptions) (*ReadReceiptsForUserPage, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/read_receipts/page?"+readReceiptsPageQuery(opts).Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var page *ReadReceiptsForUserPage
+	if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptsForUser", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return page, BuildResponse(r), nil
+}
+
+// GetReadReceiptSessionActivity returns a page of the read activity of the user
+// through each of their sessions and devices since the given time, or over the
+// last 30 days when since is 0.
+func (c *Client4) GetReadReceiptSessionActivity(ctx context.Context, userId string, since int64, page, perPage int) ([]*ReadReceiptSessionActivity, *Response, error) {
+	values := url.Values{}
+	if since > 0 {
+		values.Set("since", strconv.FormatInt(since, 10))
+	}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/read_receipts/activity?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var activity []*ReadReceiptSessionActivity
+	if err := json.NewDecoder(r.Body).Decode(&activity); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptSessionActivity", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return activity, BuildResponse(r), nil
+}
+
+// GetPostsReadState gets whether the user read each of the posts, and when, for
+// at most ReadStateMaxPosts posts.
+func (c *Client4) GetPostsReadState(ctx context.Context, userId string, postIds []string) (map[string]*PostReadState, *Response, error) {
+	js, err := json.Marshal(postIds)
+	if err != nil {
+		return nil, nil, NewAppError("GetPostsReadState", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.userRoute(userId)+"/posts/read_state", js)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var states map[string]*PostReadState
+	if err := json.NewDecoder(r.Body).Decode(&states); err != nil {
+		return nil, BuildResponse(r), NewAppError("GetPostsReadState", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return states, BuildResponse(r), nil
+}
+
+// ExportReadReceiptsForUser downloads all the read receipts of the current user,
+// in the ReadReceiptExportFormatJSON or ReadReceiptExportFormatCSV format. Each
+// user can export their receipts once per hour.
+func (c *Client4) ExportReadReceiptsForUser(ctx context.Context, format string) ([]byte, *Response, error) {
+	values := url.Values{}
+	values.Set("format", format)
+	r, err := c.DoAPIGet(ctx, c.userRoute(Me)+"/read_receipts/export?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("ExportReadReceiptsForUser", "model.client.read_file.app_error", nil, "", r.StatusCode).Wrap(err)
+	}
+	return data, BuildResponse(r), nil
+}
+
+// GetReadReceiptsForSession returns a page of the read receipts recorded through
+// the session. Must have the compliance monitoring permission.
+func (c *Client4) GetReadReceiptsForSession(ctx context.Context, sessionId string, opts GetReadReceiptsForUserOptions) (*ReadReceiptsForUserPage, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipts/sessions/"+sessionId+"?"+readReceiptsPageQuery(opts).Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var page *ReadReceiptsForUserPage
+	if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptsForSession", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return page, BuildResponse(r), nil
+}
+
+// GetThreadReadReceiptSummary returns how far the participants of the thread
+// read its replies.
+func (c *Client4) GetThreadReadReceiptSummary(ctx context.Context, rootID string) (*ThreadReadReceiptSummary, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/threads/"+rootID+"/read_summary", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var summary *ThreadReadReceiptSummary
+	if err := json.NewDecoder(r.Body).Decode(&summary); err != nil {
+		return nil, nil, NewAppError("GetThreadReadReceiptSummary", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return summary, BuildResponse(r), nil
+}
+
+// GetColdStorageReadReceipts returns the receipts of a channel offloaded to cold
+// storage that match the query. Must have the compliance monitoring permission.
+func (c *Client4) GetColdStorageReadReceipts(ctx context.Context, query ReadReceiptColdStorageQuery) (*ReadReceiptColdStorageResult, *Response, error) {
+	values := url.Values{}
+	values.Set("channel_id", query.ChannelId)
+	if query.PostId != "" {
+		values.Set("post_id", query.PostId)
+	}
+	if query.UserId != "" {
+		values.Set("user_id", query.UserId)
+	}
+	if query.Since > 0 {
+		values.Set("since", strconv.FormatInt(query.Since, 10))
+	}
+	if query.Until > 0 {
+		values.Set("until", strconv.FormatInt(query.Until, 10))
+	}
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipts/cold_storage?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var result *ReadReceiptColdStorageResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, nil, NewAppError("GetColdStorageReadReceipts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return result, BuildResponse(r), nil
+}
+
+func readReceiptsPageQuery(opts GetReadReceiptsForUserOptions) url.Values {
+	query := url.Values{}
+	if opts.ChannelId != "" {
+		query.Set("channel_id", opts.ChannelId)
+	}
+	if len(opts.Sources) > 0 {
+		query.Set("source", strings.Join(opts.Sources, ","))
+	}
+	if opts.Since > 0 {
+		query.Set("since", strconv.FormatInt(opts.Since, 10))
+	}
+	if opts.Until > 0 {
+		query.Set("until", strconv.FormatInt(opts.Until, 10))
+	}
+	if opts.PerPage > 0 {
+		query.Set("limit", strconv.Itoa(opts.PerPage))
+	}
+	if !opts.Cursor.IsEmpty() {
+		query.Set("page", opts.Cursor.String())
+	}
+	return query
+}
+
+// GetReadReceiptsOverview returns the read receipt activity overview shown in the system console.
+func (c *Client4) GetReadReceiptsOverview(ctx context.Context) (*ReadReceiptsOverview, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipts/overview", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var overview *ReadReceiptsOverview
+	if err := json.NewDecoder(r.Body).Decode(&overview); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptsOverview", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return overview, BuildResponse(r), nil
+}
+
+// GetReadReceiptTeamUsage returns the receipt volume and storage of every team.
+func (c *Client4) GetReadReceiptTeamUsage(ctx context.Context) ([]*ReadReceiptTeamUsage, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipts/usage", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var usage []*ReadReceiptTeamUsage
+	if err := json.NewDecoder(r.Body).Decode(&usage); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptTeamUsage", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return usage, BuildResponse(r), nil
+}
+
+// GetChannelReadReceiptChanges returns a page of the receipt changes of the
+// channel that follow the given sequence, for the user to resynchronize the
+// receipts of the channel.
+func (c *Client4) GetChannelReadReceiptChanges(ctx context.Context, userId, channelId string, after int64, perPage int) (*ReadReceiptChangesPage, *Response, error) {
+	return c.getReadReceiptChanges(ctx, c.userRoute(userId)+"/channels/"+channelId+"/read_receipts/changes", after, perPage)
+}
+
+// GetReadReceiptChanges returns a page of the receipt changes of every channel
+// that follow the given sequence. Must have the compliance monitoring permission.
+func (c *Client4) GetReadReceiptChanges(ctx context.Context, after int64, perPage int) (*ReadReceiptChangesPage, *Response, error) {
+	return c.getReadReceiptChanges(ctx, "/admin/read_receipts/changes", after, perPage)
+}
+
+func (c *Client4) getReadReceiptChanges(ctx context.Context, route string, after int64, perPage int) (*ReadReceiptChangesPage, *Response, error) {
+	values := url.Values{}
+	values.Set("after", strconv.FormatInt(after, 10))
+	values.Set("per_page", strconv.Itoa(perPage))
+
+	r, err := c.DoAPIGet(ctx, route+"?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var page *ReadReceiptChangesPage
+	if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+		return nil, nil, NewAppError("getReadReceiptChanges", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return page, BuildResponse(r), nil
+}
+
+// GetReadReceiptsHealth reports whether the read receipt summaries are kept up
+// to date within ServiceSettings.ReadReceiptsSummarySLASeconds.
+func (c *Client4) GetReadReceiptsHealth(ctx context.Context) (*ReadReceiptsHealth, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipts/health", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var health *ReadReceiptsHealth
+	if err := json.NewDecoder(r.Body).Decode(&health); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptsHealth", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return health, BuildResponse(r), nil
+}
+
+// VerifyReadReceiptChain checks the read receipt integrity chain of the channel.
+func (c *Client4) VerifyReadReceiptChain(ctx context.Context, channelId string) (*ReadReceiptChainVerification, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/read_receipts/verify", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var verification ReadReceiptChainVerification
+	if err := json.NewDecoder(r.Body).Decode(&verification); err != nil {
+		return nil, nil, NewAppError("VerifyReadReceiptChain", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &verification, BuildResponse(r), nil
+}
+
+func (c *Client4) GetReadReceiptPolicies(ctx context.Context) ([]*ReadReceiptPolicy, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/admin/read_receipt_policies", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var policies []*ReadReceiptPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policies); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptPolicies", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return policies, BuildResponse(r), nil
+}
+
+// GetEffectiveReadReceiptPolicy returns how read receipts behave for the current
+// user in the channel.
+func (c *Client4) GetEffectiveReadReceiptPolicy(ctx context.Context, channelId string) (*ReadReceiptEffectivePolicy, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/read_receipt_policy", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var policy *ReadReceiptEffectivePolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		return nil, nil, NewAppError("GetEffectiveReadReceiptPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return policy, BuildResponse(r), nil
+}
+
+// UpdateReadReceiptPolicies replaces every read receipt policy with the given ones.
+func (c *Client4) UpdateReadReceiptPolicies(ctx context.Context, policies []*ReadReceiptPolicy) ([]*ReadReceiptPolicy, *Response, error) {
+	buf, err := json.Marshal(policies)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateReadReceiptPolicies", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, "/admin/read_receipt_policies", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var saved []*ReadReceiptPolicy
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, nil, NewAppError("UpdateReadReceiptPolicies", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return saved, BuildResponse(r), nil
+}
+
+// GetReadReceiptChannelSettings returns the read receipt settings of the channel.
+func (c *Client4) GetReadReceiptChannelSettings(ctx context.Context, channelId string) (*ReadReceiptChannelSettings, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/read_receipts/settings", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var settings ReadReceiptChannelSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptChannelSettings", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &settings, BuildResponse(r), nil
+}
+
+// UpdateReadReceiptChannelSettings changes the read receipt settings of the channel.
+func (c *Client4) UpdateReadReceiptChannelSettings(ctx context.Context, channelId string, settings *ReadReceiptChannelSettings) (*ReadReceiptChannelSettings, *Response, error) {
+	buf, err := json.Marshal(settings)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateReadReceiptChannelSettings", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, c.channelRoute(channelId)+"/read_receipts/settings", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var saved ReadReceiptChannelSettings
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, nil, NewAppError("UpdateReadReceiptChannelSettings", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &saved, BuildResponse(r), nil
+}
+
+// PreviewReadReceiptCleanup returns how many receipts enforcing the retention of
+// the policy would delete, without deleting them.
+func (c *Client4) PreviewReadReceiptCleanup(ctx context.Context, policy *ReadReceiptPolicy) (*ReadReceiptCleanupPreview, *Response, error) {
+	buf, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, NewAppError("PreviewReadReceiptCleanup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, "/admin/read_receipts/cleanup/preview", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var preview ReadReceiptCleanupPreview
+	if err := json.NewDecoder(r.Body).Decode(&preview); err != nil {
+		return nil, nil, NewAppError("PreviewReadReceiptCleanup", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &preview, BuildResponse(r), nil
+}
+
+func (c *Client4) GetReadReceiptWebhooksForChannel(ctx context.Context, channelId string) ([]*ReadReceiptWebhook, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelId)+"/read_receipt_webhooks", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var hooks []*ReadReceiptWebhook
+	if err := json.NewDecoder(r.Body).Decode(&hooks); err != nil {
+		return nil, nil, NewAppError("GetReadReceiptWebhooksForChannel", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return hooks, BuildResponse(r), nil
+}
+
+// CreateReadReceiptWebhook adds a read receipt webhook to the channel of hook.
+// The returned webhook holds the secret its deliveries are signed with.
+func (c *Client4) CreateReadReceiptWebhook(ctx context.Context, hook *ReadReceiptWebhook) (*ReadReceiptWebhook, *Response, error) {
+	buf, err := json.Marshal(hook)
+	if err != nil {
+		return nil, nil, NewAppError("CreateReadReceiptWebhook", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.channelRoute(hook.ChannelId)+"/read_receipt_webhooks", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var saved ReadReceiptWebhook
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, nil, NewAppError("CreateReadReceiptWebhook", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &saved, BuildResponse(r), nil
+}
+
+func (c *Client4) DeleteReadReceiptWebhook(ctx context.Context, channelId, hookId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.channelRoute(channelId)+"/read_receipt_webhooks/"+hookId)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) AddUserToGroupSyncables(ctx context.Context, userID string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.ldapRoute()+"/users/"+userID+"/group_sync_memberships", "")
 	if err != nil {
@@ -9518,6 +10299,21 @@ func (c *Client4) ListChannelBookmarksForChannel(ctx context.Context, channelId
 	return b, BuildResponse(r), nil
 }
 
+// GetChannelBookmarkReadSummaries returns the read summaries of the posts the
+// bookmarks of the channel link to.
+func (c *Client4) GetChannelBookmarkReadSummaries(ctx context.Context, channelId string) ([]*ChannelBookmarkReadSummary, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.bookmarksRoute(channelId)+"/read_summaries", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var summaries []*ChannelBookmarkReadSummary
+	if err := json.NewDecoder(r.Body).Decode(&summaries); err != nil {
+		return nil, nil, NewAppError("GetChannelBookmarkReadSummaries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return summaries, BuildResponse(r), nil
+}
+
 func (c *Client4) SubmitClientMetrics(ctx context.Context, report *PerformanceReport) (*Response, error) {
 	buf, err := json.Marshal(report)
 	if err != nil {
@@ -312,10 +312,18 @@ type TeamsLimits struct {
 	Active *int `json:"active"`
 }
 
+// ReadReceiptsLimits is the number of read receipts a workspace may store. Used
+// is filled in by the server with the estimated number of receipts it stores.
+type ReadReceiptsLimits struct {
+	Receipts *int64 `json:"receipts"`
+	Used     *int64 `json:"used,omitempty"`
+}
+
 type ProductLimits struct {
-	Files    *FilesLimits    `json:"files,omitempty"`
-	Messages *MessagesLimits `json:"messages,omitempty"`
-	Teams    *TeamsLimits    `json:"teams,omitempty"`
+	Files        *FilesLimits        `json:"files,omitempty"`
+	Messages     *MessagesLimits     `json:"messages,omitempty"`
+	Teams        *TeamsLimits        `json:"teams,omitempty"`
+	ReadReceipts *ReadReceiptsLimits `json:"read_receipts,omitempty"`
 }
 
 // CreateSubscriptionRequest is the parameters for the API request to create a subscription.
@@ -42,6 +42,8 @@ const (
 	ClusterEventPluginEvent                                 ClusterEvent = "plugin_event"
 	ClusterEventInvalidateCacheForTermsOfService            ClusterEvent = "inv_terms_of_service"
 	ClusterEventBusyStateChanged                            ClusterEvent = "busy_state_change"
+	ClusterEventReadReceiptAggregate                        ClusterEvent = "read_receipt_aggregate"
+	ClusterEventInvalidateCacheForReadReceiptPolicies       ClusterEvent = "inv_read_receipt_policies"
 	// Note: if you are adding a new event, please also add it in the slice of
 	// m.ClusterEventMap in metrics/metrics.go file.
 
@@ -99,6 +99,11 @@ const (
 	ReadReceiptsEnabledDefaultOn  = "enabled_default_on"
 	ReadReceiptsAlwaysOn          = "always_on"
 
+	// ReadReceiptsExportSink constants are the built-in sinks receipts can be
+	// streamed to. Other sinks, like Kafka, register themselves under their own name.
+	ReadReceiptsExportSinkWebhook = "webhook"
+	ReadReceiptsExportSinkFile    = "file"
+
 	EmailBatchingBufferSize = 256
 	EmailBatchingInterval   = 30
 
@@ -467,6 +472,37 @@ type ServiceSettings struct {
 	ReadReceiptsThrottleIntervalMs                    *int    `access:"experimental_features"`
 	ReadReceiptsBatchWindowMs                         *int    `access:"experimental_features"`
 	ReadReceiptsEnableTeamChannels                    *bool   `access:"experimental_features"`
+	ReadReceiptsEnableBotReceipts                     *bool   `access:"experimental_features"`
+	ReadReceiptsClientDebounceMs                      *int    `access:"experimental_features"`
+	ReadReceiptsBatchMaxWaitMs                        *int    `access:"experimental_features"`
+	ReadReceiptsStoreAllDevices                       *bool   `access:"experimental_features"`
+	ReadReceiptsMaxClockSkewMs                        *int    `access:"experimental_features"`
+	ReadReceiptsEnableIntegrityChain                  *bool   `access:"experimental_features"`
+	ReadReceiptsBufferMaxSize                         *int    `access:"experimental_features"`
+	ReadReceiptsBufferFlushIntervalMs                 *int    `access:"experimental_features"`
+	ReadReceiptsBufferMaxPendingPerUser               *int    `access:"experimental_features"`
+	ReadReceiptsMaxPerChannel                         *int    `access:"experimental_features"`
+	ReadReceiptsEnableUnreadDMNudge                   *bool   `access:"experimental_features"`
+	ReadReceiptsUnreadDMNudgeHours                    *int    `access:"experimental_features"`
+	ReadReceiptsExportSink                            *string `access:"experimental_features"`
+	ReadReceiptsExportWebhookURL                      *string `access:"experimental_features"`
+	ReadReceiptsExportFilePath                        *string `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	ReadReceiptsExportBufferSize                      *int    `access:"experimental_features"`
+	ReadReceiptsExportDeadLetterPath                  *string `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	ReadReceiptsSummarySLASeconds                     *int    `access:"experimental_features"`
+	ReadReceiptsRestrictDMRecall                      *bool   `access:"experimental_features"`
+	ReadReceiptsDeactivationScrubDays                 *int    `access:"experimental_features"`
+	ReadReceiptsMinimumConfidence                     *string `access:"experimental_features"`
+	ReadReceiptsMinWriteIntervalMs                    *int    `access:"experimental_features"`
+	ReadReceiptsColdStorageDays                       *int    `access:"experimental_features"`
+	ReadReceiptsLegacyBatchEvents                     *bool   `access:"experimental_features"`
+	ReadReceiptsTransactionalSummary                  *bool   `access:"experimental_features"`
+	ReadReceiptsServerTimestamps                      *bool   `access:"experimental_features"`
+	ReadReceiptsChangesRetentionHours                 *int    `access:"experimental_features"`
+	ReadReceiptsPreciseUnread                         *bool   `access:"experimental_features"`
+	ReadReceiptsSamplingThreshold                     *int    `access:"experimental_features"`
+	ReadReceiptsBitmapStorageDays                     *int    `access:"experimental_features"`
+	ReadReceiptsBitmapMinReceipts                     *int    `access:"experimental_features"`
 }
 
 var MattermostGiphySdkKey string
@@ -1055,6 +1091,130 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 	if s.ReadReceiptsEnableTeamChannels == nil {
 		s.ReadReceiptsEnableTeamChannels = NewPointer(false)
 	}
+
+	if s.ReadReceiptsEnableBotReceipts == nil {
+		s.ReadReceiptsEnableBotReceipts = NewPointer(false)
+	}
+
+	if s.ReadReceiptsClientDebounceMs == nil {
+		s.ReadReceiptsClientDebounceMs = NewPointer(1000)
+	}
+
+	if s.ReadReceiptsBatchMaxWaitMs == nil {
+		s.ReadReceiptsBatchMaxWaitMs = NewPointer(5000)
+	}
+
+	if s.ReadReceiptsStoreAllDevices == nil {
+		s.ReadReceiptsStoreAllDevices = NewPointer(false)
+	}
+
+	if s.ReadReceiptsMaxClockSkewMs == nil {
+		s.ReadReceiptsMaxClockSkewMs = NewPointer(60000)
+	}
+
+	if s.ReadReceiptsEnableIntegrityChain == nil {
+		s.ReadReceiptsEnableIntegrityChain = NewPointer(false)
+	}
+
+	if s.ReadReceiptsBufferMaxSize == nil {
+		s.ReadReceiptsBufferMaxSize = NewPointer(1000)
+	}
+
+	if s.ReadReceiptsBufferFlushIntervalMs == nil {
+		s.ReadReceiptsBufferFlushIntervalMs = NewPointer(1000)
+	}
+
+	if s.ReadReceiptsBufferMaxPendingPerUser == nil {
+		s.ReadReceiptsBufferMaxPendingPerUser = NewPointer(100)
+	}
+
+	if s.ReadReceiptsMaxPerChannel == nil {
+		s.ReadReceiptsMaxPerChannel = NewPointer(0)
+	}
+
+	if s.ReadReceiptsEnableUnreadDMNudge == nil {
+		s.ReadReceiptsEnableUnreadDMNudge = NewPointer(false)
+	}
+
+	if s.ReadReceiptsUnreadDMNudgeHours == nil {
+		s.ReadReceiptsUnreadDMNudgeHours = NewPointer(24)
+	}
+
+	if s.ReadReceiptsExportSink == nil {
+		s.ReadReceiptsExportSink = NewPointer("")
+	}
+
+	if s.ReadReceiptsExportWebhookURL == nil {
+		s.ReadReceiptsExportWebhookURL = NewPointer("")
+	}
+
+	if s.ReadReceiptsExportFilePath == nil {
+		s.ReadReceiptsExportFilePath = NewPointer("")
+	}
+
+	if s.ReadReceiptsExportBufferSize == nil {
+		s.ReadReceiptsExportBufferSize = NewPointer(10000)
+	}
+
+	if s.ReadReceiptsExportDeadLetterPath == nil {
+		s.ReadReceiptsExportDeadLetterPath = NewPointer("read_receipts_dead_letter.jsonl")
+	}
+
+	if s.ReadReceiptsSummarySLASeconds == nil {
+		s.ReadReceiptsSummarySLASeconds = NewPointer(60)
+	}
+
+	if s.ReadReceiptsRestrictDMRecall == nil {
+		s.ReadReceiptsRestrictDMRecall = NewPointer(false)
+	}
+
+	if s.ReadReceiptsDeactivationScrubDays == nil {
+		s.ReadReceiptsDeactivationScrubDays = NewPointer(0)
+	}
+
+	if s.ReadReceiptsMinimumConfidence == nil {
+		s.ReadReceiptsMinimumConfidence = NewPointer("")
+	}
+
+	if s.ReadReceiptsMinWriteIntervalMs == nil {
+		s.ReadReceiptsMinWriteIntervalMs = NewPointer(0)
+	}
+
+	if s.ReadReceiptsColdStorageDays == nil {
+		s.ReadReceiptsColdStorageDays = NewPointer(0)
+	}
+
+	if s.ReadReceiptsLegacyBatchEvents == nil {
+		s.ReadReceiptsLegacyBatchEvents = NewPointer(false)
+	}
+
+	if s.ReadReceiptsTransactionalSummary == nil {
+		s.ReadReceiptsTransactionalSummary = NewPointer(false)
+	}
+
+	if s.ReadReceiptsServerTimestamps == nil {
+		s.ReadReceiptsServerTimestamps = NewPointer(false)
+	}
+
+	if s.ReadReceiptsChangesRetentionHours == nil {
+		s.ReadReceiptsChangesRetentionHours = NewPointer(72)
+	}
+
+	if s.ReadReceiptsPreciseUnread == nil {
+		s.ReadReceiptsPreciseUnread = NewPointer(false)
+	}
+
+	if s.ReadReceiptsSamplingThreshold == nil {
+		s.ReadReceiptsSamplingThreshold = NewPointer(0)
+	}
+
+	if s.ReadReceiptsBitmapStorageDays == nil {
+		s.ReadReceiptsBitmapStorageDays = NewPointer(0)
+	}
+
+	if s.ReadReceiptsBitmapMinReceipts == nil {
+		s.ReadReceiptsBitmapMinReceipts = NewPointer(100000)
+	}
 }
 
 type CacheSettings struct {
@@ -3572,6 +3732,7 @@ type MessageExportSettings struct {
 	DownloadExportResults   *bool   `access:"compliance_compliance_export"`
 	ChannelBatchSize        *int    `access:"compliance_compliance_export"`
 	ChannelHistoryBatchSize *int    `access:"compliance_compliance_export"`
+	IncludeReadReceipts     *bool   `access:"compliance_compliance_export"`
 
 	// formatter-specific settings - these are only expected to be non-nil if ExportFormat is set to the associated format
 	GlobalRelaySettings *GlobalRelayMessageExportSettings `access:"compliance_compliance_export"`
@@ -3610,6 +3771,10 @@ func (s *MessageExportSettings) SetDefaults() {
 		s.ChannelHistoryBatchSize = NewPointer(ComplianceExportChannelHistoryBatchSizeDefault)
 	}
 
+	if s.IncludeReadReceipts == nil {
+		s.IncludeReadReceipts = NewPointer(false)
+	}
+
 	if s.GlobalRelaySettings == nil {
 		s.GlobalRelaySettings = &GlobalRelayMessageExportSettings{}
 	}
@@ -4530,6 +4695,74 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.persistent_notifications_recipients.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.ReadReceiptsClientDebounceMs < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_client_debounce.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBatchMaxWaitMs < *s.ReadReceiptsClientDebounceMs {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_batch_max_wait.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsMaxClockSkewMs < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_max_clock_skew.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBufferMaxSize <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_buffer_max_size.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBufferFlushIntervalMs <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_buffer_flush_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBufferMaxPendingPerUser <= 0 || *s.ReadReceiptsBufferMaxPendingPerUser > *s.ReadReceiptsBufferMaxSize {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_buffer_max_pending_per_user.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsMaxPerChannel < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_max_per_channel.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsUnreadDMNudgeHours <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_unread_dm_nudge_hours.app_error", nil, "", http.StatusBadRequest)
+	}
+	switch *s.ReadReceiptsExportSink {
+	case ReadReceiptsExportSinkWebhook:
+		if !IsValidHTTPURL(*s.ReadReceiptsExportWebhookURL) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_export_webhook_url.app_error", nil, "", http.StatusBadRequest)
+		}
+	case ReadReceiptsExportSinkFile:
+		if *s.ReadReceiptsExportFilePath == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_export_file_path.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+	if *s.ReadReceiptsExportBufferSize <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_export_buffer_size.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsExportSink != "" && *s.ReadReceiptsExportDeadLetterPath == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_export_dead_letter_path.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsSummarySLASeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_summary_sla.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsDeactivationScrubDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_deactivation_scrub_days.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsChangesRetentionHours <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_changes_retention_hours.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsSamplingThreshold < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_sampling_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsMinimumConfidence != "" && !IsValidReadReceiptConfidence(*s.ReadReceiptsMinimumConfidence) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_minimum_confidence.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsMinWriteIntervalMs < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_min_write_interval.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsColdStorageDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_cold_storage_days.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBitmapStorageDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_bitmap_storage_days.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *s.ReadReceiptsBitmapMinReceipts <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_bitmap_min_receipts.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	// we check if file has a valid parent, the server will try to create the socket
 	// file if it doesn't exist, but we need to be sure if the directory exist or not
 	if *s.EnableLocalMode {
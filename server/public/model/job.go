@@ -44,6 +44,12 @@ const (
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
 	JobTypeAccessControlSync             = "access_control_sync"
+	JobTypeUnreadDMNudge                 = "unread_dm_nudge"
+	JobTypeReadReceiptsCleanup           = "read_receipts_cleanup"
+	JobTypeReadReceiptsScrub             = "read_receipts_scrub"
+	JobTypeReadReceiptsOffload           = "read_receipts_offload"
+	JobTypeReadReceiptsChangesPrune      = "read_receipts_changes_prune"
+	JobTypeReadReceiptsCompaction        = "read_receipts_compaction"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -56,4 +56,5 @@ const (
 	MigrationAddSysconsoleMobileSecurityPermission     = "add_sysconsole_mobile_security_permission"
 	MigrationKeyAddChannelBannerPermissions            = "add_channel_banner_permissions"
 	MigrationKeyAddChannelAccessRulesPermission        = "add_channel_access_rules_permission"
+	MigrationKeyAddViewReadReceiptsPermission          = "add_view_read_receipts_permission"
 )
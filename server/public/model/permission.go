@@ -108,6 +108,7 @@ var PermissionInviteGuest *Permission
 var PermissionPromoteGuest *Permission
 var PermissionDemoteToGuest *Permission
 var PermissionUseChannelMentions *Permission
+var PermissionViewReadReceipts *Permission
 var PermissionUseGroupMentions *Permission
 var PermissionAddBookmarkPublicChannel *Permission
 var PermissionEditBookmarkPublicChannel *Permission
@@ -1232,6 +1233,12 @@ func initializePermissions() {
 		"authentication.permissions.use_channel_mentions.description",
 		PermissionScopeChannel,
 	}
+	PermissionViewReadReceipts = &Permission{
+		"view_read_receipts",
+		"authentication.permissions.view_read_receipts.name",
+		"authentication.permissions.view_read_receipts.description",
+		PermissionScopeChannel,
+	}
 	PermissionUseGroupMentions = &Permission{
 		"use_group_mentions",
 		"authentication.permissions.use_group_mentions.name",
@@ -2537,6 +2544,7 @@ func initializePermissions() {
 		PermissionDeleteOthersPosts,
 		PermissionUseChannelMentions,
 		PermissionUseGroupMentions,
+		PermissionViewReadReceipts,
 		PermissionAddBookmarkPublicChannel,
 		PermissionEditBookmarkPublicChannel,
 		PermissionDeleteBookmarkPublicChannel,
@@ -2622,6 +2630,7 @@ func initializePermissions() {
 		"manage_members",
 		PermissionUseChannelMentions.Id,
 		"manage_bookmarks",
+		"manage_read_receipt_visibility",
 	}
 
 	ChannelModeratedPermissionsMap = map[string]string{
@@ -2631,6 +2640,7 @@ func initializePermissions() {
 		PermissionManagePublicChannelMembers.Id:  ChannelModeratedPermissions[2],
 		PermissionManagePrivateChannelMembers.Id: ChannelModeratedPermissions[2],
 		PermissionUseChannelMentions.Id:          ChannelModeratedPermissions[3],
+		PermissionViewReadReceipts.Id:            ChannelModeratedPermissions[5],
 	}
 
 	ModeratedBookmarkPermissions = []*Permission{
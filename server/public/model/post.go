@@ -83,6 +83,7 @@ const (
 	PostPropsForceNotification        = "force_notification"
 	PostPropsChannelMentions          = "channel_mentions"
 	PostPropsUnsafeLinks              = "unsafe_links"
+	PostPropsNoReadReceipts           = "no_read_receipts"
 
 	PostPriorityUrgent = "urgent"
 )
@@ -783,6 +784,12 @@ func (o *Post) propsIsValid() error {
 		}
 	}
 
+	if props[PostPropsNoReadReceipts] != nil {
+		if _, ok := props[PostPropsNoReadReceipts].(bool); !ok {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("no_read_receipts prop must be a boolean"))
+		}
+	}
+
 	for i, a := range o.Attachments() {
 		if err := a.IsValid(); err != nil {
 			multiErr = multierror.Append(multiErr, multierror.Prefix(err, fmt.Sprintf("message attachtment at index %d is invalid:", i)))
@@ -792,6 +799,13 @@ func (o *Post) propsIsValid() error {
 	return multiErr.ErrorOrNil()
 }
 
+// ReadReceiptsDisabled reports whether the author opted the post out of read
+// receipts, so that noisy automated posts don't accumulate them.
+func (o *Post) ReadReceiptsDisabled() bool {
+	disabled, _ := o.GetProp(PostPropsNoReadReceipts).(bool)
+	return disabled
+}
+
 func (o *Post) IsSystemMessage() bool {
 	return len(o.Type) >= len(PostSystemMessagePrefix) && o.Type[:len(PostSystemMessagePrefix)] == PostSystemMessagePrefix
 }
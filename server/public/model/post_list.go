@@ -18,6 +18,9 @@ type PostList struct {
 	HasNext *bool `json:"has_next,omitempty"`
 	// If there are inaccessible posts, FirstInaccessiblePostTime is the time of the latest inaccessible post
 	FirstInaccessiblePostTime int64 `json:"first_inaccessible_post_time"`
+	// FirstUnreadPostId is the oldest post of Order the user has not read, where
+	// clients place the new messages line.
+	FirstUnreadPostId string `json:"first_unread_post_id,omitempty"`
 }
 
 func NewPostList() *PostList {
@@ -43,6 +46,7 @@ func (o *PostList) Clone() *PostList {
 		PrevPostId:                o.PrevPostId,
 		HasNext:                   o.HasNext,
 		FirstInaccessiblePostTime: o.FirstInaccessiblePostTime,
+		FirstUnreadPostId:         o.FirstUnreadPostId,
 	}
 }
 
@@ -0,0 +1,665 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ReadReceiptDeviceTypeWeb     = "web"
+	ReadReceiptDeviceTypeMobile  = "mobile"
+	ReadReceiptDeviceTypeBot     = "bot"
+	ReadReceiptDeviceIdMaxLength = 512
+
+	// ReadReceiptSourceReaction marks receipts recorded implicitly when the
+	// user reacted to the post.
+	ReadReceiptSourceReaction = "reaction"
+	// ReadReceiptSourceReply marks receipts recorded implicitly for the earlier
+	// posts of a thread when the user replied to it.
+	ReadReceiptSourceReply = "reply"
+	// ReadReceiptSourceEmailLink marks receipts recorded when the user opened the
+	// post from the permalink of a notification email.
+	ReadReceiptSourceEmailLink = "email_link"
+	// ReadReceiptSourceAutoScroll, ReadReceiptSourceExplicitClick and
+	// ReadReceiptSourceChannelOpen are reported by clients along with a read, so
+	// that reads the user acknowledged can be told apart from posts that scrolled
+	// by or were shown when the channel opened.
+	ReadReceiptSourceAutoScroll    = "auto_scroll"
+	ReadReceiptSourceExplicitClick = "explicit_click"
+	ReadReceiptSourceChannelOpen   = "channel_open"
+
+	// ReadReceiptEstimateZScore sets the confidence level of the margin of error
+	// of estimated read percentages, 95%.
+	ReadReceiptEstimateZScore = 1.96
+
+	// ReadReceiptEmailLinkTokenParam is the query parameter of the permalinks in
+	// notification emails carrying the signed token of the email link receipt.
+	ReadReceiptEmailLinkTokenParam = "read_token"
+
+	// ReadReceiptConfidence constants are the attention signals clients report
+	// along with a read, from the weakest to the strongest.
+	ReadReceiptConfidenceScrolledPast    = "scrolled_past"
+	ReadReceiptConfidenceViewportVisible = "viewport_visible"
+	ReadReceiptConfidenceWindowFocused   = "window_focused"
+
+	// ReadReceiptBatchMaxPosts is the maximum number of posts that can be
+	// marked as read in a single batch request.
+	ReadReceiptBatchMaxPosts = 100
+
+	// ReadStateMaxPosts is the maximum number of posts a user can get their read
+	// state of in a single request.
+	ReadStateMaxPosts = 200
+
+	// ReadReceiptWatermarkMaxPosts is the number of posts marked as read per
+	// query when a batch request uses the watermark form or a thread is read.
+	// Longer backlogs take several queries.
+	ReadReceiptWatermarkMaxPosts = 1000
+
+	// ReadReceiptExportFormat constants are the formats users can export their
+	// own receipts in.
+	ReadReceiptExportFormatJSON = "json"
+	ReadReceiptExportFormatCSV  = "csv"
+)
+
+// ReadReceiptErrorCode constants are set as the code of the errors of the
+// receipt APIs, so that clients can tell them apart without parsing the message.
+const (
+	ReadReceiptErrorCodeDisabled              = "READ_RECEIPTS_DISABLED"
+	ReadReceiptErrorCodeChannelTypeNotAllowed = "CHANNEL_TYPE_NOT_ALLOWED"
+	ReadReceiptErrorCodeChannelArchived       = "CHANNEL_ARCHIVED"
+	ReadReceiptErrorCodeUserOptedOut          = "USER_OPTED_OUT"
+	ReadReceiptErrorCodePostOptedOut          = "POST_OPTED_OUT"
+	ReadReceiptErrorCodePostTypeNotAllowed    = "POST_TYPE_NOT_ALLOWED"
+	ReadReceiptErrorCodeNotThreadRoot         = "NOT_THREAD_ROOT"
+	ReadReceiptErrorCodeBatchTooLarge         = "BATCH_TOO_LARGE"
+	ReadReceiptErrorCodeInvalidConfidence     = "INVALID_CONFIDENCE"
+	ReadReceiptErrorCodeInvalidSource         = "INVALID_SOURCE"
+	ReadReceiptErrorCodeGhostModeDisabled     = "GHOST_MODE_DISABLED"
+	ReadReceiptErrorCodeBotReceiptsDisabled   = "BOT_RECEIPTS_DISABLED"
+	ReadReceiptErrorCodeBotSessionNotAllowed  = "BOT_SESSION_NOT_ALLOWED"
+	ReadReceiptErrorCodeIntegrationNotAllowed = "INTEGRATION_NOT_ALLOWED"
+	ReadReceiptErrorCodeBotTokenRequired      = "BOT_TOKEN_REQUIRED"
+	ReadReceiptErrorCodeBotTokenScopeRequired = "BOT_TOKEN_SCOPE_REQUIRED"
+	ReadReceiptErrorCodeHiddenByPolicy        = "HIDDEN_BY_POLICY"
+)
+
+type PostReadReceipt struct {
+	PostId     string `json:"post_id"`
+	UserId     string `json:"user_id"`
+	ChannelId  string `json:"channel_id"`
+	ReadAt     int64  `json:"read_at"`
+	DeviceType string `json:"device_type,omitempty"`
+	DeviceId   string `json:"device_id,omitempty"`
+	SessionId  string `json:"session_id,omitempty"`
+	Source     string `json:"source,omitempty"`
+	Confidence string `json:"confidence,omitempty"`
+	// ClientReadAt is the read time the client reported, kept for debugging when
+	// ServiceSettings.ReadReceiptsServerTimestamps stamps ReadAt with the server time.
+	ClientReadAt int64 `json:"client_read_at,omitempty"`
+	// ConvertedFromGhost is set on receipts that replaced a ghost read of the user.
+	// It is only reported by the read receipt info of a post.
+	ConvertedFromGhost bool `json:"converted_from_ghost,omitempty"`
+	// Compacted is set on receipts read back from the bitmap storage of a large
+	// channel, which only keeps who read which post. Their ReadAt is the latest
+	// read of the user in the channel when the receipt was compacted, so the post
+	// was read at or before that time.
+	Compacted bool `json:"compacted,omitempty"`
+	// FirstRead is set by the store on the receipts it inserted rather than updated,
+	// so that the summary of the post counts each reader once.
+	FirstRead bool `json:"-"`
+}
+
+func (r *PostReadReceipt) IsValid() *AppError {
+	if !IsValidId(r.PostId) {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.post_id.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.user_id.app_error", nil, "user_id="+r.UserId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.ChannelId) {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.channel_id.app_error", nil, "channel_id="+r.ChannelId, http.StatusBadRequest)
+	}
+
+	if r.ReadAt <= 0 {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.read_at.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	if len(r.DeviceId) > ReadReceiptDeviceIdMaxLength {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.device_id.app_error", nil, "post_id="+r.PostId, http.StatusBadRequest)
+	}
+
+	if r.Confidence != "" && !IsValidReadReceiptConfidence(r.Confidence) {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.confidence.app_error", nil, "confidence="+r.Confidence, http.StatusBadRequest).WithCode(ReadReceiptErrorCodeInvalidConfidence)
+	}
+
+	if r.Source != "" && !IsValidReadReceiptSource(r.Source) {
+		return NewAppError("PostReadReceipt.IsValid", "model.read_receipt.is_valid.source.app_error", nil, "source="+r.Source, http.StatusBadRequest).WithCode(ReadReceiptErrorCodeInvalidSource)
+	}
+
+	return nil
+}
+
+// IsReadReceiptPostType reports whether posts of the given type can be marked as
+// read explicitly: regular messages and plugin posts, but neither system messages
+// nor ephemeral posts, which are never persisted.
+func IsReadReceiptPostType(postType string) bool {
+	switch postType {
+	case PostTypeDefault, PostTypeSlackAttachment, PostTypeMe, PostTypeReminder:
+		return true
+	default:
+		return strings.HasPrefix(postType, PostCustomTypePrefix)
+	}
+}
+
+// IsValidReadReceiptDeviceType reports whether deviceType is one of the
+// ReadReceiptDeviceType constants.
+func IsValidReadReceiptDeviceType(deviceType string) bool {
+	switch deviceType {
+	case ReadReceiptDeviceTypeWeb, ReadReceiptDeviceTypeMobile, ReadReceiptDeviceTypeBot:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadReceiptConfidenceRank orders the ReadReceiptConfidence constants from the
+// weakest to the strongest signal, starting at 1. A missing or unknown confidence
+// ranks 0.
+func ReadReceiptConfidenceRank(confidence string) int {
+	switch confidence {
+	case ReadReceiptConfidenceScrolledPast:
+		return 1
+	case ReadReceiptConfidenceViewportVisible:
+		return 2
+	case ReadReceiptConfidenceWindowFocused:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsValidReadReceiptConfidence reports whether confidence is one of the
+// ReadReceiptConfidence constants.
+func IsValidReadReceiptConfidence(confidence string) bool {
+	return ReadReceiptConfidenceRank(confidence) > 0
+}
+
+// IsValidClientReadReceiptSource reports whether source is one of the sources
+// clients can report a read with. The other sources are only set by the server.
+func IsValidClientReadReceiptSource(source string) bool {
+	switch source {
+	case ReadReceiptSourceAutoScroll, ReadReceiptSourceExplicitClick, ReadReceiptSourceChannelOpen:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidReadReceiptSource reports whether source is one of the ReadReceiptSource
+// constants.
+func IsValidReadReceiptSource(source string) bool {
+	switch source {
+	case ReadReceiptSourceReaction, ReadReceiptSourceReply, ReadReceiptSourceEmailLink:
+		return true
+	default:
+		return IsValidClientReadReceiptSource(source)
+	}
+}
+
+func (r *PostReadReceipt) PreSave() {
+	if r.ReadAt == 0 {
+		r.ReadAt = GetMillis()
+	}
+}
+
+// IsBot reports whether the receipt was recorded by a bot account
+// through the bot acknowledgement endpoint.
+func (r *PostReadReceipt) IsBot() bool {
+	return r.DeviceType == ReadReceiptDeviceTypeBot
+}
+
+type ReadReceiptRequest struct {
+	PostId   string `json:"post_id"`
+	ReadAt   int64  `json:"read_at"`
+	DeviceId string `json:"device_id"`
+	// Ghost records the read without telling anyone, when
+	// ServiceSettings.ReadReceiptsEnableGhostMode allows it.
+	Ghost bool `json:"ghost,omitempty"`
+	// Confidence is the strongest attention signal the client observed, one of
+	// the ReadReceiptConfidence constants.
+	Confidence string `json:"confidence,omitempty"`
+	// Source is how the post came to be read, one of the sources accepted by
+	// IsValidClientReadReceiptSource.
+	Source string `json:"source,omitempty"`
+}
+
+// ReadReceiptEmailLinkRequest records that the user opened a post from the
+// permalink of a notification email. Token is the ReadReceiptEmailLinkTokenParam
+// of the permalink.
+type ReadReceiptEmailLinkRequest struct {
+	Token string `json:"token"`
+}
+
+// ReadReceiptBatchRequest marks several posts of a channel as read. Either
+// PostIds lists the posts explicitly, or UpToPostId acts as a watermark and
+// every post of the channel created up to and including it is marked as read.
+type ReadReceiptBatchRequest struct {
+	PostIds    []string `json:"post_ids,omitempty"`
+	UpToPostId string   `json:"up_to_post_id,omitempty"`
+	ChannelId  string   `json:"channel_id"`
+	ReadAt     int64    `json:"read_at"`
+	DeviceId   string   `json:"device_id"`
+	Confidence string   `json:"confidence,omitempty"`
+	Source     string   `json:"source,omitempty"`
+}
+
+func (r *ReadReceiptBatchRequest) IsValid() *AppError {
+	if !IsValidId(r.ChannelId) {
+		return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.channel_id.app_error", nil, "channel_id="+r.ChannelId, http.StatusBadRequest)
+	}
+
+	if r.Confidence != "" && !IsValidReadReceiptConfidence(r.Confidence) {
+		return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt.is_valid.confidence.app_error", nil, "confidence="+r.Confidence, http.StatusBadRequest).WithCode(ReadReceiptErrorCodeInvalidConfidence)
+	}
+
+	if r.Source != "" && !IsValidClientReadReceiptSource(r.Source) {
+		return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt.is_valid.source.app_error", nil, "source="+r.Source, http.StatusBadRequest).WithCode(ReadReceiptErrorCodeInvalidSource)
+	}
+
+	if r.UpToPostId != "" {
+		if len(r.PostIds) > 0 {
+			return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.ambiguous.app_error", nil, "", http.StatusBadRequest)
+		}
+		if !IsValidId(r.UpToPostId) {
+			return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.up_to_post_id.app_error", nil, "up_to_post_id="+r.UpToPostId, http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if len(r.PostIds) == 0 {
+		return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.post_ids.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(r.PostIds) > ReadReceiptBatchMaxPosts {
+		return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.too_many_posts.app_error", map[string]any{"Max": ReadReceiptBatchMaxPosts}, "", http.StatusBadRequest).WithCode(ReadReceiptErrorCodeBatchTooLarge)
+	}
+
+	for _, postID := range r.PostIds {
+		if !IsValidId(postID) {
+			return NewAppError("ReadReceiptBatchRequest.IsValid", "model.read_receipt_batch.is_valid.post_ids.app_error", nil, "post_id="+postID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// ReadReceiptBatchResponse lists the receipts saved by a batch request. Degraded
+// is set when the channel holds too many receipts and only accepts watermark
+// batches, so clients should stop sending explicit post ids for it.
+type ReadReceiptBatchResponse struct {
+	ProcessedCount int                `json:"processed_count"`
+	Receipts       []*PostReadReceipt `json:"receipts"`
+	Degraded       bool               `json:"degraded,omitempty"`
+	// Paused is set when the user paused their receipts, the reads were only kept
+	// as ghost reads.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ReadReceiptCursor points at the last receipt of a page when listing a user's
+// receipts, which are ordered by ReadAt and then PostId, newest first.
+type ReadReceiptCursor struct {
+	ReadAt int64
+	PostId string
+}
+
+func (c ReadReceiptCursor) IsEmpty() bool {
+	return c.PostId == ""
+}
+
+// String encodes the cursor into the opaque page token handed out to clients.
+func (c ReadReceiptCursor) String() string {
+	if c.IsEmpty() {
+		return ""
+	}
+	return fmt.Sprintf("%d_%s", c.ReadAt, c.PostId)
+}
+
+// ReadReceiptCursorFromString decodes a page token produced by ReadReceiptCursor.String.
+func ReadReceiptCursorFromString(page string) (ReadReceiptCursor, error) {
+	if page == "" {
+		return ReadReceiptCursor{}, nil
+	}
+
+	readAt, postID, ok := strings.Cut(page, "_")
+	if !ok || !IsValidId(postID) {
+		return ReadReceiptCursor{}, fmt.Errorf("invalid read receipt page %q", page)
+	}
+
+	cursor := ReadReceiptCursor{PostId: postID}
+	var err error
+	if cursor.ReadAt, err = strconv.ParseInt(readAt, 10, 64); err != nil {
+		return ReadReceiptCursor{}, fmt.Errorf("invalid read receipt page %q: %w", page, err)
+	}
+
+	return cursor, nil
+}
+
+// ReadReceiptsPageCursor points past the last receipt of a page when walking every
+// receipt of the server in primary key order, as the maintenance jobs do.
+type ReadReceiptsPageCursor struct {
+	PostId string
+	UserId string
+}
+
+// GetReadReceiptsForUserOptions filters the receipts of a user. Since is
+// inclusive and Until exclusive; zero values leave the range open.
+type GetReadReceiptsForUserOptions struct {
+	ChannelId string
+	// Sources keeps only the receipts recorded with one of the sources, when set.
+	Sources []string
+	Since   int64
+	Until   int64
+	Cursor  ReadReceiptCursor
+	PerPage int
+}
+
+type ReadReceiptsForUserPage struct {
+	Receipts []*PostReadReceipt `json:"receipts"`
+	NextPage string             `json:"next_page,omitempty"`
+}
+
+// ReadReceiptSessionActivity is the read activity of a user through one session
+// and device, shown to users next to their sessions so that they can spot reads
+// from devices they do not recognize. Platform, Os and Browser come from the
+// session, and SessionActive is false once the session was revoked or expired.
+type ReadReceiptSessionActivity struct {
+	SessionId     string `json:"session_id"`
+	DeviceType    string `json:"device_type,omitempty"`
+	DeviceId      string `json:"device_id,omitempty"`
+	Platform      string `json:"platform,omitempty"`
+	Os            string `json:"os,omitempty"`
+	Browser       string `json:"browser,omitempty"`
+	SessionActive bool   `json:"session_active"`
+	ReadCount     int64  `json:"read_count"`
+	FirstReadAt   int64  `json:"first_read_at"`
+	LastReadAt    int64  `json:"last_read_at"`
+}
+
+// ReadReceiptColdStorageMaxResults is the maximum number of receipts a cold
+// storage query returns.
+const ReadReceiptColdStorageMaxResults = 1000
+
+// ReadReceiptColdStorageQuery filters the receipts of a channel offloaded to cold
+// storage. PostId, UserId, Since and Until are optional.
+type ReadReceiptColdStorageQuery struct {
+	ChannelId string
+	PostId    string
+	UserId    string
+	Since     int64
+	Until     int64
+}
+
+// ReadReceiptColdStorageResult holds the receipts matching a cold storage query.
+// Truncated is set when more than ReadReceiptColdStorageMaxResults matched.
+type ReadReceiptColdStorageResult struct {
+	Receipts  []*PostReadReceipt `json:"receipts"`
+	Truncated bool               `json:"truncated"`
+}
+
+// PostReadReceiptSummary holds the denormalized read counters of a post.
+// Bot reads are counted separately and never contribute to ReadCount.
+// Version is incremented on every stored update and is used to detect
+// concurrent writers.
+type PostReadReceiptSummary struct {
+	PostId       string `json:"post_id"`
+	ChannelId    string `json:"channel_id"`
+	ReadCount    int64  `json:"read_count"`
+	BotReadCount int64  `json:"bot_read_count"`
+	LastReadAt   int64  `json:"last_read_at"`
+	LastUpdated  int64  `json:"last_updated"`
+	Version      int64  `json:"version"`
+	// Estimated is set when the channel only stores the receipts of a sample of
+	// its members, see ServiceSettings.ReadReceiptsSamplingThreshold, in which
+	// case the counts only cover that sample and the share of the members who
+	// read the post is estimated from it, plus or minus MarginOfError points.
+	Estimated               bool    `json:"estimated,omitempty"`
+	EstimatedReadPercentage float64 `json:"estimated_read_percentage,omitempty"`
+	MarginOfError           float64 `json:"margin_of_error,omitempty"`
+}
+
+// EstimateFromSample marks the summary as estimated from the receipts of a sample
+// of rate of the members of the channel, and fills in the estimated read
+// percentage along with its margin of error at a 95% confidence level. The
+// margin follows the Agresti-Coull interval, so that it stays meaningful when
+// none or all of the sample read the post, and accounts for the sample being
+// drawn from a finite number of members.
+func (s *PostReadReceiptSummary) EstimateFromSample(rate float64, members int64) {
+	s.Estimated = true
+	if members <= 0 {
+		return
+	}
+
+	sampleSize := min(max(rate*float64(members), 1), float64(members))
+	p := min(float64(s.ReadCount)/sampleSize, 1)
+
+	z2 := ReadReceiptEstimateZScore * ReadReceiptEstimateZScore
+	adjustedSize := sampleSize + z2
+	adjusted := (p*sampleSize + z2/2) / adjustedSize
+	margin := ReadReceiptEstimateZScore * math.Sqrt(adjusted*(1-adjusted)/adjustedSize)
+	if members > 1 {
+		margin *= math.Sqrt((float64(members) - sampleSize) / float64(members-1))
+	}
+
+	s.EstimatedReadPercentage = p * 100
+	s.MarginOfError = margin * 100
+}
+
+// ChannelBookmarkReadSummary is the read summary of the post a channel bookmark
+// links to.
+type ChannelBookmarkReadSummary struct {
+	BookmarkId string                  `json:"bookmark_id"`
+	Summary    *PostReadReceiptSummary `json:"summary"`
+}
+
+// ThreadReadReceiptSummary sums up how far the participants of a thread read its
+// replies. A participant caught up once their RepliesRead reaches ReplyCount.
+type ThreadReadReceiptSummary struct {
+	RootId           string                        `json:"root_id"`
+	ChannelId        string                        `json:"channel_id"`
+	ReplyCount       int64                         `json:"reply_count"`
+	ParticipantCount int64                         `json:"participant_count"`
+	CaughtUpCount    int64                         `json:"caught_up_count"`
+	Participants     []*ThreadParticipantReadCount `json:"participants"`
+	// Estimated is set when the channel only stores the receipts of a sample of
+	// its members, in which case CaughtUpCount only covers the participants of
+	// that sample.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ThreadParticipantReadCount is how many replies of a thread a user read, the
+// replies they wrote included.
+type ThreadParticipantReadCount struct {
+	UserId      string `json:"user_id"`
+	RepliesRead int64  `json:"replies_read"`
+	CaughtUp    bool   `db:"-" json:"caught_up"`
+	// NotSampled is set when the receipts of the participant are not stored, as
+	// they were left out of the sample of the channel, so what they read is
+	// unknown.
+	NotSampled bool `db:"-" json:"not_sampled,omitempty"`
+}
+
+// PostReadCount holds the read counters of a post computed straight from its
+// receipts, as used to render many posts at once.
+type PostReadCount struct {
+	PostId       string `json:"post_id"`
+	ReadCount    int64  `json:"read_count"`
+	BotReadCount int64  `json:"bot_read_count"`
+}
+
+// PostReadState is whether a user read a post, and when they did.
+type PostReadState struct {
+	Read   bool  `json:"read"`
+	ReadAt int64 `json:"read_at,omitempty"`
+}
+
+// PostSeenState drives the "seen" check mark of a direct message, which turns on
+// once someone other than its author read it.
+type PostSeenState struct {
+	PostId string `json:"post_id"`
+	Seen   bool   `json:"seen"`
+}
+
+// PostMentionReadState splits the users a post mentions between those who read
+// it and those who did not yet, in the order they are mentioned.
+type PostMentionReadState struct {
+	PostId string   `json:"post_id"`
+	Read   []string `json:"read"`
+	Unread []string `json:"unread"`
+	// NotSampled lists the mentioned users whose receipts are not stored, as they
+	// were left out of the sample of the channel, so whether they read the post
+	// is unknown.
+	NotSampled []string `json:"not_sampled,omitempty"`
+}
+
+// UnreadDirectChannel is a direct channel in which the user has messages they
+// have no receipt for.
+type UnreadDirectChannel struct {
+	ChannelId string `json:"channel_id"`
+	UserId    string `json:"user_id"`
+}
+
+// ReadReceiptReader is a user who read a post and when they did.
+type ReadReceiptReader struct {
+	UserId string `json:"user_id"`
+	ReadAt int64  `json:"read_at"`
+}
+
+// PostReadReceiptExtremes holds the earliest and the latest human readers of a
+// post. Both are nil while nobody has read it.
+type PostReadReceiptExtremes struct {
+	PostId      string             `json:"post_id"`
+	FirstReader *ReadReceiptReader `json:"first_reader"`
+	LastReader  *ReadReceiptReader `json:"last_reader"`
+}
+
+// ChannelMemberReadActivityDays is the window PostsRead of a
+// ChannelMemberReadActivity is counted over.
+const ChannelMemberReadActivityDays = 30
+
+// ChannelMemberReadActivity is how actively a channel member reads the channel,
+// as listed to admins pruning inactive members. LastPostReadAt is nil when the
+// member never read a post of the channel.
+type ChannelMemberReadActivity struct {
+	UserId         string `json:"user_id"`
+	LastPostReadAt *int64 `json:"last_post_read_at"`
+	PostsRead      int64  `json:"posts_read_30d"`
+}
+
+// ChannelDailyReadersMaxDays is the longest range of days the daily reader counts
+// of a channel can be asked for at once.
+const ChannelDailyReadersMaxDays = 366
+
+// DailyReaderCount is the number of distinct users who read posts of a channel
+// on Day, a UTC day formatted as 2006-01-02.
+type DailyReaderCount struct {
+	Day         string `json:"day"`
+	ReaderCount int64  `json:"reader_count"`
+}
+
+type PostReadReceiptInfo struct {
+	PostId         string             `json:"post_id"`
+	Receipts       []*PostReadReceipt `json:"receipts"`
+	ReadCount      int64              `json:"read_count"`
+	BotReadCount   int64              `json:"bot_read_count"`
+	TotalMembers   int64              `json:"total_members"`
+	ReadPercentage float64            `json:"read_percentage"`
+}
+
+// NewPostReadReceiptInfo builds the info payload for a post. humanMembers is the
+// number of non-bot channel members who can read the post. Receipts recorded by
+// bots are reported but excluded from the human read percentage.
+func NewPostReadReceiptInfo(postID string, receipts []*PostReadReceipt, humanMembers int64) *PostReadReceiptInfo {
+	info := &PostReadReceiptInfo{
+		PostId:       postID,
+		Receipts:     receipts,
+		TotalMembers: humanMembers,
+	}
+
+	for _, receipt := range receipts {
+		if receipt.IsBot() {
+			info.BotReadCount++
+			continue
+		}
+		info.ReadCount++
+	}
+
+	if humanMembers > 0 {
+		info.ReadPercentage = float64(info.ReadCount) / float64(humanMembers) * 100
+		if info.ReadPercentage > 100 {
+			info.ReadPercentage = 100
+		}
+	}
+
+	return info
+}
+
+// ReadReceiptChannelVolume is the number of receipts recorded in a channel
+// over the overview window.
+type ReadReceiptChannelVolume struct {
+	ChannelId string `json:"channel_id"`
+	Count     int64  `json:"count"`
+}
+
+// ReadReceiptsOverview is the server-wide read receipt health snapshot shown in
+// the system console, covering every node of the cluster. Lag values are in
+// milliseconds and measure how long the summary updates took to be stored after
+// the receipts that triggered them.
+type ReadReceiptsOverview struct {
+	WindowMinutes         int                         `json:"window_minutes"`
+	ReceiptsPerMinute     float64                     `json:"receipts_per_minute"`
+	ReceiptsLastMinute    int64                       `json:"receipts_last_minute"`
+	TopChannels           []*ReadReceiptChannelVolume `json:"top_channels"`
+	SummaryLagLast        int64                       `json:"summary_lag_last"`
+	SummaryLagMax         int64                       `json:"summary_lag_max"`
+	PendingSummaryUpdates int64                       `json:"pending_summary_updates"`
+	GeneratedAt           int64                       `json:"generated_at"`
+}
+
+const (
+	ReadReceiptsHealthOk       = "ok"
+	ReadReceiptsHealthDegraded = "degraded"
+)
+
+// ReadReceiptsHealth reports whether the read receipt summaries of this node are
+// kept up to date within ServiceSettings.ReadReceiptsSummarySLASeconds.
+// SummaryStaleness is how long, in milliseconds, the oldest summary update in
+// progress has been waiting.
+type ReadReceiptsHealth struct {
+	Status                string `json:"status"`
+	SummaryStaleness      int64  `json:"summary_staleness"`
+	SummaryStalenessSLA   int64  `json:"summary_staleness_sla"`
+	PendingSummaryUpdates int64  `json:"pending_summary_updates"`
+}
+
+// ReadReceiptTeamUsage is the receipt volume of a team according to the last
+// rollup of the receipts, direct and group messages being reported under an empty
+// TeamId. RecentReceiptCount only counts the receipts of the last 30 days, and
+// EstimatedBytes is the share of the receipt table and of its indexes taken by the
+// receipts of the team.
+type ReadReceiptTeamUsage struct {
+	TeamId             string `json:"team_id"`
+	TeamDisplayName    string `json:"team_display_name"`
+	ReceiptCount       int64  `json:"receipt_count"`
+	RecentReceiptCount int64  `json:"recent_receipt_count"`
+	ReaderCount        int64  `json:"reader_count"`
+	ChannelCount       int64  `json:"channel_count"`
+	EstimatedBytes     int64  `json:"estimated_bytes"`
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReadReceiptIsValid(t *testing.T) {
+	receipt := &PostReadReceipt{
+		PostId:    NewId(),
+		UserId:    NewId(),
+		ChannelId: NewId(),
+		ReadAt:    GetMillis(),
+	}
+	require.Nil(t, receipt.IsValid())
+
+	receipt.DeviceId = strings.Repeat("a", ReadReceiptDeviceIdMaxLength+1)
+	require.NotNil(t, receipt.IsValid())
+	receipt.DeviceId = ""
+
+	receipt.Confidence = "glanced"
+	require.NotNil(t, receipt.IsValid())
+	receipt.Confidence = ReadReceiptConfidenceWindowFocused
+	require.Nil(t, receipt.IsValid())
+
+	receipt.Source = "glanced"
+	require.NotNil(t, receipt.IsValid())
+	receipt.Source = ReadReceiptSourceReaction
+	require.Nil(t, receipt.IsValid())
+
+	receipt.ReadAt = 0
+	require.NotNil(t, receipt.IsValid())
+	receipt.PreSave()
+	require.Nil(t, receipt.IsValid())
+
+	receipt.PostId = "junk"
+	require.NotNil(t, receipt.IsValid())
+}
+
+func TestIsReadReceiptPostType(t *testing.T) {
+	assert.True(t, IsReadReceiptPostType(PostTypeDefault))
+	assert.True(t, IsReadReceiptPostType(PostTypeMe))
+	assert.True(t, IsReadReceiptPostType(PostCustomTypePrefix+"poll"))
+	assert.False(t, IsReadReceiptPostType(PostTypeEphemeral))
+	assert.False(t, IsReadReceiptPostType(PostTypeJoinChannel))
+}
+
+func TestReadReceiptConfidenceRank(t *testing.T) {
+	assert.Less(t, ReadReceiptConfidenceRank(""), ReadReceiptConfidenceRank(ReadReceiptConfidenceScrolledPast))
+	assert.Less(t, ReadReceiptConfidenceRank(ReadReceiptConfidenceScrolledPast), ReadReceiptConfidenceRank(ReadReceiptConfidenceViewportVisible))
+	assert.Less(t, ReadReceiptConfidenceRank(ReadReceiptConfidenceViewportVisible), ReadReceiptConfidenceRank(ReadReceiptConfidenceWindowFocused))
+	assert.False(t, IsValidReadReceiptConfidence(""))
+	assert.False(t, IsValidReadReceiptConfidence("glanced"))
+}
+
+func TestIsValidReadReceiptSource(t *testing.T) {
+	assert.True(t, IsValidClientReadReceiptSource(ReadReceiptSourceAutoScroll))
+	assert.True(t, IsValidClientReadReceiptSource(ReadReceiptSourceChannelOpen))
+	assert.False(t, IsValidClientReadReceiptSource(ReadReceiptSourceReaction))
+	assert.False(t, IsValidClientReadReceiptSource(""))
+
+	assert.True(t, IsValidReadReceiptSource(ReadReceiptSourceEmailLink))
+	assert.True(t, IsValidReadReceiptSource(ReadReceiptSourceExplicitClick))
+	assert.False(t, IsValidReadReceiptSource("glanced"))
+}
+
+func TestReadReceiptBatchRequestIsValid(t *testing.T) {
+	req := &ReadReceiptBatchRequest{ChannelId: NewId(), PostIds: []string{NewId()}}
+	require.Nil(t, req.IsValid())
+
+	req.PostIds = []string{}
+	require.NotNil(t, req.IsValid())
+
+	req.PostIds = make([]string, ReadReceiptBatchMaxPosts+1)
+	for i := range req.PostIds {
+		req.PostIds[i] = NewId()
+	}
+	require.NotNil(t, req.IsValid())
+
+	req.PostIds = []string{"junk"}
+	require.NotNil(t, req.IsValid())
+
+	req.PostIds = []string{NewId()}
+	req.Confidence = "glanced"
+	require.NotNil(t, req.IsValid())
+	req.Confidence = ReadReceiptConfidenceViewportVisible
+	require.Nil(t, req.IsValid())
+
+	req.Source = ReadReceiptSourceReply
+	require.NotNil(t, req.IsValid())
+	req.Source = ReadReceiptSourceExplicitClick
+	require.Nil(t, req.IsValid())
+
+	t.Run("watermark form", func(t *testing.T) {
+		req := &ReadReceiptBatchRequest{ChannelId: NewId(), UpToPostId: NewId()}
+		require.Nil(t, req.IsValid())
+
+		req.PostIds = []string{NewId()}
+		require.NotNil(t, req.IsValid())
+
+		req.PostIds = nil
+		req.UpToPostId = "junk"
+		require.NotNil(t, req.IsValid())
+	})
+}
+
+func TestNewPostReadReceiptInfo(t *testing.T) {
+	postID := NewId()
+	receipts := []*PostReadReceipt{
+		{PostId: postID, UserId: NewId()},
+		{PostId: postID, UserId: NewId(), DeviceType: ReadReceiptDeviceTypeMobile},
+		{PostId: postID, UserId: NewId(), DeviceType: ReadReceiptDeviceTypeBot},
+	}
+
+	info := NewPostReadReceiptInfo(postID, receipts, 4)
+	assert.Equal(t, int64(2), info.ReadCount)
+	assert.Equal(t, int64(1), info.BotReadCount)
+	assert.Equal(t, float64(50), info.ReadPercentage)
+
+	info = NewPostReadReceiptInfo(postID, receipts, 1)
+	assert.Equal(t, float64(100), info.ReadPercentage)
+
+	info = NewPostReadReceiptInfo(postID, receipts, 0)
+	assert.Zero(t, info.ReadPercentage)
+}
+
+func TestPostReadReceiptSummaryEstimateFromSample(t *testing.T) {
+	t.Run("half of the sample", func(t *testing.T) {
+		summary := &PostReadReceiptSummary{ReadCount: 50}
+		summary.EstimateFromSample(0.1, 1000)
+		assert.True(t, summary.Estimated)
+		assert.InDelta(t, 50, summary.EstimatedReadPercentage, 0.001)
+		assert.InDelta(t, 9.1, summary.MarginOfError, 0.2)
+	})
+
+	t.Run("nobody in the sample", func(t *testing.T) {
+		summary := &PostReadReceiptSummary{}
+		summary.EstimateFromSample(0.1, 1000)
+		assert.Zero(t, summary.EstimatedReadPercentage)
+		assert.Greater(t, summary.MarginOfError, float64(0))
+	})
+
+	t.Run("larger samples are more precise", func(t *testing.T) {
+		small := &PostReadReceiptSummary{ReadCount: 10}
+		small.EstimateFromSample(0.02, 1000)
+		large := &PostReadReceiptSummary{ReadCount: 100}
+		large.EstimateFromSample(0.2, 1000)
+		assert.Equal(t, small.EstimatedReadPercentage, large.EstimatedReadPercentage)
+		assert.Less(t, large.MarginOfError, small.MarginOfError)
+	})
+
+	t.Run("every member sampled", func(t *testing.T) {
+		summary := &PostReadReceiptSummary{ReadCount: 30}
+		summary.EstimateFromSample(1, 100)
+		assert.InDelta(t, 30, summary.EstimatedReadPercentage, 0.001)
+		assert.Zero(t, summary.MarginOfError)
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		summary := &PostReadReceiptSummary{ReadCount: 3}
+		summary.EstimateFromSample(0.5, 0)
+		assert.True(t, summary.Estimated)
+		assert.Zero(t, summary.EstimatedReadPercentage)
+		assert.Zero(t, summary.MarginOfError)
+	})
+}
+
+func TestReadReceiptCursor(t *testing.T) {
+	cursor := ReadReceiptCursor{ReadAt: 1234, PostId: NewId()}
+
+	parsed, err := ReadReceiptCursorFromString(cursor.String())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+
+	parsed, err = ReadReceiptCursorFromString("")
+	require.NoError(t, err)
+	assert.True(t, parsed.IsEmpty())
+
+	for _, page := range []string{"junk", "abc_" + NewId(), "1234_junk"} {
+		_, err = ReadReceiptCursorFromString(page)
+		assert.Error(t, err, page)
+	}
+}
+
+func TestPostReadReceiptCompactJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		receipt := &PostReadReceipt{
+			PostId:             NewId(),
+			UserId:             NewId(),
+			ChannelId:          NewId(),
+			ReadAt:             GetMillis(),
+			DeviceType:         ReadReceiptDeviceTypeMobile,
+			DeviceId:           "device",
+			SessionId:          NewId(),
+			Source:             "scroll",
+			Confidence:         ReadReceiptConfidenceWindowFocused,
+			ClientReadAt:       GetMillis() - 1000,
+			ConvertedFromGhost: true,
+		}
+
+		data, err := receipt.MarshalCompactJSON()
+		require.NoError(t, err)
+
+		var decoded PostReadReceipt
+		require.NoError(t, decoded.UnmarshalCompactJSON(data))
+		assert.Equal(t, receipt, &decoded)
+	})
+
+	t.Run("omits zero values", func(t *testing.T) {
+		receipt := &PostReadReceipt{PostId: NewId(), UserId: NewId(), ReadAt: 1}
+
+		data, err := receipt.MarshalCompactJSON()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"p":"`+receipt.PostId+`","u":"`+receipt.UserId+`","r":1}`, string(data))
+
+		regular, err := json.Marshal(receipt)
+		require.NoError(t, err)
+		assert.Less(t, len(data), len(regular))
+	})
+
+	t.Run("batch response round trip", func(t *testing.T) {
+		resp := &ReadReceiptBatchResponse{
+			ProcessedCount: 2,
+			Receipts: []*PostReadReceipt{
+				{PostId: NewId(), UserId: NewId(), ChannelId: NewId(), ReadAt: 1},
+				{PostId: NewId(), UserId: NewId(), ChannelId: NewId(), ReadAt: 2},
+			},
+			Degraded: true,
+		}
+
+		data, err := resp.MarshalCompactJSON()
+		require.NoError(t, err)
+
+		var decoded ReadReceiptBatchResponse
+		require.NoError(t, decoded.UnmarshalCompactJSON(data))
+		assert.Equal(t, resp, &decoded)
+	})
+
+	t.Run("info round trip", func(t *testing.T) {
+		info := &PostReadReceiptInfo{
+			PostId:         NewId(),
+			Receipts:       []*PostReadReceipt{{PostId: NewId(), UserId: NewId(), ChannelId: NewId(), ReadAt: 1}},
+			ReadCount:      1,
+			BotReadCount:   2,
+			TotalMembers:   4,
+			ReadPercentage: 25,
+		}
+
+		data, err := info.MarshalCompactJSON()
+		require.NoError(t, err)
+
+		var decoded PostReadReceiptInfo
+		require.NoError(t, decoded.UnmarshalCompactJSON(data))
+		assert.Equal(t, info, &decoded)
+	})
+
+	t.Run("page round trip", func(t *testing.T) {
+		page := &ReadReceiptsForUserPage{
+			Receipts: []*PostReadReceipt{{PostId: NewId(), UserId: NewId(), ChannelId: NewId(), ReadAt: 1}},
+			NextPage: "cursor",
+		}
+
+		data, err := page.MarshalCompactJSON()
+		require.NoError(t, err)
+
+		var decoded ReadReceiptsForUserPage
+		require.NoError(t, decoded.UnmarshalCompactJSON(data))
+		assert.Equal(t, page, &decoded)
+	})
+}
+
+func TestWantsCompactReadReceipts(t *testing.T) {
+	assert.False(t, WantsCompactReadReceipts("", ""))
+	assert.False(t, WantsCompactReadReceipts("", "application/json"))
+	assert.False(t, WantsCompactReadReceipts("", "*/*"))
+	assert.True(t, WantsCompactReadReceipts("compact", ""))
+	assert.True(t, WantsCompactReadReceipts("", ReadReceiptCompactContentType))
+	assert.True(t, WantsCompactReadReceipts("", "application/json, "+ReadReceiptCompactContentType+";q=0.9"))
+	assert.False(t, WantsCompactReadReceipts("json", ReadReceiptCompactContentType))
+}
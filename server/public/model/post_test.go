@@ -1255,6 +1255,12 @@ func TestPost_PropsIsValid(t *testing.T) {
 			},
 			wantErr: "force_notification prop must be a boolean",
 		},
+		"invalid no_read_receipts type": {
+			props: StringInterface{
+				PostPropsNoReadReceipts: "true",
+			},
+			wantErr: "no_read_receipts prop must be a boolean",
+		},
 	}
 
 	for name, tc := range tests {
@@ -51,6 +51,7 @@ const (
 	// - PreferenceNameChannelDisplayMode
 	// - PreferenceNameNameFormat
 	// - PreferenceNamePostReadReceiptsEnabled
+	// - PreferenceNameReadReceiptsPausedUntil
 	PreferenceCategoryDisplaySettings = "display_settings"
 	// PreferenceCategorySystemNotice is used store system admin notices.
 	// Possible Name values are not defined here. It can be anything with the notice name.
@@ -66,6 +67,7 @@ const (
 	// PreferenceCategoryNotifications is used to store the user's notification settings.
 	// Possible Name values are:
 	// - PreferenceNameEmailInterval
+	// - PreferenceNameUnreadDMNudge
 	PreferenceCategoryNotifications = "notifications"
 
 	// Deprecated: PreferenceRecommendedNextSteps is not used anymore.
@@ -87,6 +89,9 @@ const (
 	PreferenceNameNameFormat              = "name_format"
 	PreferenceNameUseMilitaryTime         = "use_military_time"
 	PreferenceNamePostReadReceiptsEnabled = "post_read_receipts_enabled"
+	// PreferenceNameReadReceiptsPausedUntil holds the time, in milliseconds, until
+	// which the reads of the user are kept as ghost reads instead of receipts.
+	PreferenceNameReadReceiptsPausedUntil = "read_receipts_paused_until"
 
 	PreferenceNameShowUnreadSection = "show_unread_section"
 	PreferenceLimitVisibleDmsGms    = "limit_visible_dms_gms"
@@ -107,6 +112,9 @@ const (
 	PreferenceCustomStatusModalViewed       = "custom_status_modal_viewed"
 
 	PreferenceNameEmailInterval = "email_interval"
+	// PreferenceNameUnreadDMNudge set to "false" opts the user out of the reminders
+	// about direct messages they left unread.
+	PreferenceNameUnreadDMNudge = "unread_dm_nudge"
 
 	PreferenceEmailIntervalNoBatchingSeconds = "30"  // the "immediate" setting is actually 30s
 	PreferenceEmailIntervalBatchingSeconds   = "900" // fifteen minutes is 900 seconds
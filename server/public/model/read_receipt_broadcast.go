@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// ReadReceiptBroadcastMaxChannels is the maximum number of channels an
+// announcement can be broadcast to with read tracking.
+const ReadReceiptBroadcastMaxChannels = 50
+
+// ReadReceiptBroadcast tracks the copies of an announcement posted to several
+// channels, so that their read receipts can be aggregated.
+type ReadReceiptBroadcast struct {
+	Id        string                      `json:"id"`
+	CreatorId string                      `json:"creator_id"`
+	CreateAt  int64                       `json:"create_at"`
+	Posts     []*ReadReceiptBroadcastPost `json:"posts"`
+}
+
+// ReadReceiptBroadcastPost is the copy of a broadcast posted to one channel.
+type ReadReceiptBroadcastPost struct {
+	PostId    string `json:"post_id"`
+	ChannelId string `json:"channel_id"`
+}
+
+func (o *ReadReceiptBroadcast) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+}
+
+func (o *ReadReceiptBroadcast) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("ReadReceiptBroadcast.IsValid", "model.read_receipt_broadcast.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("ReadReceiptBroadcast.IsValid", "model.read_receipt_broadcast.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ReadReceiptBroadcast.IsValid", "model.read_receipt_broadcast.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Posts) == 0 || len(o.Posts) > ReadReceiptBroadcastMaxChannels {
+		return NewAppError("ReadReceiptBroadcast.IsValid", "model.read_receipt_broadcast.is_valid.posts.app_error", map[string]any{"Max": ReadReceiptBroadcastMaxChannels}, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	for _, post := range o.Posts {
+		if !IsValidId(post.PostId) || !IsValidId(post.ChannelId) {
+			return NewAppError("ReadReceiptBroadcast.IsValid", "model.read_receipt_broadcast.is_valid.posts.app_error", map[string]any{"Max": ReadReceiptBroadcastMaxChannels}, "id="+o.Id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// ReadReceiptBroadcastChannelSummary is the read progress of the copy of a
+// broadcast posted to one channel, relative to its human members.
+type ReadReceiptBroadcastChannelSummary struct {
+	ChannelId      string  `json:"channel_id"`
+	PostId         string  `json:"post_id"`
+	ReadCount      int64   `json:"read_count"`
+	TotalMembers   int64   `json:"total_members"`
+	ReadPercentage float64 `json:"read_percentage"`
+	// Estimated is set when the channel only stores the receipts of a sample of
+	// its members, in which case ReadCount is estimated from that sample.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ReadReceiptBroadcastSummary aggregates the read progress of every copy of a
+// broadcast. Members of several channels are counted once per channel.
+type ReadReceiptBroadcastSummary struct {
+	BroadcastId    string                                `json:"broadcast_id"`
+	ReadCount      int64                                 `json:"read_count"`
+	TotalMembers   int64                                 `json:"total_members"`
+	ReadPercentage float64                               `json:"read_percentage"`
+	Channels       []*ReadReceiptBroadcastChannelSummary `json:"channels"`
+	// Estimated is set when the counts of any of the channels are estimated.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ReadReceiptAnnouncementReport aggregates the read progress of the copies of an
+// announcement cross-posted to channels of a team, whether or not it was posted as
+// a broadcast.
+type ReadReceiptAnnouncementReport struct {
+	TeamId         string                                `json:"team_id"`
+	ReadCount      int64                                 `json:"read_count"`
+	TotalMembers   int64                                 `json:"total_members"`
+	ReadPercentage float64                               `json:"read_percentage"`
+	Channels       []*ReadReceiptBroadcastChannelSummary `json:"channels"`
+	// Estimated is set when the counts of any of the channels are estimated.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ReadReceiptPercentage returns readCount as a percentage of totalMembers,
+// capped at 100 since members may leave after reading.
+func ReadReceiptPercentage(readCount, totalMembers int64) float64 {
+	if totalMembers <= 0 {
+		return 0
+	}
+	return min(float64(readCount)/float64(totalMembers)*100, 100)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// ReadReceiptChainEntry records a saved read receipt in the append-only chain of
+// its channel. Each entry carries an HMAC over the receipt and the hash of the
+// previous entry, so that altering, removing or reordering entries afterwards
+// breaks the chain. Deleting the receipt prunes its entries: the post, the user
+// and the read time are cleared but the hashes are kept, so the chain stays
+// linked.
+type ReadReceiptChainEntry struct {
+	ChannelId string `json:"channel_id"`
+	Sequence  int64  `json:"sequence"`
+	PostId    string `json:"post_id"`
+	UserId    string `json:"user_id"`
+	ReadAt    int64  `json:"read_at"`
+	PrevHash  string `json:"prev_hash"`
+	Hash      string `json:"hash"`
+}
+
+// ComputeHash returns the hex encoded HMAC-SHA256, keyed with key, over the
+// post, the user, the read time and the previous hash of the entry.
+func (e *ReadReceiptChainEntry) ComputeHash(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(e.PostId))
+	mac.Write([]byte{0})
+	mac.Write([]byte(e.UserId))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(e.ReadAt, 10)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(e.PrevHash))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Link appends the entry after prev, the current head of the chain or nil for
+// the first entry of the channel, and signs it.
+func (e *ReadReceiptChainEntry) Link(key []byte, prev *ReadReceiptChainEntry) {
+	e.Sequence = 1
+	e.PrevHash = ""
+	if prev != nil {
+		e.Sequence = prev.Sequence + 1
+		e.PrevHash = prev.Hash
+	}
+	e.Hash = e.ComputeHash(key)
+}
+
+// IsPruned reports whether the receipt of the entry was deleted.
+func (e *ReadReceiptChainEntry) IsPruned() bool {
+	return e.PostId == "" && e.UserId == ""
+}
+
+// Verify reports whether the entry follows prev, nil for the first entry of the
+// channel, and its hash matches its content. Only the link of pruned entries is
+// checked, their content being gone.
+func (e *ReadReceiptChainEntry) Verify(key []byte, prev *ReadReceiptChainEntry) bool {
+	expectedSequence, expectedPrevHash := int64(1), ""
+	if prev != nil {
+		expectedSequence, expectedPrevHash = prev.Sequence+1, prev.Hash
+	}
+	if e.Sequence != expectedSequence || e.PrevHash != expectedPrevHash {
+		return false
+	}
+	if e.IsPruned() {
+		return true
+	}
+
+	return hmac.Equal([]byte(e.Hash), []byte(e.ComputeHash(key)))
+}
+
+// ReadReceiptChainVerification is the outcome of checking the receipt chain of
+// a channel. FirstInvalidSequence is set when the chain is broken, and
+// MismatchedSequences lists the latest entries of readers whose stored receipt
+// was removed or no longer has the chained read time.
+type ReadReceiptChainVerification struct {
+	ChannelId            string  `json:"channel_id"`
+	Valid                bool    `json:"valid"`
+	EntriesChecked       int64   `json:"entries_checked"`
+	FirstInvalidSequence int64   `json:"first_invalid_sequence,omitempty"`
+	MismatchedSequences  []int64 `json:"mismatched_sequences,omitempty"`
+	HeadHash             string  `json:"head_hash,omitempty"`
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReceiptChainEntry(t *testing.T) {
+	key := []byte("integrity key")
+	channelID := NewId()
+
+	first := &ReadReceiptChainEntry{ChannelId: channelID, PostId: NewId(), UserId: NewId(), ReadAt: 1000}
+	first.Link(key, nil)
+	second := &ReadReceiptChainEntry{ChannelId: channelID, PostId: NewId(), UserId: NewId(), ReadAt: 2000}
+	second.Link(key, first)
+
+	require.EqualValues(t, 1, first.Sequence)
+	require.Empty(t, first.PrevHash)
+	require.EqualValues(t, 2, second.Sequence)
+	require.Equal(t, first.Hash, second.PrevHash)
+
+	assert.True(t, first.Verify(key, nil))
+	assert.True(t, second.Verify(key, first))
+
+	t.Run("wrong key", func(t *testing.T) {
+		assert.False(t, second.Verify([]byte("other key"), first))
+	})
+
+	t.Run("altered receipt", func(t *testing.T) {
+		altered := *second
+		altered.ReadAt++
+		assert.False(t, altered.Verify(key, first))
+	})
+
+	t.Run("pruned entry", func(t *testing.T) {
+		pruned := *second
+		pruned.PostId, pruned.UserId, pruned.ReadAt = "", "", 0
+		require.True(t, pruned.IsPruned())
+		assert.True(t, pruned.Verify(key, first))
+
+		third := &ReadReceiptChainEntry{ChannelId: channelID, PostId: NewId(), UserId: NewId(), ReadAt: 3000}
+		third.Link(key, second)
+		assert.True(t, third.Verify(key, &pruned))
+
+		pruned.PrevHash = ""
+		assert.False(t, pruned.Verify(key, first))
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		third := &ReadReceiptChainEntry{ChannelId: channelID, PostId: NewId(), UserId: NewId(), ReadAt: 3000}
+		third.Link(key, second)
+		assert.False(t, third.Verify(key, first))
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	ReadReceiptChangeOpSave   = "save"
+	ReadReceiptChangeOpDelete = "delete"
+)
+
+// ReadReceiptChange records that a receipt was saved or deleted. Changes are
+// numbered in the order they were written, so that consumers can tail them by
+// Sequence instead of scanning the receipts by read time.
+type ReadReceiptChange struct {
+	Sequence  int64  `json:"sequence"`
+	Op        string `json:"op"`
+	PostId    string `json:"post_id"`
+	UserId    string `json:"user_id"`
+	ChannelId string `json:"channel_id"`
+	At        int64  `json:"at"`
+}
+
+// ReadReceiptChangesPage is a page of changes. LastSequence is the sequence to
+// ask for the next page after, and stays the requested one when there are no new
+// changes.
+type ReadReceiptChangesPage struct {
+	Changes      []*ReadReceiptChange `json:"changes"`
+	LastSequence int64                `json:"last_sequence"`
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"mime"
+	"strings"
+)
+
+// Clients that fetch many receipts, like mobile apps on slow networks, can ask
+// for the compact encoding of the receipts, with short keys and without the
+// fields left at their zero value, either through the format query parameter or
+// the Accept header.
+const (
+	ReadReceiptFormatParam        = "format"
+	ReadReceiptFormatCompact      = "compact"
+	ReadReceiptCompactContentType = "application/vnd.mattermost.read-receipts.compact+json"
+)
+
+type compactPostReadReceipt struct {
+	PostId             string `json:"p,omitempty"`
+	UserId             string `json:"u,omitempty"`
+	ChannelId          string `json:"c,omitempty"`
+	ReadAt             int64  `json:"r,omitempty"`
+	DeviceType         string `json:"dt,omitempty"`
+	DeviceId           string `json:"d,omitempty"`
+	SessionId          string `json:"s,omitempty"`
+	Source             string `json:"src,omitempty"`
+	Confidence         string `json:"cf,omitempty"`
+	ClientReadAt       int64  `json:"cr,omitempty"`
+	ConvertedFromGhost bool   `json:"g,omitempty"`
+}
+
+func newCompactPostReadReceipt(r *PostReadReceipt) *compactPostReadReceipt {
+	if r == nil {
+		return nil
+	}
+	return &compactPostReadReceipt{
+		PostId:             r.PostId,
+		UserId:             r.UserId,
+		ChannelId:          r.ChannelId,
+		ReadAt:             r.ReadAt,
+		DeviceType:         r.DeviceType,
+		DeviceId:           r.DeviceId,
+		SessionId:          r.SessionId,
+		Source:             r.Source,
+		Confidence:         r.Confidence,
+		ClientReadAt:       r.ClientReadAt,
+		ConvertedFromGhost: r.ConvertedFromGhost,
+	}
+}
+
+func (c *compactPostReadReceipt) toReceipt() *PostReadReceipt {
+	if c == nil {
+		return nil
+	}
+	return &PostReadReceipt{
+		PostId:             c.PostId,
+		UserId:             c.UserId,
+		ChannelId:          c.ChannelId,
+		ReadAt:             c.ReadAt,
+		DeviceType:         c.DeviceType,
+		DeviceId:           c.DeviceId,
+		SessionId:          c.SessionId,
+		Source:             c.Source,
+		Confidence:         c.Confidence,
+		ClientReadAt:       c.ClientReadAt,
+		ConvertedFromGhost: c.ConvertedFromGhost,
+	}
+}
+
+func newCompactPostReadReceipts(receipts []*PostReadReceipt) []*compactPostReadReceipt {
+	if receipts == nil {
+		return nil
+	}
+	compact := make([]*compactPostReadReceipt, len(receipts))
+	for i, receipt := range receipts {
+		compact[i] = newCompactPostReadReceipt(receipt)
+	}
+	return compact
+}
+
+func compactPostReadReceiptsToReceipts(compact []*compactPostReadReceipt) []*PostReadReceipt {
+	if compact == nil {
+		return nil
+	}
+	receipts := make([]*PostReadReceipt, len(compact))
+	for i, receipt := range compact {
+		receipts[i] = receipt.toReceipt()
+	}
+	return receipts
+}
+
+// MarshalCompactJSON encodes the receipt in the compact encoding.
+func (r *PostReadReceipt) MarshalCompactJSON() ([]byte, error) {
+	return json.Marshal(newCompactPostReadReceipt(r))
+}
+
+// UnmarshalCompactJSON decodes a receipt encoded by MarshalCompactJSON.
+func (r *PostReadReceipt) UnmarshalCompactJSON(data []byte) error {
+	var compact compactPostReadReceipt
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return err
+	}
+	*r = *compact.toReceipt()
+	return nil
+}
+
+type compactReadReceiptBatchResponse struct {
+	ProcessedCount int                       `json:"n,omitempty"`
+	Receipts       []*compactPostReadReceipt `json:"rs,omitempty"`
+	Degraded       bool                      `json:"dg,omitempty"`
+	Paused         bool                      `json:"ps,omitempty"`
+}
+
+// MarshalCompactJSON encodes the response and its receipts in the compact encoding.
+func (r *ReadReceiptBatchResponse) MarshalCompactJSON() ([]byte, error) {
+	return json.Marshal(&compactReadReceiptBatchResponse{
+		ProcessedCount: r.ProcessedCount,
+		Receipts:       newCompactPostReadReceipts(r.Receipts),
+		Degraded:       r.Degraded,
+		Paused:         r.Paused,
+	})
+}
+
+// UnmarshalCompactJSON decodes a response encoded by MarshalCompactJSON.
+func (r *ReadReceiptBatchResponse) UnmarshalCompactJSON(data []byte) error {
+	var compact compactReadReceiptBatchResponse
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return err
+	}
+	*r = ReadReceiptBatchResponse{
+		ProcessedCount: compact.ProcessedCount,
+		Receipts:       compactPostReadReceiptsToReceipts(compact.Receipts),
+		Degraded:       compact.Degraded,
+		Paused:         compact.Paused,
+	}
+	return nil
+}
+
+type compactPostReadReceiptInfo struct {
+	PostId         string                    `json:"p,omitempty"`
+	Receipts       []*compactPostReadReceipt `json:"rs,omitempty"`
+	ReadCount      int64                     `json:"rc,omitempty"`
+	BotReadCount   int64                     `json:"bc,omitempty"`
+	TotalMembers   int64                     `json:"tm,omitempty"`
+	ReadPercentage float64                   `json:"pct,omitempty"`
+}
+
+// MarshalCompactJSON encodes the info and its receipts in the compact encoding.
+func (i *PostReadReceiptInfo) MarshalCompactJSON() ([]byte, error) {
+	return json.Marshal(&compactPostReadReceiptInfo{
+		PostId:         i.PostId,
+		Receipts:       newCompactPostReadReceipts(i.Receipts),
+		ReadCount:      i.ReadCount,
+		BotReadCount:   i.BotReadCount,
+		TotalMembers:   i.TotalMembers,
+		ReadPercentage: i.ReadPercentage,
+	})
+}
+
+// UnmarshalCompactJSON decodes an info encoded by MarshalCompactJSON.
+func (i *PostReadReceiptInfo) UnmarshalCompactJSON(data []byte) error {
+	var compact compactPostReadReceiptInfo
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return err
+	}
+	*i = PostReadReceiptInfo{
+		PostId:         compact.PostId,
+		Receipts:       compactPostReadReceiptsToReceipts(compact.Receipts),
+		ReadCount:      compact.ReadCount,
+		BotReadCount:   compact.BotReadCount,
+		TotalMembers:   compact.TotalMembers,
+		ReadPercentage: compact.ReadPercentage,
+	}
+	return nil
+}
+
+type compactReadReceiptsForUserPage struct {
+	Receipts []*compactPostReadReceipt `json:"rs,omitempty"`
+	NextPage string                    `json:"np,omitempty"`
+}
+
+// MarshalCompactJSON encodes the page and its receipts in the compact encoding.
+func (p *ReadReceiptsForUserPage) MarshalCompactJSON() ([]byte, error) {
+	return json.Marshal(&compactReadReceiptsForUserPage{
+		Receipts: newCompactPostReadReceipts(p.Receipts),
+		NextPage: p.NextPage,
+	})
+}
+
+// UnmarshalCompactJSON decodes a page encoded by MarshalCompactJSON.
+func (p *ReadReceiptsForUserPage) UnmarshalCompactJSON(data []byte) error {
+	var compact compactReadReceiptsForUserPage
+	if err := json.Unmarshal(data, &compact); err != nil {
+		return err
+	}
+	*p = ReadReceiptsForUserPage{
+		Receipts: compactPostReadReceiptsToReceipts(compact.Receipts),
+		NextPage: compact.NextPage,
+	}
+	return nil
+}
+
+// WantsCompactReadReceipts tells whether the client asked for the compact
+// encoding, through the format query parameter or by accepting the compact
+// content type.
+func WantsCompactReadReceipts(format, accept string) bool {
+	if format != "" {
+		return format == ReadReceiptFormatCompact
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == ReadReceiptCompactContentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// PostReadEvent is the payload of the WebsocketEventPostRead event.
+type PostReadEvent struct {
+	ReadReceipt *PostReadReceipt
+	RootId      string
+	ChannelType ChannelType
+}
+
+// PostReadBatchEvent is the payload of the WebsocketEventPostReadBatch event.
+// RootIds maps the posts that are thread replies to their root post.
+type PostReadBatchEvent struct {
+	ReadReceipts []*PostReadReceipt
+	RootIds      map[string]string
+	ChannelType  ChannelType
+}
+
+// The receipts are sent JSON encoded inside the event data, which is what the
+// web app has always consumed.
+type postReadEventData struct {
+	ReadReceipt string      `json:"read_receipt"`
+	RootId      string      `json:"root_id"`
+	ChannelType ChannelType `json:"channel_type"`
+}
+
+type postReadBatchEventData struct {
+	ReadReceipts string            `json:"read_receipts"`
+	RootIds      map[string]string `json:"root_ids"`
+	ChannelType  ChannelType       `json:"channel_type"`
+}
+
+// ToWebSocketEvent builds the event broadcast to the members of channelID.
+func (e *PostReadEvent) ToWebSocketEvent(channelID string) (*WebSocketEvent, error) {
+	receiptJSON, err := json.Marshal(e.ReadReceipt)
+	if err != nil {
+		return nil, err
+	}
+
+	message := NewWebSocketEvent(WebsocketEventPostRead, "", channelID, "", nil, "")
+	message.Add("read_receipt", string(receiptJSON))
+	message.Add("root_id", e.RootId)
+	message.Add("channel_type", e.ChannelType)
+	return message, nil
+}
+
+// ToWebSocketEvent builds the event broadcast to the members of channelID.
+func (e *PostReadBatchEvent) ToWebSocketEvent(channelID string) (*WebSocketEvent, error) {
+	receiptsJSON, err := json.Marshal(e.ReadReceipts)
+	if err != nil {
+		return nil, err
+	}
+
+	message := NewWebSocketEvent(WebsocketEventPostReadBatch, "", channelID, "", nil, "")
+	message.Add("read_receipts", string(receiptsJSON))
+	message.Add("root_ids", e.RootIds)
+	message.Add("channel_type", e.ChannelType)
+	return message, nil
+}
+
+// ChannelReadDigestEvent is the payload of the WebsocketEventChannelReadDigest
+// event, sent instead of a WebsocketEventPostReadBatch event when a user reads a
+// channel up to a post. Clients mark every post of the channel up to UpToPostId as
+// read by the user at ReadAt, or at the creation of the post when later.
+type ChannelReadDigestEvent struct {
+	UserId      string      `json:"user_id"`
+	ChannelId   string      `json:"channel_id"`
+	UpToPostId  string      `json:"up_to_post_id"`
+	ReadAt      int64       `json:"read_at"`
+	ChannelType ChannelType `json:"channel_type"`
+}
+
+// ToWebSocketEvent builds the event broadcast to the members of the channel.
+func (e *ChannelReadDigestEvent) ToWebSocketEvent() *WebSocketEvent {
+	message := NewWebSocketEvent(WebsocketEventChannelReadDigest, "", e.ChannelId, "", nil, "")
+	message.Add("user_id", e.UserId)
+	message.Add("channel_id", e.ChannelId)
+	message.Add("up_to_post_id", e.UpToPostId)
+	message.Add("read_at", e.ReadAt)
+	message.Add("channel_type", e.ChannelType)
+	return message
+}
+
+// ChannelReadDigestEventFromWebSocketEvent returns the payload of an event received
+// from the websocket, such as one read from WebSocketClient.EventChannel.
+func ChannelReadDigestEventFromWebSocketEvent(ev *WebSocketEvent) (*ChannelReadDigestEvent, error) {
+	if ev.EventType() != WebsocketEventChannelReadDigest {
+		return nil, fmt.Errorf("unexpected event type %q", ev.EventType())
+	}
+
+	data, err := json.Marshal(ev.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	var event ChannelReadDigestEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// PostReadEventFromJSON decodes the data of a WebsocketEventPostRead event.
+func PostReadEventFromJSON(data io.Reader) (*PostReadEvent, error) {
+	var o postReadEventData
+	if err := json.NewDecoder(data).Decode(&o); err != nil {
+		return nil, err
+	}
+
+	event := &PostReadEvent{RootId: o.RootId, ChannelType: o.ChannelType}
+	if err := json.Unmarshal([]byte(o.ReadReceipt), &event.ReadReceipt); err != nil {
+		return nil, fmt.Errorf("invalid read_receipt: %w", err)
+	}
+	return event, nil
+}
+
+// PostReadBatchEventFromJSON decodes the data of a WebsocketEventPostReadBatch event.
+func PostReadBatchEventFromJSON(data io.Reader) (*PostReadBatchEvent, error) {
+	var o postReadBatchEventData
+	if err := json.NewDecoder(data).Decode(&o); err != nil {
+		return nil, err
+	}
+
+	event := &PostReadBatchEvent{RootIds: o.RootIds, ChannelType: o.ChannelType}
+	if err := json.Unmarshal([]byte(o.ReadReceipts), &event.ReadReceipts); err != nil {
+		return nil, fmt.Errorf("invalid read_receipts: %w", err)
+	}
+	return event, nil
+}
+
+// PostReadEventFromWebSocketEvent returns the payload of an event received from
+// the websocket, such as one read from WebSocketClient.EventChannel.
+func PostReadEventFromWebSocketEvent(ev *WebSocketEvent) (*PostReadEvent, error) {
+	if ev.EventType() != WebsocketEventPostRead {
+		return nil, fmt.Errorf("unexpected event type %q", ev.EventType())
+	}
+
+	data, err := json.Marshal(ev.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return PostReadEventFromJSON(bytes.NewReader(data))
+}
+
+// PostReadBatchEventFromWebSocketEvent returns the payload of an event received
+// from the websocket, such as one read from WebSocketClient.EventChannel.
+func PostReadBatchEventFromWebSocketEvent(ev *WebSocketEvent) (*PostReadBatchEvent, error) {
+	if ev.EventType() != WebsocketEventPostReadBatch {
+		return nil, fmt.Errorf("unexpected event type %q", ev.EventType())
+	}
+
+	data, err := json.Marshal(ev.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return PostReadBatchEventFromJSON(bytes.NewReader(data))
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostReadEventRoundTrip(t *testing.T) {
+	event := &PostReadEvent{
+		ReadReceipt: &PostReadReceipt{PostId: NewId(), UserId: NewId(), ChannelId: NewId(), ReadAt: 1000},
+		RootId:      NewId(),
+		ChannelType: ChannelTypeOpen,
+	}
+
+	ev, err := event.ToWebSocketEvent(event.ReadReceipt.ChannelId)
+	require.NoError(t, err)
+
+	// Go through the wire format, as a client would.
+	js, err := ev.ToJSON()
+	require.NoError(t, err)
+	received, err := WebSocketEventFromJSON(bytes.NewReader(js))
+	require.NoError(t, err)
+
+	decoded, err := PostReadEventFromWebSocketEvent(received)
+	require.NoError(t, err)
+	assert.Equal(t, event, decoded)
+
+	_, err = PostReadBatchEventFromWebSocketEvent(received)
+	require.Error(t, err)
+}
+
+func TestPostReadBatchEventRoundTrip(t *testing.T) {
+	channelID := NewId()
+	rootID := NewId()
+	reply := &PostReadReceipt{PostId: NewId(), UserId: NewId(), ChannelId: channelID, ReadAt: 1000}
+	event := &PostReadBatchEvent{
+		ReadReceipts: []*PostReadReceipt{
+			reply,
+			{PostId: rootID, UserId: reply.UserId, ChannelId: channelID, ReadAt: 1000},
+		},
+		RootIds:     map[string]string{reply.PostId: rootID},
+		ChannelType: ChannelTypePrivate,
+	}
+
+	ev, err := event.ToWebSocketEvent(channelID)
+	require.NoError(t, err)
+
+	js, err := ev.ToJSON()
+	require.NoError(t, err)
+	received, err := WebSocketEventFromJSON(bytes.NewReader(js))
+	require.NoError(t, err)
+
+	decoded, err := PostReadBatchEventFromWebSocketEvent(received)
+	require.NoError(t, err)
+	assert.Equal(t, event, decoded)
+}
+
+func TestChannelReadDigestEventRoundTrip(t *testing.T) {
+	event := &ChannelReadDigestEvent{
+		UserId:      NewId(),
+		ChannelId:   NewId(),
+		UpToPostId:  NewId(),
+		ReadAt:      1000,
+		ChannelType: ChannelTypeOpen,
+	}
+
+	ev := event.ToWebSocketEvent()
+	assert.Equal(t, event.ChannelId, ev.GetBroadcast().ChannelId)
+
+	js, err := ev.ToJSON()
+	require.NoError(t, err)
+	received, err := WebSocketEventFromJSON(bytes.NewReader(js))
+	require.NoError(t, err)
+
+	decoded, err := ChannelReadDigestEventFromWebSocketEvent(received)
+	require.NoError(t, err)
+	assert.Equal(t, event, decoded)
+
+	_, err = PostReadBatchEventFromWebSocketEvent(received)
+	require.Error(t, err)
+}
+
+func TestPostReadEventFromJSONInvalid(t *testing.T) {
+	_, err := PostReadEventFromJSON(bytes.NewReader([]byte(`{"read_receipt": "junk"}`)))
+	require.Error(t, err)
+
+	_, err = PostReadBatchEventFromJSON(bytes.NewReader([]byte(`not json`)))
+	require.Error(t, err)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"slices"
+	"unicode/utf8"
+)
+
+const (
+	ReadReceiptVisibilityEveryone   = "everyone"
+	ReadReceiptVisibilityAuthorOnly = "author_only"
+	ReadReceiptVisibilityHidden     = "hidden"
+
+	// ReadReceiptGranularityUser keeps a single receipt per user and post, while
+	// ReadReceiptGranularityDevice also records every device the post was read on.
+	ReadReceiptGranularityUser   = "user"
+	ReadReceiptGranularityDevice = "device"
+
+	ReadReceiptPolicyNameMaxRunes = 64
+	// ReadReceiptPoliciesMax is the maximum number of policies that can be
+	// configured at the same time.
+	ReadReceiptPoliciesMax = 100
+	// ReadReceiptCleanupPreviewMaxChannels caps the channels broken down in a
+	// ReadReceiptCleanupPreview.
+	ReadReceiptCleanupPreviewMaxChannels = 100
+)
+
+// ReadReceiptPolicy configures read receipts for the teams and channels in its
+// scope. A policy with an empty scope applies to everything not covered by a
+// more specific policy. RetentionDays of zero keeps receipts forever.
+type ReadReceiptPolicy struct {
+	Id              string      `json:"id"`
+	Name            string      `json:"name"`
+	TeamIds         StringArray `json:"team_ids"`
+	ChannelIds      StringArray `json:"channel_ids"`
+	RetentionDays   int         `json:"retention_days"`
+	VisibilityMode  string      `json:"visibility_mode"`
+	IncludeInExport bool        `json:"include_in_export"`
+	CreateAt        int64       `json:"create_at"`
+	UpdateAt        int64       `json:"update_at"`
+}
+
+func (p *ReadReceiptPolicy) PreSave() {
+	if p.Id == "" {
+		p.Id = NewId()
+	}
+
+	p.UpdateAt = GetMillis()
+	if p.CreateAt == 0 {
+		p.CreateAt = p.UpdateAt
+	}
+
+	if p.VisibilityMode == "" {
+		p.VisibilityMode = ReadReceiptVisibilityEveryone
+	}
+	if p.TeamIds == nil {
+		p.TeamIds = StringArray{}
+	}
+	if p.ChannelIds == nil {
+		p.ChannelIds = StringArray{}
+	}
+}
+
+func (p *ReadReceiptPolicy) IsValid() *AppError {
+	if !IsValidId(p.Id) {
+		return NewAppError("ReadReceiptPolicy.IsValid", "model.read_receipt_policy.is_valid.id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.Name == "" || utf8.RuneCountInString(p.Name) > ReadReceiptPolicyNameMaxRunes {
+		return NewAppError("ReadReceiptPolicy.IsValid", "model.read_receipt_policy.is_valid.name.app_error", map[string]any{"MaxLength": ReadReceiptPolicyNameMaxRunes}, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	for _, ids := range []StringArray{p.TeamIds, p.ChannelIds} {
+		for _, id := range ids {
+			if !IsValidId(id) {
+				return NewAppError("ReadReceiptPolicy.IsValid", "model.read_receipt_policy.is_valid.scope.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+			}
+		}
+	}
+
+	if p.RetentionDays < 0 {
+		return NewAppError("ReadReceiptPolicy.IsValid", "model.read_receipt_policy.is_valid.retention_days.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	switch p.VisibilityMode {
+	case ReadReceiptVisibilityEveryone, ReadReceiptVisibilityAuthorOnly, ReadReceiptVisibilityHidden:
+	default:
+		return NewAppError("ReadReceiptPolicy.IsValid", "model.read_receipt_policy.is_valid.visibility_mode.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AppliesTo reports whether the channel, which belongs to teamID, is in the
+// scope of the policy.
+func (p *ReadReceiptPolicy) AppliesTo(teamID, channelID string) bool {
+	if len(p.TeamIds) == 0 && len(p.ChannelIds) == 0 {
+		return true
+	}
+
+	for _, id := range p.ChannelIds {
+		if id == channelID {
+			return true
+		}
+	}
+
+	if teamID == "" {
+		return false
+	}
+	for _, id := range p.TeamIds {
+		if id == teamID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResolveReadReceiptPolicy returns the policy among policies that governs the
+// channel, which belongs to teamID: a policy naming the channel wins over one
+// naming its team, which wins over the default policy. It returns nil when no
+// policy applies.
+func ResolveReadReceiptPolicy(policies []*ReadReceiptPolicy, teamID, channelID string) *ReadReceiptPolicy {
+	var teamPolicy, defaultPolicy *ReadReceiptPolicy
+	for _, policy := range policies {
+		if !policy.AppliesTo(teamID, channelID) {
+			continue
+		}
+
+		switch {
+		case slices.Contains(policy.ChannelIds, channelID):
+			return policy
+		case len(policy.TeamIds) == 0 && len(policy.ChannelIds) == 0:
+			if defaultPolicy == nil {
+				defaultPolicy = policy
+			}
+		default:
+			if teamPolicy == nil {
+				teamPolicy = policy
+			}
+		}
+	}
+
+	if teamPolicy != nil {
+		return teamPolicy
+	}
+	return defaultPolicy
+}
+
+// ReadReceiptChannelSettings holds the read receipt settings channel admins set
+// for their channel. With BroadcastReceipts off, receipts and read counters are
+// still recorded but the counter updates are not broadcast to the channel. With
+// PinnedUnreadNotice on, whoever pins a post is told which members haven't read it.
+type ReadReceiptChannelSettings struct {
+	ChannelId          string `json:"channel_id"`
+	BroadcastReceipts  bool   `json:"broadcast_receipts"`
+	PinnedUnreadNotice bool   `json:"pinned_unread_notice"`
+	UpdateAt           int64  `json:"update_at"`
+}
+
+// DefaultReadReceiptChannelSettings returns the settings of a channel whose admins
+// never changed them.
+func DefaultReadReceiptChannelSettings(channelID string) *ReadReceiptChannelSettings {
+	return &ReadReceiptChannelSettings{
+		ChannelId:         channelID,
+		BroadcastReceipts: true,
+	}
+}
+
+// ReadReceiptCleanupChannelCount is the number of receipts of a channel that a
+// cleanup would delete.
+type ReadReceiptCleanupChannelCount struct {
+	ChannelId string `json:"channel_id"`
+	Count     int64  `json:"count"`
+}
+
+// ReadReceiptCleanupPreview estimates what enforcing the retention of a policy
+// would delete: the receipts read before ExpiredBefore in the scope of the policy.
+// Channels lists the channels losing the most receipts first.
+type ReadReceiptCleanupPreview struct {
+	ExpiredBefore int64                             `json:"expired_before"`
+	TotalCount    int64                             `json:"total_count"`
+	Channels      []*ReadReceiptCleanupChannelCount `json:"channels"`
+}
+
+// ReadReceiptEffectivePolicy is the read receipt behavior resolved for a user in
+// a channel, so that clients know whether and how to send receipts. PolicyId is
+// empty when no configured policy applies. Degraded is set when the channel only
+// accepts watermark receipts.
+type ReadReceiptEffectivePolicy struct {
+	ChannelId        string `json:"channel_id"`
+	Enabled          bool   `json:"enabled"`
+	VisibilityMode   string `json:"visibility_mode"`
+	Granularity      string `json:"granularity"`
+	PolicyId         string `json:"policy_id,omitempty"`
+	BatchWindowMs    int    `json:"batch_window_ms"`
+	BatchMaxWaitMs   int    `json:"batch_max_wait_ms"`
+	BatchMaxPosts    int    `json:"batch_max_posts"`
+	ClientDebounceMs int    `json:"client_debounce_ms"`
+	Degraded         bool   `json:"degraded"`
+	// PausedUntil is set while the user paused their receipts, see
+	// PreferenceNameReadReceiptsPausedUntil.
+	PausedUntil int64 `json:"paused_until,omitempty"`
+}
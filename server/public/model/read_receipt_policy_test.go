@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReceiptPolicyIsValid(t *testing.T) {
+	policy := &ReadReceiptPolicy{Name: "default"}
+	policy.PreSave()
+	require.Nil(t, policy.IsValid())
+	assert.Equal(t, ReadReceiptVisibilityEveryone, policy.VisibilityMode)
+
+	for name, mutate := range map[string]func(p *ReadReceiptPolicy){
+		"empty name":         func(p *ReadReceiptPolicy) { p.Name = "" },
+		"long name":          func(p *ReadReceiptPolicy) { p.Name = strings.Repeat("a", ReadReceiptPolicyNameMaxRunes+1) },
+		"invalid team id":    func(p *ReadReceiptPolicy) { p.TeamIds = StringArray{"junk"} },
+		"negative retention": func(p *ReadReceiptPolicy) { p.RetentionDays = -1 },
+		"unknown visibility": func(p *ReadReceiptPolicy) { p.VisibilityMode = "junk" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := *policy
+			mutate(&invalid)
+			require.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestReadReceiptPolicyAppliesTo(t *testing.T) {
+	teamID := NewId()
+	channelID := NewId()
+
+	assert.True(t, (&ReadReceiptPolicy{}).AppliesTo(teamID, channelID))
+	assert.True(t, (&ReadReceiptPolicy{TeamIds: StringArray{teamID}}).AppliesTo(teamID, channelID))
+	assert.True(t, (&ReadReceiptPolicy{ChannelIds: StringArray{channelID}}).AppliesTo("", channelID))
+	assert.False(t, (&ReadReceiptPolicy{TeamIds: StringArray{teamID}}).AppliesTo("", channelID))
+	assert.False(t, (&ReadReceiptPolicy{ChannelIds: StringArray{NewId()}}).AppliesTo(teamID, channelID))
+}
+
+func TestResolveReadReceiptPolicy(t *testing.T) {
+	teamID := NewId()
+	channelID := NewId()
+
+	defaultPolicy := &ReadReceiptPolicy{Name: "default"}
+	teamPolicy := &ReadReceiptPolicy{Name: "team", TeamIds: StringArray{teamID}}
+	channelPolicy := &ReadReceiptPolicy{Name: "channel", ChannelIds: StringArray{channelID}}
+	otherPolicy := &ReadReceiptPolicy{Name: "other", TeamIds: StringArray{NewId()}}
+
+	assert.Nil(t, ResolveReadReceiptPolicy(nil, teamID, channelID))
+	assert.Nil(t, ResolveReadReceiptPolicy([]*ReadReceiptPolicy{otherPolicy}, teamID, channelID))
+	assert.Equal(t, defaultPolicy, ResolveReadReceiptPolicy([]*ReadReceiptPolicy{defaultPolicy, otherPolicy}, teamID, channelID))
+	assert.Equal(t, teamPolicy, ResolveReadReceiptPolicy([]*ReadReceiptPolicy{defaultPolicy, teamPolicy}, teamID, channelID))
+	assert.Equal(t, channelPolicy, ResolveReadReceiptPolicy([]*ReadReceiptPolicy{defaultPolicy, teamPolicy, channelPolicy}, teamID, channelID))
+	assert.Equal(t, defaultPolicy, ResolveReadReceiptPolicy([]*ReadReceiptPolicy{defaultPolicy, teamPolicy}, "", channelID))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	// ReadReceiptWebhooksPerChannelMax is the maximum number of read receipt
+	// webhooks a channel can have.
+	ReadReceiptWebhooksPerChannelMax = 5
+	ReadReceiptWebhookURLMaxLength   = 1024
+	ReadReceiptWebhookSecretLength   = 32
+
+	// ReadReceiptWebhookSignatureHeader carries "sha256=" followed by the hex
+	// encoded HMAC-SHA256 of the request body, keyed with the webhook secret.
+	ReadReceiptWebhookSignatureHeader = "X-Mattermost-Signature"
+)
+
+// ReadReceiptWebhookThresholds are the read percentages, relative to the human
+// members of the channel, that trigger a webhook delivery for key posts.
+var ReadReceiptWebhookThresholds = []int{50, 100}
+
+// ReadReceiptWebhook is a URL notified when the key posts of a channel, those
+// that are pinned or have a priority, reach one of ReadReceiptWebhookThresholds.
+type ReadReceiptWebhook struct {
+	Id        string `json:"id"`
+	ChannelId string `json:"channel_id"`
+	CreatorId string `json:"creator_id"`
+	URL       string `json:"url"`
+	Secret    string `json:"secret"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (o *ReadReceiptWebhook) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Secret == "" {
+		o.Secret = NewRandomString(ReadReceiptWebhookSecretLength)
+	}
+
+	o.CreateAt = GetMillis()
+}
+
+func (o *ReadReceiptWebhook) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.ChannelId) {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.channel_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.URL) > ReadReceiptWebhookURLMaxLength || !IsValidHTTPURL(o.URL) {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if len(o.Secret) != ReadReceiptWebhookSecretLength {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.secret.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("ReadReceiptWebhook.IsValid", "model.read_receipt_webhook.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ReadReceiptWebhookPost describes a key post that reached a read threshold.
+type ReadReceiptWebhookPost struct {
+	PostId         string  `json:"post_id"`
+	Threshold      int     `json:"threshold"`
+	ReadCount      int64   `json:"read_count"`
+	TotalMembers   int64   `json:"total_members"`
+	ReadPercentage float64 `json:"read_percentage"`
+	// Estimated is set when the channel only stores the receipts of a sample of
+	// its members, in which case ReadCount is estimated from that sample.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// ReadReceiptWebhookPayload is the digest POSTed to read receipt webhooks.
+type ReadReceiptWebhookPayload struct {
+	WebhookId string                    `json:"webhook_id"`
+	ChannelId string                    `json:"channel_id"`
+	Timestamp int64                     `json:"timestamp"`
+	Posts     []*ReadReceiptWebhookPost `json:"posts"`
+}
+
+// ReadReceiptThresholdsCrossed returns the thresholds of ReadReceiptWebhookThresholds
+// reached when the read count of a post went from previousReadCount to readCount.
+func ReadReceiptThresholdsCrossed(previousReadCount, readCount, totalMembers int64) []int {
+	if totalMembers <= 0 || readCount <= previousReadCount {
+		return nil
+	}
+
+	var crossed []int
+	for _, threshold := range ReadReceiptWebhookThresholds {
+		target := int64(threshold) * totalMembers
+		if previousReadCount*100 < target && readCount*100 >= target {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
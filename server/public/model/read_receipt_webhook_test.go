@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReceiptWebhookIsValid(t *testing.T) {
+	hook := &ReadReceiptWebhook{ChannelId: NewId(), CreatorId: NewId(), URL: "https://example.com/hook"}
+	hook.PreSave()
+	require.Nil(t, hook.IsValid())
+	assert.Len(t, hook.Secret, ReadReceiptWebhookSecretLength)
+
+	for name, mutate := range map[string]func(h *ReadReceiptWebhook){
+		"invalid channel id": func(h *ReadReceiptWebhook) { h.ChannelId = "junk" },
+		"invalid creator id": func(h *ReadReceiptWebhook) { h.CreatorId = "" },
+		"invalid url":        func(h *ReadReceiptWebhook) { h.URL = "ftp://example.com" },
+		"short secret":       func(h *ReadReceiptWebhook) { h.Secret = "secret" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := *hook
+			mutate(&invalid)
+			require.NotNil(t, invalid.IsValid())
+		})
+	}
+}
+
+func TestReadReceiptThresholdsCrossed(t *testing.T) {
+	assert.Equal(t, []int{50}, ReadReceiptThresholdsCrossed(1, 2, 4))
+	assert.Equal(t, []int{100}, ReadReceiptThresholdsCrossed(3, 4, 4))
+	assert.Equal(t, []int{50, 100}, ReadReceiptThresholdsCrossed(0, 1, 1))
+	// Reads merged into one update cross every threshold between the two counts.
+	assert.Equal(t, []int{50, 100}, ReadReceiptThresholdsCrossed(1, 4, 4))
+	assert.Empty(t, ReadReceiptThresholdsCrossed(2, 3, 4))
+	assert.Empty(t, ReadReceiptThresholdsCrossed(4, 4, 4))
+	assert.Empty(t, ReadReceiptThresholdsCrossed(0, 1, 0))
+}
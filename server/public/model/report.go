@@ -17,6 +17,10 @@ const (
 	ReportDurationLast6Months   = "last_6_months"
 
 	ReportingMaxPageSize = 100
+
+	ChannelArchiveReportDefaultMaxReaderPercent = 10
+	ChannelArchiveReportDefaultInactiveDays     = 30
+	ChannelArchiveReportMaxInactiveDays         = 365
 )
 
 var (
@@ -73,14 +77,23 @@ func (options *ReportingBaseOptions) IsValid() *AppError {
 	return nil
 }
 
+// UserReadStats is the read activity of a user, rolled up daily from the read
+// receipts. PostsReadPerDay averages over the days the user read posts on.
+type UserReadStats struct {
+	PostsReadPerDay *float64 `json:"posts_read_per_day,omitempty"`
+	ChannelsRead    *int     `json:"channels_read,omitempty"`
+}
+
 type UserReportQuery struct {
 	User
 	UserPostStats
+	UserReadStats
 }
 
 type UserReport struct {
 	User
 	UserPostStats
+	UserReadStats
 }
 
 func (u *UserReport) ToReport() []string {
@@ -100,6 +113,14 @@ func (u *UserReport) ToReport() []string {
 	if u.TotalPosts != nil {
 		totalPosts = strconv.Itoa(*u.TotalPosts)
 	}
+	postsReadPerDay := ""
+	if u.PostsReadPerDay != nil {
+		postsReadPerDay = strconv.FormatFloat(*u.PostsReadPerDay, 'f', 2, 64)
+	}
+	channelsRead := ""
+	if u.ChannelsRead != nil {
+		channelsRead = strconv.Itoa(*u.ChannelsRead)
+	}
 	lastLogin := ""
 	if u.LastLogin > 0 {
 		lastLogin = time.UnixMilli(u.LastLogin).String()
@@ -122,6 +143,8 @@ func (u *UserReport) ToReport() []string {
 		lastPostDate,
 		daysActive,
 		totalPosts,
+		postsReadPerDay,
+		channelsRead,
 		deleteAt,
 	}
 }
@@ -154,9 +177,59 @@ func (u *UserReportQuery) ToReport() *UserReport {
 	return &UserReport{
 		User:          u.User,
 		UserPostStats: u.UserPostStats,
+		UserReadStats: u.UserReadStats,
 	}
 }
 
+// ChannelArchiveReportOptions selects the channels in which fewer than
+// MaxReaderPercent percent of the active human members read anything in the last
+// InactiveDays days. TeamId optionally restricts them to a team.
+type ChannelArchiveReportOptions struct {
+	TeamId           string
+	MaxReaderPercent int
+	InactiveDays     int
+	Page             int
+	PerPage          int
+	// SamplingThreshold is set by the server to
+	// ServiceSettings.ReadReceiptsSamplingThreshold, so that the readers of the
+	// channels above it are estimated from their sample.
+	SamplingThreshold int
+}
+
+func (o *ChannelArchiveReportOptions) IsValid() *AppError {
+	if o.MaxReaderPercent < 1 || o.MaxReaderPercent > 100 {
+		return NewAppError("ChannelArchiveReportOptions.IsValid", "model.channel_archive_report_options.is_valid.max_reader_percent.app_error", nil, "", http.StatusBadRequest)
+	}
+	if o.InactiveDays < 1 || o.InactiveDays > ChannelArchiveReportMaxInactiveDays {
+		return NewAppError("ChannelArchiveReportOptions.IsValid", "model.channel_archive_report_options.is_valid.inactive_days.app_error", map[string]any{"Max": ChannelArchiveReportMaxInactiveDays}, "", http.StatusBadRequest)
+	}
+	if o.Page < 0 || o.PerPage <= 0 || o.PerPage > ReportingMaxPageSize {
+		return NewAppError("ChannelArchiveReportOptions.IsValid", "model.channel_archive_report_options.is_valid.page.app_error", nil, "", http.StatusBadRequest)
+	}
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("ChannelArchiveReportOptions.IsValid", "model.channel_archive_report_options.is_valid.team_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ChannelArchiveRecommendation is a channel few of whose members read anything
+// lately, according to the daily read receipt rollup, and is worth archiving.
+// Deactivated users and bots are not counted as members.
+type ChannelArchiveRecommendation struct {
+	ChannelId     string      `json:"channel_id"`
+	TeamId        string      `json:"team_id"`
+	Name          string      `json:"name"`
+	DisplayName   string      `json:"display_name"`
+	Type          ChannelType `json:"type"`
+	MemberCount   int64       `json:"member_count"`
+	ReaderCount   int64       `json:"reader_count"`
+	ReaderPercent float64     `db:"-" json:"reader_percent"`
+	// Estimated is set when the channel only stores the receipts of a sample of
+	// its members, in which case ReaderCount is estimated from that sample.
+	Estimated bool `db:"-" json:"estimated,omitempty"`
+}
+
 func IsValidReportExportFormat(format string) bool {
 	return slices.Contains(ReportExportFormats, format)
 }
@@ -854,6 +854,7 @@ func MakeDefaultRoles() map[string]*Role {
 			PermissionEditPost.Id,
 			PermissionCreatePost.Id,
 			PermissionUseChannelMentions.Id,
+			PermissionViewReadReceipts.Id,
 		},
 		SchemeManaged: true,
 		BuiltIn:       true,
@@ -873,6 +874,7 @@ func MakeDefaultRoles() map[string]*Role {
 			PermissionGetPublicLink.Id,
 			PermissionCreatePost.Id,
 			PermissionUseChannelMentions.Id,
+			PermissionViewReadReceipts.Id,
 			PermissionManagePublicChannelProperties.Id,
 			PermissionDeletePublicChannel.Id,
 			PermissionManagePrivateChannelProperties.Id,
@@ -5,6 +5,7 @@ package model
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -22,9 +23,11 @@ const (
 	SessionPropBrowser                    = "browser"
 	SessionPropType                       = "type"
 	SessionPropUserAccessTokenId          = "user_access_token_id"
+	SessionPropUserAccessTokenScope       = "user_access_token_scope"
 	SessionPropIsBot                      = "is_bot"
 	SessionPropIsBotValue                 = "true"
 	SessionPropOAuthAppID                 = "oauth_app_id"
+	SessionPropOAuthScope                 = "oauth_scope"
 	SessionPropMattermostAppID            = "mattermost_app_id"
 	SessionPropLastRemovedDeviceId        = "last_removed_device_id"
 	SessionPropDeviceNotificationDisabled = "device_notification_disabled"
@@ -33,6 +36,8 @@ const (
 	SessionTypeCloudKey                   = "CloudKey"
 	SessionTypeRemoteclusterToken         = "RemoteClusterToken"
 	SessionPropIsGuest                    = "is_guest"
+	SessionPropImpersonatedBy             = "impersonated_by"
+	SessionPropReadReceiptOptOut          = "read_receipt_opt_out"
 	SessionActivityTimeout                = 1000 * 60 * 5  // 5 minutes
 	SessionUserAccessTokenExpiryHours     = 100 * 365 * 24 // 100 years
 )
@@ -250,6 +255,69 @@ func (s *Session) IsIntegration() bool {
 	return s.IsBotUser() || s.IsUserAccessToken() || s.IsOAuth
 }
 
+// HasUserAccessTokenScope reports whether the personal access token the session
+// was created from was granted the scope.
+func (s *Session) HasUserAccessTokenScope(scope string) bool {
+	if !s.IsUserAccessToken() {
+		return false
+	}
+
+	return slices.Contains(strings.Fields(s.Props[SessionPropUserAccessTokenScope]), scope)
+}
+
+// HasOAuthScope reports whether the OAuth app the session was issued to was
+// granted the scope. Sessions issued before scopes were recorded only have the
+// default scope.
+func (s *Session) HasOAuthScope(scope string) bool {
+	if !s.IsOAuth {
+		return false
+	}
+
+	granted, ok := s.Props[SessionPropOAuthScope]
+	if !ok {
+		granted = DefaultScope
+	}
+	return slices.Contains(strings.Fields(granted), scope)
+}
+
+// HasOnlyReadReceiptsOAuthScopes reports whether the OAuth app the session was
+// issued to was only granted read receipt scopes, which limit it to the read
+// receipt endpoints. Any other scope, including ones the server does not know,
+// grants the same access as the default scope.
+func (s *Session) HasOnlyReadReceiptsOAuthScopes() bool {
+	if !s.IsOAuth {
+		return false
+	}
+
+	scopes := strings.Fields(s.Props[SessionPropOAuthScope])
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, scope := range scopes {
+		if scope != ReadReceiptsScope && scope != ReadReceiptsWriteScope {
+			return false
+		}
+	}
+	return true
+}
+
+// CanRecordReadReceipts reports whether reads may be recorded through the
+// session. Integrations act on behalf of users without them reading anything, so
+// only users signed in on a device record reads, unless the OAuth app of the
+// session was granted ReadReceiptsWriteScope.
+func (s *Session) CanRecordReadReceipts() bool {
+	if s.IsOAuth {
+		return s.HasOAuthScope(ReadReceiptsWriteScope)
+	}
+	return !s.IsIntegration()
+}
+
+// ReadReceiptsOptedOut reports whether the user asked not to record the reads
+// made through the session, such as on a shared device.
+func (s *Session) ReadReceiptsOptedOut() bool {
+	return s.Props[SessionPropReadReceiptOptOut] == "true"
+}
+
 func (s *Session) IsSSOLogin() bool {
 	return s.IsOAuthUser() || s.IsSaml()
 }
@@ -262,6 +330,14 @@ func (s *Session) IsGuest() bool {
 	return val == "true"
 }
 
+// ImpersonatedBy returns the id of the administrator or plugin acting as the
+// session user, or an empty string for the user's own sessions. It is set on
+// sessions of a user access token another user created, and on sessions created
+// through the plugin API.
+func (s *Session) ImpersonatedBy() string {
+	return s.Props[SessionPropImpersonatedBy]
+}
+
 func (s *Session) GetUserRoles() []string {
 	return strings.Fields(s.Roles)
 }
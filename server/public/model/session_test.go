@@ -134,6 +134,74 @@ func TestSessionIsOAuthUser(t *testing.T) {
 	}
 }
 
+func TestSessionCanRecordReadReceipts(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Session     Session
+		canRecord   bool
+	}{
+		{"True for users signed in on a device", Session{}, true},
+		{"False for personal access tokens", Session{Props: StringMap{SessionPropType: SessionTypeUserAccessToken}}, false},
+		{"False for bots", Session{Props: StringMap{SessionPropIsBot: SessionPropIsBotValue}}, false},
+		{"False for OAuth apps without scope", Session{IsOAuth: true}, false},
+		{"False for OAuth apps with the default scope", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: DefaultScope}}, false},
+		{"True for OAuth apps granted the write scope", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: DefaultScope + " " + ReadReceiptsWriteScope}}, true},
+		{"Not affected by the scope prop outside OAuth", Session{Props: StringMap{SessionPropType: SessionTypeUserAccessToken, SessionPropOAuthScope: ReadReceiptsWriteScope}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			require.Equal(t, tc.canRecord, tc.Session.CanRecordReadReceipts())
+		})
+	}
+}
+
+func TestSessionHasUserAccessTokenScope(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Session     Session
+		hasScope    bool
+	}{
+		{"False outside personal access tokens", Session{Props: StringMap{SessionPropUserAccessTokenScope: UserAccessTokenScopeBotReadReceipts}}, false},
+		{"False without scope", Session{Props: StringMap{SessionPropType: SessionTypeUserAccessToken}}, false},
+		{"False for another scope", Session{Props: StringMap{SessionPropType: SessionTypeUserAccessToken, SessionPropUserAccessTokenScope: "other"}}, false},
+		{"True when granted", Session{Props: StringMap{SessionPropType: SessionTypeUserAccessToken, SessionPropUserAccessTokenScope: "other " + UserAccessTokenScopeBotReadReceipts}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			require.Equal(t, tc.hasScope, tc.Session.HasUserAccessTokenScope(UserAccessTokenScopeBotReadReceipts))
+		})
+	}
+}
+
+func TestSessionHasOnlyReadReceiptsOAuthScopes(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Session     Session
+		restricted  bool
+	}{
+		{"False outside OAuth", Session{Props: StringMap{SessionPropOAuthScope: ReadReceiptsScope}}, false},
+		{"False without scope", Session{IsOAuth: true}, false},
+		{"False for the default scope", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: DefaultScope + " " + ReadReceiptsScope}}, false},
+		{"False for unknown scopes", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: "openid read"}}, false},
+		{"True for the read scope", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: ReadReceiptsScope}}, true},
+		{"True for both receipt scopes", Session{IsOAuth: true, Props: StringMap{SessionPropOAuthScope: ReadReceiptsScope + " " + ReadReceiptsWriteScope}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			require.Equal(t, tc.restricted, tc.Session.HasOnlyReadReceiptsOAuthScopes())
+		})
+	}
+}
+
+func TestSessionReadReceiptsOptedOut(t *testing.T) {
+	require.False(t, (&Session{}).ReadReceiptsOptedOut())
+	require.False(t, (&Session{Props: StringMap{SessionPropReadReceiptOptOut: "false"}}).ReadReceiptsOptedOut())
+	require.True(t, (&Session{Props: StringMap{SessionPropReadReceiptOptOut: "true"}}).ReadReceiptsOptedOut())
+}
+
 func TestIsIntegration(t *testing.T) {
 	testCases := []struct {
 		Description   string
@@ -101,6 +101,69 @@ type SupportPacketJobList struct {
 	MigrationJobs              []*Job `yaml:"migration_jobs"`
 }
 
+// SupportPacketReadReceipts contains the diagnostics of the read receipt subsystem.
+// It is included in the Support Packet.
+type SupportPacketReadReceipts struct {
+	Config                  SupportPacketReadReceiptsConfig `yaml:"config"`
+	Tables                  []*ReadReceiptTableStats        `yaml:"tables"`
+	RefreshStatsJobs        []*Job                          `yaml:"refresh_stats_jobs"`
+	DataRetentionJobs       []*Job                          `yaml:"data_retention_jobs"`
+	Overview                *ReadReceiptsOverview           `yaml:"overview"`
+	PendingImplicitReceipts int                             `yaml:"pending_implicit_receipts"`
+}
+
+// SupportPacketReadReceiptsConfig contains the read receipt settings of the server.
+type SupportPacketReadReceiptsConfig struct {
+	EnableReadReceipts                  *bool   `yaml:"enable_read_receipts"`
+	ReadReceiptsDefaultSetting          *string `yaml:"default_setting"`
+	ReadReceiptsMaxGroupSize            *int    `yaml:"max_group_size"`
+	ReadReceiptsRetentionDays           *int    `yaml:"retention_days"`
+	ReadReceiptsEnableGhostMode         *bool   `yaml:"enable_ghost_mode"`
+	ReadReceiptsRequireAuditLog         *bool   `yaml:"require_audit_log"`
+	ReadReceiptsBusinessHoursOnly       *bool   `yaml:"business_hours_only"`
+	ReadReceiptsEnableDeviceTracking    *bool   `yaml:"enable_device_tracking"`
+	ReadReceiptsThrottleIntervalMs      *int    `yaml:"throttle_interval_ms"`
+	ReadReceiptsBatchWindowMs           *int    `yaml:"batch_window_ms"`
+	ReadReceiptsEnableTeamChannels      *bool   `yaml:"enable_team_channels"`
+	ReadReceiptsEnableBotReceipts       *bool   `yaml:"enable_bot_receipts"`
+	ReadReceiptsClientDebounceMs        *int    `yaml:"client_debounce_ms"`
+	ReadReceiptsBatchMaxWaitMs          *int    `yaml:"batch_max_wait_ms"`
+	ReadReceiptsStoreAllDevices         *bool   `yaml:"store_all_devices"`
+	ReadReceiptsMaxClockSkewMs          *int    `yaml:"max_clock_skew_ms"`
+	ReadReceiptsEnableIntegrityChain    *bool   `yaml:"enable_integrity_chain"`
+	ReadReceiptsBufferMaxSize           *int    `yaml:"buffer_max_size"`
+	ReadReceiptsBufferFlushIntervalMs   *int    `yaml:"buffer_flush_interval_ms"`
+	ReadReceiptsBufferMaxPendingPerUser *int    `yaml:"buffer_max_pending_per_user"`
+	ReadReceiptsMaxPerChannel           *int    `yaml:"max_per_channel"`
+	ReadReceiptsEnableUnreadDMNudge     *bool   `yaml:"enable_unread_dm_nudge"`
+	ReadReceiptsUnreadDMNudgeHours      *int    `yaml:"unread_dm_nudge_hours"`
+	ReadReceiptsExportSink              *string `yaml:"export_sink"`
+	ReadReceiptsExportBufferSize        *int    `yaml:"export_buffer_size"`
+	ReadReceiptsSummarySLASeconds       *int    `yaml:"summary_sla_seconds"`
+	ReadReceiptsRestrictDMRecall        *bool   `yaml:"restrict_dm_recall"`
+	ReadReceiptsDeactivationScrubDays   *int    `yaml:"deactivation_scrub_days"`
+	ReadReceiptsMinimumConfidence       *string `yaml:"minimum_confidence"`
+	ReadReceiptsMinWriteIntervalMs      *int    `yaml:"min_write_interval_ms"`
+	ReadReceiptsColdStorageDays         *int    `yaml:"cold_storage_days"`
+	ReadReceiptsLegacyBatchEvents       *bool   `yaml:"legacy_batch_events"`
+	ReadReceiptsTransactionalSummary    *bool   `yaml:"transactional_summary"`
+	ReadReceiptsServerTimestamps        *bool   `yaml:"server_timestamps"`
+	ReadReceiptsChangesRetentionHours   *int    `yaml:"changes_retention_hours"`
+	ReadReceiptsPreciseUnread           *bool   `yaml:"precise_unread"`
+	ReadReceiptsSamplingThreshold       *int    `yaml:"sampling_threshold"`
+	ReadReceiptsBitmapStorageDays       *int    `yaml:"bitmap_storage_days"`
+	ReadReceiptsBitmapMinReceipts       *int    `yaml:"bitmap_min_receipts"`
+}
+
+// ReadReceiptTableStats describes a table of the read receipt subsystem. The row
+// count is the planner estimate, so that generating it stays cheap on large tables.
+type ReadReceiptTableStats struct {
+	Table          string   `yaml:"table"`
+	EstimatedRows  int64    `yaml:"estimated_rows"`
+	Indexes        []string `yaml:"indexes"`
+	MissingIndexes []string `yaml:"missing_indexes,omitempty"`
+}
+
 // SupportPacketPermissionInfo contains the list of schemes and the list of roles.
 // It is included in the Support Packet.
 type SupportPacketPermissionInfo struct {
@@ -5,6 +5,13 @@ package model
 
 import (
 	"net/http"
+	"strings"
+)
+
+const (
+	// UserAccessTokenScopeBotReadReceipts lets a personal access token of a bot
+	// acknowledge posts through the bot read receipt endpoint.
+	UserAccessTokenScopeBotReadReceipts = "read_receipts:bot"
 )
 
 type UserAccessToken struct {
@@ -13,6 +20,12 @@ type UserAccessToken struct {
 	UserId      string `json:"user_id"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
+	// CreatorId is the user that created the token, which differs from UserId
+	// when an administrator creates a token for another user.
+	CreatorId string `json:"creator_id,omitempty"`
+	// Scope is the space separated list of scopes granted to the token on top
+	// of the permissions of its user.
+	Scope string `json:"scope,omitempty"`
 }
 
 func (t *UserAccessToken) IsValid() *AppError {
@@ -32,6 +45,12 @@ func (t *UserAccessToken) IsValid() *AppError {
 		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.description.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	for _, scope := range strings.Fields(t.Scope) {
+		if scope != UserAccessTokenScopeBotReadReceipts {
+			return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.scope.app_error", nil, "scope="+scope, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
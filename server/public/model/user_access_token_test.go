@@ -26,6 +26,14 @@ func TestUserAccessTokenIsValid(t *testing.T) {
 	ad.UserId = NewRandomString(26)
 	require.Nil(t, ad.IsValid())
 
+	ad.Scope = UserAccessTokenScopeBotReadReceipts
+	require.Nil(t, ad.IsValid())
+
+	ad.Scope = UserAccessTokenScopeBotReadReceipts + " " + ReadReceiptsWriteScope
+	appErr = ad.IsValid()
+	require.False(t, appErr == nil || appErr.Id != "model.user_access_token.is_valid.scope.app_error")
+	ad.Scope = ""
+
 	ad.Description = NewRandomString(256)
 	appErr = ad.IsValid()
 	require.False(t, appErr == nil || appErr.Id != "model.user_access_token.is_valid.description.app_error")
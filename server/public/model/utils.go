@@ -235,6 +235,7 @@ type AppError struct {
 	DetailedError   string `json:"detailed_error"`        // Internal error string to help the developer
 	RequestId       string `json:"request_id,omitempty"`  // The RequestId that's also set in the header
 	StatusCode      int    `json:"status_code,omitempty"` // The http status code
+	Code            string `json:"code,omitempty"`        // A machine-readable code, set by the APIs that define one
 	Where           string `json:"-"`                     // The function where it happened in the form of Struct.Func
 	SkipTranslation bool   `json:"-"`                     // Whether translation for the error should be skipped.
 	params          map[string]any
@@ -336,6 +337,12 @@ func (er *AppError) Wrap(err error) *AppError {
 	return er
 }
 
+// WithCode sets the machine-readable code of the error.
+func (er *AppError) WithCode(code string) *AppError {
+	er.Code = code
+	return er
+}
+
 func (er *AppError) WipeDetailed() {
 	er.wrapped = nil
 	er.DetailedError = ""
@@ -150,6 +150,19 @@ func TestAppErrorSerialize(t *testing.T) {
 		require.EqualError(t, berr, aerr.Error())
 	})
 
+	t.Run("Code", func(t *testing.T) {
+		aerr := NewAppError("", "message", nil, "", http.StatusTeapot).WithCode(ReadReceiptErrorCodeUserOptedOut)
+		js := aerr.ToJSON()
+		require.Contains(t, js, `"code":"USER_OPTED_OUT"`)
+		err := AppErrorFromJSON(strings.NewReader(js))
+		berr, ok := err.(*AppError)
+		require.True(t, ok)
+		require.Equal(t, ReadReceiptErrorCodeUserOptedOut, berr.Code)
+
+		js = NewAppError("", "message", nil, "", http.StatusTeapot).ToJSON()
+		require.NotContains(t, js, `"code"`)
+	})
+
 	t.Run("Wipe Detailed", func(t *testing.T) {
 		aerr := NewAppError("", "message", nil, "detail", http.StatusTeapot)
 		aerr.WipeDetailed()
@@ -312,6 +312,20 @@ func (wsc *WebSocketClient) UserTyping(channelId, parentId string) {
 	wsc.SendMessage("user_typing", data)
 }
 
+// PostRead marks the posts of the channel as read, like the batch read receipts
+// endpoint of the REST API.
+func (wsc *WebSocketClient) PostRead(req *ReadReceiptBatchRequest) {
+	data := map[string]any{
+		"channel_id":    req.ChannelId,
+		"post_ids":      req.PostIds,
+		"up_to_post_id": req.UpToPostId,
+		"read_at":       req.ReadAt,
+		"device_id":     req.DeviceId,
+	}
+
+	wsc.SendMessage("post_read", data)
+}
+
 // GetStatuses will return a map of string statuses using user id as the key
 func (wsc *WebSocketClient) GetStatuses() {
 	wsc.SendMessage("get_statuses", nil)
@@ -21,6 +21,10 @@ const (
 	WebsocketEventPostRead                            WebsocketEventType = "post_read"
 	WebsocketEventPostReadBatch                       WebsocketEventType = "post_read_batch"
 	WebsocketEventReadReceiptSummary                  WebsocketEventType = "read_receipt_summary"
+	WebsocketEventReadSummaryUpdated                  WebsocketEventType = "read_summary_updated"
+	WebsocketEventReadReceiptsResync                  WebsocketEventType = "read_receipts_resync"
+	WebsocketEventReadReceiptsResumed                 WebsocketEventType = "read_receipts_resumed"
+	WebsocketEventChannelReadDigest                   WebsocketEventType = "channel_read_digest"
 	WebsocketEventChannelConverted                    WebsocketEventType = "channel_converted"
 	WebsocketEventChannelCreated                      WebsocketEventType = "channel_created"
 	WebsocketEventChannelDeleted                      WebsocketEventType = "channel_deleted"
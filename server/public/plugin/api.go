@@ -1552,6 +1552,20 @@ type API interface {
 	// @tag Audit
 	// Minimum server version: 10.10
 	LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level)
+
+	// GetReadReceiptInfoForPost gets the read receipts of a post along with how many
+	// of the members of its channel read it.
+	//
+	// @tag ReadReceipt
+	// Minimum server version: 11.0
+	GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError)
+
+	// GetPostsReadStateForUser gets whether the user read each of the posts, keyed by
+	// post id.
+	//
+	// @tag ReadReceipt
+	// Minimum server version: 11.0
+	GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError)
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -1657,3 +1657,17 @@ func (api *apiTimerLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlo
 	api.apiImpl.LogAuditRecWithLevel(rec, level)
 	api.recordTime(startTime, "LogAuditRecWithLevel", true)
 }
+
+func (api *apiTimerLayer) GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetReadReceiptInfoForPost(postID)
+	api.recordTime(startTime, "GetReadReceiptInfoForPost", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostsReadStateForUser(userID, postIDs)
+	api.recordTime(startTime, "GetPostsReadStateForUser", _returnsB == nil)
+	return _returnsA, _returnsB
+}
@@ -7848,3 +7848,62 @@ func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValues
 	}
 	return nil
 }
+
+type Z_GetReadReceiptInfoForPostArgs struct {
+	A string
+}
+
+type Z_GetReadReceiptInfoForPostReturns struct {
+	A *model.PostReadReceiptInfo
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError) {
+	_args := &Z_GetReadReceiptInfoForPostArgs{postID}
+	_returns := &Z_GetReadReceiptInfoForPostReturns{}
+	if err := g.client.Call("Plugin.GetReadReceiptInfoForPost", _args, _returns); err != nil {
+		log.Printf("RPC call to GetReadReceiptInfoForPost API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetReadReceiptInfoForPost(args *Z_GetReadReceiptInfoForPostArgs, returns *Z_GetReadReceiptInfoForPostReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetReadReceiptInfoForPost(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetReadReceiptInfoForPost called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetPostsReadStateForUserArgs struct {
+	A string
+	B []string
+}
+
+type Z_GetPostsReadStateForUserReturns struct {
+	A map[string]*model.PostReadState
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError) {
+	_args := &Z_GetPostsReadStateForUserArgs{userID, postIDs}
+	_returns := &Z_GetPostsReadStateForUserReturns{}
+	if err := g.client.Call("Plugin.GetPostsReadStateForUser", _args, _returns); err != nil {
+		log.Printf("RPC call to GetPostsReadStateForUser API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetPostsReadStateForUser(args *Z_GetPostsReadStateForUserArgs, returns *Z_GetPostsReadStateForUserReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetPostsReadStateForUser(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API GetPostsReadStateForUser called but not implemented."))
+	}
+	return nil
+}
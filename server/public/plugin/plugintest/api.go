@@ -2662,6 +2662,38 @@ func (_m *API) GetPostsForChannel(channelId string, page int, perPage int) (*mod
 	return r0, r1
 }
 
+// GetPostsReadStateForUser provides a mock function with given fields: userID, postIDs
+func (_m *API) GetPostsReadStateForUser(userID string, postIDs []string) (map[string]*model.PostReadState, *model.AppError) {
+	ret := _m.Called(userID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostsReadStateForUser")
+	}
+
+	var r0 map[string]*model.PostReadState
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, []string) (map[string]*model.PostReadState, *model.AppError)); ok {
+		return rf(userID, postIDs)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) map[string]*model.PostReadState); ok {
+		r0 = rf(userID, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*model.PostReadState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) *model.AppError); ok {
+		r1 = rf(userID, postIDs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetPostsSince provides a mock function with given fields: channelId, time
 func (_m *API) GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError) {
 	ret := _m.Called(channelId, time)
@@ -3032,6 +3064,38 @@ func (_m *API) GetReactions(postId string) ([]*model.Reaction, *model.AppError)
 	return r0, r1
 }
 
+// GetReadReceiptInfoForPost provides a mock function with given fields: postID
+func (_m *API) GetReadReceiptInfoForPost(postID string) (*model.PostReadReceiptInfo, *model.AppError) {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadReceiptInfoForPost")
+	}
+
+	var r0 *model.PostReadReceiptInfo
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) (*model.PostReadReceiptInfo, *model.AppError)); ok {
+		return rf(postID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.PostReadReceiptInfo); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostReadReceiptInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(postID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetServerVersion provides a mock function with no fields
 func (_m *API) GetServerVersion() string {
 	ret := _m.Called()
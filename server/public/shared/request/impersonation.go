@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package request
+
+import "context"
+
+// impersonationContextKey is the context key marking requests made on behalf of
+// the session user by someone else.
+type impersonationContextKey struct{}
+
+// WithImpersonation marks the request as made on behalf of the session user by
+// actor, such as an administrator logged in as the user or a plugin. Actions the
+// user would otherwise perform implicitly, like reading posts, are not recorded
+// for such requests.
+func WithImpersonation(c CTX, actor string) CTX {
+	return c.WithContext(context.WithValue(c.Context(), impersonationContextKey{}, actor))
+}
+
+// ImpersonatedBy returns the actor the request is made by, as given to
+// WithImpersonation, or an empty string if the user is acting themselves.
+func ImpersonatedBy(c CTX) string {
+	if actor, ok := c.Context().Value(impersonationContextKey{}).(string); ok {
+		return actor
+	}
+	return ""
+}
@@ -78,7 +78,7 @@ Client4.getChannelReadReceiptSummary = function(channelId: string, userId: strin
     );
 };
 
-Client4.getUserReadReceiptHistory = function(userId: string, channelId?: string, since?: number, limit?: number) {
+Client4.getUserReadReceiptHistory = function(userId: string, channelId?: string, since?: number, limit?: number, until?: number) {
     const params = new URLSearchParams();
     if (channelId) {
         params.set('channel_id', channelId);
@@ -86,6 +86,9 @@ Client4.getUserReadReceiptHistory = function(userId: string, channelId?: string,
     if (since) {
         params.set('since', since.toString());
     }
+    if (until) {
+        params.set('until', until.toString());
+    }
     if (limit) {
         params.set('limit', limit.toString());
     }
@@ -96,6 +99,30 @@ Client4.getUserReadReceiptHistory = function(userId: string, channelId?: string,
     );
 };
 
+Client4.getUserReadReceiptHistoryPage = function(userId: string, channelId?: string, since?: number, limit?: number, until?: number, page?: string) {
+    const params = new URLSearchParams();
+    if (channelId) {
+        params.set('channel_id', channelId);
+    }
+    if (since) {
+        params.set('since', since.toString());
+    }
+    if (until) {
+        params.set('until', until.toString());
+    }
+    if (limit) {
+        params.set('limit', limit.toString());
+    }
+    if (page) {
+        params.set('page', page);
+    }
+    
+    return this.doFetch<{receipts: PostReadReceipt[]; next_page?: string}>(
+        `${this.getUserRoute(userId)}/read_receipts/page?${params.toString()}`,
+        {method: 'get'},
+    );
+};
+
 export {
     Client4,
     DEFAULT_LIMIT_AFTER,